@@ -13,10 +13,21 @@ import (
 	"backend/adapter/logger"
 	"backend/adapter/server"
 	"backend/core/budget/account"
+	"backend/core/budget/attachment"
+	attachmentPort "backend/core/budget/attachment/port"
 	"backend/core/budget/budget"
+	"backend/core/budget/budget_allocation"
+	"backend/core/budget/categorization_rule"
 	"backend/core/budget/category"
 	"backend/core/budget/currency"
 	"backend/core/budget/organization_currency"
+	"backend/core/budget/payee"
+	"backend/core/budget/reconciliation"
+	"backend/core/budget/report"
+	"backend/core/budget/scheduled_transaction"
+	scheduledTransactionPort "backend/core/budget/scheduled_transaction/port"
+	"backend/core/budget/statement_import"
+	"backend/core/budget/tag"
 	"backend/core/budget/transaction"
 	"backend/core/notifications/email_dispatcher"
 	"backend/core/notifications/email_log"
@@ -51,6 +62,7 @@ func main() {
 	}
 	di.ProvideValue(injector, db)
 	di.ProvideValue(injector, db.Pool)
+	di.ProvideValue(injector, database.NewUnitOfWork(db.Pool, log))
 
 	// Register feature modules
 	currency.Module(injector)
@@ -58,7 +70,22 @@ func main() {
 	organization_currency.Module(injector)
 	account.Module(injector)
 	category.Module(injector)
+	categorization_rule.Module(injector)
+	payee.Module(injector)
+	tag.Module(injector)
+	attachment.Module(injector, cfg.Attachments.Path, attachmentPort.S3Config{
+		Endpoint:        cfg.Attachments.S3Endpoint,
+		Region:          cfg.Attachments.S3Region,
+		Bucket:          cfg.Attachments.S3Bucket,
+		AccessKeyID:     cfg.Attachments.S3AccessKeyID,
+		SecretAccessKey: cfg.Attachments.S3SecretAccessKey,
+	})
 	budget.Module(injector)
+	budget_allocation.Module(injector)
+	report.Module(injector)
+	scheduled_transaction.Module(injector)
+	reconciliation.Module(injector)
+	statement_import.Module(injector)
 	email_log.Module(injector)
 	email_template.Module(injector)
 	eventbus.Module(injector)
@@ -68,6 +95,10 @@ func main() {
 	bus := di.MustInvoke[eventbusPort.EventBus](injector)
 	bus.Start(ctx)
 
+	// Start scheduled transaction generation
+	scheduler := di.MustInvoke[scheduledTransactionPort.Scheduler](injector)
+	scheduler.Start(ctx)
+
 	// Build server config
 	config := server.Config{
 		Port:        cfg.Service.Port(),
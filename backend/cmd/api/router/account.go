@@ -15,6 +15,7 @@ func RegisterAccountRoutes(injector do.Injector, e *echo.Echo) {
 	g.POST("", h.Create)
 	g.PUT("/:id", h.Update)
 	g.DELETE("/:id", h.Delete)
+	g.POST("/:id/recompute-balance", h.RecomputeBalance)
 	g.GET("", h.FindAll)
 	g.GET("/:id", h.FindOne)
 }
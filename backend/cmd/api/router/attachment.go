@@ -0,0 +1,21 @@
+package router
+
+import (
+	"backend/adapter/di"
+	"backend/core/budget/attachment/adapter/handler"
+
+	"github.com/labstack/echo/v4"
+	echomiddleware "github.com/labstack/echo/v4/middleware"
+	"github.com/samber/do/v2"
+)
+
+func RegisterAttachmentRoutes(injector do.Injector, e *echo.Echo) {
+	h := di.MustInvoke[handler.HTTP](injector)
+
+	g := e.Group("/v1/transactions/:id/attachments")
+
+	g.POST("", h.Upload, echomiddleware.BodyLimit(uploadBodyLimit))
+	g.GET("", h.FindAll)
+	g.GET("/:attachmentId", h.Download)
+	g.DELETE("/:attachmentId", h.Delete)
+}
@@ -13,8 +13,10 @@ func RegisterBudgetRoutes(injector do.Injector, e *echo.Echo) {
 	g := e.Group("/v1/budgets")
 
 	g.POST("", h.Create)
+	g.POST("/:id/clone", h.Clone)
 	g.PUT("/:id", h.Update)
 	g.DELETE("/:id", h.Delete)
 	g.GET("", h.FindAll)
 	g.GET("/:id", h.FindOne)
+	g.GET("/:id/summary", h.Summary)
 }
@@ -0,0 +1,21 @@
+package router
+
+import (
+	"backend/adapter/di"
+	"backend/core/budget/budget_allocation/adapter/handler"
+
+	"github.com/labstack/echo/v4"
+	"github.com/samber/do/v2"
+)
+
+func RegisterBudgetAllocationRoutes(injector do.Injector, e *echo.Echo) {
+	h := di.MustInvoke[handler.HTTP](injector)
+
+	g := e.Group("/v1/budgets/:id/allocations")
+
+	g.POST("", h.Create)
+	g.PUT("/:allocationId", h.Update)
+	g.DELETE("/:allocationId", h.Delete)
+	g.GET("", h.FindAll)
+	g.GET("/:allocationId", h.FindOne)
+}
@@ -0,0 +1,21 @@
+package router
+
+import (
+	"backend/adapter/di"
+	"backend/core/budget/categorization_rule/adapter/handler"
+
+	"github.com/labstack/echo/v4"
+	"github.com/samber/do/v2"
+)
+
+func RegisterCategorizationRuleRoutes(injector do.Injector, e *echo.Echo) {
+	h := di.MustInvoke[handler.HTTP](injector)
+
+	g := e.Group("/v1/categorization-rules")
+
+	g.POST("", h.Create)
+	g.PUT("/:id", h.Update)
+	g.DELETE("/:id", h.Delete)
+	g.GET("", h.FindAll)
+	g.GET("/:id", h.FindOne)
+}
@@ -17,4 +17,5 @@ func RegisterCategoryRoutes(injector do.Injector, e *echo.Echo) {
 	g.DELETE("/:id", h.Delete)
 	g.GET("", h.FindAll)
 	g.GET("/:id", h.FindOne)
+	g.GET("/:id/goal-progress", h.GoalProgress)
 }
@@ -17,4 +17,6 @@ func RegisterOrganizationCurrencyRoutes(injector do.Injector, e *echo.Echo) {
 	g.DELETE("/:id", h.Delete)
 	g.GET("", h.FindAll)
 	g.GET("/:id", h.FindOne)
+	g.POST("/:id/rates", h.AddRate)
+	g.GET("/:id/rates", h.FindRates)
 }
@@ -0,0 +1,23 @@
+package router
+
+import (
+	"backend/adapter/di"
+	"backend/core/budget/reconciliation/adapter/handler"
+
+	"github.com/labstack/echo/v4"
+	"github.com/samber/do/v2"
+)
+
+func RegisterReconciliationRoutes(injector do.Injector, e *echo.Echo) {
+	h := di.MustInvoke[handler.HTTP](injector)
+
+	g := e.Group("/v1/reconciliations")
+
+	g.POST("", h.Create)
+	g.PUT("/:id", h.Update)
+	g.DELETE("/:id", h.Delete)
+	g.PUT("/:id/transactions", h.ClearTransactions)
+	g.POST("/:id/finalize", h.Finalize)
+	g.GET("", h.FindAll)
+	g.GET("/:id", h.FindOne)
+}
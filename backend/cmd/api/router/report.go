@@ -0,0 +1,18 @@
+package router
+
+import (
+	"backend/adapter/di"
+	"backend/core/budget/report/adapter/handler"
+
+	"github.com/labstack/echo/v4"
+	"github.com/samber/do/v2"
+)
+
+func RegisterReportRoutes(injector do.Injector, e *echo.Echo) {
+	h := di.MustInvoke[handler.HTTP](injector)
+
+	g := e.Group("/v1/reports")
+
+	g.GET("/budget-vs-actual", h.BudgetVsActual)
+	g.GET("/tag-activity", h.TagActivity)
+}
@@ -12,6 +12,10 @@ import (
 	"github.com/samber/do/v2"
 )
 
+// uploadBodyLimit caps the requests of the routes taking a file: 10 MB of content and
+// room for the multipart framing and the other form fields.
+const uploadBodyLimit = "11M"
+
 func SetupRoutes(injector do.Injector) func(e *echo.Echo) {
 	return func(e *echo.Echo) {
 		cfg := di.MustInvoke[localconfig.LocalConfig](injector)
@@ -25,14 +29,44 @@ func SetupRoutes(injector do.Injector) func(e *echo.Echo) {
 			"/v1/currencies/:code":         {Resource: "currency", Actions: middleware.ReadOnlyActions},
 			"/v1/organization-currencies":      {Resource: "organizationCurrency"},
 			"/v1/organization-currencies/:id":  {Resource: "organizationCurrency"},
+			"/v1/organization-currencies/:id/rates": {Resource: "organizationCurrency"},
 			"/v1/accounts":                 {Resource: "account"},
 			"/v1/accounts/:id":             {Resource: "account"},
+			"/v1/accounts/:id/recompute-balance": {Resource: "account", Actions: map[string]string{"POST": "update"}},
+			"/v1/accounts/:id/imports":     {Resource: "transaction"},
+			"/v1/import-profiles":          {Resource: "account"},
+			"/v1/import-profiles/:id":      {Resource: "account"},
 			"/v1/categories":               {Resource: "category"},
 			"/v1/categories/:id":           {Resource: "category"},
+			"/v1/categories/:id/goal-progress": {Resource: "category", Actions: middleware.ReadOnlyActions},
+			"/v1/categorization-rules":     {Resource: "category"},
+			"/v1/categorization-rules/:id": {Resource: "category"},
 			"/v1/budgets":                  {Resource: "budget"},
 			"/v1/budgets/:id":              {Resource: "budget"},
+			"/v1/budgets/:id/summary":      {Resource: "budget", Actions: middleware.ReadOnlyActions},
+			"/v1/budgets/:id/clone":        {Resource: "budget"},
+			"/v1/budgets/:id/allocations":  {Resource: "budget"},
+			"/v1/budgets/:id/allocations/:allocationId": {Resource: "budget"},
 			"/v1/transactions":             {Resource: "transaction"},
 			"/v1/transactions/:id":         {Resource: "transaction"},
+			"/v1/transactions/:id/unlock":  {Resource: "transaction", Actions: map[string]string{"POST": "update"}},
+			"/v1/transactions/categorize":  {Resource: "transaction", Actions: map[string]string{"POST": "update"}},
+			"/v1/transactions/:id/attachments":               {Resource: "transaction"},
+			"/v1/transactions/:id/attachments/:attachmentId": {Resource: "transaction"},
+			"/v1/payees":                   {Resource: "transaction"},
+			"/v1/payees/:id":               {Resource: "transaction"},
+			"/v1/tags":                     {Resource: "transaction"},
+			"/v1/tags/:id":                 {Resource: "transaction"},
+			"/v1/transfers":                {Resource: "transaction"},
+			"/v1/transfers/:id":            {Resource: "transaction"},
+			"/v1/scheduled-transactions":     {Resource: "transaction"},
+			"/v1/scheduled-transactions/:id": {Resource: "transaction"},
+			"/v1/reconciliations":          {Resource: "account"},
+			"/v1/reconciliations/:id":      {Resource: "account"},
+			"/v1/reconciliations/:id/transactions": {Resource: "account"},
+			"/v1/reconciliations/:id/finalize":     {Resource: "account", Actions: map[string]string{"POST": "update"}},
+			"/v1/reports/budget-vs-actual": {Resource: "budget", Actions: middleware.ReadOnlyActions},
+			"/v1/reports/tag-activity":     {Resource: "budget", Actions: middleware.ReadOnlyActions},
 		}))
 
 		RegisterEmailTemplateRoutes(injector, e)
@@ -41,8 +75,17 @@ func SetupRoutes(injector do.Injector) func(e *echo.Echo) {
 		RegisterOrganizationCurrencyRoutes(injector, e)
 		RegisterAccountRoutes(injector, e)
 		RegisterCategoryRoutes(injector, e)
+		RegisterCategorizationRuleRoutes(injector, e)
 		RegisterBudgetRoutes(injector, e)
+		RegisterBudgetAllocationRoutes(injector, e)
+		RegisterPayeeRoutes(injector, e)
+		RegisterTagRoutes(injector, e)
 		RegisterTransactionRoutes(injector, e)
+		RegisterAttachmentRoutes(injector, e)
+		RegisterScheduledTransactionRoutes(injector, e)
+		RegisterReconciliationRoutes(injector, e)
+		RegisterStatementImportRoutes(injector, e)
+		RegisterReportRoutes(injector, e)
 
 		e.GET("/v1/docs", func(c echo.Context) error {
 			configService := do.MustInvoke[*localconfig.ConfigService](injector)
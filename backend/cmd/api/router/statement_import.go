@@ -0,0 +1,24 @@
+package router
+
+import (
+	"backend/adapter/di"
+	"backend/core/budget/statement_import/adapter/handler"
+
+	"github.com/labstack/echo/v4"
+	echomiddleware "github.com/labstack/echo/v4/middleware"
+	"github.com/samber/do/v2"
+)
+
+func RegisterStatementImportRoutes(injector do.Injector, e *echo.Echo) {
+	h := di.MustInvoke[handler.HTTP](injector)
+
+	g := e.Group("/v1/import-profiles")
+
+	g.POST("", h.Create)
+	g.PUT("/:id", h.Update)
+	g.DELETE("/:id", h.Delete)
+	g.GET("", h.FindAll)
+	g.GET("/:id", h.FindOne)
+
+	e.POST("/v1/accounts/:id/imports", h.Import, echomiddleware.BodyLimit(uploadBodyLimit))
+}
@@ -13,8 +13,17 @@ func RegisterTransactionRoutes(injector do.Injector, e *echo.Echo) {
 	g := e.Group("/v1/transactions")
 
 	g.POST("", h.Create)
+	g.POST("/categorize", h.Recategorize)
 	g.PUT("/:id", h.Update)
 	g.DELETE("/:id", h.Delete)
+	g.POST("/:id/unlock", h.Unlock)
 	g.GET("", h.FindAll)
 	g.GET("/:id", h.FindOne)
+
+	t := e.Group("/v1/transfers")
+
+	t.POST("", h.CreateTransfer)
+	t.PUT("/:id", h.UpdateTransfer)
+	t.DELETE("/:id", h.DeleteTransfer)
+	t.GET("/:id", h.FindTransfer)
 }
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS budget.budget_allocations;
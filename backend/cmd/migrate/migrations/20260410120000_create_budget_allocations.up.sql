@@ -0,0 +1,25 @@
+CREATE TABLE budget.budget_allocations (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    organization_id TEXT NOT NULL REFERENCES identity.organizations(id) ON DELETE CASCADE,
+    budget_id UUID NOT NULL REFERENCES budget.budgets(id) ON DELETE CASCADE,
+    category_id UUID NOT NULL REFERENCES budget.categories(id) ON DELETE CASCADE,
+    subcategory_id UUID REFERENCES budget.categories(id) ON DELETE CASCADE,
+    assigned_amount BIGINT NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT budget_allocations_unique_line UNIQUE NULLS NOT DISTINCT (budget_id, category_id, subcategory_id)
+);
+
+CREATE INDEX budget_allocations_organization_id_idx
+    ON budget.budget_allocations (organization_id);
+CREATE INDEX budget_allocations_budget_id_idx
+    ON budget.budget_allocations (budget_id);
+CREATE INDEX budget_allocations_category_id_idx
+    ON budget.budget_allocations (category_id);
+
+ALTER TABLE budget.budget_allocations ENABLE ROW LEVEL SECURITY;
+
+CREATE POLICY budget_allocations_org_scope ON budget.budget_allocations
+    FOR ALL USING (
+        organization_id = current_setting('app.current_organization_id', true)
+    );
@@ -0,0 +1,8 @@
+DROP FUNCTION IF EXISTS budget.convert_amount(TEXT, BIGINT, VARCHAR, VARCHAR);
+
+ALTER TABLE budget.budget_allocations
+    DROP COLUMN IF EXISTS carryover_amount;
+
+ALTER TABLE budget.categories
+    DROP CONSTRAINT IF EXISTS categories_rollover_policy_check,
+    DROP COLUMN IF EXISTS rollover_policy;
@@ -0,0 +1,37 @@
+ALTER TABLE budget.categories
+    ADD COLUMN rollover_policy VARCHAR(20) NOT NULL DEFAULT 'reset',
+    ADD CONSTRAINT categories_rollover_policy_check
+        CHECK (rollover_policy IN ('carry', 'reset', 'carry_positive'));
+
+-- Opening balance brought in from the previous month by the budget rollover
+ALTER TABLE budget.budget_allocations
+    ADD COLUMN carryover_amount BIGINT NOT NULL DEFAULT 0;
+
+-- Converts an amount in minor units between two currencies of an organization using
+-- the organization rates (units per one base unit), rescaling between the currencies'
+-- decimal places. Returns NULL when either currency has no rate for the organization.
+CREATE OR REPLACE FUNCTION budget.convert_amount(
+    p_organization_id TEXT,
+    p_amount BIGINT,
+    p_from VARCHAR(3),
+    p_to VARCHAR(3)
+)
+RETURNS BIGINT
+LANGUAGE sql
+STABLE
+AS $$
+    SELECT CASE
+        WHEN p_from = p_to THEN p_amount
+        ELSE ROUND(
+            p_amount * t.rate / f.rate
+            * POWER(10::NUMERIC, tc.decimal_places - fc.decimal_places)
+        )::BIGINT
+    END
+    FROM budget.currencies fc
+    JOIN budget.currencies tc ON tc.code = p_to
+    LEFT JOIN budget.organization_currencies f
+        ON f.organization_id = p_organization_id AND f.currency_code = p_from
+    LEFT JOIN budget.organization_currencies t
+        ON t.organization_id = p_organization_id AND t.currency_code = p_to
+    WHERE fc.code = p_from
+$$;
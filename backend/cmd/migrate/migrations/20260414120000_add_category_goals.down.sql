@@ -0,0 +1,9 @@
+DROP INDEX IF EXISTS budget.transactions_subcategory_id_idx;
+
+ALTER TABLE budget.categories
+    DROP CONSTRAINT IF EXISTS categories_goal_complete_check,
+    DROP CONSTRAINT IF EXISTS categories_goal_type_check,
+    DROP COLUMN IF EXISTS goal_target_date,
+    DROP COLUMN IF EXISTS goal_currency_code,
+    DROP COLUMN IF EXISTS goal_amount,
+    DROP COLUMN IF EXISTS goal_type;
@@ -0,0 +1,17 @@
+-- Optional funding goal of a category; goal_amount is in minor units of goal_currency_code
+ALTER TABLE budget.categories
+    ADD COLUMN goal_type VARCHAR(20),
+    ADD COLUMN goal_amount BIGINT,
+    ADD COLUMN goal_currency_code VARCHAR(3) REFERENCES budget.currencies(code) ON DELETE RESTRICT,
+    ADD COLUMN goal_target_date DATE,
+    ADD CONSTRAINT categories_goal_type_check
+        CHECK (goal_type IN ('target_balance', 'monthly_funding', 'spending_cap')),
+    ADD CONSTRAINT categories_goal_complete_check
+        CHECK (
+            (goal_type IS NULL AND goal_amount IS NULL AND goal_currency_code IS NULL AND goal_target_date IS NULL)
+            OR (goal_type IS NOT NULL AND goal_amount > 0 AND goal_currency_code IS NOT NULL
+                AND (goal_type = 'target_balance') = (goal_target_date IS NOT NULL))
+        );
+
+CREATE INDEX transactions_subcategory_id_idx
+    ON budget.transactions (subcategory_id);
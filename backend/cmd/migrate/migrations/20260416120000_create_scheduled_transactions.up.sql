@@ -0,0 +1,41 @@
+CREATE TABLE budget.scheduled_transactions (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    organization_id TEXT NOT NULL REFERENCES identity.organizations(id) ON DELETE CASCADE,
+    account_id UUID NOT NULL REFERENCES budget.accounts(id) ON DELETE CASCADE,
+    category_id UUID REFERENCES budget.categories(id) ON DELETE SET NULL,
+    subcategory_id UUID REFERENCES budget.categories(id) ON DELETE SET NULL,
+    type VARCHAR(20) NOT NULL,
+    amount BIGINT NOT NULL,
+    description TEXT,
+    frequency VARCHAR(10) NOT NULL,
+    interval_count SMALLINT NOT NULL DEFAULT 1,
+    start_date DATE NOT NULL,
+    end_date DATE,
+    occurrence_count INTEGER,
+    occurrences_generated INTEGER NOT NULL DEFAULT 0,
+    next_occurrence DATE,
+    is_active BOOLEAN NOT NULL DEFAULT true,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT scheduled_transactions_frequency_check
+        CHECK (frequency IN ('daily', 'weekly', 'monthly', 'yearly')),
+    CONSTRAINT scheduled_transactions_interval_check CHECK (interval_count > 0),
+    CONSTRAINT scheduled_transactions_end_date_check CHECK (end_date IS NULL OR end_date >= start_date),
+    CONSTRAINT scheduled_transactions_occurrence_count_check CHECK (occurrence_count IS NULL OR occurrence_count > 0)
+);
+
+CREATE INDEX scheduled_transactions_organization_id_idx
+    ON budget.scheduled_transactions (organization_id);
+CREATE INDEX scheduled_transactions_account_id_idx
+    ON budget.scheduled_transactions (account_id);
+-- Due schedules lookup of the background generator
+CREATE INDEX scheduled_transactions_next_occurrence_idx
+    ON budget.scheduled_transactions (next_occurrence)
+    WHERE is_active AND next_occurrence IS NOT NULL;
+
+ALTER TABLE budget.scheduled_transactions ENABLE ROW LEVEL SECURITY;
+
+CREATE POLICY scheduled_transactions_org_scope ON budget.scheduled_transactions
+    FOR ALL USING (
+        organization_id = current_setting('app.current_organization_id', true)
+    );
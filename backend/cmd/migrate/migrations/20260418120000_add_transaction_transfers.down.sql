@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS budget.transactions_counterpart_id_idx;
+DROP INDEX IF EXISTS budget.transactions_transfer_id_idx;
+
+ALTER TABLE budget.transactions
+    DROP CONSTRAINT IF EXISTS transactions_counterpart_self_check,
+    DROP CONSTRAINT IF EXISTS transactions_transfer_link_check,
+    DROP COLUMN IF EXISTS counterpart_id,
+    DROP COLUMN IF EXISTS transfer_id;
@@ -0,0 +1,16 @@
+-- Both legs of a transfer share transfer_id and point at each other through counterpart_id.
+-- The foreign key is deferred so the two legs can be inserted in the same transaction.
+ALTER TABLE budget.transactions
+    ADD COLUMN transfer_id UUID,
+    ADD COLUMN counterpart_id UUID REFERENCES budget.transactions(id) DEFERRABLE INITIALLY DEFERRED,
+    ADD CONSTRAINT transactions_transfer_link_check
+        CHECK ((transfer_id IS NULL) = (counterpart_id IS NULL)),
+    ADD CONSTRAINT transactions_counterpart_self_check
+        CHECK (counterpart_id IS NULL OR counterpart_id <> id);
+
+CREATE INDEX transactions_transfer_id_idx
+    ON budget.transactions (transfer_id)
+    WHERE transfer_id IS NOT NULL;
+CREATE UNIQUE INDEX transactions_counterpart_id_idx
+    ON budget.transactions (counterpart_id)
+    WHERE counterpart_id IS NOT NULL;
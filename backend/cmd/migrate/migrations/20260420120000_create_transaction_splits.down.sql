@@ -0,0 +1,2 @@
+DROP VIEW IF EXISTS budget.transaction_lines;
+DROP TABLE IF EXISTS budget.transaction_splits;
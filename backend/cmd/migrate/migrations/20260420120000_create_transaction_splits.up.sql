@@ -0,0 +1,59 @@
+CREATE TABLE budget.transaction_splits (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    organization_id TEXT NOT NULL REFERENCES identity.organizations(id) ON DELETE CASCADE,
+    transaction_id UUID NOT NULL REFERENCES budget.transactions(id) ON DELETE CASCADE,
+    position SMALLINT NOT NULL,
+    category_id UUID REFERENCES budget.categories(id) ON DELETE SET NULL,
+    subcategory_id UUID REFERENCES budget.categories(id) ON DELETE SET NULL,
+    amount BIGINT NOT NULL,
+    memo VARCHAR(255),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT transaction_splits_position_key UNIQUE (transaction_id, position),
+    CONSTRAINT transaction_splits_amount_check CHECK (amount <> 0)
+);
+
+CREATE INDEX transaction_splits_organization_id_idx
+    ON budget.transaction_splits (organization_id);
+CREATE INDEX transaction_splits_category_id_idx
+    ON budget.transaction_splits (category_id);
+CREATE INDEX transaction_splits_subcategory_id_idx
+    ON budget.transaction_splits (subcategory_id);
+
+ALTER TABLE budget.transaction_splits ENABLE ROW LEVEL SECURITY;
+
+CREATE POLICY transaction_splits_org_scope ON budget.transaction_splits
+    FOR ALL USING (
+        organization_id = current_setting('app.current_organization_id', true)
+    );
+
+-- One row per categorized line: the split lines of split transactions and the
+-- transaction itself otherwise. Budget activity is computed from this view.
+CREATE VIEW budget.transaction_lines WITH (security_invoker = true) AS
+SELECT
+    t.id AS transaction_id,
+    s.id AS split_id,
+    t.organization_id,
+    t.account_id,
+    t.budget_id,
+    s.category_id,
+    s.subcategory_id,
+    s.amount,
+    t.date,
+    t.transfer_id
+FROM budget.transactions t
+JOIN budget.transaction_splits s ON s.transaction_id = t.id
+UNION ALL
+SELECT
+    t.id,
+    NULL,
+    t.organization_id,
+    t.account_id,
+    t.budget_id,
+    t.category_id,
+    t.subcategory_id,
+    t.amount,
+    t.date,
+    t.transfer_id
+FROM budget.transactions t
+WHERE NOT EXISTS (SELECT 1 FROM budget.transaction_splits s WHERE s.transaction_id = t.id);
@@ -0,0 +1,2 @@
+ALTER TABLE budget.accounts
+    DROP COLUMN IF EXISTS opening_balance;
@@ -0,0 +1,9 @@
+-- current_balance is kept equal to opening_balance plus the sum of the account transactions.
+ALTER TABLE budget.accounts
+    ADD COLUMN opening_balance BIGINT NOT NULL DEFAULT 0;
+
+-- Balances were maintained by hand so far: keep them and derive the opening balance
+-- from the transactions already recorded.
+UPDATE budget.accounts a
+SET opening_balance = a.current_balance - COALESCE(
+    (SELECT SUM(t.amount) FROM budget.transactions t WHERE t.account_id = a.id), 0);
@@ -0,0 +1,6 @@
+ALTER TABLE budget.transactions
+    DROP CONSTRAINT IF EXISTS transactions_reconciled_check,
+    DROP COLUMN IF EXISTS reconciliation_id,
+    DROP COLUMN IF EXISTS cleared;
+
+DROP TABLE IF EXISTS budget.reconciliations;
@@ -0,0 +1,41 @@
+CREATE TABLE budget.reconciliations (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    organization_id TEXT NOT NULL REFERENCES identity.organizations(id) ON DELETE CASCADE,
+    account_id UUID NOT NULL REFERENCES budget.accounts(id) ON DELETE CASCADE,
+    statement_date DATE NOT NULL,
+    statement_balance BIGINT NOT NULL,
+    status VARCHAR(10) NOT NULL DEFAULT 'open',
+    adjustment_transaction_id UUID REFERENCES budget.transactions(id) ON DELETE SET NULL,
+    finalized_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT reconciliations_status_check CHECK (status IN ('open', 'finalized')),
+    CONSTRAINT reconciliations_finalized_at_check CHECK ((status = 'finalized') = (finalized_at IS NOT NULL))
+);
+
+CREATE INDEX reconciliations_organization_id_idx
+    ON budget.reconciliations (organization_id);
+CREATE INDEX reconciliations_account_id_idx
+    ON budget.reconciliations (account_id, statement_date);
+-- An account is reconciled against one statement at a time
+CREATE UNIQUE INDEX reconciliations_account_id_open_key
+    ON budget.reconciliations (account_id)
+    WHERE status = 'open';
+
+ALTER TABLE budget.reconciliations ENABLE ROW LEVEL SECURITY;
+
+CREATE POLICY reconciliations_org_scope ON budget.reconciliations
+    FOR ALL USING (
+        organization_id = current_setting('app.current_organization_id', true)
+    );
+
+-- A transaction is cleared once it shows on the bank statement, and reconciled once
+-- a finalized reconciliation locked it.
+ALTER TABLE budget.transactions
+    ADD COLUMN cleared BOOLEAN NOT NULL DEFAULT false,
+    ADD COLUMN reconciliation_id UUID REFERENCES budget.reconciliations(id) ON DELETE RESTRICT,
+    ADD CONSTRAINT transactions_reconciled_check CHECK (reconciliation_id IS NULL OR cleared);
+
+CREATE INDEX transactions_reconciliation_id_idx
+    ON budget.transactions (reconciliation_id)
+    WHERE reconciliation_id IS NOT NULL;
@@ -0,0 +1,12 @@
+DROP INDEX IF EXISTS budget.transactions_account_id_status_idx;
+
+ALTER TABLE budget.transactions
+    ADD COLUMN cleared BOOLEAN NOT NULL DEFAULT false;
+
+UPDATE budget.transactions SET cleared = status <> 'pending';
+
+ALTER TABLE budget.transactions
+    DROP CONSTRAINT transactions_reconciled_check,
+    DROP CONSTRAINT transactions_status_check,
+    DROP COLUMN status,
+    ADD CONSTRAINT transactions_reconciled_check CHECK (reconciliation_id IS NULL OR cleared);
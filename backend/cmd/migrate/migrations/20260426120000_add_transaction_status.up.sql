@@ -0,0 +1,22 @@
+-- A transaction is pending until the bank posts it, cleared once it shows on a bank
+-- statement and reconciled once a finalized reconciliation locked it.
+ALTER TABLE budget.transactions
+    ADD COLUMN status VARCHAR(10) NOT NULL DEFAULT 'pending',
+    ADD CONSTRAINT transactions_status_check CHECK (status IN ('pending', 'cleared', 'reconciled'));
+
+UPDATE budget.transactions
+SET status = CASE
+    WHEN reconciliation_id IS NOT NULL THEN 'reconciled'
+    WHEN cleared THEN 'cleared'
+    ELSE 'pending'
+END;
+
+ALTER TABLE budget.transactions
+    DROP CONSTRAINT transactions_reconciled_check,
+    DROP COLUMN cleared,
+    ADD CONSTRAINT transactions_reconciled_check
+        CHECK ((status = 'reconciled') = (reconciliation_id IS NOT NULL));
+
+-- Cleared balance of an account
+CREATE INDEX transactions_account_id_status_idx
+    ON budget.transactions (account_id, status);
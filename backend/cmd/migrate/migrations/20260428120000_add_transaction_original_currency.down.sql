@@ -0,0 +1,6 @@
+ALTER TABLE budget.transactions
+    DROP CONSTRAINT transactions_exchange_rate_check,
+    DROP CONSTRAINT transactions_original_currency_check,
+    DROP COLUMN exchange_rate,
+    DROP COLUMN original_amount,
+    DROP COLUMN original_currency_code;
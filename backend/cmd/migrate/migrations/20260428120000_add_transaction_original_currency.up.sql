@@ -0,0 +1,12 @@
+-- A transaction charged in another currency than its account keeps the charged amount,
+-- in minor units of that currency, and the rate that converted it into the account
+-- currency (account currency units per one unit of the original currency).
+ALTER TABLE budget.transactions
+    ADD COLUMN original_currency_code VARCHAR(3) REFERENCES budget.currencies(code) ON DELETE RESTRICT,
+    ADD COLUMN original_amount BIGINT,
+    ADD COLUMN exchange_rate NUMERIC(20, 10),
+    ADD CONSTRAINT transactions_original_currency_check
+        CHECK ((original_currency_code IS NULL) = (original_amount IS NULL)
+            AND (original_currency_code IS NULL) = (exchange_rate IS NULL)),
+    ADD CONSTRAINT transactions_exchange_rate_check
+        CHECK (exchange_rate IS NULL OR exchange_rate > 0);
@@ -0,0 +1,31 @@
+DROP FUNCTION IF EXISTS budget.convert_amount(TEXT, BIGINT, VARCHAR, VARCHAR, DATE);
+
+CREATE OR REPLACE FUNCTION budget.convert_amount(
+    p_organization_id TEXT,
+    p_amount BIGINT,
+    p_from VARCHAR(3),
+    p_to VARCHAR(3)
+)
+RETURNS BIGINT
+LANGUAGE sql
+STABLE
+AS $$
+    SELECT CASE
+        WHEN p_from = p_to THEN p_amount
+        ELSE ROUND(
+            p_amount * t.rate / f.rate
+            * POWER(10::NUMERIC, tc.decimal_places - fc.decimal_places)
+        )::BIGINT
+    END
+    FROM budget.currencies fc
+    JOIN budget.currencies tc ON tc.code = p_to
+    LEFT JOIN budget.organization_currencies f
+        ON f.organization_id = p_organization_id AND f.currency_code = p_from
+    LEFT JOIN budget.organization_currencies t
+        ON t.organization_id = p_organization_id AND t.currency_code = p_to
+    WHERE fc.code = p_from
+$$;
+
+DROP FUNCTION IF EXISTS budget.rate_on(TEXT, VARCHAR, DATE);
+
+DROP TABLE IF EXISTS budget.exchange_rates;
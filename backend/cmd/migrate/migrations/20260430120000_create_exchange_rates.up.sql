@@ -0,0 +1,76 @@
+-- Rate history of the organization currencies. A rate applies from effective_from until
+-- the next one takes over; organization_currencies.rate keeps the rate in effect today.
+CREATE TABLE budget.exchange_rates (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    organization_id TEXT NOT NULL,
+    currency_code VARCHAR(3) NOT NULL,
+    rate NUMERIC(20, 10) NOT NULL,
+    effective_from DATE NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT exchange_rates_organization_currency_fkey FOREIGN KEY (organization_id, currency_code)
+        REFERENCES budget.organization_currencies (organization_id, currency_code) ON DELETE CASCADE,
+    CONSTRAINT exchange_rates_unique UNIQUE (organization_id, currency_code, effective_from),
+    CONSTRAINT exchange_rates_rate_check CHECK (rate > 0)
+);
+
+ALTER TABLE budget.exchange_rates ENABLE ROW LEVEL SECURITY;
+
+CREATE POLICY exchange_rates_org_scope ON budget.exchange_rates
+    FOR ALL USING (
+        organization_id = current_setting('app.current_organization_id', true)
+    );
+
+INSERT INTO budget.exchange_rates (organization_id, currency_code, rate, effective_from)
+SELECT organization_id, currency_code, rate, created_at::DATE
+FROM budget.organization_currencies;
+
+-- Rate of a currency of an organization in effect on a date. Dates before the first
+-- recorded rate use that first rate. Returns NULL when the currency has no rate.
+CREATE OR REPLACE FUNCTION budget.rate_on(
+    p_organization_id TEXT,
+    p_currency VARCHAR(3),
+    p_on DATE
+)
+RETURNS NUMERIC
+LANGUAGE sql
+STABLE
+AS $$
+    SELECT COALESCE(
+        (SELECT rate FROM budget.exchange_rates
+         WHERE organization_id = p_organization_id AND currency_code = p_currency AND effective_from <= p_on
+         ORDER BY effective_from DESC
+         LIMIT 1),
+        (SELECT rate FROM budget.exchange_rates
+         WHERE organization_id = p_organization_id AND currency_code = p_currency
+         ORDER BY effective_from
+         LIMIT 1)
+    )
+$$;
+
+-- Conversions now take the date the amount belongs to, so past amounts keep the rate
+-- that was in effect at the time.
+DROP FUNCTION budget.convert_amount(TEXT, BIGINT, VARCHAR, VARCHAR);
+
+CREATE FUNCTION budget.convert_amount(
+    p_organization_id TEXT,
+    p_amount BIGINT,
+    p_from VARCHAR(3),
+    p_to VARCHAR(3),
+    p_on DATE
+)
+RETURNS BIGINT
+LANGUAGE sql
+STABLE
+AS $$
+    SELECT CASE
+        WHEN p_from = p_to THEN p_amount
+        ELSE ROUND(
+            p_amount * budget.rate_on(p_organization_id, p_to, p_on) / budget.rate_on(p_organization_id, p_from, p_on)
+            * POWER(10::NUMERIC, tc.decimal_places - fc.decimal_places)
+        )::BIGINT
+    END
+    FROM budget.currencies fc
+    JOIN budget.currencies tc ON tc.code = p_to
+    WHERE fc.code = p_from
+$$;
@@ -0,0 +1,45 @@
+-- A mapping profile tells the CSV importer where a bank puts each field. Columns are
+-- numbered from 1 as spreadsheets show them. A row carries either a signed amount or
+-- separate debit and credit columns.
+CREATE TABLE budget.import_profiles (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    organization_id TEXT NOT NULL REFERENCES identity.organizations(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    delimiter VARCHAR(1) NOT NULL DEFAULT ',',
+    skip_rows INTEGER NOT NULL DEFAULT 1,
+    date_column INTEGER NOT NULL,
+    date_format VARCHAR(20) NOT NULL DEFAULT 'YYYY-MM-DD',
+    amount_column INTEGER,
+    debit_column INTEGER,
+    credit_column INTEGER,
+    description_column INTEGER,
+    reference_column INTEGER,
+    decimal_separator VARCHAR(1) NOT NULL DEFAULT '.',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT import_profiles_organization_id_name_key UNIQUE (organization_id, name),
+    CONSTRAINT import_profiles_amount_columns_check CHECK (
+        (amount_column IS NOT NULL AND debit_column IS NULL AND credit_column IS NULL)
+        OR (amount_column IS NULL AND debit_column IS NOT NULL AND credit_column IS NOT NULL)
+    ),
+    CONSTRAINT import_profiles_columns_check CHECK (
+        date_column >= 1
+        AND COALESCE(amount_column, 1) >= 1
+        AND COALESCE(debit_column, 1) >= 1
+        AND COALESCE(credit_column, 1) >= 1
+        AND COALESCE(description_column, 1) >= 1
+        AND COALESCE(reference_column, 1) >= 1
+    ),
+    CONSTRAINT import_profiles_skip_rows_check CHECK (skip_rows >= 0),
+    CONSTRAINT import_profiles_decimal_separator_check CHECK (decimal_separator IN ('.', ','))
+);
+
+CREATE INDEX import_profiles_organization_id_idx
+    ON budget.import_profiles (organization_id);
+
+ALTER TABLE budget.import_profiles ENABLE ROW LEVEL SECURITY;
+
+CREATE POLICY import_profiles_org_scope ON budget.import_profiles
+    FOR ALL USING (
+        organization_id = current_setting('app.current_organization_id', true)
+    );
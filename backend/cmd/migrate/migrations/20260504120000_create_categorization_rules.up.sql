@@ -0,0 +1,33 @@
+-- A categorization rule files the transactions it matches under a category, and
+-- optionally a subcategory and a budget. Conditions are a JSON array of filters,
+-- {"field": ..., "operator": ..., "value": ...}, written with the dafi operators and
+-- all of which must hold. Rules are tried from the lowest priority up; the first that
+-- matches wins.
+CREATE TABLE budget.categorization_rules (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    organization_id TEXT NOT NULL REFERENCES identity.organizations(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    priority INTEGER NOT NULL DEFAULT 0,
+    conditions JSONB NOT NULL,
+    category_id UUID NOT NULL REFERENCES budget.categories(id) ON DELETE CASCADE,
+    subcategory_id UUID REFERENCES budget.categories(id) ON DELETE SET NULL,
+    budget_id UUID REFERENCES budget.budgets(id) ON DELETE SET NULL,
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT categorization_rules_organization_id_name_key UNIQUE (organization_id, name),
+    CONSTRAINT categorization_rules_conditions_check CHECK (
+        CASE WHEN jsonb_typeof(conditions) = 'array' THEN jsonb_array_length(conditions) > 0 ELSE FALSE END
+    )
+);
+
+CREATE INDEX categorization_rules_organization_id_priority_idx
+    ON budget.categorization_rules (organization_id, priority)
+    WHERE is_active;
+
+ALTER TABLE budget.categorization_rules ENABLE ROW LEVEL SECURITY;
+
+CREATE POLICY categorization_rules_org_scope ON budget.categorization_rules
+    FOR ALL USING (
+        organization_id = current_setting('app.current_organization_id', true)
+    );
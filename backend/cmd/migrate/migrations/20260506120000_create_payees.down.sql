@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS budget.transactions_payee_id_idx;
+
+ALTER TABLE budget.transactions
+    DROP COLUMN payee_id;
+
+DROP TABLE IF EXISTS budget.payees;
@@ -0,0 +1,30 @@
+-- A payee is the merchant or person on the other side of a transaction. Its name and
+-- aliases are compared with the normalized description of new transactions; patterns
+-- are matched against the description with * and ? wildcards, ignoring case. The
+-- default category files the transactions of the payee no categorization rule matched.
+CREATE TABLE budget.payees (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    organization_id TEXT NOT NULL REFERENCES identity.organizations(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    aliases TEXT[] NOT NULL DEFAULT '{}',
+    patterns TEXT[] NOT NULL DEFAULT '{}',
+    default_category_id UUID REFERENCES budget.categories(id) ON DELETE SET NULL,
+    default_subcategory_id UUID REFERENCES budget.categories(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT payees_organization_id_name_key UNIQUE (organization_id, name),
+    CONSTRAINT payees_default_subcategory_check
+        CHECK (default_subcategory_id IS NULL OR default_category_id IS NOT NULL)
+);
+
+ALTER TABLE budget.payees ENABLE ROW LEVEL SECURITY;
+
+CREATE POLICY payees_org_scope ON budget.payees
+    FOR ALL USING (
+        organization_id = current_setting('app.current_organization_id', true)
+    );
+
+ALTER TABLE budget.transactions
+    ADD COLUMN payee_id UUID REFERENCES budget.payees(id) ON DELETE SET NULL;
+
+CREATE INDEX transactions_payee_id_idx ON budget.transactions (payee_id);
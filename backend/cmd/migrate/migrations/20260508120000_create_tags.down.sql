@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS budget.transaction_tags;
+
+DROP TABLE IF EXISTS budget.tags;
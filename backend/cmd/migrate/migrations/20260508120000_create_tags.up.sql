@@ -0,0 +1,44 @@
+-- A tag is a free label an organization puts on transactions, across categories. The
+-- name is unique within the organization regardless of case.
+CREATE TABLE budget.tags (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    organization_id TEXT NOT NULL REFERENCES identity.organizations(id) ON DELETE CASCADE,
+    name VARCHAR(50) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT tags_id_organization_id_key UNIQUE (id, organization_id)
+);
+
+CREATE UNIQUE INDEX tags_organization_id_name_key
+    ON budget.tags (organization_id, lower(name));
+
+ALTER TABLE budget.tags ENABLE ROW LEVEL SECURITY;
+
+CREATE POLICY tags_org_scope ON budget.tags
+    FOR ALL USING (
+        organization_id = current_setting('app.current_organization_id', true)
+    );
+
+-- The tag is referenced together with the organization so a transaction can only
+-- carry the tags of its own organization.
+CREATE TABLE budget.transaction_tags (
+    transaction_id UUID NOT NULL REFERENCES budget.transactions(id) ON DELETE CASCADE,
+    tag_id UUID NOT NULL,
+    organization_id TEXT NOT NULL REFERENCES identity.organizations(id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (transaction_id, tag_id),
+    CONSTRAINT transaction_tags_tag_fkey FOREIGN KEY (tag_id, organization_id)
+        REFERENCES budget.tags (id, organization_id) ON DELETE CASCADE
+);
+
+CREATE INDEX transaction_tags_tag_id_idx
+    ON budget.transaction_tags (tag_id);
+CREATE INDEX transaction_tags_organization_id_idx
+    ON budget.transaction_tags (organization_id);
+
+ALTER TABLE budget.transaction_tags ENABLE ROW LEVEL SECURITY;
+
+CREATE POLICY transaction_tags_org_scope ON budget.transaction_tags
+    FOR ALL USING (
+        organization_id = current_setting('app.current_organization_id', true)
+    );
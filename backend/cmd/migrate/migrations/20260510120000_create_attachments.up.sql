@@ -0,0 +1,29 @@
+-- Receipts and other documents attached to transactions. The content lives in the
+-- blob store under storage_key; the row keeps what is needed to serve and check it.
+-- A transaction cannot be deleted while attachments refer to it: the transaction
+-- service removes them first so their content does not outlive them.
+CREATE TABLE budget.attachments (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    organization_id TEXT NOT NULL REFERENCES identity.organizations(id) ON DELETE CASCADE,
+    transaction_id UUID NOT NULL REFERENCES budget.transactions(id) ON DELETE RESTRICT,
+    file_name VARCHAR(255) NOT NULL,
+    mime_type VARCHAR(100) NOT NULL,
+    size BIGINT NOT NULL,
+    sha256 CHAR(64) NOT NULL,
+    storage_key TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT attachments_storage_key_key UNIQUE (storage_key),
+    CONSTRAINT attachments_size_check CHECK (size > 0)
+);
+
+CREATE INDEX attachments_transaction_id_idx
+    ON budget.attachments (transaction_id);
+CREATE INDEX attachments_organization_id_idx
+    ON budget.attachments (organization_id);
+
+ALTER TABLE budget.attachments ENABLE ROW LEVEL SECURITY;
+
+CREATE POLICY attachments_org_scope ON budget.attachments
+    FOR ALL USING (
+        organization_id = current_setting('app.current_organization_id', true)
+    );
@@ -0,0 +1,3 @@
+ALTER TABLE budget.scheduled_transactions
+    DROP COLUMN retry_after,
+    DROP COLUMN failed_attempts;
@@ -0,0 +1,6 @@
+-- A schedule that fails to generate its transactions is tried again after
+-- retry_after, which backs off with every consecutive failure, so broken schedules
+-- do not keep the head of the due queue.
+ALTER TABLE budget.scheduled_transactions
+    ADD COLUMN failed_attempts INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN retry_after TIMESTAMPTZ;
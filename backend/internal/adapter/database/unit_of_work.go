@@ -0,0 +1,71 @@
+package database
+
+import (
+	"context"
+	"errors"
+
+	"backend/port"
+	"github.com/jackc/pgx/v5"
+	"github.com/samber/oops"
+)
+
+// transaction adapts a pgx.Tx to domain.Transaction so repositories can join it
+// through their WithTx method.
+type transaction struct {
+	tx pgx.Tx
+}
+
+func (t transaction) GetTx() domain.Tx {
+	return t.tx
+}
+
+type unitOfWork struct {
+	pool   PoolInterface
+	logger domain.Logger
+}
+
+// NewUnitOfWork returns a domain.UnitOfWork that opens transactions on the pool.
+func NewUnitOfWork(pool PoolInterface, logger domain.Logger) domain.UnitOfWork {
+	return unitOfWork{
+		pool:   pool,
+		logger: logger.With("component", "database.unit_of_work"),
+	}
+}
+
+func (u unitOfWork) Begin(ctx context.Context) (domain.Transaction, error) {
+	tx, err := u.pool.Begin(ctx)
+	if err != nil {
+		return nil, oops.
+			Code("db_begin_failed").
+			With("error_type", "transaction").
+			Wrapf(err, "failed to begin transaction")
+	}
+
+	return transaction{tx: tx}, nil
+}
+
+func (u unitOfWork) Commit(ctx context.Context, tx domain.Transaction) error {
+	if err := tx.GetTx().Commit(ctx); err != nil {
+		return oops.
+			Code("db_commit_failed").
+			With("error_type", "transaction").
+			Wrapf(err, "failed to commit transaction")
+	}
+
+	return nil
+}
+
+// Rollback aborts the transaction. Rolling back a transaction that was already
+// committed is a no-op, so callers can defer it right after Begin.
+func (u unitOfWork) Rollback(ctx context.Context, tx domain.Transaction) error {
+	err := tx.GetTx().Rollback(ctx)
+	if err != nil && !errors.Is(err, pgx.ErrTxClosed) {
+		u.logger.WithContext(ctx).Error("transaction rollback failed", "error", err)
+		return oops.
+			Code("db_rollback_failed").
+			With("error_type", "transaction").
+			Wrapf(err, "failed to roll back transaction")
+	}
+
+	return nil
+}
@@ -0,0 +1,109 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"backend/adapter/logger"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeTx struct {
+	pgx.Tx
+	commitErr   error
+	rollbackErr error
+	committed   bool
+	rolledBack  bool
+}
+
+func (t *fakeTx) Commit(context.Context) error {
+	t.committed = true
+	return t.commitErr
+}
+
+func (t *fakeTx) Rollback(context.Context) error {
+	t.rolledBack = true
+	return t.rollbackErr
+}
+
+type fakePool struct {
+	tx       *fakeTx
+	beginErr error
+}
+
+func (p fakePool) Query(context.Context, string, ...any) (pgx.Rows, error) { return nil, nil }
+func (p fakePool) QueryRow(context.Context, string, ...any) pgx.Row        { return nil }
+func (p fakePool) Exec(context.Context, string, ...any) (pgconn.CommandTag, error) {
+	return pgconn.CommandTag{}, nil
+}
+func (p fakePool) Ping(context.Context) error { return nil }
+func (p fakePool) Close()                     {}
+
+func (p fakePool) Begin(context.Context) (pgx.Tx, error) {
+	if p.beginErr != nil {
+		return nil, p.beginErr
+	}
+	return p.tx, nil
+}
+
+func TestUnitOfWork_BeginCommit(t *testing.T) {
+	tx := &fakeTx{}
+	uow := NewUnitOfWork(fakePool{tx: tx}, logger.NewNoop())
+
+	trx, err := uow.Begin(context.Background())
+	require.NoError(t, err)
+	assert.Same(t, tx, trx.GetTx())
+
+	require.NoError(t, uow.Commit(context.Background(), trx))
+	assert.True(t, tx.committed)
+}
+
+func TestUnitOfWork_BeginError(t *testing.T) {
+	uow := NewUnitOfWork(fakePool{beginErr: errors.New("pool exhausted")}, logger.NewNoop())
+
+	_, err := uow.Begin(context.Background())
+	assert.Error(t, err)
+}
+
+func TestUnitOfWork_Rollback(t *testing.T) {
+	tests := []struct {
+		name        string
+		rollbackErr error
+		expectError bool
+	}{
+		{
+			name: "open transaction",
+		},
+		{
+			name:        "already committed",
+			rollbackErr: pgx.ErrTxClosed,
+		},
+		{
+			name:        "connection lost",
+			rollbackErr: errors.New("conn closed"),
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tx := &fakeTx{rollbackErr: tt.rollbackErr}
+			uow := NewUnitOfWork(fakePool{tx: tx}, logger.NewNoop())
+
+			trx, err := uow.Begin(context.Background())
+			require.NoError(t, err)
+
+			err = uow.Rollback(context.Background(), trx)
+			if tt.expectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.True(t, tx.rolledBack)
+		})
+	}
+}
@@ -14,10 +14,11 @@ type ConfigOptions struct {
 
 // LocalConfig holds the complete application configuration.
 type LocalConfig struct {
-	Service  Service
-	Database Database
-	Resend   Resend
-	Identity Identity
+	Service     Service
+	Database    Database
+	Resend      Resend
+	Identity    Identity
+	Attachments Attachments
 }
 
 // Identity holds identity service configuration.
@@ -44,6 +45,17 @@ type Resend struct {
 	FromAddress string
 }
 
+// Attachments holds where attachment content is stored. Content goes to the S3
+// bucket when one is set and below Path otherwise.
+type Attachments struct {
+	Path              string
+	S3Endpoint        string
+	S3Region          string
+	S3Bucket          string
+	S3AccessKeyID     string
+	S3SecretAccessKey string
+}
+
 func getPort() int {
 	p := os.Getenv("SERVICE_PORT")
 	if p == "" {
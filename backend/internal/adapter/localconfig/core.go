@@ -67,6 +67,14 @@ func GetConfigWithOptions(opts ConfigOptions, log domain.Logger) (LocalConfig, e
 			URL:            getEnvAsString("IDENTITY_URL"),
 			InternalAPIKey: getEnvAsString("INTERNAL_API_KEY"),
 		},
+		Attachments: Attachments{
+			Path:              getEnvOrDefault("ATTACHMENTS_PATH", "data/attachments"),
+			S3Endpoint:        getEnvAsString("ATTACHMENTS_S3_ENDPOINT"),
+			S3Region:          getEnvOrDefault("ATTACHMENTS_S3_REGION", "us-east-1"),
+			S3Bucket:          getEnvAsString("ATTACHMENTS_S3_BUCKET"),
+			S3AccessKeyID:     getEnvAsString("ATTACHMENTS_S3_ACCESS_KEY_ID"),
+			S3SecretAccessKey: getEnvAsString("ATTACHMENTS_S3_SECRET_ACCESS_KEY"),
+		},
 	}
 
 	log.Debug("configuration loaded",
@@ -7,6 +7,7 @@ import (
 	basedomain "backend/port"
 	apperrors "backend/port/errors"
 
+	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
 	"github.com/samber/oops"
 )
@@ -96,3 +97,19 @@ func (h HTTP) Delete(c echo.Context) error {
 
 	return httpresponse.NoContent(c)
 }
+
+func (h HTTP) RecomputeBalance(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return oops.WithContext(ctx).In(apperrors.LayerHandler).Code(apperrors.CodeBadRequest).Wrap(err)
+	}
+
+	recomputation, err := h.svc.RecomputeBalance(ctx, id)
+	if err != nil {
+		return oops.WithContext(ctx).In(apperrors.LayerHandler).Wrap(err)
+	}
+
+	return httpresponse.OK(c, recomputation)
+}
@@ -12,6 +12,7 @@ import (
 	basedomain "backend/port"
 	apperrors "backend/port/errors"
 
+	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/samber/oops"
@@ -34,6 +35,29 @@ var columns = []string{
 	"account_number",
 	"currency_code",
 	"current_balance",
+	"opening_balance",
+	"is_active",
+	"created_at",
+	"updated_at",
+}
+
+// clearedBalance is the opening balance plus the transactions that are no longer
+// pending.
+const clearedBalance = `opening_balance + COALESCE((
+    SELECT SUM(t.amount) FROM budget.transactions t
+    WHERE t.account_id = budget.accounts.id AND t.status <> 'pending'), 0)`
+
+var selectColumns = []string{
+	"id",
+	"organization_id",
+	"name",
+	"type",
+	"institution",
+	"account_number",
+	"currency_code",
+	"current_balance",
+	sqlcraft.As(clearedBalance, "cleared_balance"),
+	"opening_balance",
 	"is_active",
 	"created_at",
 	"updated_at",
@@ -48,6 +72,7 @@ var sqlColumnByDomainField = map[string]string{
 	"accountNumber":  "account_number",
 	"currencyCode":   "currency_code",
 	"currentBalance": "current_balance",
+	"openingBalance": "opening_balance",
 	"isActive":       "is_active",
 	"createdAt":      "created_at",
 	"updatedAt":      "updated_at",
@@ -73,7 +98,7 @@ func (r postgres) WithTx(tx basedomain.Transaction) port.Repository {
 }
 
 func (r postgres) FindOne(ctx context.Context, criteria dafi.Criteria) (port.Account, error) {
-	query := sqlcraft.Select(columns...).
+	query := sqlcraft.Select(selectColumns...).
 		From(tableName).
 		Where(criteria.Filters...).
 		SQLColumnByDomainField(sqlColumnByDomainField).
@@ -98,6 +123,8 @@ func (r postgres) FindOne(ctx context.Context, criteria dafi.Criteria) (port.Acc
 		&acct.AccountNumber,
 		&acct.CurrencyCode,
 		&acct.CurrentBalance,
+		&acct.ClearedBalance,
+		&acct.OpeningBalance,
 		&acct.IsActive,
 		&acct.CreatedAt,
 		&acct.UpdatedAt,
@@ -108,12 +135,13 @@ func (r postgres) FindOne(ctx context.Context, criteria dafi.Criteria) (port.Acc
 		}
 		return port.Account{}, oops.WithContext(ctx).In(apperrors.LayerRepository).Wrap(err)
 	}
+	acct.WorkingBalance = acct.CurrentBalance
 
 	return acct, nil
 }
 
 func (r postgres) FindAll(ctx context.Context, criteria dafi.Criteria) (basedomain.List[port.Account], error) {
-	query := sqlcraft.Select(columns...).
+	query := sqlcraft.Select(selectColumns...).
 		From(tableName).
 		Where(criteria.Filters...).
 		OrderBy(criteria.Sorts...).
@@ -146,6 +174,8 @@ func (r postgres) FindAll(ctx context.Context, criteria dafi.Criteria) (basedoma
 			&acct.AccountNumber,
 			&acct.CurrencyCode,
 			&acct.CurrentBalance,
+			&acct.ClearedBalance,
+			&acct.OpeningBalance,
 			&acct.IsActive,
 			&acct.CreatedAt,
 			&acct.UpdatedAt,
@@ -153,6 +183,7 @@ func (r postgres) FindAll(ctx context.Context, criteria dafi.Criteria) (basedoma
 		if err != nil {
 			return nil, oops.WithContext(ctx).In(apperrors.LayerRepository).Wrap(err)
 		}
+		acct.WorkingBalance = acct.CurrentBalance
 		accts = append(accts, acct)
 	}
 
@@ -173,6 +204,7 @@ func (r postgres) Create(ctx context.Context, input port.CreateAccount) error {
 			input.AccountNumber,
 			input.CurrencyCode,
 			input.CurrentBalance.Minor,
+			input.CurrentBalance.Minor,
 			input.IsActive,
 			now,
 			now,
@@ -211,6 +243,7 @@ func (r postgres) CreateBulk(ctx context.Context, inputs basedomain.List[port.Cr
 			input.AccountNumber,
 			input.CurrencyCode,
 			input.CurrentBalance.Minor,
+			input.CurrentBalance.Minor,
 			input.IsActive,
 			now,
 			now,
@@ -234,7 +267,7 @@ func (r postgres) CreateBulk(ctx context.Context, inputs basedomain.List[port.Cr
 
 func (r postgres) Update(ctx context.Context, input port.UpdateAccount, filters ...dafi.Filter) error {
 	query := sqlcraft.Update(tableName).
-		WithColumns("name", "type", "institution", "account_number", "currency_code", "current_balance", "is_active", "updated_at").
+		WithColumns("name", "type", "institution", "account_number", "currency_code", "current_balance", "opening_balance", "is_active", "updated_at").
 		WithValues(
 			input.Name,
 			input.Type,
@@ -242,6 +275,7 @@ func (r postgres) Update(ctx context.Context, input port.UpdateAccount, filters
 			input.AccountNumber,
 			input.CurrencyCode,
 			input.CurrentBalance,
+			input.OpeningBalance,
 			input.IsActive,
 			time.Now(),
 		).
@@ -264,6 +298,80 @@ func (r postgres) Update(ctx context.Context, input port.UpdateAccount, filters
 	return nil
 }
 
+const adjustBalanceQuery = `
+UPDATE budget.accounts
+SET current_balance = current_balance + $2, updated_at = $3
+WHERE id = $1`
+
+func (r postgres) AdjustBalance(ctx context.Context, accountID uuid.UUID, delta int64) error {
+	r.logger.WithContext(ctx).Debug("executing query", "sql", adjustBalanceQuery)
+
+	tag, err := r.db.Exec(ctx, adjustBalanceQuery, accountID, delta, time.Now())
+	if err != nil {
+		return oops.WithContext(ctx).In(apperrors.LayerRepository).Wrap(err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return oops.WithContext(ctx).In(apperrors.LayerRepository).
+			Code(apperrors.CodeNotFound).
+			Errorf("account %s not found", accountID)
+	}
+
+	return nil
+}
+
+const lockQuery = `
+SELECT id, current_balance, opening_balance FROM budget.accounts WHERE id = $1 FOR UPDATE`
+
+func (r postgres) Lock(ctx context.Context, accountID uuid.UUID) (port.Account, error) {
+	r.logger.WithContext(ctx).Debug("executing query", "sql", lockQuery)
+
+	var acct port.Account
+	err := r.db.QueryRow(ctx, lockQuery, accountID).Scan(&acct.ID, &acct.CurrentBalance, &acct.OpeningBalance)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return port.Account{}, oops.WithContext(ctx).In(apperrors.LayerRepository).Code(apperrors.CodeNotFound).Wrap(err)
+		}
+		return port.Account{}, oops.WithContext(ctx).In(apperrors.LayerRepository).Wrap(err)
+	}
+
+	return acct, nil
+}
+
+// recomputeBalanceQuery resets the balance of an account to its opening balance plus
+// the sum of its transactions, returning the balance before and after.
+const recomputeBalanceQuery = `
+WITH previous AS (
+    SELECT id, current_balance FROM budget.accounts WHERE id = $1 FOR UPDATE
+)
+UPDATE budget.accounts a
+SET current_balance = a.opening_balance + COALESCE(
+        (SELECT SUM(t.amount) FROM budget.transactions t WHERE t.account_id = a.id), 0),
+    updated_at = $2
+FROM previous p
+WHERE a.id = p.id
+RETURNING p.current_balance, a.current_balance`
+
+func (r postgres) RecomputeBalance(ctx context.Context, accountID uuid.UUID) (port.BalanceRecomputation, error) {
+	r.logger.WithContext(ctx).Debug("executing query", "sql", recomputeBalanceQuery)
+
+	recomputation := port.BalanceRecomputation{AccountID: accountID}
+	err := r.db.QueryRow(ctx, recomputeBalanceQuery, accountID, time.Now()).Scan(
+		&recomputation.PreviousBalance,
+		&recomputation.CurrentBalance,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return port.BalanceRecomputation{}, oops.WithContext(ctx).In(apperrors.LayerRepository).Code(apperrors.CodeNotFound).Wrap(err)
+		}
+		return port.BalanceRecomputation{}, oops.WithContext(ctx).In(apperrors.LayerRepository).Wrap(err)
+	}
+
+	recomputation.Drift = recomputation.PreviousBalance - recomputation.CurrentBalance
+
+	return recomputation, nil
+}
+
 const pgErrForeignKeyViolation = "23503"
 
 func (r postgres) Delete(ctx context.Context, filters ...dafi.Filter) error {
@@ -0,0 +1,188 @@
+package core
+
+import (
+	"context"
+	"testing"
+
+	"backend/core/budget/account/port"
+	"backend/infra/dafi"
+	"backend/infra/money"
+	apperrors "backend/port/errors"
+
+	"github.com/google/uuid"
+	"github.com/guregu/null/v6"
+	"github.com/samber/oops"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestService_Update_CurrentBalanceMovesOpeningBalance(t *testing.T) {
+	t.Parallel()
+
+	id := uuid.MustParse("44444444-4444-4444-4444-444444444444")
+	acctRepo := &stubAccountRepo{
+		findResult: port.Account{
+			ID:             id,
+			CurrencyCode:   "USD",
+			CurrentBalance: money.Minor(7000),
+			OpeningBalance: money.Minor(10000),
+		},
+	}
+
+	svc := New(acctRepo, &stubTxnRepo{}, stubCurrencyRepo{}, stubUnitOfWork{}, noopLogger{})
+
+	err := svc.Update(context.Background(), port.UpdateAccount{CurrentBalance: majorBalance(t, "80")}, dafi.FilterBy("id", dafi.Equal, id)...)
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, acctRepo.locked)
+	require.Len(t, acctRepo.updates, 1)
+	assert.Equal(t, money.Minor(8000), acctRepo.updates[0].CurrentBalance.Amount.Minor)
+	assert.Equal(t, int64(11000), acctRepo.updates[0].OpeningBalance.Int64)
+}
+
+func TestService_Update_CurrentBalanceInCurrencyDecimalPlaces(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		account      string
+		currencyCode null.String
+		want         money.Minor
+	}{
+		{name: "account currency", account: "CLP", want: 80},
+		{name: "new currency", account: "CLP", currencyCode: null.StringFrom("KWD"), want: 80000},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			id := uuid.New()
+			acctRepo := &stubAccountRepo{findResult: port.Account{ID: id, CurrencyCode: tt.account}}
+			svc := New(acctRepo, &stubTxnRepo{}, stubCurrencyRepo{}, stubUnitOfWork{}, noopLogger{})
+
+			err := svc.Update(context.Background(), port.UpdateAccount{CurrencyCode: tt.currencyCode, CurrentBalance: majorBalance(t, "80")}, dafi.FilterBy("id", dafi.Equal, id)...)
+			require.NoError(t, err)
+
+			require.Len(t, acctRepo.updates, 1)
+			assert.Equal(t, tt.want, acctRepo.updates[0].CurrentBalance.Amount.Minor)
+			assert.Equal(t, int64(tt.want), acctRepo.updates[0].OpeningBalance.Int64)
+		})
+	}
+}
+
+func TestService_Update_CurrentBalanceInUnknownCurrency(t *testing.T) {
+	t.Parallel()
+
+	id := uuid.New()
+	acctRepo := &stubAccountRepo{findResult: port.Account{ID: id, CurrencyCode: "CLP"}}
+	svc := New(acctRepo, &stubTxnRepo{}, stubCurrencyRepo{}, stubUnitOfWork{}, noopLogger{})
+
+	err := svc.Update(context.Background(), port.UpdateAccount{CurrencyCode: null.StringFrom("XXX"), CurrentBalance: majorBalance(t, "80")}, dafi.FilterBy("id", dafi.Equal, id)...)
+	require.Error(t, err)
+
+	oopsErr, ok := oops.AsOops(err)
+	require.True(t, ok)
+	assert.Equal(t, apperrors.CodeValidation, oopsErr.Code())
+	assert.Empty(t, acctRepo.updates)
+}
+
+func majorBalance(t *testing.T, s string) money.NullMajorAmount {
+	t.Helper()
+	amount, err := money.MajorAmountFrom(s)
+	require.NoError(t, err)
+	return money.NullMajorAmountFrom(amount)
+}
+
+func TestService_Update_WithoutBalanceKeepsOpeningBalance(t *testing.T) {
+	t.Parallel()
+
+	acctRepo := &stubAccountRepo{}
+	svc := New(acctRepo, &stubTxnRepo{}, stubCurrencyRepo{}, stubUnitOfWork{}, noopLogger{})
+
+	err := svc.Update(context.Background(), port.UpdateAccount{Name: null.StringFrom("Checking")}, dafi.FilterBy("id", dafi.Equal, uuid.New())...)
+	require.NoError(t, err)
+
+	require.Len(t, acctRepo.updates, 1)
+	assert.False(t, acctRepo.updates[0].OpeningBalance.Valid)
+}
+
+func TestService_Create_BalanceInCurrencyDecimalPlaces(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		currency string
+		balance  string
+		want     money.Minor
+	}{
+		{currency: "USD", balance: "40", want: 4000},
+		{currency: "CLP", balance: "40", want: 40},
+		{currency: "JPY", balance: "1500", want: 1500},
+		{currency: "KWD", balance: "12.345", want: 12345},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.currency, func(t *testing.T) {
+			t.Parallel()
+
+			balance, err := money.MajorAmountFrom(tt.balance)
+			require.NoError(t, err)
+
+			acctRepo := &stubAccountRepo{}
+			svc := New(acctRepo, &stubTxnRepo{}, stubCurrencyRepo{}, stubUnitOfWork{}, noopLogger{})
+
+			err = svc.Create(context.Background(), port.CreateAccount{
+				ID:             uuid.New(),
+				Name:           "Checking",
+				Type:           "checking",
+				CurrencyCode:   tt.currency,
+				CurrentBalance: balance,
+			})
+			require.NoError(t, err)
+
+			require.Len(t, acctRepo.created, 1)
+			assert.Equal(t, tt.want, acctRepo.created[0].CurrentBalance.Minor)
+		})
+	}
+}
+
+func TestService_Create_UnknownCurrency(t *testing.T) {
+	t.Parallel()
+
+	acctRepo := &stubAccountRepo{}
+	svc := New(acctRepo, &stubTxnRepo{}, stubCurrencyRepo{}, stubUnitOfWork{}, noopLogger{})
+
+	err := svc.Create(context.Background(), port.CreateAccount{
+		ID:           uuid.New(),
+		Name:         "Checking",
+		Type:         "checking",
+		CurrencyCode: "XXX",
+	})
+	require.Error(t, err)
+
+	oopsErr, ok := oops.AsOops(err)
+	require.True(t, ok)
+	assert.Equal(t, apperrors.CodeValidation, oopsErr.Code())
+	assert.Empty(t, acctRepo.created)
+}
+
+func TestService_RecomputeBalance(t *testing.T) {
+	t.Parallel()
+
+	id := uuid.MustParse("55555555-5555-5555-5555-555555555555")
+	acctRepo := &stubAccountRepo{
+		recomputation: port.BalanceRecomputation{
+			AccountID:       id,
+			PreviousBalance: money.Minor(9000),
+			CurrentBalance:  money.Minor(8500),
+			Drift:           money.Minor(500),
+		},
+	}
+
+	svc := New(acctRepo, &stubTxnRepo{}, stubCurrencyRepo{}, stubUnitOfWork{}, noopLogger{})
+
+	recomputation, err := svc.RecomputeBalance(context.Background(), id)
+	require.NoError(t, err)
+	assert.Equal(t, money.Minor(8500), recomputation.CurrentBalance)
+	assert.Equal(t, money.Minor(500), recomputation.Drift)
+}
@@ -2,34 +2,50 @@ package core
 
 import (
 	"context"
+	"fmt"
 
 	"backend/core/budget/account/port"
+	currencyport "backend/core/budget/currency/port"
 	transactionport "backend/core/budget/transaction/port"
 	"backend/infra/dafi"
 	basedomain "backend/port"
 	apperrors "backend/port/errors"
 
+	"github.com/google/uuid"
+	"github.com/guregu/null/v6"
 	"github.com/samber/oops"
 )
 
 type service struct {
 	repo                  port.Repository
 	transactionRepository transactionport.Repository
+	currencyRepository    currencyport.Repository
+	uow                   basedomain.UnitOfWork
+	tx                    basedomain.Transaction
 	logger                basedomain.Logger
 }
 
-func New(repo port.Repository, transactionRepository transactionport.Repository, logger basedomain.Logger) port.Service {
+func New(repo port.Repository, transactionRepository transactionport.Repository, currencyRepository currencyport.Repository, uow basedomain.UnitOfWork, logger basedomain.Logger) port.Service {
 	return service{
 		repo:                  repo,
 		transactionRepository: transactionRepository,
+		currencyRepository:    currencyRepository,
+		uow:                   uow,
 		logger:                logger.With("component", "account.service"),
 	}
 }
 
 func (s service) WithTx(tx basedomain.Transaction) port.Service {
+	return s.withTx(tx)
+}
+
+func (s service) withTx(tx basedomain.Transaction) service {
 	return service{
 		repo:                  s.repo.WithTx(tx),
 		transactionRepository: s.transactionRepository.WithTx(tx),
+		currencyRepository:    s.currencyRepository,
+		uow:                   s.uow,
+		tx:                    tx,
 		logger:                s.logger,
 	}
 }
@@ -57,6 +73,10 @@ func (s service) Create(ctx context.Context, input port.CreateAccount) error {
 		return oops.WithContext(ctx).In(apperrors.LayerService).Code(apperrors.CodeValidation).Wrap(err)
 	}
 
+	if err := s.resolveBalance(ctx, &input); err != nil {
+		return oops.WithContext(ctx).In(apperrors.LayerService).Wrap(err)
+	}
+
 	if err := s.repo.Create(ctx, input); err != nil {
 		return oops.WithContext(ctx).In(apperrors.LayerService).Wrap(err)
 	}
@@ -67,6 +87,12 @@ func (s service) Create(ctx context.Context, input port.CreateAccount) error {
 }
 
 func (s service) CreateBulk(ctx context.Context, inputs basedomain.List[port.CreateAccount]) error {
+	for i := range inputs {
+		if err := s.resolveBalance(ctx, &inputs[i]); err != nil {
+			return oops.WithContext(ctx).In(apperrors.LayerService).Wrap(err)
+		}
+	}
+
 	if err := s.repo.CreateBulk(ctx, inputs); err != nil {
 		return oops.WithContext(ctx).In(apperrors.LayerService).Wrap(err)
 	}
@@ -76,20 +102,116 @@ func (s service) CreateBulk(ctx context.Context, inputs basedomain.List[port.Cre
 	return nil
 }
 
+// resolveBalance converts the balance given in major units into minor units of the
+// account currency, e.g. 40 is 4000 cents in USD but 40 pesos in CLP.
+func (s service) resolveBalance(ctx context.Context, input *port.CreateAccount) error {
+	decimalPlaces, err := s.decimalPlaces(ctx, input.CurrencyCode)
+	if err != nil {
+		return err
+	}
+
+	if err := input.CurrentBalance.Resolve(decimalPlaces); err != nil {
+		return oops.WithContext(ctx).In(apperrors.LayerService).Code(apperrors.CodeValidation).Wrap(err)
+	}
+
+	return nil
+}
+
+// decimalPlaces returns the decimal places of a currency, refusing an unknown one.
+func (s service) decimalPlaces(ctx context.Context, code string) (int16, error) {
+	currency, err := s.currencyRepository.FindOne(ctx, dafi.Where("code", dafi.Equal, code))
+	if err != nil {
+		if oopsErr, ok := oops.AsOops(err); ok && oopsErr.Code() == apperrors.CodeNotFound {
+			return 0, oops.WithContext(ctx).In(apperrors.LayerService).
+				Code(apperrors.CodeValidation).
+				Public(fmt.Sprintf("The currency %s does not exist.", code)).
+				Errorf("unknown currency %s", code)
+		}
+		return 0, err
+	}
+
+	return currency.DecimalPlaces, nil
+}
+
 func (s service) Update(ctx context.Context, input port.UpdateAccount, filters ...dafi.Filter) error {
 	if err := input.Validate(ctx); err != nil {
 		return oops.WithContext(ctx).In(apperrors.LayerService).Code(apperrors.CodeValidation).Wrap(err)
 	}
 
-	if err := s.repo.Update(ctx, input, filters...); err != nil {
+	if !input.CurrentBalance.Valid {
+		if err := s.repo.Update(ctx, input, filters...); err != nil {
+			return oops.WithContext(ctx).In(apperrors.LayerService).Wrap(err)
+		}
+
+		s.logger.WithContext(ctx).Info("account updated")
+
+		return nil
+	}
+
+	var accts basedomain.List[port.Account]
+	err := basedomain.Atomically(ctx, s.uow, s.tx, s.withTx, func(txSvc service) error {
+		var err error
+		accts, err = txSvc.repo.FindAll(ctx, dafi.Criteria{Filters: filters})
+		if err != nil {
+			return err
+		}
+
+		// A balance set by hand moves the opening balance, so the balance stays equal
+		// to the opening balance plus the transactions. The account is locked first so
+		// that a transaction written meanwhile cannot change the balance in between.
+		// The balance is in major units of the currency the account ends up in.
+		for _, found := range accts {
+			acct, err := txSvc.repo.Lock(ctx, found.ID)
+			if err != nil {
+				return err
+			}
+			ledger := int64(acct.CurrentBalance - acct.OpeningBalance)
+
+			currencyCode := acct.CurrencyCode
+			if input.CurrencyCode.Valid {
+				currencyCode = input.CurrencyCode.String
+			}
+			decimalPlaces, err := txSvc.decimalPlaces(ctx, currencyCode)
+			if err != nil {
+				return err
+			}
+
+			update := input
+			if err := update.CurrentBalance.Resolve(decimalPlaces); err != nil {
+				return oops.WithContext(ctx).In(apperrors.LayerService).Code(apperrors.CodeValidation).Wrap(err)
+			}
+			update.OpeningBalance = null.IntFrom(int64(update.CurrentBalance.Amount.Minor) - ledger)
+			if err := txSvc.repo.Update(ctx, update, dafi.FilterBy("id", dafi.Equal, acct.ID)...); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
 		return oops.WithContext(ctx).In(apperrors.LayerService).Wrap(err)
 	}
 
-	s.logger.WithContext(ctx).Info("account updated")
+	s.logger.WithContext(ctx).Info("account updated", "balanceCorrected", len(accts))
 
 	return nil
 }
 
+// RecomputeBalance repairs the balance of an account from its opening balance and
+// transactions.
+func (s service) RecomputeBalance(ctx context.Context, id uuid.UUID) (port.BalanceRecomputation, error) {
+	recomputation, err := s.repo.RecomputeBalance(ctx, id)
+	if err != nil {
+		return port.BalanceRecomputation{}, oops.WithContext(ctx).In(apperrors.LayerService).Wrap(err)
+	}
+
+	if recomputation.Drift != 0 {
+		s.logger.WithContext(ctx).Warn("account balance drifted from its transactions", "accountId", id, "drift", recomputation.Drift)
+	}
+
+	return recomputation, nil
+}
+
 func (s service) Delete(ctx context.Context, filters ...dafi.Filter) error {
 	criteria := dafi.Criteria{Filters: filters}
 
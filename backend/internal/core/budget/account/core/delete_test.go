@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"backend/core/budget/account/port"
+	currencyport "backend/core/budget/currency/port"
 	transactionport "backend/core/budget/transaction/port"
 	"backend/infra/dafi"
 	"backend/infra/money"
@@ -27,11 +28,28 @@ func (noopLogger) Error(string, ...any)                          {}
 func (noopLogger) With(...any) basedomain.Logger                 { return noopLogger{} }
 func (noopLogger) WithContext(context.Context) basedomain.Logger { return noopLogger{} }
 
+type stubTransaction struct{}
+
+func (stubTransaction) GetTx() basedomain.Tx { return nil }
+
+type stubUnitOfWork struct{}
+
+func (stubUnitOfWork) Begin(context.Context) (basedomain.Transaction, error) {
+	return stubTransaction{}, nil
+}
+
+func (stubUnitOfWork) Commit(context.Context, basedomain.Transaction) error   { return nil }
+func (stubUnitOfWork) Rollback(context.Context, basedomain.Transaction) error { return nil }
+
 type stubAccountRepo struct {
-	findResult port.Account
-	findErr    error
-	deleteN    int
-	deleteErr  error
+	findResult    port.Account
+	findErr       error
+	deleteN       int
+	deleteErr     error
+	created       []port.CreateAccount
+	updates       []port.UpdateAccount
+	recomputation port.BalanceRecomputation
+	locked        int
 }
 
 func (s *stubAccountRepo) FindOne(ctx context.Context, criteria dafi.Criteria) (port.Account, error) {
@@ -43,12 +61,12 @@ func (s *stubAccountRepo) FindOne(ctx context.Context, criteria dafi.Criteria) (
 func (s *stubAccountRepo) FindAll(ctx context.Context, criteria dafi.Criteria) (basedomain.List[port.Account], error) {
 	_ = ctx
 	_ = criteria
-	return nil, nil
+	return basedomain.List[port.Account]{s.findResult}, s.findErr
 }
 
 func (s *stubAccountRepo) Create(ctx context.Context, input port.CreateAccount) error {
 	_ = ctx
-	_ = input
+	s.created = append(s.created, input)
 	return nil
 }
 
@@ -60,8 +78,8 @@ func (s *stubAccountRepo) CreateBulk(ctx context.Context, inputs basedomain.List
 
 func (s *stubAccountRepo) Update(ctx context.Context, input port.UpdateAccount, filters ...dafi.Filter) error {
 	_ = ctx
-	_ = input
 	_ = filters
+	s.updates = append(s.updates, input)
 	return nil
 }
 
@@ -72,8 +90,43 @@ func (s *stubAccountRepo) Delete(ctx context.Context, filters ...dafi.Filter) er
 	return s.deleteErr
 }
 
+func (s *stubAccountRepo) AdjustBalance(ctx context.Context, accountID uuid.UUID, delta int64) error {
+	_ = ctx
+	_ = accountID
+	_ = delta
+	return nil
+}
+
+func (s *stubAccountRepo) Lock(ctx context.Context, accountID uuid.UUID) (port.Account, error) {
+	_ = ctx
+	_ = accountID
+	s.locked++
+	return s.findResult, s.findErr
+}
+
+func (s *stubAccountRepo) RecomputeBalance(ctx context.Context, accountID uuid.UUID) (port.BalanceRecomputation, error) {
+	_ = ctx
+	_ = accountID
+	return s.recomputation, nil
+}
+
 func (s *stubAccountRepo) WithTx(basedomain.Transaction) port.Repository { return s }
 
+// stubCurrencyRepo knows the decimal places of a few currencies.
+type stubCurrencyRepo struct {
+	currencyport.Repository
+}
+
+func (stubCurrencyRepo) FindOne(ctx context.Context, criteria dafi.Criteria) (currencyport.Currency, error) {
+	_ = ctx
+	code, _ := criteria.Filters[0].Value.(string)
+	decimalPlaces, ok := map[string]int16{"USD": 2, "CLP": 0, "JPY": 0, "KWD": 3}[code]
+	if !ok {
+		return currencyport.Currency{}, oops.Code(apperrors.CodeNotFound).Errorf("currency not found")
+	}
+	return currencyport.Currency{Code: code, DecimalPlaces: decimalPlaces}, nil
+}
+
 type stubTxnRepo struct {
 	count    int64
 	countErr error
@@ -128,6 +181,45 @@ func (s *stubTxnRepo) Delete(ctx context.Context, filters ...dafi.Filter) error
 	return nil
 }
 
+func (s *stubTxnRepo) ReplaceSplits(ctx context.Context, transactionID uuid.UUID, organizationID string, splits []transactionport.SplitLine) error {
+	_ = ctx
+	_ = transactionID
+	_ = organizationID
+	_ = splits
+	return nil
+}
+
+func (s *stubTxnRepo) ReplaceTags(ctx context.Context, transactionID uuid.UUID, organizationID string, tagIDs []uuid.UUID) error {
+	_ = ctx
+	_ = transactionID
+	_ = organizationID
+	_ = tagIDs
+	return nil
+}
+
+func (s *stubTxnRepo) MarkReconciled(ctx context.Context, accountID, reconciliationID uuid.UUID, through time.Time) (int64, error) {
+	_ = ctx
+	_ = accountID
+	_ = reconciliationID
+	_ = through
+	return 0, nil
+}
+
+func (s *stubTxnRepo) Unlock(ctx context.Context, id uuid.UUID) error {
+	_ = ctx
+	_ = id
+	return nil
+}
+
+func (s *stubTxnRepo) Categorize(ctx context.Context, id uuid.UUID, categoryID uuid.UUID, subcategoryID, budgetID *uuid.UUID) error {
+	_ = ctx
+	_ = id
+	_ = categoryID
+	_ = subcategoryID
+	_ = budgetID
+	return nil
+}
+
 func (s *stubTxnRepo) WithTx(basedomain.Transaction) transactionport.Repository { return s }
 
 func TestService_Delete_NoTransactions_Deletes(t *testing.T) {
@@ -149,7 +241,7 @@ func TestService_Delete_NoTransactions_Deletes(t *testing.T) {
 	}
 	transactionRepository := &stubTxnRepo{count: 0}
 
-	svc := New(acctRepo, transactionRepository, noopLogger{})
+	svc := New(acctRepo, transactionRepository, stubCurrencyRepo{}, stubUnitOfWork{}, noopLogger{})
 
 	err := svc.Delete(context.Background(), dafi.FilterBy("id", dafi.Equal, id.String())...)
 	require.NoError(t, err)
@@ -175,7 +267,7 @@ func TestService_Delete_HasTransactions_Conflict(t *testing.T) {
 	}
 	transactionRepository := &stubTxnRepo{count: 3}
 
-	svc := New(acctRepo, transactionRepository, noopLogger{})
+	svc := New(acctRepo, transactionRepository, stubCurrencyRepo{}, stubUnitOfWork{}, noopLogger{})
 
 	err := svc.Delete(context.Background(), dafi.FilterBy("id", dafi.Equal, id.String())...)
 	require.Error(t, err)
@@ -194,7 +286,7 @@ func TestService_Delete_FindOneError_NoDelete(t *testing.T) {
 	acctRepo := &stubAccountRepo{findErr: oops.Code(apperrors.CodeNotFound).Errorf("missing")}
 	transactionRepository := &stubTxnRepo{}
 
-	svc := New(acctRepo, transactionRepository, noopLogger{})
+	svc := New(acctRepo, transactionRepository, stubCurrencyRepo{}, stubUnitOfWork{}, noopLogger{})
 
 	err := svc.Delete(context.Background(), dafi.FilterBy("id", dafi.Equal, id.String())...)
 	require.Error(t, err)
@@ -5,6 +5,7 @@ import (
 	"backend/core/budget/account/adapter/postgres"
 	"backend/core/budget/account/core"
 	"backend/core/budget/account/port"
+	currencyport "backend/core/budget/currency/port"
 	transactionport "backend/core/budget/transaction/port"
 	basedomain "backend/port"
 	"backend/adapter/database"
@@ -22,8 +23,10 @@ func Module(i do.Injector) {
 	di.Provide(i, func(i do.Injector) (port.Service, error) {
 		repo := di.MustInvoke[port.Repository](i)
 		transactionRepository := di.MustInvoke[transactionport.Repository](i)
+		currencyRepository := di.MustInvoke[currencyport.Repository](i)
+		uow := di.MustInvoke[basedomain.UnitOfWork](i)
 		logger := di.MustInvoke[basedomain.Logger](i)
-		return core.New(repo, transactionRepository, logger), nil
+		return core.New(repo, transactionRepository, currencyRepository, uow, logger), nil
 	})
 
 	di.Provide(i, func(i do.Injector) (handler.HTTP, error) {
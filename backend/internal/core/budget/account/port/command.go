@@ -32,14 +32,18 @@ func (c CreateAccount) Validate(ctx context.Context) error {
 	)
 }
 
+// UpdateAccount changes an account. Setting CurrentBalance, in major units of the
+// account currency like on create, corrects the balance by moving the opening
+// balance; the transactions are left untouched.
 type UpdateAccount struct {
-	Name           null.String `json:"name"`
-	Type           null.String `json:"type"`
-	Institution    null.String `json:"institution"`
-	AccountNumber  null.String `json:"accountNumber"`
-	CurrencyCode   null.String `json:"currencyCode"`
-	CurrentBalance null.Int    `json:"currentBalance"`
-	IsActive       null.Bool   `json:"isActive"`
+	Name           null.String           `json:"name"`
+	Type           null.String           `json:"type"`
+	Institution    null.String           `json:"institution"`
+	AccountNumber  null.String           `json:"accountNumber"`
+	CurrencyCode   null.String           `json:"currencyCode"`
+	CurrentBalance money.NullMajorAmount `json:"currentBalance"`
+	OpeningBalance null.Int              `json:"-"`
+	IsActive       null.Bool             `json:"isActive"`
 }
 
 func (u UpdateAccount) Validate(ctx context.Context) error {
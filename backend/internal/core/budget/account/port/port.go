@@ -1,15 +1,28 @@
 package port
 
-import basedomain "backend/port"
+import (
+	"context"
+
+	basedomain "backend/port"
+
+	"github.com/google/uuid"
+)
 
 type Repository interface {
 	basedomain.RepositoryCommand[CreateAccount, UpdateAccount]
 	basedomain.RepositoryQuery[Account]
 	basedomain.RepositoryTx[Repository]
+	// AdjustBalance adds delta, in minor units, to the current balance of an account.
+	AdjustBalance(ctx context.Context, accountID uuid.UUID, delta int64) error
+	// Lock locks an account until the end of the current transaction and returns it
+	// with only its ID and balances set.
+	Lock(ctx context.Context, accountID uuid.UUID) (Account, error)
+	RecomputeBalance(ctx context.Context, accountID uuid.UUID) (BalanceRecomputation, error)
 }
 
 type Service interface {
 	basedomain.UseCaseCommand[CreateAccount, UpdateAccount]
 	basedomain.UseCaseQuery[Account]
 	basedomain.UseCaseTx[Service]
+	RecomputeBalance(ctx context.Context, id uuid.UUID) (BalanceRecomputation, error)
 }
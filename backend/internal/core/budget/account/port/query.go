@@ -7,6 +7,9 @@ import (
 	"github.com/google/uuid"
 )
 
+// Account is a ledger account. CurrentBalance counts every transaction and is also
+// exposed as WorkingBalance; ClearedBalance only counts the transactions the bank has
+// posted, cleared or reconciled.
 type Account struct {
 	ID             uuid.UUID   `json:"id"`
 	OrganizationID string      `json:"organizationId"`
@@ -16,7 +19,20 @@ type Account struct {
 	AccountNumber  string      `json:"accountNumber"`
 	CurrencyCode   string      `json:"currencyCode"`
 	CurrentBalance money.Minor `json:"currentBalance"`
+	ClearedBalance money.Minor `json:"clearedBalance"`
+	WorkingBalance money.Minor `json:"workingBalance"`
+	OpeningBalance money.Minor `json:"openingBalance"`
 	IsActive       bool        `json:"isActive"`
 	CreatedAt      time.Time   `json:"createdAt"`
 	UpdatedAt      time.Time   `json:"updatedAt"`
 }
+
+// BalanceRecomputation is the outcome of resetting an account balance to its opening
+// balance plus the sum of its transactions. Drift is how far the stored balance was
+// off.
+type BalanceRecomputation struct {
+	AccountID       uuid.UUID   `json:"accountId"`
+	PreviousBalance money.Minor `json:"previousBalance"`
+	CurrentBalance  money.Minor `json:"currentBalance"`
+	Drift           money.Minor `json:"drift"`
+}
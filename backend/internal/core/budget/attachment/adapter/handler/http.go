@@ -0,0 +1,119 @@
+package handler
+
+import (
+	"mime"
+	"net/http"
+	"strconv"
+
+	"backend/core/budget/attachment/port"
+	"backend/infra/httpresponse"
+	basedomain "backend/port"
+	apperrors "backend/port/errors"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/samber/oops"
+)
+
+type HTTP struct {
+	svc    port.Service
+	logger basedomain.Logger
+}
+
+func NewHTTP(svc port.Service, logger basedomain.Logger) HTTP {
+	return HTTP{
+		svc:    svc,
+		logger: logger.With("component", "attachment.handler"),
+	}
+}
+
+// Upload attaches the file uploaded as the multipart field "file" to the transaction.
+func (h HTTP) Upload(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	transactionID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return oops.WithContext(ctx).In(apperrors.LayerHandler).Code(apperrors.CodeBadRequest).Wrap(err)
+	}
+
+	input := port.UploadAttachment{TransactionID: transactionID}
+	input.FileName, input.Content, err = httpresponse.FormFile(c, "file", port.MaxSize)
+	if err != nil {
+		return oops.WithContext(ctx).In(apperrors.LayerHandler).Code(apperrors.CodeBadRequest).Wrap(err)
+	}
+
+	attachment, err := h.svc.Upload(ctx, input)
+	if err != nil {
+		return oops.WithContext(ctx).In(apperrors.LayerHandler).Wrap(err)
+	}
+
+	return httpresponse.Created(c, attachment)
+}
+
+func (h HTTP) FindAll(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	transactionID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return oops.WithContext(ctx).In(apperrors.LayerHandler).Code(apperrors.CodeBadRequest).Wrap(err)
+	}
+
+	attachments, err := h.svc.FindAll(ctx, transactionID)
+	if err != nil {
+		return oops.WithContext(ctx).In(apperrors.LayerHandler).Wrap(err)
+	}
+
+	return httpresponse.OK(c, attachments)
+}
+
+// Download streams the content of an attachment as a file to save.
+func (h HTTP) Download(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	transactionID, id, err := parseIDs(c)
+	if err != nil {
+		return oops.WithContext(ctx).In(apperrors.LayerHandler).Code(apperrors.CodeBadRequest).Wrap(err)
+	}
+
+	attachment, content, err := h.svc.Download(ctx, transactionID, id)
+	if err != nil {
+		return oops.WithContext(ctx).In(apperrors.LayerHandler).Wrap(err)
+	}
+	defer content.Close()
+
+	header := c.Response().Header()
+	header.Set(echo.HeaderContentLength, strconv.FormatInt(attachment.Size, 10))
+	header.Set(echo.HeaderContentDisposition, mime.FormatMediaType("attachment", map[string]string{"filename": attachment.FileName}))
+	header.Set("X-Content-Type-Options", "nosniff")
+
+	return c.Stream(http.StatusOK, attachment.MimeType, content)
+}
+
+func (h HTTP) Delete(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	transactionID, id, err := parseIDs(c)
+	if err != nil {
+		return oops.WithContext(ctx).In(apperrors.LayerHandler).Code(apperrors.CodeBadRequest).Wrap(err)
+	}
+
+	if err := h.svc.Delete(ctx, transactionID, id); err != nil {
+		return oops.WithContext(ctx).In(apperrors.LayerHandler).Wrap(err)
+	}
+
+	return httpresponse.NoContent(c)
+}
+
+func parseIDs(c echo.Context) (uuid.UUID, uuid.UUID, error) {
+	transactionID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return uuid.Nil, uuid.Nil, err
+	}
+
+	id, err := uuid.Parse(c.Param("attachmentId"))
+	if err != nil {
+		return uuid.Nil, uuid.Nil, err
+	}
+
+	return transactionID, id, nil
+}
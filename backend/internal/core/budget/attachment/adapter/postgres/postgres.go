@@ -0,0 +1,197 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"backend/adapter/database"
+	"backend/core/budget/attachment/port"
+	"backend/infra/dafi"
+	"backend/infra/sqlcraft"
+	basedomain "backend/port"
+	apperrors "backend/port/errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/samber/oops"
+)
+
+type dbConn interface {
+	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+}
+
+const tableName = "budget.attachments"
+
+var columns = []string{
+	"id",
+	"organization_id",
+	"transaction_id",
+	"file_name",
+	"mime_type",
+	"size",
+	"sha256",
+	"storage_key",
+	"created_at",
+}
+
+var sqlColumnByDomainField = map[string]string{
+	"id":             "id",
+	"organizationId": "organization_id",
+	"transactionId":  "transaction_id",
+	"fileName":       "file_name",
+	"mimeType":       "mime_type",
+	"size":           "size",
+	"createdAt":      "created_at",
+}
+
+type postgres struct {
+	db     dbConn
+	logger basedomain.Logger
+}
+
+func NewPostgres(db database.PoolInterface, logger basedomain.Logger) port.Repository {
+	return postgres{
+		db:     db,
+		logger: logger.With("component", "attachment.repository"),
+	}
+}
+
+func (r postgres) WithTx(tx basedomain.Transaction) port.Repository {
+	return postgres{
+		db:     tx.GetTx(),
+		logger: r.logger,
+	}
+}
+
+func (r postgres) FindOne(ctx context.Context, criteria dafi.Criteria) (port.Attachment, error) {
+	query := sqlcraft.Select(columns...).
+		From(tableName).
+		Where(criteria.Filters...).
+		Limit(1).
+		SQLColumnByDomainField(sqlColumnByDomainField)
+
+	result, err := query.ToSQL()
+	if err != nil {
+		return port.Attachment{}, oops.WithContext(ctx).In(apperrors.LayerRepository).Wrap(err)
+	}
+
+	r.logger.WithContext(ctx).Debug("executing query", "sql", result.SQL)
+
+	attachment, err := scan(r.db.QueryRow(ctx, result.SQL, result.Args...))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return port.Attachment{}, oops.WithContext(ctx).In(apperrors.LayerRepository).Code(apperrors.CodeNotFound).Wrap(err)
+		}
+		return port.Attachment{}, oops.WithContext(ctx).In(apperrors.LayerRepository).Wrap(err)
+	}
+
+	return attachment, nil
+}
+
+func (r postgres) FindAll(ctx context.Context, criteria dafi.Criteria) (basedomain.List[port.Attachment], error) {
+	query := sqlcraft.Select(columns...).
+		From(tableName).
+		Where(criteria.Filters...).
+		OrderBy(criteria.Sorts...).
+		Limit(criteria.Pagination.PageSize).
+		Page(criteria.Pagination.PageNumber).
+		SQLColumnByDomainField(sqlColumnByDomainField)
+
+	result, err := query.ToSQL()
+	if err != nil {
+		return nil, oops.WithContext(ctx).In(apperrors.LayerRepository).Wrap(err)
+	}
+
+	r.logger.WithContext(ctx).Debug("executing query", "sql", result.SQL)
+
+	rows, err := r.db.Query(ctx, result.SQL, result.Args...)
+	if err != nil {
+		return nil, oops.WithContext(ctx).In(apperrors.LayerRepository).Wrap(err)
+	}
+	defer rows.Close()
+
+	var attachments basedomain.List[port.Attachment]
+	for rows.Next() {
+		attachment, err := scan(rows)
+		if err != nil {
+			return nil, oops.WithContext(ctx).In(apperrors.LayerRepository).Wrap(err)
+		}
+		attachments = append(attachments, attachment)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, oops.WithContext(ctx).In(apperrors.LayerRepository).Wrap(err)
+	}
+
+	return attachments, nil
+}
+
+func (r postgres) Create(ctx context.Context, input port.CreateAttachment) error {
+	query := sqlcraft.InsertInto(tableName).
+		WithColumns(columns...).
+		WithValues(
+			input.ID,
+			input.OrganizationID,
+			input.TransactionID,
+			input.FileName,
+			input.MimeType,
+			input.Size,
+			input.SHA256,
+			input.StorageKey,
+			time.Now(),
+		)
+
+	result, err := query.ToSQL()
+	if err != nil {
+		return oops.WithContext(ctx).In(apperrors.LayerRepository).Wrap(err)
+	}
+
+	r.logger.WithContext(ctx).Debug("executing query", "sql", result.SQL)
+
+	_, err = r.db.Exec(ctx, result.SQL, result.Args...)
+	if err != nil {
+		return oops.WithContext(ctx).In(apperrors.LayerRepository).Wrap(err)
+	}
+
+	return nil
+}
+
+func (r postgres) Delete(ctx context.Context, filters ...dafi.Filter) error {
+	query := sqlcraft.DeleteFrom(tableName).
+		Where(filters...).
+		SQLColumnByDomainField(sqlColumnByDomainField)
+
+	result, err := query.ToSQL()
+	if err != nil {
+		return oops.WithContext(ctx).In(apperrors.LayerRepository).Wrap(err)
+	}
+
+	r.logger.WithContext(ctx).Debug("executing query", "sql", result.SQL)
+
+	_, err = r.db.Exec(ctx, result.SQL, result.Args...)
+	if err != nil {
+		return oops.WithContext(ctx).In(apperrors.LayerRepository).Wrap(err)
+	}
+
+	return nil
+}
+
+func scan(row pgx.Row) (port.Attachment, error) {
+	var attachment port.Attachment
+	err := row.Scan(
+		&attachment.ID,
+		&attachment.OrganizationID,
+		&attachment.TransactionID,
+		&attachment.FileName,
+		&attachment.MimeType,
+		&attachment.Size,
+		&attachment.SHA256,
+		&attachment.StorageKey,
+		&attachment.CreatedAt,
+	)
+
+	return attachment, err
+}
@@ -0,0 +1,143 @@
+package core
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"path"
+	"strings"
+
+	"backend/core/budget/attachment/port"
+	transactionport "backend/core/budget/transaction/port"
+	"backend/infra/dafi"
+	basedomain "backend/port"
+	apperrors "backend/port/errors"
+
+	"github.com/google/uuid"
+	"github.com/samber/oops"
+)
+
+type service struct {
+	repo           port.Repository
+	blobs          port.BlobStore
+	transactionSvc transactionport.Service
+	logger         basedomain.Logger
+}
+
+func New(repo port.Repository, blobs port.BlobStore, transactionSvc transactionport.Service, logger basedomain.Logger) port.Service {
+	return service{
+		repo:           repo,
+		blobs:          blobs,
+		transactionSvc: transactionSvc,
+		logger:         logger.With("component", "attachment.service"),
+	}
+}
+
+// Upload stores the content first and records it after, so a recorded attachment
+// always has content. Content whose record could not be written is removed again.
+func (s service) Upload(ctx context.Context, input port.UploadAttachment) (port.Attachment, error) {
+	if err := input.Validate(ctx); err != nil {
+		return port.Attachment{}, oops.WithContext(ctx).In(apperrors.LayerService).Code(apperrors.CodeValidation).Wrap(err)
+	}
+
+	txn, err := s.transactionSvc.FindOne(ctx, dafi.Where("id", dafi.Equal, input.TransactionID))
+	if err != nil {
+		return port.Attachment{}, oops.WithContext(ctx).In(apperrors.LayerService).Wrap(err)
+	}
+
+	id := uuid.New()
+	sum := sha256.Sum256(input.Content)
+	create := port.CreateAttachment{
+		ID:             id,
+		OrganizationID: txn.OrganizationID,
+		TransactionID:  txn.ID,
+		FileName:       baseName(input.FileName),
+		MimeType:       input.MimeType(),
+		Size:           int64(len(input.Content)),
+		SHA256:         hex.EncodeToString(sum[:]),
+		StorageKey:     path.Join(txn.OrganizationID, txn.ID.String(), id.String()),
+	}
+
+	if err := s.blobs.Put(ctx, create.StorageKey, input.Content, create.MimeType); err != nil {
+		return port.Attachment{}, oops.WithContext(ctx).In(apperrors.LayerService).Wrap(err)
+	}
+
+	if err := s.repo.Create(ctx, create); err != nil {
+		if err := s.blobs.Delete(ctx, create.StorageKey); err != nil {
+			s.logger.WithContext(ctx).Warn("attachment content left behind", "key", create.StorageKey, "error", err)
+		}
+		return port.Attachment{}, oops.WithContext(ctx).In(apperrors.LayerService).Wrap(err)
+	}
+
+	s.logger.WithContext(ctx).Info("attachment uploaded", "mimeType", create.MimeType, "size", create.Size)
+
+	attachment, err := s.find(ctx, txn.ID, id)
+	if err != nil {
+		return port.Attachment{}, oops.WithContext(ctx).In(apperrors.LayerService).Wrap(err)
+	}
+
+	return attachment, nil
+}
+
+func (s service) FindAll(ctx context.Context, transactionID uuid.UUID) (basedomain.List[port.Attachment], error) {
+	attachments, err := s.repo.FindAll(ctx, dafi.Criteria{
+		Filters: dafi.FilterBy("transactionId", dafi.Equal, transactionID),
+		Sorts:   dafi.Sorts{{Field: "createdAt", Type: dafi.Asc}},
+	})
+	if err != nil {
+		return nil, oops.WithContext(ctx).In(apperrors.LayerService).Wrap(err)
+	}
+
+	return attachments, nil
+}
+
+func (s service) Download(ctx context.Context, transactionID, id uuid.UUID) (port.Attachment, io.ReadCloser, error) {
+	attachment, err := s.find(ctx, transactionID, id)
+	if err != nil {
+		return port.Attachment{}, nil, oops.WithContext(ctx).In(apperrors.LayerService).Wrap(err)
+	}
+
+	content, err := s.blobs.Open(ctx, attachment.StorageKey)
+	if err != nil {
+		return port.Attachment{}, nil, oops.WithContext(ctx).In(apperrors.LayerService).Wrap(err)
+	}
+
+	return attachment, content, nil
+}
+
+// Delete drops the record before the content: content that could not be removed is
+// only left unreachable.
+func (s service) Delete(ctx context.Context, transactionID, id uuid.UUID) error {
+	attachment, err := s.find(ctx, transactionID, id)
+	if err != nil {
+		return oops.WithContext(ctx).In(apperrors.LayerService).Wrap(err)
+	}
+
+	if err := s.repo.Delete(ctx, dafi.FilterBy("id", dafi.Equal, attachment.ID)...); err != nil {
+		return oops.WithContext(ctx).In(apperrors.LayerService).Wrap(err)
+	}
+
+	if err := s.blobs.Delete(ctx, attachment.StorageKey); err != nil {
+		s.logger.WithContext(ctx).Warn("attachment content left behind", "key", attachment.StorageKey, "error", err)
+	}
+
+	s.logger.WithContext(ctx).Info("attachment deleted")
+
+	return nil
+}
+
+func (s service) find(ctx context.Context, transactionID, id uuid.UUID) (port.Attachment, error) {
+	filters := dafi.FilterBy("id", dafi.Equal, id).And("transactionId", dafi.Equal, transactionID)
+
+	return s.repo.FindOne(ctx, dafi.Criteria{Filters: filters})
+}
+
+// baseName drops any directory a client put in the name of an uploaded file.
+func baseName(name string) string {
+	name = path.Base(strings.ReplaceAll(name, `\`, "/"))
+	if name == "." || name == "/" {
+		return "attachment"
+	}
+	return name
+}
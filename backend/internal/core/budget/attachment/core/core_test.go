@@ -0,0 +1,250 @@
+package core
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"slices"
+	"testing"
+
+	"backend/core/budget/attachment/port"
+	transactionport "backend/core/budget/transaction/port"
+	"backend/infra/dafi"
+	basedomain "backend/port"
+	apperrors "backend/port/errors"
+
+	"github.com/google/uuid"
+	"github.com/samber/oops"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type noopLogger struct{}
+
+func (noopLogger) Debug(string, ...any)                          {}
+func (noopLogger) Info(string, ...any)                           {}
+func (noopLogger) Warn(string, ...any)                           {}
+func (noopLogger) Error(string, ...any)                          {}
+func (noopLogger) With(...any) basedomain.Logger                 { return noopLogger{} }
+func (noopLogger) WithContext(context.Context) basedomain.Logger { return noopLogger{} }
+
+// pngContent starts with the PNG signature, which is all content sniffing looks at.
+var pngContent = []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+
+// memoryRepo keeps attachments in memory and honours equality filters, and the in
+// filter on transactionId.
+type memoryRepo struct {
+	attachments []port.Attachment
+	createErr   error
+}
+
+func (r *memoryRepo) matches(a port.Attachment, filters dafi.Filters) bool {
+	for _, f := range filters {
+		switch f.Field {
+		case "id":
+			if a.ID != f.Value {
+				return false
+			}
+		case "transactionId":
+			if ids, ok := f.Value.([]uuid.UUID); ok && f.Operator == dafi.In {
+				if !slices.Contains(ids, a.TransactionID) {
+					return false
+				}
+			} else if a.TransactionID != f.Value {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func (r *memoryRepo) FindOne(ctx context.Context, criteria dafi.Criteria) (port.Attachment, error) {
+	for _, a := range r.attachments {
+		if r.matches(a, criteria.Filters) {
+			return a, nil
+		}
+	}
+	return port.Attachment{}, oops.WithContext(ctx).Code(apperrors.CodeNotFound).Errorf("attachment not found")
+}
+
+func (r *memoryRepo) FindAll(_ context.Context, criteria dafi.Criteria) (basedomain.List[port.Attachment], error) {
+	var found basedomain.List[port.Attachment]
+	for _, a := range r.attachments {
+		if r.matches(a, criteria.Filters) {
+			found = append(found, a)
+		}
+	}
+	return found, nil
+}
+
+func (r *memoryRepo) Create(_ context.Context, input port.CreateAttachment) error {
+	if r.createErr != nil {
+		return r.createErr
+	}
+	r.attachments = append(r.attachments, port.Attachment{
+		ID:             input.ID,
+		OrganizationID: input.OrganizationID,
+		TransactionID:  input.TransactionID,
+		FileName:       input.FileName,
+		MimeType:       input.MimeType,
+		Size:           input.Size,
+		SHA256:         input.SHA256,
+		StorageKey:     input.StorageKey,
+	})
+	return nil
+}
+
+func (r *memoryRepo) Delete(_ context.Context, filters ...dafi.Filter) error {
+	var kept []port.Attachment
+	for _, a := range r.attachments {
+		if !r.matches(a, filters) {
+			kept = append(kept, a)
+		}
+	}
+	r.attachments = kept
+	return nil
+}
+
+func (r *memoryRepo) WithTx(basedomain.Transaction) port.Repository { return r }
+
+type memoryBlobs map[string][]byte
+
+func (b memoryBlobs) Put(_ context.Context, key string, content []byte, _ string) error {
+	b[key] = content
+	return nil
+}
+
+func (b memoryBlobs) Open(ctx context.Context, key string) (io.ReadCloser, error) {
+	content, ok := b[key]
+	if !ok {
+		return nil, oops.WithContext(ctx).Code(apperrors.CodeNotFound).Errorf("no content under %s", key)
+	}
+	return io.NopCloser(bytes.NewReader(content)), nil
+}
+
+func (b memoryBlobs) Delete(_ context.Context, key string) error {
+	delete(b, key)
+	return nil
+}
+
+type stubTransactionSvc struct {
+	transactionport.Service
+	txn transactionport.Transaction
+}
+
+func (s stubTransactionSvc) FindOne(ctx context.Context, criteria dafi.Criteria) (transactionport.Transaction, error) {
+	if criteria.Filters[0].Value != s.txn.ID {
+		return transactionport.Transaction{}, oops.WithContext(ctx).Code(apperrors.CodeNotFound).Errorf("transaction not found")
+	}
+	return s.txn, nil
+}
+
+type fixture struct {
+	svc   port.Service
+	repo  *memoryRepo
+	blobs memoryBlobs
+	txn   transactionport.Transaction
+}
+
+func newFixture() fixture {
+	txn := transactionport.Transaction{ID: uuid.New(), OrganizationID: "org_1"}
+	repo := &memoryRepo{}
+	blobs := memoryBlobs{}
+	return fixture{
+		svc:   New(repo, blobs, stubTransactionSvc{txn: txn}, noopLogger{}),
+		repo:  repo,
+		blobs: blobs,
+		txn:   txn,
+	}
+}
+
+func requireCode(t *testing.T, err error, code string) {
+	t.Helper()
+	require.Error(t, err)
+	oopsErr, ok := oops.AsOops(err)
+	require.True(t, ok)
+	assert.Equal(t, code, oopsErr.Code())
+}
+
+func TestService_Upload(t *testing.T) {
+	f := newFixture()
+
+	attachment, err := f.svc.Upload(context.Background(), port.UploadAttachment{
+		TransactionID: f.txn.ID,
+		FileName:      `C:\scans\receipt.png`,
+		Content:       pngContent,
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "receipt.png", attachment.FileName)
+	assert.Equal(t, "image/png", attachment.MimeType)
+	assert.Equal(t, int64(len(pngContent)), attachment.Size)
+	assert.Equal(t, "org_1", attachment.OrganizationID)
+	assert.Len(t, attachment.SHA256, 64)
+	assert.Equal(t, "org_1/"+f.txn.ID.String()+"/"+attachment.ID.String(), attachment.StorageKey)
+	assert.Equal(t, pngContent, f.blobs[attachment.StorageKey])
+}
+
+func TestService_UploadRejectsUnsupportedType(t *testing.T) {
+	f := newFixture()
+
+	_, err := f.svc.Upload(context.Background(), port.UploadAttachment{
+		TransactionID: f.txn.ID,
+		FileName:      "notes.txt",
+		Content:       []byte("plain text"),
+	})
+	requireCode(t, err, apperrors.CodeValidation)
+	assert.Empty(t, f.blobs)
+}
+
+func TestService_UploadRejectsUnknownTransaction(t *testing.T) {
+	f := newFixture()
+
+	_, err := f.svc.Upload(context.Background(), port.UploadAttachment{
+		TransactionID: uuid.New(),
+		FileName:      "receipt.png",
+		Content:       pngContent,
+	})
+	requireCode(t, err, apperrors.CodeNotFound)
+	assert.Empty(t, f.blobs)
+}
+
+func TestService_UploadRemovesContentWhenRecordFails(t *testing.T) {
+	f := newFixture()
+	f.repo.createErr = errors.New("connection reset")
+
+	_, err := f.svc.Upload(context.Background(), port.UploadAttachment{
+		TransactionID: f.txn.ID,
+		FileName:      "receipt.png",
+		Content:       pngContent,
+	})
+	require.Error(t, err)
+	assert.Empty(t, f.blobs)
+}
+
+func TestService_DownloadAndDelete(t *testing.T) {
+	f := newFixture()
+	ctx := context.Background()
+
+	uploaded, err := f.svc.Upload(ctx, port.UploadAttachment{TransactionID: f.txn.ID, FileName: "receipt.png", Content: pngContent})
+	require.NoError(t, err)
+
+	_, _, err = f.svc.Download(ctx, uuid.New(), uploaded.ID)
+	requireCode(t, err, apperrors.CodeNotFound)
+
+	attachment, content, err := f.svc.Download(ctx, f.txn.ID, uploaded.ID)
+	require.NoError(t, err)
+	defer content.Close()
+	got, err := io.ReadAll(content)
+	require.NoError(t, err)
+	assert.Equal(t, uploaded.ID, attachment.ID)
+	assert.Equal(t, pngContent, got)
+
+	require.NoError(t, f.svc.Delete(ctx, f.txn.ID, uploaded.ID))
+	assert.Empty(t, f.repo.attachments)
+	assert.Empty(t, f.blobs)
+
+	err = f.svc.Delete(ctx, f.txn.ID, uploaded.ID)
+	requireCode(t, err, apperrors.CodeNotFound)
+}
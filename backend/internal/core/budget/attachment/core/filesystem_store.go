@@ -0,0 +1,104 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"backend/core/budget/attachment/port"
+	basedomain "backend/port"
+	apperrors "backend/port/errors"
+
+	"github.com/samber/oops"
+)
+
+type filesystemStore struct {
+	root   string
+	logger basedomain.Logger
+}
+
+// NewFilesystemStore keeps attachments as files below root, one directory per key
+// segment.
+func NewFilesystemStore(root string, logger basedomain.Logger) port.BlobStore {
+	return filesystemStore{
+		root:   root,
+		logger: logger.With("component", "attachment.filesystem_store"),
+	}
+}
+
+// Put writes the content to a temporary file first, so that a reader never sees
+// a partly written one.
+func (s filesystemStore) Put(ctx context.Context, key string, content []byte, _ string) error {
+	name, err := s.path(key)
+	if err != nil {
+		return oops.WithContext(ctx).In(apperrors.LayerRepository).Wrap(err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(name), 0o750); err != nil {
+		return oops.WithContext(ctx).In(apperrors.LayerRepository).Wrap(err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(name), ".upload-*")
+	if err != nil {
+		return oops.WithContext(ctx).In(apperrors.LayerRepository).Wrap(err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(content); err != nil {
+		tmp.Close()
+		return oops.WithContext(ctx).In(apperrors.LayerRepository).Wrap(err)
+	}
+	if err := tmp.Close(); err != nil {
+		return oops.WithContext(ctx).In(apperrors.LayerRepository).Wrap(err)
+	}
+
+	if err := os.Rename(tmp.Name(), name); err != nil {
+		return oops.WithContext(ctx).In(apperrors.LayerRepository).Wrap(err)
+	}
+
+	s.logger.WithContext(ctx).Debug("attachment content stored", "key", key)
+
+	return nil
+}
+
+func (s filesystemStore) Open(ctx context.Context, key string) (io.ReadCloser, error) {
+	name, err := s.path(key)
+	if err != nil {
+		return nil, oops.WithContext(ctx).In(apperrors.LayerRepository).Wrap(err)
+	}
+
+	file, err := os.Open(name)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, oops.WithContext(ctx).In(apperrors.LayerRepository).Code(apperrors.CodeNotFound).Wrap(err)
+		}
+		return nil, oops.WithContext(ctx).In(apperrors.LayerRepository).Wrap(err)
+	}
+
+	return file, nil
+}
+
+func (s filesystemStore) Delete(ctx context.Context, key string) error {
+	name, err := s.path(key)
+	if err != nil {
+		return oops.WithContext(ctx).In(apperrors.LayerRepository).Wrap(err)
+	}
+
+	if err := os.Remove(name); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return oops.WithContext(ctx).In(apperrors.LayerRepository).Wrap(err)
+	}
+
+	return nil
+}
+
+// path maps a key to a file below the root, refusing keys that would leave it.
+func (s filesystemStore) path(key string) (string, error) {
+	if !filepath.IsLocal(filepath.FromSlash(key)) {
+		return "", oops.Errorf("attachment key %q is not below the store root", key)
+	}
+
+	return filepath.Join(s.root, filepath.FromSlash(key)), nil
+}
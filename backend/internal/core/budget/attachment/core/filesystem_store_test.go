@@ -0,0 +1,40 @@
+package core
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	apperrors "backend/port/errors"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFilesystemStore_PutOpenDelete(t *testing.T) {
+	store := NewFilesystemStore(t.TempDir(), noopLogger{})
+	ctx := context.Background()
+
+	require.NoError(t, store.Put(ctx, "org_1/txn/receipt", pngContent, "image/png"))
+
+	content, err := store.Open(ctx, "org_1/txn/receipt")
+	require.NoError(t, err)
+	got, err := io.ReadAll(content)
+	require.NoError(t, err)
+	require.NoError(t, content.Close())
+	assert.Equal(t, pngContent, got)
+
+	require.NoError(t, store.Delete(ctx, "org_1/txn/receipt"))
+	require.NoError(t, store.Delete(ctx, "org_1/txn/receipt"))
+
+	_, err = store.Open(ctx, "org_1/txn/receipt")
+	requireCode(t, err, apperrors.CodeNotFound)
+}
+
+func TestFilesystemStore_RejectsKeysOutsideRoot(t *testing.T) {
+	store := NewFilesystemStore(t.TempDir(), noopLogger{})
+
+	for _, key := range []string{"../escape", "/etc/passwd", "org_1/../../escape"} {
+		assert.Error(t, store.Put(context.Background(), key, pngContent, "image/png"), key)
+	}
+}
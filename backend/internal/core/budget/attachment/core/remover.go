@@ -0,0 +1,75 @@
+package core
+
+import (
+	"context"
+
+	"backend/core/budget/attachment/port"
+	transactionport "backend/core/budget/transaction/port"
+	"backend/infra/dafi"
+	basedomain "backend/port"
+
+	"github.com/google/uuid"
+)
+
+type remover struct {
+	repo   port.Repository
+	blobs  port.BlobStore
+	logger basedomain.Logger
+}
+
+// NewRemover returns what the transaction service uses to remove the attachments of
+// the transactions it deletes.
+func NewRemover(repo port.Repository, blobs port.BlobStore, logger basedomain.Logger) transactionport.AttachmentRemover {
+	return remover{
+		repo:   repo,
+		blobs:  blobs,
+		logger: logger.With("component", "attachment.remover"),
+	}
+}
+
+func (r remover) WithTx(tx basedomain.Transaction) transactionport.AttachmentRemover {
+	return remover{
+		repo:   r.repo.WithTx(tx),
+		blobs:  r.blobs,
+		logger: r.logger,
+	}
+}
+
+func (r remover) Detach(ctx context.Context, transactionIDs []uuid.UUID) ([]string, error) {
+	if len(transactionIDs) == 0 {
+		return nil, nil
+	}
+
+	filters := dafi.FilterBy("transactionId", dafi.In, transactionIDs)
+
+	attachments, err := r.repo.FindAll(ctx, dafi.Criteria{Filters: filters})
+	if err != nil {
+		return nil, err
+	}
+	if len(attachments) == 0 {
+		return nil, nil
+	}
+
+	if err := r.repo.Delete(ctx, filters...); err != nil {
+		return nil, err
+	}
+
+	keys := make([]string, 0, len(attachments))
+	for _, attachment := range attachments {
+		keys = append(keys, attachment.StorageKey)
+	}
+
+	return keys, nil
+}
+
+func (r remover) Purge(ctx context.Context, keys []string) {
+	for _, key := range keys {
+		if err := r.blobs.Delete(ctx, key); err != nil {
+			r.logger.WithContext(ctx).Warn("attachment content left behind", "key", key, "error", err)
+		}
+	}
+
+	if len(keys) > 0 {
+		r.logger.WithContext(ctx).Info("attachments of deleted transactions removed", "count", len(keys))
+	}
+}
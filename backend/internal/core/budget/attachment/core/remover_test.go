@@ -0,0 +1,43 @@
+package core
+
+import (
+	"context"
+	"testing"
+
+	"backend/core/budget/attachment/port"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRemover_DetachThenPurge(t *testing.T) {
+	ctx := context.Background()
+	outflow, inflow, other := uuid.New(), uuid.New(), uuid.New()
+	repo := &memoryRepo{attachments: []port.Attachment{
+		{ID: uuid.New(), TransactionID: outflow, StorageKey: "org_1/outflow/a"},
+		{ID: uuid.New(), TransactionID: inflow, StorageKey: "org_1/inflow/b"},
+		{ID: uuid.New(), TransactionID: other, StorageKey: "org_1/other/c"},
+	}}
+	blobs := memoryBlobs{"org_1/outflow/a": pngContent, "org_1/inflow/b": pngContent, "org_1/other/c": pngContent}
+	r := NewRemover(repo, blobs, noopLogger{})
+
+	keys, err := r.Detach(ctx, []uuid.UUID{outflow, inflow})
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"org_1/outflow/a", "org_1/inflow/b"}, keys)
+	require.Len(t, repo.attachments, 1)
+	assert.Equal(t, other, repo.attachments[0].TransactionID)
+	assert.Len(t, blobs, 3, "content stays until the deletion is committed")
+
+	r.Purge(ctx, keys)
+	assert.Equal(t, memoryBlobs{"org_1/other/c": pngContent}, blobs)
+}
+
+func TestRemover_DetachWithoutAttachments(t *testing.T) {
+	repo := &memoryRepo{}
+	r := NewRemover(repo, memoryBlobs{}, noopLogger{})
+
+	keys, err := r.Detach(context.Background(), []uuid.UUID{uuid.New()})
+	require.NoError(t, err)
+	assert.Empty(t, keys)
+}
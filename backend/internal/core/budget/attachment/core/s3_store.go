@@ -0,0 +1,101 @@
+package core
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/url"
+
+	"backend/core/budget/attachment/port"
+	basedomain "backend/port"
+	apperrors "backend/port/errors"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+	"github.com/samber/oops"
+)
+
+const awsS3Endpoint = "s3.amazonaws.com"
+
+type s3Store struct {
+	client *minio.Client
+	bucket string
+	logger basedomain.Logger
+}
+
+// NewS3Store keeps attachments as objects of a bucket. A bucket on AWS is addressed
+// by its host name, a bucket elsewhere by the first path segment, which is what
+// S3-compatible services such as MinIO or R2 accept.
+func NewS3Store(cfg port.S3Config, logger basedomain.Logger) (port.BlobStore, error) {
+	opts := &minio.Options{
+		Creds:        credentials.NewStaticV4(cfg.AccessKeyID, cfg.SecretAccessKey, ""),
+		Secure:       true,
+		Region:       cfg.Region,
+		BucketLookup: minio.BucketLookupDNS,
+	}
+
+	host := awsS3Endpoint
+	if cfg.Endpoint != "" {
+		endpoint, err := url.Parse(cfg.Endpoint)
+		if err != nil {
+			return nil, oops.Wrapf(err, "invalid S3 endpoint %q", cfg.Endpoint)
+		}
+		if endpoint.Scheme == "" || endpoint.Host == "" {
+			return nil, oops.Errorf("invalid S3 endpoint %q: scheme and host are required", cfg.Endpoint)
+		}
+		host = endpoint.Host
+		opts.Secure = endpoint.Scheme == "https"
+		opts.BucketLookup = minio.BucketLookupPath
+	}
+
+	client, err := minio.New(host, opts)
+	if err != nil {
+		return nil, oops.Wrapf(err, "failed to create S3 client for %q", host)
+	}
+
+	return s3Store{
+		client: client,
+		bucket: cfg.Bucket,
+		logger: logger.With("component", "attachment.s3_store"),
+	}, nil
+}
+
+func (s s3Store) Put(ctx context.Context, key string, content []byte, mimeType string) error {
+	_, err := s.client.PutObject(ctx, s.bucket, key, bytes.NewReader(content), int64(len(content)), minio.PutObjectOptions{
+		ContentType: mimeType,
+	})
+	if err != nil {
+		return oops.WithContext(ctx).In(apperrors.LayerRepository).Wrap(err)
+	}
+
+	s.logger.WithContext(ctx).Debug("attachment content stored", "key", key)
+
+	return nil
+}
+
+// Open asks for the object right away rather than on the first read, so that a
+// missing one is reported here.
+func (s s3Store) Open(ctx context.Context, key string) (io.ReadCloser, error) {
+	object, err := s.client.GetObject(ctx, s.bucket, key, minio.GetObjectOptions{})
+	if err != nil {
+		return nil, oops.WithContext(ctx).In(apperrors.LayerRepository).Wrap(err)
+	}
+
+	if _, err := object.Stat(); err != nil {
+		_ = object.Close()
+		if minio.ToErrorResponse(err).Code == minio.NoSuchKey {
+			return nil, oops.WithContext(ctx).In(apperrors.LayerRepository).Code(apperrors.CodeNotFound).Wrap(err)
+		}
+		return nil, oops.WithContext(ctx).In(apperrors.LayerRepository).Wrap(err)
+	}
+
+	return object, nil
+}
+
+func (s s3Store) Delete(ctx context.Context, key string) error {
+	if err := s.client.RemoveObject(ctx, s.bucket, key, minio.RemoveObjectOptions{}); err != nil {
+		return oops.WithContext(ctx).In(apperrors.LayerRepository).Wrap(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,158 @@
+package core
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"backend/core/budget/attachment/port"
+	apperrors "backend/port/errors"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeBucket stands in for an S3-compatible service reached by path, answering with
+// the headers and error documents the client reads.
+type fakeBucket struct {
+	mu      sync.Mutex
+	objects map[string][]byte
+}
+
+func (b *fakeBucket) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if !strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=key/") {
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
+	key, ok := strings.CutPrefix(r.URL.Path, "/receipts/")
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodPut:
+		content, _ := io.ReadAll(r.Body)
+		if strings.HasPrefix(r.Header.Get("X-Amz-Content-Sha256"), "STREAMING-") {
+			content = decodeChunks(content)
+		}
+		b.objects[key] = content
+		w.Header().Set("ETag", etag(content))
+	case http.MethodGet, http.MethodHead:
+		content, ok := b.objects[key]
+		if !ok {
+			w.Header().Set("Content-Type", "application/xml")
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte("<Error><Code>NoSuchKey</Code><Message>The specified key does not exist.</Message></Error>"))
+			return
+		}
+		w.Header().Set("ETag", etag(content))
+		w.Header().Set("Last-Modified", time.Now().UTC().Format(http.TimeFormat))
+		w.Header().Set("Content-Type", "image/png")
+		http.ServeContent(w, r, key, time.Time{}, strings.NewReader(string(content)))
+	case http.MethodDelete:
+		delete(b.objects, key)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// decodeChunks joins the data of an aws-chunked body, where every chunk is its size
+// in hex and a signature on one line, then the data and a line break.
+func decodeChunks(body []byte) []byte {
+	var content []byte
+	for len(body) > 0 {
+		header, rest, _ := bytes.Cut(body, []byte("\r\n"))
+		sizeHex, _, _ := bytes.Cut(header, []byte(";"))
+		size, err := strconv.ParseInt(string(sizeHex), 16, 64)
+		if err != nil || size == 0 || int64(len(rest)) < size {
+			break
+		}
+		content = append(content, rest[:size]...)
+		body = bytes.TrimPrefix(rest[size:], []byte("\r\n"))
+	}
+	return content
+}
+
+func etag(content []byte) string {
+	sum := md5.Sum(content)
+	return `"` + hex.EncodeToString(sum[:]) + `"`
+}
+
+func TestS3Store_PutOpenDelete(t *testing.T) {
+	bucket := &fakeBucket{objects: map[string][]byte{}}
+	server := httptest.NewServer(bucket)
+	defer server.Close()
+
+	store, err := NewS3Store(port.S3Config{
+		Endpoint:        server.URL,
+		Region:          "auto",
+		Bucket:          "receipts",
+		AccessKeyID:     "key",
+		SecretAccessKey: "secret",
+	}, noopLogger{})
+	require.NoError(t, err)
+
+	testBlobStore(t, store, "org_1/txn/receipt")
+	assert.Empty(t, bucket.objects)
+}
+
+// TestS3Store_MinIO runs against a real S3-compatible service when one is configured,
+// for instance a local MinIO started with
+//
+//	docker run -p 9000:9000 -e MINIO_ROOT_USER=minioadmin -e MINIO_ROOT_PASSWORD=minioadmin minio/minio server /data
+//
+// and ATTACHMENTS_S3_TEST_ENDPOINT=http://localhost:9000 plus the _BUCKET, _ACCESS_KEY_ID
+// and _SECRET_ACCESS_KEY variables naming an existing bucket and its credentials.
+func TestS3Store_MinIO(t *testing.T) {
+	endpoint := os.Getenv("ATTACHMENTS_S3_TEST_ENDPOINT")
+	if endpoint == "" {
+		t.Skip("ATTACHMENTS_S3_TEST_ENDPOINT is not set")
+	}
+
+	store, err := NewS3Store(port.S3Config{
+		Endpoint:        endpoint,
+		Region:          "us-east-1",
+		Bucket:          os.Getenv("ATTACHMENTS_S3_TEST_BUCKET"),
+		AccessKeyID:     os.Getenv("ATTACHMENTS_S3_TEST_ACCESS_KEY_ID"),
+		SecretAccessKey: os.Getenv("ATTACHMENTS_S3_TEST_SECRET_ACCESS_KEY"),
+	}, noopLogger{})
+	require.NoError(t, err)
+
+	testBlobStore(t, store, "org_1/"+uuid.NewString()+"/receipt (1).png")
+}
+
+// testBlobStore stores, reads back and removes content under key.
+func testBlobStore(t *testing.T, store port.BlobStore, key string) {
+	t.Helper()
+	ctx := context.Background()
+
+	require.NoError(t, store.Put(ctx, key, pngContent, "image/png"))
+
+	content, err := store.Open(ctx, key)
+	require.NoError(t, err)
+	got, err := io.ReadAll(content)
+	require.NoError(t, err)
+	require.NoError(t, content.Close())
+	assert.Equal(t, pngContent, got)
+
+	require.NoError(t, store.Delete(ctx, key))
+	require.NoError(t, store.Delete(ctx, key), "a missing key is not an error")
+
+	_, err = store.Open(ctx, key)
+	requireCode(t, err, apperrors.CodeNotFound)
+}
@@ -0,0 +1,52 @@
+package attachment
+
+import (
+	"backend/adapter/database"
+	"backend/adapter/di"
+	"backend/core/budget/attachment/adapter/handler"
+	"backend/core/budget/attachment/adapter/postgres"
+	"backend/core/budget/attachment/core"
+	"backend/core/budget/attachment/port"
+	transactionport "backend/core/budget/transaction/port"
+	basedomain "backend/port"
+
+	"github.com/samber/do/v2"
+)
+
+func Module(i do.Injector, storagePath string, s3 port.S3Config) {
+	di.Provide(i, func(i do.Injector) (port.Repository, error) {
+		db := di.MustInvoke[database.PoolInterface](i)
+		logger := di.MustInvoke[basedomain.Logger](i)
+		return postgres.NewPostgres(db, logger), nil
+	})
+
+	di.Provide(i, func(i do.Injector) (port.BlobStore, error) {
+		logger := di.MustInvoke[basedomain.Logger](i)
+		if s3.Bucket != "" {
+			return core.NewS3Store(s3, logger)
+		}
+		logger.Info("ATTACHMENTS_S3_BUCKET not set, storing attachments on the filesystem", "path", storagePath)
+		return core.NewFilesystemStore(storagePath, logger), nil
+	})
+
+	di.Provide(i, func(i do.Injector) (transactionport.AttachmentRemover, error) {
+		repo := di.MustInvoke[port.Repository](i)
+		blobs := di.MustInvoke[port.BlobStore](i)
+		logger := di.MustInvoke[basedomain.Logger](i)
+		return core.NewRemover(repo, blobs, logger), nil
+	})
+
+	di.Provide(i, func(i do.Injector) (port.Service, error) {
+		repo := di.MustInvoke[port.Repository](i)
+		blobs := di.MustInvoke[port.BlobStore](i)
+		transactionSvc := di.MustInvoke[transactionport.Service](i)
+		logger := di.MustInvoke[basedomain.Logger](i)
+		return core.New(repo, blobs, transactionSvc, logger), nil
+	})
+
+	di.Provide(i, func(i do.Injector) (handler.HTTP, error) {
+		svc := di.MustInvoke[port.Service](i)
+		logger := di.MustInvoke[basedomain.Logger](i)
+		return handler.NewHTTP(svc, logger), nil
+	})
+}
@@ -0,0 +1,69 @@
+package port
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+
+	"backend/adapter/validation"
+	"github.com/google/uuid"
+)
+
+// MaxSize bounds the content of an attachment, in bytes.
+const MaxSize = 10 << 20
+
+// MimeTypes lists the kinds of files that can be attached: scanned or photographed
+// receipts and PDF documents.
+var MimeTypes = []string{
+	"application/pdf",
+	"image/jpeg",
+	"image/png",
+	"image/webp",
+}
+
+// UploadAttachment attaches a file to a transaction. Its type is told from the
+// content rather than from what the client claims.
+type UploadAttachment struct {
+	TransactionID uuid.UUID
+	FileName      string
+	Content       []byte
+}
+
+func (u UploadAttachment) Validate(ctx context.Context) error {
+	err := validation.ValidateStruct(ctx, &u,
+		validation.Field(&u.TransactionID, validation.Required),
+		validation.Field(&u.FileName, validation.Required, validation.Length(1, 255)),
+		validation.Field(&u.Content, validation.Required),
+	)
+	if err != nil {
+		return err
+	}
+
+	if len(u.Content) > MaxSize {
+		return fmt.Errorf("content: the file is larger than %d MB", MaxSize>>20)
+	}
+
+	if !slices.Contains(MimeTypes, u.MimeType()) {
+		return fmt.Errorf("content: %s files cannot be attached", u.MimeType())
+	}
+
+	return nil
+}
+
+// MimeType is the type sniffed from the content.
+func (u UploadAttachment) MimeType() string {
+	return http.DetectContentType(u.Content)
+}
+
+// CreateAttachment records an attachment whose content is already stored.
+type CreateAttachment struct {
+	ID             uuid.UUID
+	OrganizationID string
+	TransactionID  uuid.UUID
+	FileName       string
+	MimeType       string
+	Size           int64
+	SHA256         string
+	StorageKey     string
+}
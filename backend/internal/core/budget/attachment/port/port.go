@@ -0,0 +1,27 @@
+package port
+
+import (
+	"context"
+	"io"
+
+	"backend/infra/dafi"
+	basedomain "backend/port"
+
+	"github.com/google/uuid"
+)
+
+type Repository interface {
+	basedomain.RepositoryQuery[Attachment]
+	basedomain.RepositoryTx[Repository]
+	Create(ctx context.Context, input CreateAttachment) error
+	Delete(ctx context.Context, filters ...dafi.Filter) error
+}
+
+type Service interface {
+	Upload(ctx context.Context, input UploadAttachment) (Attachment, error)
+	FindAll(ctx context.Context, transactionID uuid.UUID) (basedomain.List[Attachment], error)
+	// Download returns an attachment of a transaction along with its content, which
+	// the caller must close.
+	Download(ctx context.Context, transactionID, id uuid.UUID) (Attachment, io.ReadCloser, error)
+	Delete(ctx context.Context, transactionID, id uuid.UUID) error
+}
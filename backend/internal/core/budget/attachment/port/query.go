@@ -0,0 +1,21 @@
+package port
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Attachment is a receipt or another document attached to a transaction. SHA256 is
+// the hex digest of the content, which the blob store keeps under StorageKey.
+type Attachment struct {
+	ID             uuid.UUID `json:"id"`
+	OrganizationID string    `json:"organizationId"`
+	TransactionID  uuid.UUID `json:"transactionId"`
+	FileName       string    `json:"fileName"`
+	MimeType       string    `json:"mimeType"`
+	Size           int64     `json:"size"`
+	SHA256         string    `json:"sha256"`
+	StorageKey     string    `json:"-"`
+	CreatedAt      time.Time `json:"createdAt"`
+}
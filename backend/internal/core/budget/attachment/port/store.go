@@ -0,0 +1,27 @@
+package port
+
+import (
+	"context"
+	"io"
+)
+
+// BlobStore keeps the content of attachments under keys made of slash-separated
+// segments.
+type BlobStore interface {
+	Put(ctx context.Context, key string, content []byte, mimeType string) error
+	// Open returns the content stored under key. It fails with a CodeNotFound error
+	// when there is none.
+	Open(ctx context.Context, key string) (io.ReadCloser, error)
+	// Delete removes the content stored under key. A missing key is not an error.
+	Delete(ctx context.Context, key string) error
+}
+
+// S3Config points the blob store at a bucket of S3 or of a compatible service.
+// Endpoint defaults to AWS for the region.
+type S3Config struct {
+	Endpoint        string
+	Region          string
+	Bucket          string
+	AccessKeyID     string
+	SecretAccessKey string
+}
@@ -6,6 +6,7 @@ import (
 	apperrors "backend/port/errors"
 	"backend/infra/dafi"
 	"backend/infra/httpresponse"
+	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
 	"github.com/samber/oops"
 )
@@ -52,6 +53,22 @@ func (h HTTP) FindAll(c echo.Context) error {
 	return httpresponse.OK(c, budgets)
 }
 
+func (h HTTP) Summary(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	budgetID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return oops.WithContext(ctx).In(apperrors.LayerHandler).Code(apperrors.CodeBadRequest).Wrap(err)
+	}
+
+	summary, err := h.svc.Summary(ctx, budgetID)
+	if err != nil {
+		return oops.WithContext(ctx).In(apperrors.LayerHandler).Wrap(err)
+	}
+
+	return httpresponse.OK(c, summary)
+}
+
 func (h HTTP) Create(c echo.Context) error {
 	ctx := c.Request().Context()
 
@@ -67,6 +84,26 @@ func (h HTTP) Create(c echo.Context) error {
 	return httpresponse.Created(c, nil)
 }
 
+func (h HTTP) Clone(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	sourceID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return oops.WithContext(ctx).In(apperrors.LayerHandler).Code(apperrors.CodeBadRequest).Wrap(err)
+	}
+
+	var input port.CloneBudget
+	if err := c.Bind(&input); err != nil {
+		return oops.WithContext(ctx).In(apperrors.LayerHandler).Code(apperrors.CodeBadRequest).Wrap(err)
+	}
+
+	if err := h.svc.Clone(ctx, sourceID, input); err != nil {
+		return oops.WithContext(ctx).In(apperrors.LayerHandler).Wrap(err)
+	}
+
+	return httpresponse.Created(c, nil)
+}
+
 func (h HTTP) Update(c echo.Context) error {
 	ctx := c.Request().Context()
 	id := c.Param("id")
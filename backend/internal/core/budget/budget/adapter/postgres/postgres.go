@@ -12,6 +12,7 @@ import (
 	"backend/adapter/database"
 	"backend/infra/sqlcraft"
 
+	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/samber/oops"
@@ -177,7 +178,7 @@ func (r postgres) Create(ctx context.Context, input port.CreateBudget) error {
 
 	_, err = r.db.Exec(ctx, result.SQL, result.Args...)
 	if err != nil {
-		return oops.WithContext(ctx).In(apperrors.LayerRepository).Wrap(err)
+		return r.wrapWriteError(ctx, err)
 	}
 
 	return nil
@@ -220,6 +221,20 @@ func (r postgres) CreateBulk(ctx context.Context, inputs basedomain.List[port.Cr
 	return nil
 }
 
+const pgErrUniqueViolation = "23505"
+
+func (r postgres) wrapWriteError(ctx context.Context, err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == pgErrUniqueViolation {
+		return oops.WithContext(ctx).In(apperrors.LayerRepository).
+			Code(apperrors.CodeAlreadyExists).
+			Public("The organization already has a budget for this month.").
+			Wrap(err)
+	}
+
+	return oops.WithContext(ctx).In(apperrors.LayerRepository).Wrap(err)
+}
+
 func (r postgres) Update(ctx context.Context, input port.UpdateBudget, filters ...dafi.Filter) error {
 	query := sqlcraft.Update(tableName).
 		WithColumns("name", "is_active", "updated_at").
@@ -266,3 +281,181 @@ func (r postgres) Delete(ctx context.Context, filters ...dafi.Filter) error {
 
 	return nil
 }
+
+// summaryQuery totals the transactions of a budget in the budget currency, converting
+// amounts from accounts in other currencies with budget.convert_amount at the rate in
+// effect on the transaction date. unconverted counts the transactions that could not be
+// converted for lack of an exchange rate.
+const summaryQuery = `
+WITH lines AS (
+    SELECT budget.convert_amount(t.organization_id, t.amount, a.currency_code, b.currency_code, t.date) AS amount
+    FROM budget.transactions t
+    JOIN budget.accounts a ON a.id = t.account_id
+    JOIN budget.budgets b ON b.id = t.budget_id
+    WHERE t.budget_id = $1
+)
+SELECT
+    b.currency_code,
+    COALESCE((SELECT SUM(amount) FROM lines WHERE amount > 0), 0)::BIGINT,
+    COALESCE((SELECT -SUM(amount) FROM lines WHERE amount < 0), 0)::BIGINT,
+    COALESCE((SELECT SUM(assigned_amount) FROM budget.budget_allocations WHERE budget_id = b.id), 0)::BIGINT,
+    (SELECT COUNT(*) FROM lines WHERE amount IS NULL)
+FROM budget.budgets b
+WHERE b.id = $1`
+
+func (r postgres) Summary(ctx context.Context, budgetID uuid.UUID) (port.Summary, error) {
+	r.logger.WithContext(ctx).Debug("executing query", "sql", summaryQuery)
+
+	summary := port.Summary{BudgetID: budgetID}
+	var unconverted int64
+	err := r.db.QueryRow(ctx, summaryQuery, budgetID).Scan(
+		&summary.CurrencyCode,
+		&summary.Income,
+		&summary.Spent,
+		&summary.Assigned,
+		&unconverted,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return port.Summary{}, oops.WithContext(ctx).In(apperrors.LayerRepository).Code(apperrors.CodeNotFound).Wrap(err)
+		}
+		return port.Summary{}, oops.WithContext(ctx).In(apperrors.LayerRepository).Wrap(err)
+	}
+
+	if unconverted > 0 {
+		return port.Summary{}, oops.WithContext(ctx).In(apperrors.LayerRepository).
+			Code(apperrors.CodeConflict).
+			Public("An exchange rate is missing for one of the currencies used in this budget.").
+			Errorf("%d transactions of budget %s could not be converted to %s", unconverted, budgetID, summary.CurrencyCode)
+	}
+
+	return summary, nil
+}
+
+// categoryBalancesQuery groups the allocations and categorized transaction lines of a
+// budget by category line, converted to the currency given in $2. The rollover policy is
+// taken from the most specific category of the line.
+const categoryBalancesQuery = `
+WITH lines AS (
+    SELECT
+        ba.category_id,
+        ba.subcategory_id,
+        budget.convert_amount(ba.organization_id, ba.carryover_amount, b.currency_code, $2, make_date(b.year, b.month, 1)) AS carryover,
+        budget.convert_amount(ba.organization_id, ba.assigned_amount, b.currency_code, $2, make_date(b.year, b.month, 1)) AS assigned,
+        0::BIGINT AS activity
+    FROM budget.budget_allocations ba
+    JOIN budget.budgets b ON b.id = ba.budget_id
+    WHERE ba.budget_id = $1
+    UNION ALL
+    SELECT
+        t.category_id,
+        t.subcategory_id,
+        0::BIGINT,
+        0::BIGINT,
+        budget.convert_amount(t.organization_id, t.amount, a.currency_code, $2, t.date)
+    FROM budget.transaction_lines t
+    JOIN budget.accounts a ON a.id = t.account_id
+    WHERE t.budget_id = $1 AND t.category_id IS NOT NULL
+)
+SELECT
+    l.category_id,
+    l.subcategory_id,
+    c.rollover_policy,
+    COALESCE(SUM(l.carryover), 0)::BIGINT,
+    COALESCE(SUM(l.assigned), 0)::BIGINT,
+    COALESCE(SUM(l.activity), 0)::BIGINT,
+    BOOL_OR(l.carryover IS NULL OR l.assigned IS NULL OR l.activity IS NULL)
+FROM lines l
+JOIN budget.categories c ON c.id = COALESCE(l.subcategory_id, l.category_id)
+GROUP BY l.category_id, l.subcategory_id, c.rollover_policy`
+
+func (r postgres) CategoryBalances(ctx context.Context, budgetID uuid.UUID, currencyCode string) (basedomain.List[port.CategoryBalance], error) {
+	r.logger.WithContext(ctx).Debug("executing query", "sql", categoryBalancesQuery)
+
+	rows, err := r.db.Query(ctx, categoryBalancesQuery, budgetID, currencyCode)
+	if err != nil {
+		return nil, oops.WithContext(ctx).In(apperrors.LayerRepository).Wrap(err)
+	}
+	defer rows.Close()
+
+	var balances basedomain.List[port.CategoryBalance]
+	for rows.Next() {
+		var (
+			b           port.CategoryBalance
+			unconverted bool
+		)
+		err = rows.Scan(
+			&b.CategoryID,
+			&b.SubcategoryID,
+			&b.RolloverPolicy,
+			&b.Carryover,
+			&b.Assigned,
+			&b.Activity,
+			&unconverted,
+		)
+		if err != nil {
+			return nil, oops.WithContext(ctx).In(apperrors.LayerRepository).Wrap(err)
+		}
+		if unconverted {
+			return nil, oops.WithContext(ctx).In(apperrors.LayerRepository).
+				Code(apperrors.CodeConflict).
+				Public("An exchange rate is missing for one of the currencies used in the source budget.").
+				Errorf("category %s of budget %s could not be converted to %s", b.CategoryID, budgetID, currencyCode)
+		}
+		balances = append(balances, b)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, oops.WithContext(ctx).In(apperrors.LayerRepository).Wrap(err)
+	}
+
+	return balances, nil
+}
+
+var categoryLineColumns = []string{
+	"id",
+	"organization_id",
+	"budget_id",
+	"category_id",
+	"subcategory_id",
+	"assigned_amount",
+	"carryover_amount",
+	"created_at",
+	"updated_at",
+}
+
+func (r postgres) CreateCategoryLines(ctx context.Context, inputs basedomain.List[port.CategoryLine]) error {
+	if inputs.IsEmpty() {
+		return nil
+	}
+
+	now := time.Now()
+	query := sqlcraft.InsertInto("budget.budget_allocations").WithColumns(categoryLineColumns...)
+
+	for _, input := range inputs {
+		query = query.WithValues(
+			input.ID,
+			input.OrganizationID,
+			input.BudgetID,
+			input.CategoryID,
+			input.SubcategoryID,
+			input.Assigned,
+			input.Carryover,
+			now,
+			now,
+		)
+	}
+
+	result, err := query.ToSQL()
+	if err != nil {
+		return oops.WithContext(ctx).In(apperrors.LayerRepository).Wrap(err)
+	}
+
+	r.logger.WithContext(ctx).Debug("bulk insert", "sql", result.SQL, "count", len(inputs))
+
+	_, err = r.db.Exec(ctx, result.SQL, result.Args...)
+	if err != nil {
+		return oops.WithContext(ctx).In(apperrors.LayerRepository).Wrap(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,164 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"backend/core/budget/budget/port"
+	"backend/infra/dafi"
+	"backend/infra/money"
+	basedomain "backend/port"
+	apperrors "backend/port/errors"
+
+	"github.com/google/uuid"
+	"github.com/samber/oops"
+)
+
+// Clone creates a budget month from the source budget. The new month is opened like
+// Create does, rollover included, and gets the source category lines assigned either
+// from the amounts assigned in the source or from what was spent in it.
+func (s service) Clone(ctx context.Context, sourceID uuid.UUID, input port.CloneBudget) error {
+	if err := input.Validate(ctx); err != nil {
+		return oops.WithContext(ctx).In(apperrors.LayerService).Code(apperrors.CodeValidation).Wrap(err)
+	}
+
+	source, err := s.repo.FindOne(ctx, dafi.Where("id", dafi.Equal, sourceID))
+	if err != nil {
+		return oops.WithContext(ctx).In(apperrors.LayerService).Wrap(err)
+	}
+
+	target := cloneTarget(source, input)
+	if err := target.Validate(ctx); err != nil {
+		return oops.WithContext(ctx).In(apperrors.LayerService).Code(apperrors.CodeValidation).Wrap(err)
+	}
+
+	var lines basedomain.List[port.CategoryLine]
+	err = basedomain.Atomically(ctx, s.uow, s.tx, s.withTx, func(txSvc service) error {
+		if err := txSvc.repo.Create(ctx, target); err != nil {
+			return err
+		}
+
+		rolled, err := txSvc.rollover(ctx, target)
+		if err != nil {
+			return err
+		}
+
+		balances, err := txSvc.repo.CategoryBalances(ctx, source.ID, target.CurrencyCode)
+		if err != nil {
+			return err
+		}
+
+		lines = mergeCategoryLines(rolled, clonedLines(target, balances, input))
+		return txSvc.repo.CreateCategoryLines(ctx, lines)
+	})
+	if err != nil {
+		return oops.WithContext(ctx).In(apperrors.LayerService).Wrap(err)
+	}
+
+	s.logger.WithContext(ctx).Info("budget cloned",
+		"source", source.ID,
+		"name", target.Name,
+		"amountSource", input.AmountSource,
+		"categoryLines", len(lines),
+	)
+
+	return nil
+}
+
+// cloneTarget builds the budget a clone creates, defaulting to the month after the
+// source and to a "<Month> <Year>" name.
+func cloneTarget(source port.Budget, input port.CloneBudget) port.CreateBudget {
+	month, year := input.Month, input.Year
+	if month == 0 {
+		month, year = nextMonth(source.Month, source.Year)
+	}
+
+	name := input.Name
+	if name == "" {
+		name = fmt.Sprintf("%s %d", time.Month(month), year)
+	}
+
+	return port.CreateBudget{
+		ID:             input.ID,
+		OrganizationID: source.OrganizationID,
+		Name:           name,
+		Month:          month,
+		Year:           year,
+		CurrencyCode:   source.CurrencyCode,
+		IsActive:       true,
+	}
+}
+
+// clonedLines returns the lines to assign in the target budget from the source
+// balances. Lines without anything to copy are left out.
+func clonedLines(target port.CreateBudget, balances basedomain.List[port.CategoryBalance], input port.CloneBudget) basedomain.List[port.CategoryLine] {
+	var lines basedomain.List[port.CategoryLine]
+	for _, b := range balances {
+		amount := b.Assigned
+		if input.AmountSource == port.CloneFromActivity {
+			// Spending is recorded as negative activity; inflows leave nothing to plan.
+			amount = max(-b.Activity, 0)
+		}
+		if input.ScalePercent.Valid {
+			amount = scalePercent(amount, input.ScalePercent.Int64)
+		}
+		if amount == 0 {
+			continue
+		}
+
+		lines = append(lines, port.CategoryLine{
+			ID:             uuid.New(),
+			OrganizationID: target.OrganizationID,
+			BudgetID:       target.ID,
+			CategoryID:     b.CategoryID,
+			SubcategoryID:  b.SubcategoryID,
+			Assigned:       amount,
+		})
+	}
+
+	return lines
+}
+
+type categoryLineKey struct {
+	category    uuid.UUID
+	subcategory uuid.UUID
+}
+
+func lineKey(l port.CategoryLine) categoryLineKey {
+	k := categoryLineKey{category: l.CategoryID}
+	if l.SubcategoryID != nil {
+		k.subcategory = *l.SubcategoryID
+	}
+	return k
+}
+
+// mergeCategoryLines folds the assigned lines into the rolled-over ones so each
+// category line is written once.
+func mergeCategoryLines(rolled, assigned basedomain.List[port.CategoryLine]) basedomain.List[port.CategoryLine] {
+	lines := make(basedomain.List[port.CategoryLine], 0, len(rolled)+len(assigned))
+	index := make(map[categoryLineKey]int, len(rolled))
+	for _, l := range rolled {
+		index[lineKey(l)] = len(lines)
+		lines = append(lines, l)
+	}
+
+	for _, l := range assigned {
+		if i, ok := index[lineKey(l)]; ok {
+			lines[i].Assigned += l.Assigned
+			continue
+		}
+		lines = append(lines, l)
+	}
+
+	return lines
+}
+
+// scalePercent returns percent% of amount, rounding half away from zero.
+func scalePercent(amount money.Minor, percent int64) money.Minor {
+	v := int64(amount) * percent
+	if v < 0 {
+		return money.Minor((v - 50) / 100)
+	}
+	return money.Minor((v + 50) / 100)
+}
@@ -0,0 +1,176 @@
+package core
+
+import (
+	"context"
+	"testing"
+
+	"backend/core/budget/budget/port"
+	categoryport "backend/core/budget/category/port"
+	"backend/infra/money"
+	basedomain "backend/port"
+	apperrors "backend/port/errors"
+
+	"github.com/google/uuid"
+	"github.com/guregu/null/v6"
+	"github.com/samber/oops"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	cloneSourceID = uuid.MustParse("bbbbbbbb-0000-0000-0000-000000000004")
+	cloneTargetID = uuid.MustParse("bbbbbbbb-0000-0000-0000-000000000005")
+	groceriesLine = uuid.MustParse("cccccccc-0000-0000-0000-000000000001")
+	rentLine      = uuid.MustParse("cccccccc-0000-0000-0000-000000000002")
+	salaryLine    = uuid.MustParse("cccccccc-0000-0000-0000-000000000003")
+)
+
+func cloneSourceRepo() *stubBudgetRepo {
+	return &stubBudgetRepo{
+		previous: &port.Budget{
+			ID:             cloneSourceID,
+			OrganizationID: "org-1",
+			Name:           "April 2026",
+			Month:          4,
+			Year:           2026,
+			CurrencyCode:   "USD",
+		},
+		balances: basedomain.List[port.CategoryBalance]{
+			{CategoryID: groceriesLine, RolloverPolicy: categoryport.RolloverCarry, Assigned: 40000, Activity: -35000},
+			{CategoryID: rentLine, RolloverPolicy: categoryport.RolloverReset, Assigned: 120000, Activity: -120000},
+			{CategoryID: salaryLine, RolloverPolicy: categoryport.RolloverReset, Activity: 500000},
+		},
+	}
+}
+
+func linesByCategory(lines basedomain.List[port.CategoryLine]) map[uuid.UUID]port.CategoryLine {
+	out := make(map[uuid.UUID]port.CategoryLine, len(lines))
+	for _, l := range lines {
+		out[l.CategoryID] = l
+	}
+	return out
+}
+
+func TestService_Clone(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		input     port.CloneBudget
+		wantLines map[uuid.UUID]port.CategoryLine
+	}{
+		{
+			name:  "copies assigned amounts into the next month",
+			input: port.CloneBudget{ID: cloneTargetID},
+			wantLines: map[uuid.UUID]port.CategoryLine{
+				// the source is also the previous month, so groceries rolls over 50.00
+				groceriesLine: {Assigned: 40000, Carryover: 5000},
+				rentLine:      {Assigned: 120000},
+			},
+		},
+		{
+			name:  "scales assigned amounts",
+			input: port.CloneBudget{ID: cloneTargetID, ScalePercent: null.IntFrom(105)},
+			wantLines: map[uuid.UUID]port.CategoryLine{
+				groceriesLine: {Assigned: 42000, Carryover: 5000},
+				rentLine:      {Assigned: 126000},
+			},
+		},
+		{
+			name:  "seeds from actual spending",
+			input: port.CloneBudget{ID: cloneTargetID, AmountSource: port.CloneFromActivity},
+			wantLines: map[uuid.UUID]port.CategoryLine{
+				groceriesLine: {Assigned: 35000, Carryover: 5000},
+				rentLine:      {Assigned: 120000},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			repo := cloneSourceRepo()
+			uow := &stubUnitOfWork{}
+			svc := New(repo, uow, noopLogger{})
+
+			require.NoError(t, svc.Clone(context.Background(), cloneSourceID, tt.input))
+			assert.True(t, uow.committed)
+
+			require.Len(t, repo.created, 1)
+			created := repo.created[0]
+			assert.Equal(t, cloneTargetID, created.ID)
+			assert.Equal(t, "org-1", created.OrganizationID)
+			assert.Equal(t, "May 2026", created.Name)
+			assert.Equal(t, int16(5), created.Month)
+			assert.Equal(t, int16(2026), created.Year)
+			assert.Equal(t, "USD", created.CurrencyCode)
+
+			got := linesByCategory(repo.lines)
+			require.Len(t, got, len(tt.wantLines))
+			for category, want := range tt.wantLines {
+				line, ok := got[category]
+				require.True(t, ok, "missing line for %s", category)
+				assert.Equal(t, want.Assigned, line.Assigned, "assigned for %s", category)
+				assert.Equal(t, want.Carryover, line.Carryover, "carryover for %s", category)
+				assert.Equal(t, cloneTargetID, line.BudgetID)
+			}
+		})
+	}
+}
+
+func TestService_Clone_IntoTemplateMonth(t *testing.T) {
+	t.Parallel()
+
+	repo := cloneSourceRepo()
+	svc := New(repo, &stubUnitOfWork{}, noopLogger{})
+
+	input := port.CloneBudget{ID: cloneTargetID, Name: "Holidays", Month: 1, Year: 2027}
+	require.NoError(t, svc.Clone(context.Background(), cloneSourceID, input))
+
+	require.Len(t, repo.created, 1)
+	assert.Equal(t, "Holidays", repo.created[0].Name)
+	assert.Equal(t, int16(1), repo.created[0].Month)
+	assert.Equal(t, int16(2027), repo.created[0].Year)
+}
+
+func TestService_Clone_InvalidInput(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		input port.CloneBudget
+	}{
+		{name: "name too short", input: port.CloneBudget{ID: cloneTargetID, Name: "x"}},
+		{name: "month without year", input: port.CloneBudget{ID: cloneTargetID, Month: 3}},
+		{name: "month out of range", input: port.CloneBudget{ID: cloneTargetID, Month: 13, Year: 2026}},
+		{name: "unknown amount source", input: port.CloneBudget{ID: cloneTargetID, AmountSource: "forecast"}},
+		{name: "negative scale", input: port.CloneBudget{ID: cloneTargetID, ScalePercent: null.IntFrom(-10)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			repo := cloneSourceRepo()
+			svc := New(repo, &stubUnitOfWork{}, noopLogger{})
+
+			err := svc.Clone(context.Background(), cloneSourceID, tt.input)
+			require.Error(t, err)
+
+			oopsErr, ok := oops.AsOops(err)
+			require.True(t, ok)
+			assert.Equal(t, apperrors.CodeValidation, oopsErr.Code())
+			assert.Empty(t, repo.created)
+		})
+	}
+}
+
+func TestScalePercent(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, money.Minor(11000), scalePercent(10000, 110))
+	assert.Equal(t, money.Minor(0), scalePercent(10000, 0))
+	assert.Equal(t, money.Minor(2), scalePercent(3, 50))   // 1.5 rounds up
+	assert.Equal(t, money.Minor(-2), scalePercent(-3, 50)) // -1.5 rounds away from zero
+}
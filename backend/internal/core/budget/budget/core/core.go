@@ -1,30 +1,42 @@
 package core
 
 import (
+	"cmp"
 	"context"
+	"slices"
 
 	"backend/core/budget/budget/port"
 	basedomain "backend/port"
 	apperrors "backend/port/errors"
 	"backend/infra/dafi"
+	"github.com/google/uuid"
 	"github.com/samber/oops"
 )
 
 type service struct {
 	repo   port.Repository
+	uow    basedomain.UnitOfWork
+	tx     basedomain.Transaction
 	logger basedomain.Logger
 }
 
-func New(repo port.Repository, logger basedomain.Logger) port.Service {
+func New(repo port.Repository, uow basedomain.UnitOfWork, logger basedomain.Logger) port.Service {
 	return service{
 		repo:   repo,
+		uow:    uow,
 		logger: logger.With("component", "budget.service"),
 	}
 }
 
 func (s service) WithTx(tx basedomain.Transaction) port.Service {
+	return s.withTx(tx)
+}
+
+func (s service) withTx(tx basedomain.Transaction) service {
 	return service{
 		repo:   s.repo.WithTx(tx),
+		uow:    s.uow,
+		tx:     tx,
 		logger: s.logger,
 	}
 }
@@ -47,30 +59,92 @@ func (s service) FindAll(ctx context.Context, criteria dafi.Criteria) (basedomai
 	return budgets, nil
 }
 
+// Summary returns the budget totals with ToBeBudgeted set to the income that has
+// not been assigned to any category yet.
+func (s service) Summary(ctx context.Context, budgetID uuid.UUID) (port.Summary, error) {
+	summary, err := s.repo.Summary(ctx, budgetID)
+	if err != nil {
+		return port.Summary{}, oops.WithContext(ctx).In(apperrors.LayerService).Wrap(err)
+	}
+
+	summary.ToBeBudgeted = summary.Income - summary.Assigned
+
+	return summary, nil
+}
+
 func (s service) Create(ctx context.Context, input port.CreateBudget) error {
 	if err := input.Validate(ctx); err != nil {
 		return oops.WithContext(ctx).In(apperrors.LayerService).Code(apperrors.CodeValidation).Wrap(err)
 	}
 
-	if err := s.repo.Create(ctx, input); err != nil {
+	var lines basedomain.List[port.CategoryLine]
+	err := basedomain.Atomically(ctx, s.uow, s.tx, s.withTx, func(txSvc service) error {
+		var err error
+		lines, err = txSvc.create(ctx, input)
+		return err
+	})
+	if err != nil {
 		return oops.WithContext(ctx).In(apperrors.LayerService).Wrap(err)
 	}
 
-	s.logger.WithContext(ctx).Info("budget created", "name", input.Name)
+	s.logger.WithContext(ctx).Info("budget created", "name", input.Name, "categoryLines", len(lines))
 
 	return nil
 }
 
+// CreateBulk creates the budgets in month order, so that each one rolls over the
+// lines of a previous month created along with it.
 func (s service) CreateBulk(ctx context.Context, inputs basedomain.List[port.CreateBudget]) error {
-	if err := s.repo.CreateBulk(ctx, inputs); err != nil {
+	for _, input := range inputs {
+		if err := input.Validate(ctx); err != nil {
+			return oops.WithContext(ctx).In(apperrors.LayerService).Code(apperrors.CodeValidation).Wrap(err)
+		}
+	}
+
+	ordered := slices.Clone(inputs)
+	slices.SortStableFunc(ordered, func(a, b port.CreateBudget) int {
+		return cmp.Or(cmp.Compare(a.Year, b.Year), cmp.Compare(a.Month, b.Month))
+	})
+
+	var lines int
+	err := basedomain.Atomically(ctx, s.uow, s.tx, s.withTx, func(txSvc service) error {
+		for _, input := range ordered {
+			created, err := txSvc.create(ctx, input)
+			if err != nil {
+				return err
+			}
+			lines += len(created)
+		}
+		return nil
+	})
+	if err != nil {
 		return oops.WithContext(ctx).In(apperrors.LayerService).Wrap(err)
 	}
 
-	s.logger.WithContext(ctx).Info("budgets created", "count", len(inputs))
+	s.logger.WithContext(ctx).Info("budgets created", "count", len(inputs), "categoryLines", lines)
 
 	return nil
 }
 
+// create creates a budget with the category lines it rolls over from the previous
+// month and returns those lines. It must run in a transaction.
+func (s service) create(ctx context.Context, input port.CreateBudget) (basedomain.List[port.CategoryLine], error) {
+	if err := s.repo.Create(ctx, input); err != nil {
+		return nil, err
+	}
+
+	lines, err := s.rollover(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.CreateCategoryLines(ctx, lines); err != nil {
+		return nil, err
+	}
+
+	return lines, nil
+}
+
 func (s service) Update(ctx context.Context, input port.UpdateBudget, filters ...dafi.Filter) error {
 	if err := input.Validate(ctx); err != nil {
 		return oops.WithContext(ctx).In(apperrors.LayerService).Code(apperrors.CodeValidation).Wrap(err)
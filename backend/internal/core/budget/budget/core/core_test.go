@@ -0,0 +1,188 @@
+package core
+
+import (
+	"context"
+	"testing"
+
+	"backend/core/budget/budget/port"
+	"backend/infra/dafi"
+	"backend/infra/money"
+	basedomain "backend/port"
+	apperrors "backend/port/errors"
+
+	"github.com/google/uuid"
+	"github.com/samber/oops"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type noopLogger struct{}
+
+func (noopLogger) Debug(string, ...any)                          {}
+func (noopLogger) Info(string, ...any)                           {}
+func (noopLogger) Warn(string, ...any)                           {}
+func (noopLogger) Error(string, ...any)                          {}
+func (noopLogger) With(...any) basedomain.Logger                 { return noopLogger{} }
+func (noopLogger) WithContext(context.Context) basedomain.Logger { return noopLogger{} }
+
+type stubTx struct{}
+
+func (stubTx) GetTx() basedomain.Tx { return nil }
+
+type stubUnitOfWork struct {
+	committed  bool
+	rolledBack bool
+}
+
+func (u *stubUnitOfWork) Begin(ctx context.Context) (basedomain.Transaction, error) {
+	_ = ctx
+	return stubTx{}, nil
+}
+
+func (u *stubUnitOfWork) Commit(ctx context.Context, tx basedomain.Transaction) error {
+	_ = ctx
+	_ = tx
+	u.committed = true
+	return nil
+}
+
+func (u *stubUnitOfWork) Rollback(ctx context.Context, tx basedomain.Transaction) error {
+	_ = ctx
+	_ = tx
+	if !u.committed {
+		u.rolledBack = true
+	}
+	return nil
+}
+
+type stubBudgetRepo struct {
+	summary    port.Summary
+	summaryErr error
+
+	previous    *port.Budget
+	balances    basedomain.List[port.CategoryBalance]
+	balancesErr error
+	created     []port.CreateBudget
+	lines       basedomain.List[port.CategoryLine]
+}
+
+func (s *stubBudgetRepo) FindOne(ctx context.Context, criteria dafi.Criteria) (port.Budget, error) {
+	_ = ctx
+	_ = criteria
+	if s.previous == nil {
+		return port.Budget{}, oops.Code(apperrors.CodeNotFound).Errorf("budget not found")
+	}
+	return *s.previous, nil
+}
+
+func (s *stubBudgetRepo) FindAll(ctx context.Context, criteria dafi.Criteria) (basedomain.List[port.Budget], error) {
+	_ = ctx
+	_ = criteria
+	return nil, nil
+}
+
+func (s *stubBudgetRepo) Create(ctx context.Context, input port.CreateBudget) error {
+	_ = ctx
+	s.created = append(s.created, input)
+	return nil
+}
+
+func (s *stubBudgetRepo) CreateBulk(ctx context.Context, inputs basedomain.List[port.CreateBudget]) error {
+	_ = ctx
+	_ = inputs
+	return nil
+}
+
+func (s *stubBudgetRepo) Update(ctx context.Context, input port.UpdateBudget, filters ...dafi.Filter) error {
+	_ = ctx
+	_ = input
+	_ = filters
+	return nil
+}
+
+func (s *stubBudgetRepo) Delete(ctx context.Context, filters ...dafi.Filter) error {
+	_ = ctx
+	_ = filters
+	return nil
+}
+
+func (s *stubBudgetRepo) WithTx(tx basedomain.Transaction) port.Repository {
+	_ = tx
+	return s
+}
+
+func (s *stubBudgetRepo) Summary(ctx context.Context, budgetID uuid.UUID) (port.Summary, error) {
+	_ = ctx
+	_ = budgetID
+	return s.summary, s.summaryErr
+}
+
+func (s *stubBudgetRepo) CategoryBalances(ctx context.Context, budgetID uuid.UUID, currencyCode string) (basedomain.List[port.CategoryBalance], error) {
+	_ = ctx
+	_ = budgetID
+	_ = currencyCode
+	return s.balances, s.balancesErr
+}
+
+func (s *stubBudgetRepo) CreateCategoryLines(ctx context.Context, inputs basedomain.List[port.CategoryLine]) error {
+	_ = ctx
+	s.lines = append(s.lines, inputs...)
+	return nil
+}
+
+func TestService_Summary(t *testing.T) {
+	t.Parallel()
+
+	id := uuid.MustParse("aaaaaaaa-bbbb-cccc-dddd-eeeeeeeeeeee")
+
+	tests := []struct {
+		name    string
+		repo    *stubBudgetRepo
+		want    money.Minor
+		wantErr bool
+	}{
+		{
+			name: "unassigned income is left to budget",
+			repo: &stubBudgetRepo{summary: port.Summary{
+				BudgetID:     id,
+				CurrencyCode: "USD",
+				Income:       500000,
+				Assigned:     320000,
+				Spent:        150000,
+			}},
+			want: 180000,
+		},
+		{
+			name: "over-assigning goes negative",
+			repo: &stubBudgetRepo{summary: port.Summary{
+				BudgetID:     id,
+				CurrencyCode: "USD",
+				Income:       100000,
+				Assigned:     125000,
+			}},
+			want: -25000,
+		},
+		{
+			name:    "repository error",
+			repo:    &stubBudgetRepo{summaryErr: oops.Code(apperrors.CodeNotFound).Errorf("budget not found")},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			svc := New(tt.repo, &stubUnitOfWork{}, noopLogger{})
+
+			got, err := svc.Summary(context.Background(), id)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got.ToBeBudgeted)
+			assert.Equal(t, tt.repo.summary.Spent, got.Spent)
+		})
+	}
+}
@@ -0,0 +1,83 @@
+package core
+
+import (
+	"context"
+
+	"backend/core/budget/budget/port"
+	categoryport "backend/core/budget/category/port"
+	"backend/infra/dafi"
+	"backend/infra/money"
+	basedomain "backend/port"
+	apperrors "backend/port/errors"
+
+	"github.com/google/uuid"
+	"github.com/samber/oops"
+)
+
+// rollover returns the category lines a new budget month opens with: what the
+// previous month left on each line, according to the category rollover policy.
+// A month without a predecessor opens no lines.
+func (s service) rollover(ctx context.Context, input port.CreateBudget) (basedomain.List[port.CategoryLine], error) {
+	month, year := previousMonth(input.Month, input.Year)
+	criteria := dafi.Where("organizationId", dafi.Equal, input.OrganizationID).
+		And("month", dafi.Equal, month).
+		And("year", dafi.Equal, year)
+
+	previous, err := s.repo.FindOne(ctx, criteria)
+	if err != nil {
+		if oopsErr, ok := oops.AsOops(err); ok && oopsErr.Code() == apperrors.CodeNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	balances, err := s.repo.CategoryBalances(ctx, previous.ID, input.CurrencyCode)
+	if err != nil {
+		return nil, err
+	}
+
+	var lines basedomain.List[port.CategoryLine]
+	for _, b := range balances {
+		amount := openingBalance(b.RolloverPolicy, b.Available())
+		if amount == 0 {
+			continue
+		}
+		lines = append(lines, port.CategoryLine{
+			ID:             uuid.New(),
+			OrganizationID: input.OrganizationID,
+			BudgetID:       input.ID,
+			CategoryID:     b.CategoryID,
+			SubcategoryID:  b.SubcategoryID,
+			Carryover:      amount,
+		})
+	}
+
+	return lines, nil
+}
+
+// openingBalance applies a category rollover policy to the amount a line had
+// available at the end of the previous month.
+func openingBalance(policy string, available money.Minor) money.Minor {
+	switch policy {
+	case categoryport.RolloverCarry:
+		return available
+	case categoryport.RolloverCarryPositive:
+		return max(available, 0)
+	default:
+		return 0
+	}
+}
+
+func previousMonth(month, year int16) (int16, int16) {
+	if month == 1 {
+		return 12, year - 1
+	}
+	return month - 1, year
+}
+
+func nextMonth(month, year int16) (int16, int16) {
+	if month == 12 {
+		return 1, year + 1
+	}
+	return month + 1, year
+}
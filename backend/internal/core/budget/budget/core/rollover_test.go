@@ -0,0 +1,186 @@
+package core
+
+import (
+	"context"
+	"testing"
+
+	"backend/core/budget/budget/port"
+	categoryport "backend/core/budget/category/port"
+	"backend/infra/money"
+	basedomain "backend/port"
+	apperrors "backend/port/errors"
+
+	"github.com/google/uuid"
+	"github.com/samber/oops"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOpeningBalance(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		policy    string
+		available money.Minor
+		want      money.Minor
+	}{
+		{name: "carry unspent", policy: categoryport.RolloverCarry, available: 4000, want: 4000},
+		{name: "carry overspent", policy: categoryport.RolloverCarry, available: -2500, want: -2500},
+		{name: "reset unspent", policy: categoryport.RolloverReset, available: 4000, want: 0},
+		{name: "reset overspent", policy: categoryport.RolloverReset, available: -2500, want: 0},
+		{name: "carry positive unspent", policy: categoryport.RolloverCarryPositive, available: 4000, want: 4000},
+		{name: "carry positive overspent", policy: categoryport.RolloverCarryPositive, available: -2500, want: 0},
+		{name: "unknown policy resets", policy: "", available: 4000, want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, openingBalance(tt.policy, tt.available))
+		})
+	}
+}
+
+func TestPreviousMonth(t *testing.T) {
+	t.Parallel()
+
+	month, year := previousMonth(5, 2026)
+	assert.Equal(t, int16(4), month)
+	assert.Equal(t, int16(2026), year)
+
+	month, year = previousMonth(1, 2026)
+	assert.Equal(t, int16(12), month)
+	assert.Equal(t, int16(2025), year)
+}
+
+func newBudgetInput() port.CreateBudget {
+	return port.CreateBudget{
+		ID:             uuid.MustParse("bbbbbbbb-0000-0000-0000-000000000005"),
+		OrganizationID: "org-1",
+		Name:           "May 2026",
+		Month:          5,
+		Year:           2026,
+		CurrencyCode:   "USD",
+		IsActive:       true,
+	}
+}
+
+func TestService_Create_RollsOverPreviousMonth(t *testing.T) {
+	t.Parallel()
+
+	groceries := uuid.MustParse("cccccccc-0000-0000-0000-000000000001")
+	rent := uuid.MustParse("cccccccc-0000-0000-0000-000000000002")
+	fun := uuid.MustParse("cccccccc-0000-0000-0000-000000000003")
+	vacation := uuid.MustParse("cccccccc-0000-0000-0000-000000000004")
+
+	repo := &stubBudgetRepo{
+		previous: &port.Budget{ID: uuid.MustParse("bbbbbbbb-0000-0000-0000-000000000004"), OrganizationID: "org-1", Month: 4, Year: 2026},
+		balances: basedomain.List[port.CategoryBalance]{
+			{CategoryID: groceries, RolloverPolicy: categoryport.RolloverCarry, Assigned: 40000, Activity: -45000},
+			{CategoryID: rent, RolloverPolicy: categoryport.RolloverReset, Assigned: 120000, Activity: -100000},
+			{CategoryID: fun, RolloverPolicy: categoryport.RolloverCarryPositive, Assigned: 10000, Activity: -15000},
+			{CategoryID: vacation, RolloverPolicy: categoryport.RolloverCarryPositive, Carryover: 20000, Assigned: 10000, Activity: 0},
+		},
+	}
+	uow := &stubUnitOfWork{}
+	svc := New(repo, uow, noopLogger{})
+
+	input := newBudgetInput()
+	require.NoError(t, svc.Create(context.Background(), input))
+
+	assert.True(t, uow.committed)
+	require.Len(t, repo.created, 1)
+	require.Len(t, repo.lines, 2)
+
+	assert.Equal(t, groceries, repo.lines[0].CategoryID)
+	assert.Equal(t, money.Minor(-5000), repo.lines[0].Carryover)
+	assert.Equal(t, input.ID, repo.lines[0].BudgetID)
+	assert.Equal(t, "org-1", repo.lines[0].OrganizationID)
+
+	assert.Equal(t, vacation, repo.lines[1].CategoryID)
+	assert.Equal(t, money.Minor(30000), repo.lines[1].Carryover)
+}
+
+func TestService_Create_FirstMonth_NoRollover(t *testing.T) {
+	t.Parallel()
+
+	repo := &stubBudgetRepo{}
+	uow := &stubUnitOfWork{}
+	svc := New(repo, uow, noopLogger{})
+
+	require.NoError(t, svc.Create(context.Background(), newBudgetInput()))
+
+	assert.True(t, uow.committed)
+	assert.Len(t, repo.created, 1)
+	assert.Empty(t, repo.lines)
+}
+
+func TestService_Create_RolloverError_RollsBack(t *testing.T) {
+	t.Parallel()
+
+	repo := &stubBudgetRepo{
+		previous:    &port.Budget{ID: uuid.MustParse("bbbbbbbb-0000-0000-0000-000000000004"), OrganizationID: "org-1", Month: 4, Year: 2026},
+		balancesErr: oops.Code(apperrors.CodeConflict).Errorf("missing rate"),
+	}
+	uow := &stubUnitOfWork{}
+	svc := New(repo, uow, noopLogger{})
+
+	err := svc.Create(context.Background(), newBudgetInput())
+	require.Error(t, err)
+
+	assert.False(t, uow.committed)
+	assert.True(t, uow.rolledBack)
+	assert.Empty(t, repo.lines)
+}
+
+func TestService_CreateBulk_RollsOverInMonthOrder(t *testing.T) {
+	t.Parallel()
+
+	groceries := uuid.MustParse("cccccccc-0000-0000-0000-000000000001")
+	repo := &stubBudgetRepo{
+		previous: &port.Budget{ID: uuid.MustParse("bbbbbbbb-0000-0000-0000-000000000004"), OrganizationID: "org-1", Month: 4, Year: 2026},
+		balances: basedomain.List[port.CategoryBalance]{
+			{CategoryID: groceries, RolloverPolicy: categoryport.RolloverCarry, Assigned: 40000, Activity: -45000},
+		},
+	}
+	uow := &stubUnitOfWork{}
+	svc := New(repo, uow, noopLogger{})
+
+	may := newBudgetInput()
+	june := newBudgetInput()
+	june.ID, june.Name, june.Month = uuid.MustParse("bbbbbbbb-0000-0000-0000-000000000006"), "June 2026", 6
+	january := newBudgetInput()
+	january.ID, january.Name, january.Month, january.Year = uuid.MustParse("bbbbbbbb-0000-0000-0000-000000000007"), "January 2027", 1, 2027
+
+	require.NoError(t, svc.CreateBulk(context.Background(), basedomain.List[port.CreateBudget]{june, january, may}))
+
+	assert.True(t, uow.committed)
+	require.Len(t, repo.created, 3)
+	assert.Equal(t, []uuid.UUID{may.ID, june.ID, january.ID}, []uuid.UUID{repo.created[0].ID, repo.created[1].ID, repo.created[2].ID})
+
+	require.Len(t, repo.lines, 3)
+	assert.Equal(t, may.ID, repo.lines[0].BudgetID)
+	assert.Equal(t, june.ID, repo.lines[1].BudgetID)
+	assert.Equal(t, january.ID, repo.lines[2].BudgetID)
+	assert.Equal(t, money.Minor(-5000), repo.lines[0].Carryover)
+}
+
+func TestService_CreateBulk_InvalidBudget_CreatesNothing(t *testing.T) {
+	t.Parallel()
+
+	repo := &stubBudgetRepo{}
+	uow := &stubUnitOfWork{}
+	svc := New(repo, uow, noopLogger{})
+
+	invalid := newBudgetInput()
+	invalid.CurrencyCode = ""
+
+	err := svc.CreateBulk(context.Background(), basedomain.List[port.CreateBudget]{newBudgetInput(), invalid})
+	require.Error(t, err)
+
+	oopsErr, ok := oops.AsOops(err)
+	require.True(t, ok)
+	assert.Equal(t, apperrors.CodeValidation, oopsErr.Code())
+	assert.Empty(t, repo.created)
+}
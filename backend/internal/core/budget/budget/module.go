@@ -20,8 +20,9 @@ func Module(i do.Injector) {
 
 	di.Provide(i, func(i do.Injector) (port.Service, error) {
 		repo := di.MustInvoke[port.Repository](i)
+		uow := di.MustInvoke[basedomain.UnitOfWork](i)
 		logger := di.MustInvoke[basedomain.Logger](i)
-		return core.New(repo, logger), nil
+		return core.New(repo, uow, logger), nil
 	})
 
 	di.Provide(i, func(i do.Injector) (handler.HTTP, error) {
@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"backend/adapter/validation"
+	"backend/infra/money"
 	"github.com/google/uuid"
 	"github.com/guregu/null/v6"
 )
@@ -38,3 +39,44 @@ func (u UpdateBudget) Validate(ctx context.Context) error {
 		validation.Field(&u.Name, validation.NilOrNotEmpty, validation.Length(2, 255)),
 	)
 }
+
+// Amount sources a budget clone can copy its category lines from.
+const (
+	CloneFromAssigned = "assigned" // the amounts assigned in the source month
+	CloneFromActivity = "activity" // what was actually spent in the source month
+)
+
+// CloneBudget creates a budget month from another one, copying its category lines.
+// Month and Year default to the month after the source; ScalePercent scales every
+// copied amount (110 copies 110% of it).
+type CloneBudget struct {
+	ID           uuid.UUID `json:"id"`
+	Name         string    `json:"name"`
+	Month        int16     `json:"month"`
+	Year         int16     `json:"year"`
+	AmountSource string    `json:"amountSource"`
+	ScalePercent null.Int  `json:"scalePercent"`
+}
+
+func (c CloneBudget) Validate(ctx context.Context) error {
+	return validation.ValidateStruct(ctx, &c,
+		validation.Field(&c.ID, validation.Required, validation.IsUUID),
+		validation.Field(&c.Name, validation.Length(2, 255)),
+		validation.Field(&c.Month, validation.When(c.Year != 0, validation.Required), validation.Min(int16(1)), validation.Max(int16(12))),
+		validation.Field(&c.Year, validation.When(c.Month != 0, validation.Required)),
+		validation.Field(&c.AmountSource, validation.In(CloneFromAssigned, CloneFromActivity)),
+		validation.Field(&c.ScalePercent, validation.Min(int64(0)), validation.Max(int64(1000))),
+	)
+}
+
+// CategoryLine is a category line written when a budget month is opened: the amount
+// assigned to it and the balance it carries over from the previous month.
+type CategoryLine struct {
+	ID             uuid.UUID
+	OrganizationID string
+	BudgetID       uuid.UUID
+	CategoryID     uuid.UUID
+	SubcategoryID  *uuid.UUID
+	Assigned       money.Minor
+	Carryover      money.Minor
+}
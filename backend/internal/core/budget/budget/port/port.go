@@ -1,15 +1,27 @@
 package port
 
-import basedomain "backend/port"
+import (
+	"context"
+
+	basedomain "backend/port"
+
+	"github.com/google/uuid"
+)
 
 type Repository interface {
 	basedomain.RepositoryCommand[CreateBudget, UpdateBudget]
 	basedomain.RepositoryQuery[Budget]
 	basedomain.RepositoryTx[Repository]
+	Summary(ctx context.Context, budgetID uuid.UUID) (Summary, error)
+	// CategoryBalances returns the per-category balances of a budget converted to currencyCode.
+	CategoryBalances(ctx context.Context, budgetID uuid.UUID, currencyCode string) (basedomain.List[CategoryBalance], error)
+	CreateCategoryLines(ctx context.Context, inputs basedomain.List[CategoryLine]) error
 }
 
 type Service interface {
 	basedomain.UseCaseCommand[CreateBudget, UpdateBudget]
 	basedomain.UseCaseQuery[Budget]
 	basedomain.UseCaseTx[Service]
+	Summary(ctx context.Context, budgetID uuid.UUID) (Summary, error)
+	Clone(ctx context.Context, sourceID uuid.UUID, input CloneBudget) error
 }
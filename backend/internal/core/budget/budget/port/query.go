@@ -3,6 +3,8 @@ package port
 import (
 	"time"
 
+	"backend/infra/money"
+
 	"github.com/google/uuid"
 )
 
@@ -17,3 +19,33 @@ type Budget struct {
 	CreatedAt      time.Time `json:"createdAt"`
 	UpdatedAt      time.Time `json:"updatedAt"`
 }
+
+// Summary is the ready-to-assign view of a budget month. Every amount is in minor
+// units of the budget currency; transactions recorded in other account currencies
+// are converted with the organization exchange rates. Income and Spent are the
+// magnitudes of inflows (positive amounts) and outflows (negative amounts).
+type Summary struct {
+	BudgetID     uuid.UUID   `json:"budgetId"`
+	CurrencyCode string      `json:"currencyCode"`
+	Income       money.Minor `json:"income"`
+	Assigned     money.Minor `json:"assigned"`
+	Spent        money.Minor `json:"spent"`
+	ToBeBudgeted money.Minor `json:"toBeBudgeted"`
+}
+
+// CategoryBalance is what a budget month left on one category line: the amount it
+// opened with, the amount assigned to it and the net of its transactions, all in the
+// currency the balances were requested in.
+type CategoryBalance struct {
+	CategoryID     uuid.UUID
+	SubcategoryID  *uuid.UUID
+	RolloverPolicy string
+	Carryover      money.Minor
+	Assigned       money.Minor
+	Activity       money.Minor
+}
+
+// Available is the amount left on the line; negative when it was overspent.
+func (b CategoryBalance) Available() money.Minor {
+	return b.Carryover + b.Assigned + b.Activity
+}
@@ -0,0 +1,110 @@
+package handler
+
+import (
+	"backend/core/budget/budget_allocation/port"
+	"backend/infra/dafi"
+	"backend/infra/httpresponse"
+	basedomain "backend/port"
+	apperrors "backend/port/errors"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/samber/oops"
+)
+
+type HTTP struct {
+	svc    port.Service
+	logger basedomain.Logger
+}
+
+func NewHTTP(svc port.Service, logger basedomain.Logger) HTTP {
+	return HTTP{
+		svc:    svc,
+		logger: logger.With("component", "budget_allocation.handler"),
+	}
+}
+
+func (h HTTP) FindOne(c echo.Context) error {
+	ctx := c.Request().Context()
+	budgetID := c.Param("id")
+	id := c.Param("allocationId")
+
+	criteria := dafi.Where("id", dafi.Equal, id).And("budgetId", dafi.Equal, budgetID)
+	alloc, err := h.svc.FindOne(ctx, criteria)
+	if err != nil {
+		return oops.WithContext(ctx).In(apperrors.LayerHandler).Wrap(err)
+	}
+
+	return httpresponse.OK(c, alloc)
+}
+
+func (h HTTP) FindAll(c echo.Context) error {
+	ctx := c.Request().Context()
+	budgetID := c.Param("id")
+
+	parser := dafi.NewQueryParser()
+	criteria, err := parser.Parse(c.QueryParams())
+	if err != nil {
+		return oops.WithContext(ctx).In(apperrors.LayerHandler).Code(apperrors.CodeBadRequest).Wrap(err)
+	}
+	criteria.Filters = criteria.Filters.And("budgetId", dafi.Equal, budgetID)
+
+	allocs, err := h.svc.FindAll(ctx, criteria)
+	if err != nil {
+		return oops.WithContext(ctx).In(apperrors.LayerHandler).Wrap(err)
+	}
+
+	return httpresponse.OK(c, allocs)
+}
+
+func (h HTTP) Create(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	budgetID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return oops.WithContext(ctx).In(apperrors.LayerHandler).Code(apperrors.CodeBadRequest).Wrap(err)
+	}
+
+	var input port.CreateBudgetAllocation
+	if err := c.Bind(&input); err != nil {
+		return oops.WithContext(ctx).In(apperrors.LayerHandler).Code(apperrors.CodeBadRequest).Wrap(err)
+	}
+	input.BudgetID = budgetID
+
+	if err := h.svc.Create(ctx, input); err != nil {
+		return oops.WithContext(ctx).In(apperrors.LayerHandler).Wrap(err)
+	}
+
+	return httpresponse.Created(c, nil)
+}
+
+func (h HTTP) Update(c echo.Context) error {
+	ctx := c.Request().Context()
+	budgetID := c.Param("id")
+	id := c.Param("allocationId")
+
+	var input port.UpdateBudgetAllocation
+	if err := c.Bind(&input); err != nil {
+		return oops.WithContext(ctx).In(apperrors.LayerHandler).Code(apperrors.CodeBadRequest).Wrap(err)
+	}
+
+	filters := dafi.FilterBy("id", dafi.Equal, id).And("budgetId", dafi.Equal, budgetID)
+	if err := h.svc.Update(ctx, input, filters...); err != nil {
+		return oops.WithContext(ctx).In(apperrors.LayerHandler).Wrap(err)
+	}
+
+	return httpresponse.NoContent(c)
+}
+
+func (h HTTP) Delete(c echo.Context) error {
+	ctx := c.Request().Context()
+	budgetID := c.Param("id")
+	id := c.Param("allocationId")
+
+	filters := dafi.FilterBy("id", dafi.Equal, id).And("budgetId", dafi.Equal, budgetID)
+	if err := h.svc.Delete(ctx, filters...); err != nil {
+		return oops.WithContext(ctx).In(apperrors.LayerHandler).Wrap(err)
+	}
+
+	return httpresponse.NoContent(c)
+}
@@ -0,0 +1,282 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"backend/adapter/database"
+	"backend/core/budget/budget_allocation/port"
+	"backend/infra/dafi"
+	"backend/infra/money"
+	"backend/infra/sqlcraft"
+	basedomain "backend/port"
+	apperrors "backend/port/errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/samber/oops"
+)
+
+type dbConn interface {
+	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+}
+
+const tableName = "budget.budget_allocations"
+
+var columns = []string{
+	"id",
+	"organization_id",
+	"budget_id",
+	"category_id",
+	"subcategory_id",
+	"assigned_amount",
+	"carryover_amount",
+	"created_at",
+	"updated_at",
+}
+
+var sqlColumnByDomainField = map[string]string{
+	"id":              "id",
+	"organizationId":  "organization_id",
+	"budgetId":        "budget_id",
+	"categoryId":      "category_id",
+	"subcategoryId":   "subcategory_id",
+	"assignedAmount":  "assigned_amount",
+	"carryoverAmount": "carryover_amount",
+	"createdAt":       "created_at",
+	"updatedAt":       "updated_at",
+}
+
+type postgres struct {
+	db     dbConn
+	logger basedomain.Logger
+}
+
+func NewPostgres(db database.PoolInterface, logger basedomain.Logger) port.Repository {
+	return postgres{
+		db:     db,
+		logger: logger.With("component", "budget_allocation.repository"),
+	}
+}
+
+func (r postgres) WithTx(tx basedomain.Transaction) port.Repository {
+	return postgres{
+		db:     tx.GetTx(),
+		logger: r.logger,
+	}
+}
+
+func (r postgres) FindOne(ctx context.Context, criteria dafi.Criteria) (port.BudgetAllocation, error) {
+	query := sqlcraft.Select(columns...).
+		From(tableName).
+		Where(criteria.Filters...).
+		SQLColumnByDomainField(sqlColumnByDomainField).
+		Limit(1)
+
+	result, err := query.ToSQL()
+	if err != nil {
+		return port.BudgetAllocation{}, oops.WithContext(ctx).In(apperrors.LayerRepository).Wrap(err)
+	}
+
+	r.logger.WithContext(ctx).Debug("executing query", "sql", result.SQL)
+
+	row := r.db.QueryRow(ctx, result.SQL, result.Args...)
+
+	var alloc port.BudgetAllocation
+	err = row.Scan(
+		&alloc.ID,
+		&alloc.OrganizationID,
+		&alloc.BudgetID,
+		&alloc.CategoryID,
+		&alloc.SubcategoryID,
+		&alloc.AssignedAmount,
+		&alloc.CarryoverAmount,
+		&alloc.CreatedAt,
+		&alloc.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return port.BudgetAllocation{}, oops.WithContext(ctx).In(apperrors.LayerRepository).Code(apperrors.CodeNotFound).Wrap(err)
+		}
+		return port.BudgetAllocation{}, oops.WithContext(ctx).In(apperrors.LayerRepository).Wrap(err)
+	}
+
+	return alloc, nil
+}
+
+func (r postgres) FindAll(ctx context.Context, criteria dafi.Criteria) (basedomain.List[port.BudgetAllocation], error) {
+	query := sqlcraft.Select(columns...).
+		From(tableName).
+		Where(criteria.Filters...).
+		OrderBy(criteria.Sorts...).
+		Limit(criteria.Pagination.PageSize).
+		Page(criteria.Pagination.PageNumber).
+		SQLColumnByDomainField(sqlColumnByDomainField)
+
+	result, err := query.ToSQL()
+	if err != nil {
+		return nil, oops.WithContext(ctx).In(apperrors.LayerRepository).Wrap(err)
+	}
+
+	r.logger.WithContext(ctx).Debug("executing query", "sql", result.SQL)
+
+	rows, err := r.db.Query(ctx, result.SQL, result.Args...)
+	if err != nil {
+		return nil, oops.WithContext(ctx).In(apperrors.LayerRepository).Wrap(err)
+	}
+	defer rows.Close()
+
+	var allocs basedomain.List[port.BudgetAllocation]
+	for rows.Next() {
+		var alloc port.BudgetAllocation
+		err = rows.Scan(
+			&alloc.ID,
+			&alloc.OrganizationID,
+			&alloc.BudgetID,
+			&alloc.CategoryID,
+			&alloc.SubcategoryID,
+			&alloc.AssignedAmount,
+			&alloc.CarryoverAmount,
+			&alloc.CreatedAt,
+			&alloc.UpdatedAt,
+		)
+		if err != nil {
+			return nil, oops.WithContext(ctx).In(apperrors.LayerRepository).Wrap(err)
+		}
+		allocs = append(allocs, alloc)
+	}
+
+	return allocs, nil
+}
+
+func (r postgres) Create(ctx context.Context, input port.CreateBudgetAllocation) error {
+	now := time.Now()
+
+	query := sqlcraft.InsertInto(tableName).
+		WithColumns(columns...).
+		WithValues(
+			input.ID,
+			input.OrganizationID,
+			input.BudgetID,
+			input.CategoryID,
+			input.SubcategoryID,
+			input.AssignedAmount,
+			money.Minor(0),
+			now,
+			now,
+		)
+
+	result, err := query.ToSQL()
+	if err != nil {
+		return oops.WithContext(ctx).In(apperrors.LayerRepository).Wrap(err)
+	}
+
+	r.logger.WithContext(ctx).Debug("executing query", "sql", result.SQL)
+
+	_, err = r.db.Exec(ctx, result.SQL, result.Args...)
+	if err != nil {
+		return r.wrapWriteError(ctx, err)
+	}
+
+	return nil
+}
+
+func (r postgres) CreateBulk(ctx context.Context, inputs basedomain.List[port.CreateBudgetAllocation]) error {
+	if inputs.IsEmpty() {
+		return nil
+	}
+
+	now := time.Now()
+	query := sqlcraft.InsertInto(tableName).WithColumns(columns...)
+
+	for _, input := range inputs {
+		query = query.WithValues(
+			input.ID,
+			input.OrganizationID,
+			input.BudgetID,
+			input.CategoryID,
+			input.SubcategoryID,
+			input.AssignedAmount,
+			money.Minor(0),
+			now,
+			now,
+		)
+	}
+
+	result, err := query.ToSQL()
+	if err != nil {
+		return oops.WithContext(ctx).In(apperrors.LayerRepository).Wrap(err)
+	}
+
+	r.logger.WithContext(ctx).Debug("bulk insert", "sql", result.SQL, "count", len(inputs))
+
+	_, err = r.db.Exec(ctx, result.SQL, result.Args...)
+	if err != nil {
+		return r.wrapWriteError(ctx, err)
+	}
+
+	return nil
+}
+
+func (r postgres) Update(ctx context.Context, input port.UpdateBudgetAllocation, filters ...dafi.Filter) error {
+	query := sqlcraft.Update(tableName).
+		WithColumns("assigned_amount", "updated_at").
+		WithValues(
+			input.AssignedAmount,
+			time.Now(),
+		).
+		Where(filters...).
+		SQLColumnByDomainField(sqlColumnByDomainField).
+		WithPartialUpdate()
+
+	result, err := query.ToSQL()
+	if err != nil {
+		return oops.WithContext(ctx).In(apperrors.LayerRepository).Wrap(err)
+	}
+
+	r.logger.WithContext(ctx).Debug("executing query", "sql", result.SQL)
+
+	_, err = r.db.Exec(ctx, result.SQL, result.Args...)
+	if err != nil {
+		return oops.WithContext(ctx).In(apperrors.LayerRepository).Wrap(err)
+	}
+
+	return nil
+}
+
+func (r postgres) Delete(ctx context.Context, filters ...dafi.Filter) error {
+	query := sqlcraft.DeleteFrom(tableName).
+		Where(filters...).
+		SQLColumnByDomainField(sqlColumnByDomainField)
+
+	result, err := query.ToSQL()
+	if err != nil {
+		return oops.WithContext(ctx).In(apperrors.LayerRepository).Wrap(err)
+	}
+
+	r.logger.WithContext(ctx).Debug("executing query", "sql", result.SQL)
+
+	_, err = r.db.Exec(ctx, result.SQL, result.Args...)
+	if err != nil {
+		return oops.WithContext(ctx).In(apperrors.LayerRepository).Wrap(err)
+	}
+
+	return nil
+}
+
+const pgErrUniqueViolation = "23505"
+
+func (r postgres) wrapWriteError(ctx context.Context, err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == pgErrUniqueViolation {
+		return oops.WithContext(ctx).In(apperrors.LayerRepository).
+			Code(apperrors.CodeAlreadyExists).
+			Public("This category already has an allocation in the budget.").
+			Wrap(err)
+	}
+
+	return oops.WithContext(ctx).In(apperrors.LayerRepository).Wrap(err)
+}
@@ -0,0 +1,134 @@
+package core
+
+import (
+	"context"
+
+	budgetport "backend/core/budget/budget/port"
+	"backend/core/budget/budget_allocation/port"
+	categoryport "backend/core/budget/category/port"
+	"backend/infra/dafi"
+	basedomain "backend/port"
+	apperrors "backend/port/errors"
+
+	"github.com/samber/oops"
+)
+
+type service struct {
+	repo         port.Repository
+	budgetRepo   budgetport.Repository
+	categoryRepo categoryport.Repository
+	logger       basedomain.Logger
+}
+
+func New(repo port.Repository, budgetRepo budgetport.Repository, categoryRepo categoryport.Repository, logger basedomain.Logger) port.Service {
+	return service{
+		repo:         repo,
+		budgetRepo:   budgetRepo,
+		categoryRepo: categoryRepo,
+		logger:       logger.With("component", "budget_allocation.service"),
+	}
+}
+
+func (s service) WithTx(tx basedomain.Transaction) port.Service {
+	return service{
+		repo:         s.repo.WithTx(tx),
+		budgetRepo:   s.budgetRepo.WithTx(tx),
+		categoryRepo: s.categoryRepo.WithTx(tx),
+		logger:       s.logger,
+	}
+}
+
+func (s service) FindOne(ctx context.Context, criteria dafi.Criteria) (port.BudgetAllocation, error) {
+	alloc, err := s.repo.FindOne(ctx, criteria)
+	if err != nil {
+		return port.BudgetAllocation{}, oops.WithContext(ctx).In(apperrors.LayerService).Wrap(err)
+	}
+
+	return alloc, nil
+}
+
+func (s service) FindAll(ctx context.Context, criteria dafi.Criteria) (basedomain.List[port.BudgetAllocation], error) {
+	allocs, err := s.repo.FindAll(ctx, criteria)
+	if err != nil {
+		return nil, oops.WithContext(ctx).In(apperrors.LayerService).Wrap(err)
+	}
+
+	return allocs, nil
+}
+
+func (s service) Create(ctx context.Context, input port.CreateBudgetAllocation) error {
+	if err := input.Validate(ctx); err != nil {
+		return oops.WithContext(ctx).In(apperrors.LayerService).Code(apperrors.CodeValidation).Wrap(err)
+	}
+
+	if err := s.validateOwnership(ctx, input); err != nil {
+		return oops.WithContext(ctx).In(apperrors.LayerService).Wrap(err)
+	}
+
+	if err := s.repo.Create(ctx, input); err != nil {
+		return oops.WithContext(ctx).In(apperrors.LayerService).Wrap(err)
+	}
+
+	s.logger.WithContext(ctx).Info("budget allocation created", "budgetId", input.BudgetID, "categoryId", input.CategoryID)
+
+	return nil
+}
+
+func (s service) CreateBulk(ctx context.Context, inputs basedomain.List[port.CreateBudgetAllocation]) error {
+	for _, in := range inputs {
+		if err := in.Validate(ctx); err != nil {
+			return oops.WithContext(ctx).In(apperrors.LayerService).Code(apperrors.CodeValidation).Wrap(err)
+		}
+		if err := s.validateOwnership(ctx, in); err != nil {
+			return oops.WithContext(ctx).In(apperrors.LayerService).Wrap(err)
+		}
+	}
+
+	if err := s.repo.CreateBulk(ctx, inputs); err != nil {
+		return oops.WithContext(ctx).In(apperrors.LayerService).Wrap(err)
+	}
+
+	s.logger.WithContext(ctx).Info("budget allocations created", "count", len(inputs))
+
+	return nil
+}
+
+func (s service) Update(ctx context.Context, input port.UpdateBudgetAllocation, filters ...dafi.Filter) error {
+	if err := input.Validate(ctx); err != nil {
+		return oops.WithContext(ctx).In(apperrors.LayerService).Code(apperrors.CodeValidation).Wrap(err)
+	}
+
+	if err := s.repo.Update(ctx, input, filters...); err != nil {
+		return oops.WithContext(ctx).In(apperrors.LayerService).Wrap(err)
+	}
+
+	s.logger.WithContext(ctx).Info("budget allocation updated")
+
+	return nil
+}
+
+func (s service) Delete(ctx context.Context, filters ...dafi.Filter) error {
+	if err := s.repo.Delete(ctx, filters...); err != nil {
+		return oops.WithContext(ctx).In(apperrors.LayerService).Wrap(err)
+	}
+
+	s.logger.WithContext(ctx).Info("budget allocation deleted")
+
+	return nil
+}
+
+// validateOwnership checks that the budget, category and subcategory referenced by an
+// allocation all belong to the organization the allocation is created for.
+func (s service) validateOwnership(ctx context.Context, input port.CreateBudgetAllocation) error {
+	b, err := s.budgetRepo.FindOne(ctx, dafi.Where("id", dafi.Equal, input.BudgetID))
+	if err != nil {
+		return err
+	}
+	if b.OrganizationID != input.OrganizationID {
+		return oops.Code(apperrors.CodeValidation).
+			Public("The budget does not belong to this organization.").
+			Errorf("budget %s belongs to organization %s, not %s", b.ID, b.OrganizationID, input.OrganizationID)
+	}
+
+	return categoryport.CheckOwnership(ctx, s.categoryRepo, input.OrganizationID, input.CategoryID, input.SubcategoryID)
+}
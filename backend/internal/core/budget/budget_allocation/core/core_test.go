@@ -0,0 +1,195 @@
+package core
+
+import (
+	"context"
+	"testing"
+
+	budgetport "backend/core/budget/budget/port"
+	"backend/core/budget/budget_allocation/port"
+	"backend/core/budget/category/categorytest"
+	categoryport "backend/core/budget/category/port"
+	"backend/infra/dafi"
+	"backend/infra/money"
+	basedomain "backend/port"
+	apperrors "backend/port/errors"
+
+	"github.com/google/uuid"
+	"github.com/samber/oops"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type noopLogger struct{}
+
+func (noopLogger) Debug(string, ...any)                          {}
+func (noopLogger) Info(string, ...any)                           {}
+func (noopLogger) Warn(string, ...any)                           {}
+func (noopLogger) Error(string, ...any)                          {}
+func (noopLogger) With(...any) basedomain.Logger                 { return noopLogger{} }
+func (noopLogger) WithContext(context.Context) basedomain.Logger { return noopLogger{} }
+
+type stubAllocationRepo struct {
+	created []port.CreateBudgetAllocation
+}
+
+func (s *stubAllocationRepo) FindOne(context.Context, dafi.Criteria) (port.BudgetAllocation, error) {
+	return port.BudgetAllocation{}, nil
+}
+
+func (s *stubAllocationRepo) FindAll(context.Context, dafi.Criteria) (basedomain.List[port.BudgetAllocation], error) {
+	return nil, nil
+}
+
+func (s *stubAllocationRepo) Create(_ context.Context, input port.CreateBudgetAllocation) error {
+	s.created = append(s.created, input)
+	return nil
+}
+
+func (s *stubAllocationRepo) CreateBulk(_ context.Context, inputs basedomain.List[port.CreateBudgetAllocation]) error {
+	s.created = append(s.created, inputs...)
+	return nil
+}
+
+func (s *stubAllocationRepo) Update(context.Context, port.UpdateBudgetAllocation, ...dafi.Filter) error {
+	return nil
+}
+
+func (s *stubAllocationRepo) Delete(context.Context, ...dafi.Filter) error { return nil }
+
+func (s *stubAllocationRepo) WithTx(basedomain.Transaction) port.Repository { return s }
+
+type stubBudgetRepo struct {
+	budget budgetport.Budget
+}
+
+func (s *stubBudgetRepo) FindOne(context.Context, dafi.Criteria) (budgetport.Budget, error) {
+	return s.budget, nil
+}
+
+func (s *stubBudgetRepo) FindAll(context.Context, dafi.Criteria) (basedomain.List[budgetport.Budget], error) {
+	return nil, nil
+}
+
+func (s *stubBudgetRepo) Create(context.Context, budgetport.CreateBudget) error { return nil }
+
+func (s *stubBudgetRepo) CreateBulk(context.Context, basedomain.List[budgetport.CreateBudget]) error {
+	return nil
+}
+
+func (s *stubBudgetRepo) Update(context.Context, budgetport.UpdateBudget, ...dafi.Filter) error {
+	return nil
+}
+
+func (s *stubBudgetRepo) Delete(context.Context, ...dafi.Filter) error { return nil }
+
+func (s *stubBudgetRepo) WithTx(basedomain.Transaction) budgetport.Repository { return s }
+
+func (s *stubBudgetRepo) Summary(context.Context, uuid.UUID) (budgetport.Summary, error) {
+	return budgetport.Summary{}, nil
+}
+
+func (s *stubBudgetRepo) CategoryBalances(context.Context, uuid.UUID, string) (basedomain.List[budgetport.CategoryBalance], error) {
+	return nil, nil
+}
+
+func (s *stubBudgetRepo) CreateCategoryLines(context.Context, basedomain.List[budgetport.CategoryLine]) error {
+	return nil
+}
+
+var (
+	budgetID     = uuid.MustParse("11111111-1111-1111-1111-111111111111")
+	groceriesID  = uuid.MustParse("22222222-2222-2222-2222-222222222222")
+	householdID  = uuid.MustParse("33333333-3333-3333-3333-333333333333")
+	otherOrgCat  = uuid.MustParse("44444444-4444-4444-4444-444444444444")
+	allocationID = uuid.MustParse("55555555-5555-5555-5555-555555555555")
+)
+
+func newTestService(repo *stubAllocationRepo) port.Service {
+	budgets := &stubBudgetRepo{budget: budgetport.Budget{ID: budgetID, OrganizationID: "org-1"}}
+	categories := &categorytest.Repository{Categories: []categoryport.Category{
+		{ID: groceriesID, OrganizationID: "org-1", Name: "Groceries"},
+		{ID: householdID, OrganizationID: "org-1", ParentID: &groceriesID, Name: "Household"},
+		{ID: otherOrgCat, OrganizationID: "org-2", Name: "Rent"},
+	}}
+
+	return New(repo, budgets, categories, noopLogger{})
+}
+
+func assertCode(t *testing.T, err error, code string) {
+	t.Helper()
+
+	oopsErr, ok := oops.AsOops(err)
+	require.True(t, ok)
+	assert.Equal(t, code, oopsErr.Code())
+}
+
+func TestService_Create_SameOrganization_Creates(t *testing.T) {
+	t.Parallel()
+
+	repo := &stubAllocationRepo{}
+	svc := newTestService(repo)
+
+	err := svc.Create(context.Background(), port.CreateBudgetAllocation{
+		ID:             allocationID,
+		OrganizationID: "org-1",
+		BudgetID:       budgetID,
+		CategoryID:     groceriesID,
+		SubcategoryID:  &householdID,
+		AssignedAmount: money.Minor(25000),
+	})
+	require.NoError(t, err)
+	require.Len(t, repo.created, 1)
+	assert.Equal(t, money.Minor(25000), repo.created[0].AssignedAmount)
+}
+
+func TestService_Create_CategoryFromOtherOrganization_Validation(t *testing.T) {
+	t.Parallel()
+
+	repo := &stubAllocationRepo{}
+	svc := newTestService(repo)
+
+	err := svc.Create(context.Background(), port.CreateBudgetAllocation{
+		ID:             allocationID,
+		OrganizationID: "org-1",
+		BudgetID:       budgetID,
+		CategoryID:     otherOrgCat,
+	})
+	require.Error(t, err)
+	assert.Empty(t, repo.created)
+	assertCode(t, err, apperrors.CodeValidation)
+}
+
+func TestService_Create_BudgetFromOtherOrganization_Validation(t *testing.T) {
+	t.Parallel()
+
+	repo := &stubAllocationRepo{}
+	svc := newTestService(repo)
+
+	err := svc.Create(context.Background(), port.CreateBudgetAllocation{
+		ID:             allocationID,
+		OrganizationID: "org-2",
+		BudgetID:       budgetID,
+		CategoryID:     otherOrgCat,
+	})
+	require.Error(t, err)
+	assert.Empty(t, repo.created)
+	assertCode(t, err, apperrors.CodeValidation)
+}
+
+func TestService_Create_SubcategoryOfAnotherParent_Validation(t *testing.T) {
+	t.Parallel()
+
+	repo := &stubAllocationRepo{}
+	svc := newTestService(repo)
+
+	err := svc.Create(context.Background(), port.CreateBudgetAllocation{
+		ID:             allocationID,
+		OrganizationID: "org-1",
+		BudgetID:       budgetID,
+		CategoryID:     householdID,
+		SubcategoryID:  &groceriesID,
+	})
+	require.Error(t, err)
+	assert.Empty(t, repo.created)
+	assertCode(t, err, apperrors.CodeValidation)
+}
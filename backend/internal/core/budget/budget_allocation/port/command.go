@@ -0,0 +1,36 @@
+package port
+
+import (
+	"context"
+
+	"backend/adapter/validation"
+	"backend/infra/money"
+	"github.com/google/uuid"
+	"github.com/guregu/null/v6"
+)
+
+type CreateBudgetAllocation struct {
+	ID             uuid.UUID   `json:"id"`
+	OrganizationID string      `json:"organizationId"`
+	BudgetID       uuid.UUID   `json:"budgetId"`
+	CategoryID     uuid.UUID   `json:"categoryId"`
+	SubcategoryID  *uuid.UUID  `json:"subcategoryId"`
+	AssignedAmount money.Minor `json:"assignedAmount"`
+}
+
+func (c CreateBudgetAllocation) Validate(ctx context.Context) error {
+	return validation.ValidateStruct(ctx, &c,
+		validation.Field(&c.ID, validation.Required, validation.IsUUID),
+		validation.Field(&c.OrganizationID, validation.Required),
+		validation.Field(&c.BudgetID, validation.Required, validation.IsUUID),
+		validation.Field(&c.CategoryID, validation.Required, validation.IsUUID),
+	)
+}
+
+type UpdateBudgetAllocation struct {
+	AssignedAmount null.Int `json:"assignedAmount"`
+}
+
+func (u UpdateBudgetAllocation) Validate(ctx context.Context) error {
+	return validation.ValidateStruct(ctx, &u)
+}
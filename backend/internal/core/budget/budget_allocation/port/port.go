@@ -0,0 +1,15 @@
+package port
+
+import basedomain "backend/port"
+
+type Repository interface {
+	basedomain.RepositoryCommand[CreateBudgetAllocation, UpdateBudgetAllocation]
+	basedomain.RepositoryQuery[BudgetAllocation]
+	basedomain.RepositoryTx[Repository]
+}
+
+type Service interface {
+	basedomain.UseCaseCommand[CreateBudgetAllocation, UpdateBudgetAllocation]
+	basedomain.UseCaseQuery[BudgetAllocation]
+	basedomain.UseCaseTx[Service]
+}
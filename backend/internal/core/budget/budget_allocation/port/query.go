@@ -0,0 +1,23 @@
+package port
+
+import (
+	"time"
+
+	"backend/infra/money"
+	"github.com/google/uuid"
+)
+
+// BudgetAllocation is the amount of money assigned to a category (and optionally a
+// subcategory) for a given budget month. CarryoverAmount is the opening balance the
+// budget rollover brought in from the previous month.
+type BudgetAllocation struct {
+	ID              uuid.UUID   `json:"id"`
+	OrganizationID  string      `json:"organizationId"`
+	BudgetID        uuid.UUID   `json:"budgetId"`
+	CategoryID      uuid.UUID   `json:"categoryId"`
+	SubcategoryID   *uuid.UUID  `json:"subcategoryId"`
+	AssignedAmount  money.Minor `json:"assignedAmount"`
+	CarryoverAmount money.Minor `json:"carryoverAmount"`
+	CreatedAt       time.Time   `json:"createdAt"`
+	UpdatedAt       time.Time   `json:"updatedAt"`
+}
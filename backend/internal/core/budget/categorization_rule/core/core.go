@@ -0,0 +1,179 @@
+package core
+
+import (
+	"context"
+
+	budgetport "backend/core/budget/budget/port"
+	"backend/core/budget/categorization_rule/port"
+	categoryport "backend/core/budget/category/port"
+	"backend/infra/dafi"
+	basedomain "backend/port"
+	apperrors "backend/port/errors"
+
+	"github.com/google/uuid"
+	"github.com/samber/oops"
+)
+
+type service struct {
+	repo         port.Repository
+	budgetRepo   budgetport.Repository
+	categoryRepo categoryport.Repository
+	logger       basedomain.Logger
+}
+
+func New(repo port.Repository, budgetRepo budgetport.Repository, categoryRepo categoryport.Repository, logger basedomain.Logger) port.Service {
+	return service{
+		repo:         repo,
+		budgetRepo:   budgetRepo,
+		categoryRepo: categoryRepo,
+		logger:       logger.With("component", "categorization_rule.service"),
+	}
+}
+
+func (s service) WithTx(tx basedomain.Transaction) port.Service {
+	return service{
+		repo:         s.repo.WithTx(tx),
+		budgetRepo:   s.budgetRepo.WithTx(tx),
+		categoryRepo: s.categoryRepo.WithTx(tx),
+		logger:       s.logger,
+	}
+}
+
+func (s service) FindOne(ctx context.Context, criteria dafi.Criteria) (port.Rule, error) {
+	rule, err := s.repo.FindOne(ctx, criteria)
+	if err != nil {
+		return port.Rule{}, oops.WithContext(ctx).In(apperrors.LayerService).Wrap(err)
+	}
+
+	return rule, nil
+}
+
+func (s service) FindAll(ctx context.Context, criteria dafi.Criteria) (basedomain.List[port.Rule], error) {
+	rules, err := s.repo.FindAll(ctx, criteria)
+	if err != nil {
+		return nil, oops.WithContext(ctx).In(apperrors.LayerService).Wrap(err)
+	}
+
+	return rules, nil
+}
+
+func (s service) Active(ctx context.Context, organizationID string) ([]port.Rule, error) {
+	rules, err := s.repo.FindAll(ctx, dafi.Criteria{
+		Filters: dafi.FilterBy("organizationId", dafi.Equal, organizationID).And("isActive", dafi.Equal, true),
+		Sorts:   dafi.Sorts{{Field: "priority", Type: dafi.Asc}, {Field: "name", Type: dafi.Asc}},
+	})
+	if err != nil {
+		return nil, oops.WithContext(ctx).In(apperrors.LayerService).Wrap(err)
+	}
+
+	return rules, nil
+}
+
+func (s service) Create(ctx context.Context, input port.CreateRule) error {
+	if err := input.Validate(ctx); err != nil {
+		return oops.WithContext(ctx).In(apperrors.LayerService).Code(apperrors.CodeValidation).Wrap(err)
+	}
+
+	if err := s.validateOwnership(ctx, input.OrganizationID, input.CategoryID, input.SubcategoryID, input.BudgetID); err != nil {
+		return oops.WithContext(ctx).In(apperrors.LayerService).Wrap(err)
+	}
+
+	if err := s.repo.Create(ctx, input); err != nil {
+		return oops.WithContext(ctx).In(apperrors.LayerService).Wrap(err)
+	}
+
+	s.logger.WithContext(ctx).Info("categorization rule created", "name", input.Name, "priority", input.Priority)
+
+	return nil
+}
+
+func (s service) CreateBulk(ctx context.Context, inputs basedomain.List[port.CreateRule]) error {
+	for _, input := range inputs {
+		if err := input.Validate(ctx); err != nil {
+			return oops.WithContext(ctx).In(apperrors.LayerService).Code(apperrors.CodeValidation).Wrap(err)
+		}
+
+		if err := s.validateOwnership(ctx, input.OrganizationID, input.CategoryID, input.SubcategoryID, input.BudgetID); err != nil {
+			return oops.WithContext(ctx).In(apperrors.LayerService).Wrap(err)
+		}
+	}
+
+	if err := s.repo.CreateBulk(ctx, inputs); err != nil {
+		return oops.WithContext(ctx).In(apperrors.LayerService).Wrap(err)
+	}
+
+	s.logger.WithContext(ctx).Info("categorization rules created", "count", len(inputs))
+
+	return nil
+}
+
+func (s service) Update(ctx context.Context, input port.UpdateRule, filters ...dafi.Filter) error {
+	if err := input.Validate(ctx); err != nil {
+		return oops.WithContext(ctx).In(apperrors.LayerService).Code(apperrors.CodeValidation).Wrap(err)
+	}
+
+	if input.CategoryID != nil || input.SubcategoryID != nil || input.BudgetID != nil {
+		rule, err := s.repo.FindOne(ctx, dafi.Criteria{Filters: filters})
+		if err != nil {
+			return oops.WithContext(ctx).In(apperrors.LayerService).Wrap(err)
+		}
+
+		categoryID, subcategoryID := rule.CategoryID, rule.SubcategoryID
+		if input.CategoryID != nil {
+			// A subcategory of the old category cannot stay under the new one.
+			categoryID, subcategoryID = *input.CategoryID, nil
+		}
+		if input.SubcategoryID != nil {
+			subcategoryID = input.SubcategoryID
+		}
+
+		if err := s.validateOwnership(ctx, rule.OrganizationID, categoryID, subcategoryID, input.BudgetID); err != nil {
+			return oops.WithContext(ctx).In(apperrors.LayerService).Wrap(err)
+		}
+	}
+
+	if err := s.repo.Update(ctx, input, filters...); err != nil {
+		return oops.WithContext(ctx).In(apperrors.LayerService).Wrap(err)
+	}
+
+	s.logger.WithContext(ctx).Info("categorization rule updated")
+
+	return nil
+}
+
+// validateOwnership checks that the category, subcategory and, when given, budget a
+// rule files transactions under all belong to the organization of the rule.
+func (s service) validateOwnership(ctx context.Context, organizationID string, categoryID uuid.UUID, subcategoryID, budgetID *uuid.UUID) error {
+	if err := categoryport.CheckOwnership(ctx, s.categoryRepo, organizationID, categoryID, subcategoryID); err != nil {
+		return err
+	}
+
+	if budgetID == nil {
+		return nil
+	}
+
+	b, err := s.budgetRepo.FindOne(ctx, dafi.Where("id", dafi.Equal, *budgetID))
+	if oopsErr, ok := oops.AsOops(err); err != nil && (!ok || oopsErr.Code() != apperrors.CodeNotFound) {
+		return err
+	}
+
+	// A budget of another organization is reported like a missing one so as not to
+	// reveal that it exists.
+	if err != nil || b.OrganizationID != organizationID {
+		return oops.Code(apperrors.CodeValidation).
+			Public("The budget does not belong to this organization.").
+			Errorf("budget %s not found in organization %s", *budgetID, organizationID)
+	}
+
+	return nil
+}
+
+func (s service) Delete(ctx context.Context, filters ...dafi.Filter) error {
+	if err := s.repo.Delete(ctx, filters...); err != nil {
+		return oops.WithContext(ctx).In(apperrors.LayerService).Wrap(err)
+	}
+
+	s.logger.WithContext(ctx).Info("categorization rule deleted")
+
+	return nil
+}
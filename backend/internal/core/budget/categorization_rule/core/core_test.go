@@ -0,0 +1,322 @@
+package core
+
+import (
+	"context"
+	"testing"
+
+	budgetport "backend/core/budget/budget/port"
+	"backend/core/budget/categorization_rule/port"
+	"backend/core/budget/category/categorytest"
+	categoryport "backend/core/budget/category/port"
+	"backend/infra/dafi"
+	basedomain "backend/port"
+	apperrors "backend/port/errors"
+
+	"github.com/google/uuid"
+	"github.com/guregu/null/v6"
+	"github.com/samber/oops"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type noopLogger struct{}
+
+func (noopLogger) Debug(string, ...any)                          {}
+func (noopLogger) Info(string, ...any)                           {}
+func (noopLogger) Warn(string, ...any)                           {}
+func (noopLogger) Error(string, ...any)                          {}
+func (noopLogger) With(...any) basedomain.Logger                 { return noopLogger{} }
+func (noopLogger) WithContext(context.Context) basedomain.Logger { return noopLogger{} }
+
+type stubRuleRepo struct {
+	rule    port.Rule
+	created []port.CreateRule
+	updated []port.UpdateRule
+}
+
+func (s *stubRuleRepo) FindOne(context.Context, dafi.Criteria) (port.Rule, error) {
+	return s.rule, nil
+}
+
+func (s *stubRuleRepo) FindAll(context.Context, dafi.Criteria) (basedomain.List[port.Rule], error) {
+	return nil, nil
+}
+
+func (s *stubRuleRepo) Create(_ context.Context, input port.CreateRule) error {
+	s.created = append(s.created, input)
+	return nil
+}
+
+func (s *stubRuleRepo) CreateBulk(_ context.Context, inputs basedomain.List[port.CreateRule]) error {
+	s.created = append(s.created, inputs...)
+	return nil
+}
+
+func (s *stubRuleRepo) Update(_ context.Context, input port.UpdateRule, _ ...dafi.Filter) error {
+	s.updated = append(s.updated, input)
+	return nil
+}
+
+func (s *stubRuleRepo) Delete(context.Context, ...dafi.Filter) error { return nil }
+
+func (s *stubRuleRepo) WithTx(basedomain.Transaction) port.Repository { return s }
+
+// stubBudgetRepo finds the budgets it holds and reports every other one as not found.
+type stubBudgetRepo struct {
+	budgetport.Repository
+	budgets []budgetport.Budget
+}
+
+func (s *stubBudgetRepo) FindOne(_ context.Context, criteria dafi.Criteria) (budgetport.Budget, error) {
+	for _, b := range s.budgets {
+		if b.ID == criteria.Filters[0].Value {
+			return b, nil
+		}
+	}
+	return budgetport.Budget{}, oops.Code(apperrors.CodeNotFound).Errorf("budget not found")
+}
+
+func (s *stubBudgetRepo) WithTx(basedomain.Transaction) budgetport.Repository { return s }
+
+var (
+	transportID = uuid.MustParse("22222222-2222-2222-2222-222222222222")
+	taxiID      = uuid.MustParse("33333333-3333-3333-3333-333333333333")
+	diningID    = uuid.MustParse("44444444-4444-4444-4444-444444444444")
+	otherOrgCat = uuid.MustParse("55555555-5555-5555-5555-555555555555")
+	ruleID      = uuid.MustParse("66666666-6666-6666-6666-666666666666")
+	budgetID    = uuid.MustParse("77777777-7777-7777-7777-777777777777")
+	otherOrgBud = uuid.MustParse("88888888-8888-8888-8888-888888888888")
+)
+
+func newTestService(repo *stubRuleRepo) port.Service {
+	categories := &categorytest.Repository{Categories: []categoryport.Category{
+		{ID: transportID, OrganizationID: "org-1", Name: "Transport"},
+		{ID: taxiID, OrganizationID: "org-1", ParentID: &transportID, Name: "Taxi"},
+		{ID: diningID, OrganizationID: "org-1", Name: "Dining"},
+		{ID: otherOrgCat, OrganizationID: "org-2", Name: "Rent"},
+	}}
+
+	budgets := &stubBudgetRepo{budgets: []budgetport.Budget{
+		{ID: budgetID, OrganizationID: "org-1", Name: "Household"},
+		{ID: otherOrgBud, OrganizationID: "org-2", Name: "Travel"},
+	}}
+
+	return New(repo, budgets, categories, noopLogger{})
+}
+
+func assertCode(t *testing.T, err error, code string) {
+	t.Helper()
+
+	oopsErr, ok := oops.AsOops(err)
+	require.True(t, ok)
+	assert.Equal(t, code, oopsErr.Code())
+}
+
+func uberRule() port.CreateRule {
+	return port.CreateRule{
+		ID:             ruleID,
+		OrganizationID: "org-1",
+		Name:           "Uber rides",
+		Conditions:     port.Conditions{{Field: port.FieldDescription, Operator: dafi.Contains, Value: "uber"}},
+		CategoryID:     transportID,
+		SubcategoryID:  &taxiID,
+	}
+}
+
+func TestService_Create_Creates(t *testing.T) {
+	t.Parallel()
+
+	repo := &stubRuleRepo{}
+	err := newTestService(repo).Create(context.Background(), uberRule())
+	require.NoError(t, err)
+	require.Len(t, repo.created, 1)
+}
+
+func TestService_Create_NoConditions_Validation(t *testing.T) {
+	t.Parallel()
+
+	input := uberRule()
+	input.Conditions = nil
+
+	repo := &stubRuleRepo{}
+	err := newTestService(repo).Create(context.Background(), input)
+	assertCode(t, err, apperrors.CodeValidation)
+	assert.Empty(t, repo.created)
+}
+
+func TestService_Create_InvalidCondition_Validation(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]port.Condition{
+		"unknown field":     {Field: "categoryId", Operator: dafi.Equal, Value: "x"},
+		"text operator":     {Field: port.FieldAmount, Operator: dafi.Contains, Value: float64(100)},
+		"amount operator":   {Field: port.FieldDescription, Operator: dafi.Greater, Value: "a"},
+		"fractional amount": {Field: port.FieldAmount, Operator: dafi.Equal, Value: 12.5},
+		"empty list":        {Field: port.FieldType, Operator: dafi.In, Value: []any{}},
+		"empty text":        {Field: port.FieldDescription, Operator: dafi.Equal, Value: ""},
+	}
+
+	for name, condition := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			input := uberRule()
+			input.Conditions = port.Conditions{condition}
+			err := newTestService(&stubRuleRepo{}).Create(context.Background(), input)
+			assertCode(t, err, apperrors.CodeValidation)
+		})
+	}
+}
+
+func TestService_Create_CategoryFromOtherOrganization_Validation(t *testing.T) {
+	t.Parallel()
+
+	input := uberRule()
+	input.CategoryID, input.SubcategoryID = otherOrgCat, nil
+
+	err := newTestService(&stubRuleRepo{}).Create(context.Background(), input)
+	assertCode(t, err, apperrors.CodeValidation)
+}
+
+func TestService_Create_SubcategoryOfAnotherParent_Validation(t *testing.T) {
+	t.Parallel()
+
+	input := uberRule()
+	input.CategoryID = diningID
+
+	err := newTestService(&stubRuleRepo{}).Create(context.Background(), input)
+	assertCode(t, err, apperrors.CodeValidation)
+}
+
+func TestService_Create_BudgetOwnership(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		budgetID uuid.UUID
+		wantErr  bool
+	}{
+		"own budget":         {budgetID: budgetID},
+		"other organization": {budgetID: otherOrgBud, wantErr: true},
+		"nonexistent budget": {budgetID: uuid.New(), wantErr: true},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			input := uberRule()
+			input.BudgetID = &tt.budgetID
+
+			repo := &stubRuleRepo{}
+			err := newTestService(repo).Create(context.Background(), input)
+			if !tt.wantErr {
+				require.NoError(t, err)
+				return
+			}
+			assertCode(t, err, apperrors.CodeValidation)
+			assert.Empty(t, repo.created)
+		})
+	}
+}
+
+func TestService_CreateBulk_BudgetFromOtherOrganization_Validation(t *testing.T) {
+	t.Parallel()
+
+	input := uberRule()
+	input.BudgetID = &otherOrgBud
+
+	repo := &stubRuleRepo{}
+	err := newTestService(repo).CreateBulk(context.Background(), basedomain.List[port.CreateRule]{uberRule(), input})
+	assertCode(t, err, apperrors.CodeValidation)
+	assert.Empty(t, repo.created)
+}
+
+func TestService_Update_BudgetFromOtherOrganization_Validation(t *testing.T) {
+	t.Parallel()
+
+	repo := &stubRuleRepo{rule: port.Rule{ID: ruleID, OrganizationID: "org-1", CategoryID: transportID}}
+	err := newTestService(repo).Update(context.Background(), port.UpdateRule{BudgetID: &otherOrgBud}, dafi.FilterBy("id", dafi.Equal, ruleID)...)
+	assertCode(t, err, apperrors.CodeValidation)
+	assert.Empty(t, repo.updated)
+}
+
+func TestService_Update_NewCategory_DropsSubcategory(t *testing.T) {
+	t.Parallel()
+
+	repo := &stubRuleRepo{rule: port.Rule{ID: ruleID, OrganizationID: "org-1", CategoryID: transportID, SubcategoryID: &taxiID}}
+	err := newTestService(repo).Update(context.Background(), port.UpdateRule{CategoryID: &diningID}, dafi.FilterBy("id", dafi.Equal, ruleID)...)
+	require.NoError(t, err)
+	require.Len(t, repo.updated, 1)
+}
+
+func TestMatch_FirstMatchingRuleWins(t *testing.T) {
+	t.Parallel()
+
+	rules := []port.Rule{
+		{Name: "large uber", CategoryID: diningID, Conditions: port.Conditions{
+			{Field: port.FieldDescription, Operator: dafi.Contains, Value: "uber"},
+			{Field: port.FieldAmount, Operator: dafi.LessOrEqual, Value: float64(-10000)},
+		}},
+		{Name: "uber", CategoryID: transportID, Conditions: port.Conditions{
+			{Field: port.FieldDescription, Operator: dafi.Contains, Value: "UBER"},
+		}},
+	}
+
+	tests := map[string]struct {
+		subject port.Subject
+		want    string
+		matched bool
+	}{
+		"first rule":     {subject: port.Subject{Amount: -12000, Description: null.StringFrom("Uber Eats")}, want: "large uber", matched: true},
+		"second rule":    {subject: port.Subject{Amount: -1500, Description: null.StringFrom("UBER *TRIP")}, want: "uber", matched: true},
+		"no description": {subject: port.Subject{Amount: -1500}},
+		"no rule":        {subject: port.Subject{Amount: -1500, Description: null.StringFrom("Lyft")}},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			rule, ok := port.Match(rules, tt.subject)
+			assert.Equal(t, tt.matched, ok)
+			assert.Equal(t, tt.want, rule.Name)
+		})
+	}
+}
+
+func TestCondition_Match(t *testing.T) {
+	t.Parallel()
+
+	subject := port.Subject{
+		AccountID:   ruleID,
+		Type:        "expense",
+		Amount:      -2500,
+		Description: null.StringFrom("Spotify AB"),
+	}
+
+	tests := map[string]struct {
+		condition port.Condition
+		want      bool
+	}{
+		"type in":                {port.Condition{Field: port.FieldType, Operator: dafi.In, Value: []any{"income", "EXPENSE"}}, true},
+		"type not in":            {port.Condition{Field: port.FieldType, Operator: dafi.NotIn, Value: []any{"expense"}}, false},
+		"account equal":          {port.Condition{Field: port.FieldAccountID, Operator: dafi.Equal, Value: ruleID.String()}, true},
+		"amount in":              {port.Condition{Field: port.FieldAmount, Operator: dafi.In, Value: []any{float64(-2500)}}, true},
+		"amount greater":         {port.Condition{Field: port.FieldAmount, Operator: dafi.Greater, Value: float64(-3000)}, true},
+		"description equal":      {port.Condition{Field: port.FieldDescription, Operator: dafi.Equal, Value: "spotify ab"}, true},
+		"description ncontains":  {port.Condition{Field: port.FieldDescription, Operator: dafi.NotContains, Value: "netflix"}, true},
+		"reference isnull":       {port.Condition{Field: port.FieldExternalReferenceNumber, Operator: dafi.IsNull}, true},
+		"reference ne when null": {port.Condition{Field: port.FieldExternalReferenceNumber, Operator: dafi.NotEqual, Value: "x"}, true},
+		"reference eq when null": {port.Condition{Field: port.FieldExternalReferenceNumber, Operator: dafi.Equal, Value: "x"}, false},
+		"currency isnnull":       {port.Condition{Field: port.FieldOriginalCurrencyCode, Operator: dafi.IsNotNull}, false},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			require.NoError(t, tt.condition.Validate())
+			assert.Equal(t, tt.want, tt.condition.Match(subject))
+		})
+	}
+}
@@ -0,0 +1,37 @@
+package categorization_rule
+
+import (
+	"backend/adapter/database"
+	"backend/adapter/di"
+	budgetport "backend/core/budget/budget/port"
+	"backend/core/budget/categorization_rule/adapter/handler"
+	"backend/core/budget/categorization_rule/adapter/postgres"
+	"backend/core/budget/categorization_rule/core"
+	"backend/core/budget/categorization_rule/port"
+	categoryport "backend/core/budget/category/port"
+	basedomain "backend/port"
+
+	"github.com/samber/do/v2"
+)
+
+func Module(i do.Injector) {
+	di.Provide(i, func(i do.Injector) (port.Repository, error) {
+		db := di.MustInvoke[database.PoolInterface](i)
+		logger := di.MustInvoke[basedomain.Logger](i)
+		return postgres.NewPostgres(db, logger), nil
+	})
+
+	di.Provide(i, func(i do.Injector) (port.Service, error) {
+		repo := di.MustInvoke[port.Repository](i)
+		budgetRepo := di.MustInvoke[budgetport.Repository](i)
+		categoryRepo := di.MustInvoke[categoryport.Repository](i)
+		logger := di.MustInvoke[basedomain.Logger](i)
+		return core.New(repo, budgetRepo, categoryRepo, logger), nil
+	})
+
+	di.Provide(i, func(i do.Injector) (handler.HTTP, error) {
+		svc := di.MustInvoke[port.Service](i)
+		logger := di.MustInvoke[basedomain.Logger](i)
+		return handler.NewHTTP(svc, logger), nil
+	})
+}
@@ -0,0 +1,58 @@
+package port
+
+import (
+	"context"
+
+	"backend/adapter/validation"
+	"github.com/google/uuid"
+	"github.com/guregu/null/v6"
+)
+
+type CreateRule struct {
+	ID             uuid.UUID  `json:"id"`
+	OrganizationID string     `json:"organizationId"`
+	Name           string     `json:"name"`
+	Priority       int        `json:"priority"`
+	Conditions     Conditions `json:"conditions"`
+	CategoryID     uuid.UUID  `json:"categoryId"`
+	SubcategoryID  *uuid.UUID `json:"subcategoryId"`
+	BudgetID       *uuid.UUID `json:"budgetId"`
+}
+
+func (c CreateRule) Validate(ctx context.Context) error {
+	err := validation.ValidateStruct(ctx, &c,
+		validation.Field(&c.ID, validation.Required, validation.IsUUID),
+		validation.Field(&c.OrganizationID, validation.Required),
+		validation.Field(&c.Name, validation.Required, validation.Length(1, 100)),
+		validation.Field(&c.CategoryID, validation.Required, validation.IsUUID),
+	)
+	if err != nil {
+		return err
+	}
+
+	return c.Conditions.Validate()
+}
+
+// UpdateRule changes a rule. Fields left out keep their value; Conditions, when
+// given, replace all of them. A new CategoryID drops the subcategory unless a new
+// SubcategoryID comes with it.
+type UpdateRule struct {
+	Name          null.String `json:"name"`
+	Priority      null.Int    `json:"priority"`
+	Conditions    Conditions  `json:"conditions"`
+	CategoryID    *uuid.UUID  `json:"categoryId"`
+	SubcategoryID *uuid.UUID  `json:"subcategoryId"`
+	BudgetID      *uuid.UUID  `json:"budgetId"`
+	IsActive      null.Bool   `json:"isActive"`
+}
+
+func (u UpdateRule) Validate(ctx context.Context) error {
+	err := validation.ValidateStruct(ctx, &u,
+		validation.Field(&u.Name, validation.NilOrNotEmpty, validation.Length(1, 100)),
+	)
+	if err != nil || u.Conditions == nil {
+		return err
+	}
+
+	return u.Conditions.Validate()
+}
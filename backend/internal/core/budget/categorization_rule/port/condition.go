@@ -0,0 +1,269 @@
+package port
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+
+	"backend/infra/dafi"
+)
+
+// Fields of a transaction a condition can test, named as in its JSON.
+const (
+	FieldAccountID               = "accountId"
+	FieldPayeeID                 = "payeeId"
+	FieldType                    = "type"
+	FieldAmount                  = "amount"
+	FieldDescription             = "description"
+	FieldExternalReferenceNumber = "externalReferenceNumber"
+	FieldOriginalCurrencyCode    = "originalCurrencyCode"
+)
+
+var (
+	textOperators = []dafi.FilterOperator{
+		dafi.Equal, dafi.NotEqual, dafi.In, dafi.NotIn,
+		dafi.Contains, dafi.NotContains, dafi.IsNull, dafi.IsNotNull,
+	}
+	amountOperators = []dafi.FilterOperator{
+		dafi.Equal, dafi.NotEqual, dafi.Greater, dafi.GreaterOrEqual,
+		dafi.Less, dafi.LessOrEqual, dafi.In, dafi.NotIn,
+	}
+)
+
+// Condition is a filter on a field of a transaction, written like a dafi filter:
+// {"field": "description", "operator": "contains", "value": "UBER"}. Text is compared
+// ignoring case, as contains does in a query. Amounts are in minor units and signed,
+// money out being negative. in and nin take a list of values; isnull and isnnull take
+// none.
+type Condition struct {
+	Field    string              `json:"field"`
+	Operator dafi.FilterOperator `json:"operator"`
+	Value    any                 `json:"value"`
+}
+
+// Conditions must all hold for a rule to match.
+type Conditions []Condition
+
+func (c Conditions) Validate() error {
+	if len(c) == 0 {
+		return fmt.Errorf("conditions: at least one condition is needed")
+	}
+
+	for i, condition := range c {
+		if err := condition.Validate(); err != nil {
+			return fmt.Errorf("conditions: condition %d: %w", i+1, err)
+		}
+	}
+
+	return nil
+}
+
+// Value stores the conditions as JSON. No conditions are stored as NULL, which a
+// partial update leaves as it was.
+func (c Conditions) Value() (driver.Value, error) {
+	if c == nil {
+		return nil, nil
+	}
+
+	b, err := json.Marshal(c)
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+func (c *Conditions) Scan(src any) error {
+	switch v := src.(type) {
+	case nil:
+		*c = nil
+		return nil
+	case string:
+		return json.Unmarshal([]byte(v), c)
+	case []byte:
+		return json.Unmarshal(v, c)
+	}
+	return fmt.Errorf("conditions: cannot scan %T", src)
+}
+
+// Match reports whether subject meets every condition.
+func (c Conditions) Match(subject Subject) bool {
+	for _, condition := range c {
+		if !condition.Match(subject) {
+			return false
+		}
+	}
+	return true
+}
+
+func (c Condition) Validate() error {
+	operators := textOperators
+	switch c.Field {
+	case FieldAmount:
+		operators = amountOperators
+	case FieldAccountID, FieldPayeeID, FieldType, FieldDescription, FieldExternalReferenceNumber, FieldOriginalCurrencyCode:
+	default:
+		return fmt.Errorf("field %q cannot be tested", c.Field)
+	}
+
+	if !slices.Contains(operators, c.Operator) {
+		return fmt.Errorf("operator %q does not apply to %s", c.Operator, c.Field)
+	}
+
+	switch c.Operator {
+	case dafi.IsNull, dafi.IsNotNull:
+		return nil
+	case dafi.In, dafi.NotIn:
+		values, ok := c.Value.([]any)
+		if !ok || len(values) == 0 {
+			return fmt.Errorf("%s needs a list of values", c.Operator)
+		}
+		for _, value := range values {
+			if err := c.validateValue(value); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	return c.validateValue(c.Value)
+}
+
+func (c Condition) validateValue(value any) error {
+	if c.Field == FieldAmount {
+		if _, ok := toMinor(value); !ok {
+			return fmt.Errorf("%v is not an amount in minor units", value)
+		}
+		return nil
+	}
+
+	if s, ok := value.(string); !ok || s == "" {
+		return fmt.Errorf("%s needs a text value", c.Field)
+	}
+	return nil
+}
+
+// Match reports whether subject meets the condition. A condition on a field the
+// subject leaves empty only holds for isnull and the negative operators.
+func (c Condition) Match(subject Subject) bool {
+	if c.Field == FieldAmount {
+		return c.matchAmount(subject.Amount)
+	}
+
+	text, ok := c.text(subject)
+	switch c.Operator {
+	case dafi.IsNull:
+		return !ok
+	case dafi.IsNotNull:
+		return ok
+	case dafi.NotEqual, dafi.NotIn, dafi.NotContains:
+		if !ok {
+			return true
+		}
+	default:
+		if !ok {
+			return false
+		}
+	}
+
+	text = strings.ToLower(text)
+	switch c.Operator {
+	case dafi.Equal:
+		return text == lowerString(c.Value)
+	case dafi.NotEqual:
+		return text != lowerString(c.Value)
+	case dafi.Contains:
+		return strings.Contains(text, lowerString(c.Value))
+	case dafi.NotContains:
+		return !strings.Contains(text, lowerString(c.Value))
+	case dafi.In, dafi.NotIn:
+		found := slices.ContainsFunc(list(c.Value), func(v any) bool { return text == lowerString(v) })
+		return found == (c.Operator == dafi.In)
+	}
+
+	return false
+}
+
+func (c Condition) text(subject Subject) (string, bool) {
+	switch c.Field {
+	case FieldAccountID:
+		return subject.AccountID.String(), true
+	case FieldPayeeID:
+		if subject.PayeeID == nil {
+			return "", false
+		}
+		return subject.PayeeID.String(), true
+	case FieldType:
+		return subject.Type, subject.Type != ""
+	case FieldDescription:
+		return subject.Description.String, subject.Description.Valid
+	case FieldExternalReferenceNumber:
+		return subject.ExternalReferenceNumber.String, subject.ExternalReferenceNumber.Valid
+	case FieldOriginalCurrencyCode:
+		return subject.OriginalCurrencyCode.String, subject.OriginalCurrencyCode.Valid
+	}
+	return "", false
+}
+
+func (c Condition) matchAmount(amount int64) bool {
+	if c.Operator == dafi.In || c.Operator == dafi.NotIn {
+		found := slices.ContainsFunc(list(c.Value), func(v any) bool {
+			value, ok := toMinor(v)
+			return ok && value == amount
+		})
+		return found == (c.Operator == dafi.In)
+	}
+
+	value, ok := toMinor(c.Value)
+	if !ok {
+		return false
+	}
+
+	switch c.Operator {
+	case dafi.Equal:
+		return amount == value
+	case dafi.NotEqual:
+		return amount != value
+	case dafi.Greater:
+		return amount > value
+	case dafi.GreaterOrEqual:
+		return amount >= value
+	case dafi.Less:
+		return amount < value
+	case dafi.LessOrEqual:
+		return amount <= value
+	}
+
+	return false
+}
+
+// toMinor reads a whole amount in minor units from a decoded JSON value.
+func toMinor(value any) (int64, bool) {
+	switch v := value.(type) {
+	case int64:
+		return v, true
+	case int:
+		return int64(v), true
+	case float64:
+		if v != math.Trunc(v) || math.Abs(v) > 1<<53 {
+			return 0, false
+		}
+		return int64(v), true
+	case json.Number:
+		n, err := v.Int64()
+		return n, err == nil
+	}
+	return 0, false
+}
+
+func lowerString(value any) string {
+	s, _ := value.(string)
+	return strings.ToLower(s)
+}
+
+func list(value any) []any {
+	values, _ := value.([]any)
+	return values
+}
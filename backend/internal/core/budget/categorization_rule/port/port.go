@@ -0,0 +1,21 @@
+package port
+
+import (
+	"context"
+
+	basedomain "backend/port"
+)
+
+type Repository interface {
+	basedomain.RepositoryCommand[CreateRule, UpdateRule]
+	basedomain.RepositoryQuery[Rule]
+	basedomain.RepositoryTx[Repository]
+}
+
+type Service interface {
+	basedomain.UseCaseCommand[CreateRule, UpdateRule]
+	basedomain.UseCaseQuery[Rule]
+	basedomain.UseCaseTx[Service]
+	// Active returns the active rules of an organization in the order they are tried.
+	Active(ctx context.Context, organizationID string) ([]Rule, error)
+}
@@ -0,0 +1,47 @@
+package port
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/guregu/null/v6"
+)
+
+// Rule files the transactions that meet all of its Conditions under a category, and
+// optionally a subcategory and a budget. Rules are tried from the lowest Priority up;
+// the first that matches wins.
+type Rule struct {
+	ID             uuid.UUID  `json:"id"`
+	OrganizationID string     `json:"organizationId"`
+	Name           string     `json:"name"`
+	Priority       int        `json:"priority"`
+	Conditions     Conditions `json:"conditions"`
+	CategoryID     uuid.UUID  `json:"categoryId"`
+	SubcategoryID  *uuid.UUID `json:"subcategoryId"`
+	BudgetID       *uuid.UUID `json:"budgetId"`
+	IsActive       bool       `json:"isActive"`
+	CreatedAt      time.Time  `json:"createdAt"`
+	UpdatedAt      time.Time  `json:"updatedAt"`
+}
+
+// Subject is what a rule sees of a transaction. PayeeID is the payee the transaction
+// was given or resolved to.
+type Subject struct {
+	AccountID               uuid.UUID
+	PayeeID                 *uuid.UUID
+	Type                    string
+	Amount                  int64
+	Description             null.String
+	ExternalReferenceNumber null.String
+	OriginalCurrencyCode    null.String
+}
+
+// Match returns the first of rules, in the order given, that matches subject.
+func Match(rules []Rule, subject Subject) (Rule, bool) {
+	for _, rule := range rules {
+		if rule.Conditions.Match(subject) {
+			return rule, true
+		}
+	}
+	return Rule{}, false
+}
@@ -1,11 +1,14 @@
 package handler
 
 import (
+	"time"
+
 	"backend/core/budget/category/port"
 	basedomain "backend/port"
 	apperrors "backend/port/errors"
 	"backend/infra/dafi"
 	"backend/infra/httpresponse"
+	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
 	"github.com/samber/oops"
 )
@@ -52,6 +55,22 @@ func (h HTTP) FindAll(c echo.Context) error {
 	return httpresponse.OK(c, cats)
 }
 
+func (h HTTP) GoalProgress(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return oops.WithContext(ctx).In(apperrors.LayerHandler).Code(apperrors.CodeBadRequest).Wrap(err)
+	}
+
+	progress, err := h.svc.GoalProgress(ctx, id, time.Now())
+	if err != nil {
+		return oops.WithContext(ctx).In(apperrors.LayerHandler).Wrap(err)
+	}
+
+	return httpresponse.OK(c, progress)
+}
+
 func (h HTTP) Create(c echo.Context) error {
 	ctx := c.Request().Context()
 
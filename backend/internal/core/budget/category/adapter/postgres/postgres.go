@@ -12,6 +12,7 @@ import (
 	"backend/adapter/database"
 	"backend/infra/sqlcraft"
 
+	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/samber/oops"
@@ -33,20 +34,30 @@ var columns = []string{
 	"icon",
 	"color",
 	"is_active",
+	"rollover_policy",
+	"goal_type",
+	"goal_amount",
+	"goal_currency_code",
+	"goal_target_date",
 	"created_at",
 	"updated_at",
 }
 
 var sqlColumnByDomainField = map[string]string{
-	"id":             "id",
-	"organizationId": "organization_id",
-	"parentId":       "parent_id",
-	"name":           "name",
-	"icon":           "icon",
-	"color":          "color",
-	"isActive":       "is_active",
-	"createdAt":      "created_at",
-	"updatedAt":      "updated_at",
+	"id":               "id",
+	"organizationId":   "organization_id",
+	"parentId":         "parent_id",
+	"name":             "name",
+	"icon":             "icon",
+	"color":            "color",
+	"isActive":         "is_active",
+	"rolloverPolicy":   "rollover_policy",
+	"goalType":         "goal_type",
+	"goalAmount":       "goal_amount",
+	"goalCurrencyCode": "goal_currency_code",
+	"goalTargetDate":   "goal_target_date",
+	"createdAt":        "created_at",
+	"updatedAt":        "updated_at",
 }
 
 type postgres struct {
@@ -93,6 +104,11 @@ func (r postgres) FindOne(ctx context.Context, criteria dafi.Criteria) (port.Cat
 		&cat.Icon,
 		&cat.Color,
 		&cat.IsActive,
+		&cat.RolloverPolicy,
+		&cat.GoalType,
+		&cat.GoalAmount,
+		&cat.GoalCurrencyCode,
+		&cat.GoalTargetDate,
 		&cat.CreatedAt,
 		&cat.UpdatedAt,
 	)
@@ -139,6 +155,11 @@ func (r postgres) FindAll(ctx context.Context, criteria dafi.Criteria) (basedoma
 			&cat.Icon,
 			&cat.Color,
 			&cat.IsActive,
+			&cat.RolloverPolicy,
+			&cat.GoalType,
+			&cat.GoalAmount,
+			&cat.GoalCurrencyCode,
+			&cat.GoalTargetDate,
 			&cat.CreatedAt,
 			&cat.UpdatedAt,
 		)
@@ -164,6 +185,11 @@ func (r postgres) Create(ctx context.Context, input port.CreateCategory) error {
 			input.Icon,
 			input.Color,
 			input.IsActive,
+			input.RolloverPolicy,
+			input.GoalType,
+			input.GoalAmount,
+			input.GoalCurrencyCode,
+			input.GoalTargetDate,
 			now,
 			now,
 		)
@@ -200,6 +226,11 @@ func (r postgres) CreateBulk(ctx context.Context, inputs basedomain.List[port.Cr
 			input.Icon,
 			input.Color,
 			input.IsActive,
+			input.RolloverPolicy,
+			input.GoalType,
+			input.GoalAmount,
+			input.GoalCurrencyCode,
+			input.GoalTargetDate,
 			now,
 			now,
 		)
@@ -222,13 +253,14 @@ func (r postgres) CreateBulk(ctx context.Context, inputs basedomain.List[port.Cr
 
 func (r postgres) Update(ctx context.Context, input port.UpdateCategory, filters ...dafi.Filter) error {
 	query := sqlcraft.Update(tableName).
-		WithColumns("parent_id", "name", "icon", "color", "is_active", "updated_at").
+		WithColumns("parent_id", "name", "icon", "color", "is_active", "rollover_policy", "updated_at").
 		WithValues(
 			input.ParentID,
 			input.Name,
 			input.Icon,
 			input.Color,
 			input.IsActive,
+			input.RolloverPolicy,
 			time.Now(),
 		).
 		Where(filters...).
@@ -250,6 +282,94 @@ func (r postgres) Update(ctx context.Context, input port.UpdateCategory, filters
 	return nil
 }
 
+func (r postgres) UpdateGoal(ctx context.Context, goal port.Goal, filters ...dafi.Filter) error {
+	query := sqlcraft.Update(tableName).
+		WithColumns("goal_type", "goal_amount", "goal_currency_code", "goal_target_date", "updated_at").
+		WithValues(
+			goal.GoalType,
+			goal.GoalAmount,
+			goal.GoalCurrencyCode,
+			goal.GoalTargetDate,
+			time.Now(),
+		).
+		Where(filters...).
+		SQLColumnByDomainField(sqlColumnByDomainField)
+
+	result, err := query.ToSQL()
+	if err != nil {
+		return oops.WithContext(ctx).In(apperrors.LayerRepository).Wrap(err)
+	}
+
+	r.logger.WithContext(ctx).Debug("executing query", "sql", result.SQL)
+
+	_, err = r.db.Exec(ctx, result.SQL, result.Args...)
+	if err != nil {
+		return oops.WithContext(ctx).In(apperrors.LayerRepository).Wrap(err)
+	}
+
+	return nil
+}
+
+// goalActivityQuery totals the allocations and the transaction lines of a category, its
+// subcategories included, converted to the goal currency in $2 and split between
+// what happened before the month starting at $3 and within it. unconverted counts the
+// amounts that could not be converted for lack of an exchange rate.
+const goalActivityQuery = `
+WITH lines AS (
+    SELECT
+        make_date(b.year, b.month, 1) < $3 AS before,
+        budget.convert_amount(ba.organization_id, ba.assigned_amount, b.currency_code, $2, make_date(b.year, b.month, 1)) AS assigned,
+        0::BIGINT AS activity
+    FROM budget.budget_allocations ba
+    JOIN budget.budgets b ON b.id = ba.budget_id
+    WHERE (ba.category_id = $1 OR ba.subcategory_id = $1)
+      AND make_date(b.year, b.month, 1) < $3::DATE + INTERVAL '1 month'
+    UNION ALL
+    SELECT
+        t.date < $3,
+        0::BIGINT,
+        budget.convert_amount(t.organization_id, t.amount, a.currency_code, $2, t.date)
+    FROM budget.transaction_lines t
+    JOIN budget.accounts a ON a.id = t.account_id
+    WHERE (t.category_id = $1 OR t.subcategory_id = $1)
+      AND t.date < $3::DATE + INTERVAL '1 month'
+)
+SELECT
+    COALESCE(SUM(assigned) FILTER (WHERE before), 0)::BIGINT,
+    COALESCE(SUM(activity) FILTER (WHERE before), 0)::BIGINT,
+    COALESCE(SUM(assigned) FILTER (WHERE NOT before), 0)::BIGINT,
+    COALESCE(SUM(activity) FILTER (WHERE NOT before), 0)::BIGINT,
+    COUNT(*) FILTER (WHERE assigned IS NULL OR activity IS NULL)
+FROM lines`
+
+func (r postgres) GoalActivity(ctx context.Context, categoryID uuid.UUID, currencyCode string, monthStart time.Time) (port.GoalActivity, error) {
+	r.logger.WithContext(ctx).Debug("executing query", "sql", goalActivityQuery)
+
+	var (
+		activity    port.GoalActivity
+		unconverted int64
+	)
+	err := r.db.QueryRow(ctx, goalActivityQuery, categoryID, currencyCode, monthStart).Scan(
+		&activity.AssignedBefore,
+		&activity.ActivityBefore,
+		&activity.Assigned,
+		&activity.Activity,
+		&unconverted,
+	)
+	if err != nil {
+		return port.GoalActivity{}, oops.WithContext(ctx).In(apperrors.LayerRepository).Wrap(err)
+	}
+
+	if unconverted > 0 {
+		return port.GoalActivity{}, oops.WithContext(ctx).In(apperrors.LayerRepository).
+			Code(apperrors.CodeConflict).
+			Public("An exchange rate is missing for one of the currencies used by this category.").
+			Errorf("%d amounts of category %s could not be converted to %s", unconverted, categoryID, currencyCode)
+	}
+
+	return activity, nil
+}
+
 func (r postgres) Delete(ctx context.Context, filters ...dafi.Filter) error {
 	query := sqlcraft.DeleteFrom(tableName).
 		Where(filters...).
@@ -0,0 +1,66 @@
+// Package categorytest provides an in-memory category repository for the tests of
+// features that look categories up.
+package categorytest
+
+import (
+	"context"
+	"time"
+
+	"backend/core/budget/category/port"
+	"backend/infra/dafi"
+	basedomain "backend/port"
+	apperrors "backend/port/errors"
+
+	"github.com/google/uuid"
+	"github.com/samber/oops"
+)
+
+// Repository resolves FindOne by the "id" and "organizationId" filters. Every other
+// method is a no-op.
+type Repository struct {
+	Categories []port.Category
+}
+
+var _ port.Repository = (*Repository)(nil)
+
+func (r *Repository) FindOne(_ context.Context, criteria dafi.Criteria) (port.Category, error) {
+	var id, org string
+	for _, f := range criteria.Filters {
+		switch f.Field {
+		case "id":
+			id, _ = f.Value.(string)
+		case "organizationId":
+			org, _ = f.Value.(string)
+		}
+	}
+	for _, c := range r.Categories {
+		if c.ID.String() == id && c.OrganizationID == org {
+			return c, nil
+		}
+	}
+	return port.Category{}, oops.Code(apperrors.CodeNotFound).Errorf("category not found")
+}
+
+func (r *Repository) FindAll(context.Context, dafi.Criteria) (basedomain.List[port.Category], error) {
+	return nil, nil
+}
+
+func (r *Repository) Create(context.Context, port.CreateCategory) error { return nil }
+
+func (r *Repository) CreateBulk(context.Context, basedomain.List[port.CreateCategory]) error {
+	return nil
+}
+
+func (r *Repository) Update(context.Context, port.UpdateCategory, ...dafi.Filter) error {
+	return nil
+}
+
+func (r *Repository) Delete(context.Context, ...dafi.Filter) error { return nil }
+
+func (r *Repository) UpdateGoal(context.Context, port.Goal, ...dafi.Filter) error { return nil }
+
+func (r *Repository) GoalActivity(context.Context, uuid.UUID, string, time.Time) (port.GoalActivity, error) {
+	return port.GoalActivity{}, nil
+}
+
+func (r *Repository) WithTx(basedomain.Transaction) port.Repository { return r }
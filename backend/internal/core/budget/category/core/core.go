@@ -12,19 +12,28 @@ import (
 
 type service struct {
 	repo   port.Repository
+	uow    basedomain.UnitOfWork
+	tx     basedomain.Transaction
 	logger basedomain.Logger
 }
 
-func New(repo port.Repository, logger basedomain.Logger) port.Service {
+func New(repo port.Repository, uow basedomain.UnitOfWork, logger basedomain.Logger) port.Service {
 	return service{
 		repo:   repo,
+		uow:    uow,
 		logger: logger.With("component", "category.service"),
 	}
 }
 
 func (s service) WithTx(tx basedomain.Transaction) port.Service {
+	return s.withTx(tx)
+}
+
+func (s service) withTx(tx basedomain.Transaction) service {
 	return service{
 		repo:   s.repo.WithTx(tx),
+		uow:    s.uow,
+		tx:     tx,
 		logger: s.logger,
 	}
 }
@@ -52,6 +61,10 @@ func (s service) Create(ctx context.Context, input port.CreateCategory) error {
 		return oops.WithContext(ctx).In(apperrors.LayerService).Code(apperrors.CodeValidation).Wrap(err)
 	}
 
+	if input.RolloverPolicy == "" {
+		input.RolloverPolicy = port.RolloverReset
+	}
+
 	if err := s.repo.Create(ctx, input); err != nil {
 		return oops.WithContext(ctx).In(apperrors.LayerService).Wrap(err)
 	}
@@ -62,6 +75,12 @@ func (s service) Create(ctx context.Context, input port.CreateCategory) error {
 }
 
 func (s service) CreateBulk(ctx context.Context, inputs basedomain.List[port.CreateCategory]) error {
+	for i := range inputs {
+		if inputs[i].RolloverPolicy == "" {
+			inputs[i].RolloverPolicy = port.RolloverReset
+		}
+	}
+
 	if err := s.repo.CreateBulk(ctx, inputs); err != nil {
 		return oops.WithContext(ctx).In(apperrors.LayerService).Wrap(err)
 	}
@@ -76,11 +95,41 @@ func (s service) Update(ctx context.Context, input port.UpdateCategory, filters
 		return oops.WithContext(ctx).In(apperrors.LayerService).Code(apperrors.CodeValidation).Wrap(err)
 	}
 
-	if err := s.repo.Update(ctx, input, filters...); err != nil {
+	if !input.HasGoalChanges() && !input.ClearGoal {
+		if err := s.repo.Update(ctx, input, filters...); err != nil {
+			return oops.WithContext(ctx).In(apperrors.LayerService).Wrap(err)
+		}
+
+		s.logger.WithContext(ctx).Info("category updated")
+
+		return nil
+	}
+
+	// Goal fields depend on each other, so the goal left on the category is validated
+	// as a whole and written without the partial update's COALESCE, letting fields
+	// the new goal type does not use be cleared.
+	current, err := s.repo.FindOne(ctx, dafi.Criteria{Filters: filters})
+	if err != nil {
+		return oops.WithContext(ctx).In(apperrors.LayerService).Wrap(err)
+	}
+
+	goal := input.Merge(current.Goal)
+	if err := goal.Validate(ctx); err != nil {
+		return oops.WithContext(ctx).In(apperrors.LayerService).Code(apperrors.CodeValidation).Wrap(err)
+	}
+
+	err = basedomain.Atomically(ctx, s.uow, s.tx, s.withTx, func(txSvc service) error {
+		if err := txSvc.repo.Update(ctx, input, filters...); err != nil {
+			return err
+		}
+
+		return txSvc.repo.UpdateGoal(ctx, goal, filters...)
+	})
+	if err != nil {
 		return oops.WithContext(ctx).In(apperrors.LayerService).Wrap(err)
 	}
 
-	s.logger.WithContext(ctx).Info("category updated")
+	s.logger.WithContext(ctx).Info("category updated", "goalType", goal.GoalType.String)
 
 	return nil
 }
@@ -0,0 +1,100 @@
+package core
+
+import (
+	"context"
+	"time"
+
+	"backend/core/budget/category/port"
+	"backend/infra/dafi"
+	"backend/infra/money"
+	apperrors "backend/port/errors"
+
+	"github.com/google/uuid"
+	"github.com/samber/oops"
+)
+
+func (s service) GoalProgress(ctx context.Context, id uuid.UUID, asOf time.Time) (port.GoalProgress, error) {
+	cat, err := s.repo.FindOne(ctx, dafi.Where("id", dafi.Equal, id))
+	if err != nil {
+		return port.GoalProgress{}, oops.WithContext(ctx).In(apperrors.LayerService).Wrap(err)
+	}
+
+	if !cat.GoalType.Valid {
+		return port.GoalProgress{}, oops.WithContext(ctx).In(apperrors.LayerService).
+			Code(apperrors.CodeNotFound).
+			Public("The category has no goal.").
+			Errorf("category %s has no goal", id)
+	}
+
+	monthStart := time.Date(asOf.Year(), asOf.Month(), 1, 0, 0, 0, 0, time.UTC)
+
+	activity, err := s.repo.GoalActivity(ctx, id, cat.GoalCurrencyCode.String, monthStart)
+	if err != nil {
+		return port.GoalProgress{}, oops.WithContext(ctx).In(apperrors.LayerService).Wrap(err)
+	}
+
+	return goalProgress(cat, activity, monthStart), nil
+}
+
+// goalProgress measures a category against its goal for the month starting at
+// monthStart.
+func goalProgress(cat port.Category, activity port.GoalActivity, monthStart time.Time) port.GoalProgress {
+	goal := money.Minor(cat.GoalAmount.Int64)
+
+	progress := port.GoalProgress{
+		CategoryID:   cat.ID,
+		GoalType:     cat.GoalType.String,
+		CurrencyCode: cat.GoalCurrencyCode.String,
+		GoalAmount:   goal,
+		TargetDate:   cat.GoalTargetDate,
+		Month:        int16(monthStart.Month()),
+		Year:         int16(monthStart.Year()),
+		Balance:      activity.AssignedBefore + activity.ActivityBefore,
+		Assigned:     activity.Assigned,
+		Activity:     activity.Activity,
+	}
+
+	switch progress.GoalType {
+	case port.GoalTargetBalance:
+		// What is still missing is spread evenly over the months left until the
+		// target month, this one included; a target in the past is due now.
+		months := monthsUntil(monthStart, *cat.GoalTargetDate)
+		perMonth := ceilDiv(nonNegative(goal-progress.Balance), money.Minor(months))
+		progress.Needed = nonNegative(perMonth - progress.Assigned)
+		progress.Remaining = nonNegative(goal - (progress.Balance + progress.Assigned + progress.Activity))
+	case port.GoalMonthlyFunding:
+		progress.Needed = nonNegative(goal - progress.Assigned)
+		progress.Remaining = progress.Needed
+	case port.GoalSpendingCap:
+		progress.Remaining = goal + progress.Activity
+		progress.OnTrack = progress.Remaining >= 0
+		return progress
+	}
+
+	progress.OnTrack = progress.Needed == 0
+
+	return progress
+}
+
+// monthsUntil counts the months from the one of monthStart to the one of target,
+// both included, and at least one.
+func monthsUntil(monthStart, target time.Time) int {
+	months := (target.Year()-monthStart.Year())*12 + int(target.Month()) - int(monthStart.Month()) + 1
+	if months < 1 {
+		return 1
+	}
+
+	return months
+}
+
+func ceilDiv(amount, parts money.Minor) money.Minor {
+	return (amount + parts - 1) / parts
+}
+
+func nonNegative(amount money.Minor) money.Minor {
+	if amount < 0 {
+		return 0
+	}
+
+	return amount
+}
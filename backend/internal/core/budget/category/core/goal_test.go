@@ -0,0 +1,239 @@
+package core
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"backend/core/budget/category/port"
+	"backend/infra/dafi"
+	"backend/infra/money"
+	basedomain "backend/port"
+	apperrors "backend/port/errors"
+
+	"github.com/google/uuid"
+	"github.com/guregu/null/v6"
+	"github.com/samber/oops"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type noopLogger struct{}
+
+func (noopLogger) Debug(string, ...any)                          {}
+func (noopLogger) Info(string, ...any)                           {}
+func (noopLogger) Warn(string, ...any)                           {}
+func (noopLogger) Error(string, ...any)                          {}
+func (noopLogger) With(...any) basedomain.Logger                 { return noopLogger{} }
+func (noopLogger) WithContext(context.Context) basedomain.Logger { return noopLogger{} }
+
+type stubTransaction struct{}
+
+func (stubTransaction) GetTx() basedomain.Tx { return nil }
+
+type stubUnitOfWork struct{ committed bool }
+
+func (u *stubUnitOfWork) Begin(context.Context) (basedomain.Transaction, error) {
+	return stubTransaction{}, nil
+}
+
+func (u *stubUnitOfWork) Commit(context.Context, basedomain.Transaction) error {
+	u.committed = true
+	return nil
+}
+
+func (u *stubUnitOfWork) Rollback(context.Context, basedomain.Transaction) error { return nil }
+
+type stubCategoryRepo struct {
+	port.Repository
+	category port.Category
+	activity port.GoalActivity
+	goal     *port.Goal
+}
+
+func (s *stubCategoryRepo) FindOne(context.Context, dafi.Criteria) (port.Category, error) {
+	return s.category, nil
+}
+
+func (s *stubCategoryRepo) Update(context.Context, port.UpdateCategory, ...dafi.Filter) error {
+	return nil
+}
+
+func (s *stubCategoryRepo) UpdateGoal(_ context.Context, goal port.Goal, _ ...dafi.Filter) error {
+	s.goal = &goal
+	return nil
+}
+
+func (s *stubCategoryRepo) GoalActivity(context.Context, uuid.UUID, string, time.Time) (port.GoalActivity, error) {
+	return s.activity, nil
+}
+
+func (s *stubCategoryRepo) WithTx(basedomain.Transaction) port.Repository { return s }
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func TestGoalProgress(t *testing.T) {
+	target := date(2026, time.December, 15)
+	june := date(2026, time.June, 1)
+
+	tests := []struct {
+		name     string
+		goal     port.Goal
+		activity port.GoalActivity
+		want     port.GoalProgress
+	}{
+		{
+			name: "target balance spreads the shortfall over the months left",
+			goal: port.Goal{
+				GoalType:         null.StringFrom(port.GoalTargetBalance),
+				GoalAmount:       null.IntFrom(120000),
+				GoalCurrencyCode: null.StringFrom("USD"),
+				GoalTargetDate:   &target,
+			},
+			// 50000 saved so far, 70000 to go over June..December (7 months).
+			activity: port.GoalActivity{AssignedBefore: 60000, ActivityBefore: -10000, Assigned: 4000},
+			want: port.GoalProgress{
+				Balance:   50000,
+				Assigned:  4000,
+				Needed:    6000,
+				Remaining: 66000,
+			},
+		},
+		{
+			name: "target balance already reached",
+			goal: port.Goal{
+				GoalType:         null.StringFrom(port.GoalTargetBalance),
+				GoalAmount:       null.IntFrom(30000),
+				GoalCurrencyCode: null.StringFrom("USD"),
+				GoalTargetDate:   &target,
+			},
+			activity: port.GoalActivity{AssignedBefore: 35000},
+			want: port.GoalProgress{
+				Balance: 35000,
+				OnTrack: true,
+			},
+		},
+		{
+			name: "monthly funding partially assigned",
+			goal: port.Goal{
+				GoalType:         null.StringFrom(port.GoalMonthlyFunding),
+				GoalAmount:       null.IntFrom(25000),
+				GoalCurrencyCode: null.StringFrom("USD"),
+			},
+			activity: port.GoalActivity{AssignedBefore: 50000, ActivityBefore: -50000, Assigned: 10000, Activity: -3000},
+			want: port.GoalProgress{
+				Assigned:  10000,
+				Activity:  -3000,
+				Needed:    15000,
+				Remaining: 15000,
+			},
+		},
+		{
+			name: "spending cap exceeded",
+			goal: port.Goal{
+				GoalType:         null.StringFrom(port.GoalSpendingCap),
+				GoalAmount:       null.IntFrom(20000),
+				GoalCurrencyCode: null.StringFrom("USD"),
+			},
+			activity: port.GoalActivity{Activity: -21500},
+			want: port.GoalProgress{
+				Activity:  -21500,
+				Remaining: -1500,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cat := port.Category{ID: uuid.New(), Goal: tt.goal}
+
+			got := goalProgress(cat, tt.activity, june)
+
+			assert.Equal(t, cat.ID, got.CategoryID)
+			assert.Equal(t, int16(6), got.Month)
+			assert.Equal(t, int16(2026), got.Year)
+			assert.Equal(t, money.Minor(tt.goal.GoalAmount.Int64), got.GoalAmount)
+			assert.Equal(t, tt.want.Balance, got.Balance)
+			assert.Equal(t, tt.want.Assigned, got.Assigned)
+			assert.Equal(t, tt.want.Activity, got.Activity)
+			assert.Equal(t, tt.want.Needed, got.Needed)
+			assert.Equal(t, tt.want.Remaining, got.Remaining)
+			assert.Equal(t, tt.want.OnTrack, got.OnTrack)
+		})
+	}
+}
+
+func TestMonthsUntil(t *testing.T) {
+	june := date(2026, time.June, 1)
+
+	assert.Equal(t, 1, monthsUntil(june, date(2026, time.June, 30)))
+	assert.Equal(t, 13, monthsUntil(june, date(2027, time.June, 1)))
+	assert.Equal(t, 1, monthsUntil(june, date(2025, time.January, 1)))
+}
+
+func TestService_GoalProgress_NoGoal(t *testing.T) {
+	repo := &stubCategoryRepo{category: port.Category{ID: uuid.New()}}
+	svc := New(repo, &stubUnitOfWork{}, noopLogger{})
+
+	_, err := svc.GoalProgress(context.Background(), repo.category.ID, date(2026, time.June, 18))
+	require.Error(t, err)
+
+	oopsErr, ok := oops.AsOops(err)
+	require.True(t, ok)
+	assert.Equal(t, apperrors.CodeNotFound, oopsErr.Code())
+}
+
+func TestService_Update_Goal(t *testing.T) {
+	target := date(2026, time.December, 1)
+	current := port.Category{
+		ID: uuid.New(),
+		Goal: port.Goal{
+			GoalType:         null.StringFrom(port.GoalTargetBalance),
+			GoalAmount:       null.IntFrom(120000),
+			GoalCurrencyCode: null.StringFrom("USD"),
+			GoalTargetDate:   &target,
+		},
+	}
+
+	t.Run("switching type drops the target date", func(t *testing.T) {
+		repo := &stubCategoryRepo{category: current}
+		uow := &stubUnitOfWork{}
+		svc := New(repo, uow, noopLogger{})
+
+		input := port.UpdateCategory{Goal: port.Goal{GoalType: null.StringFrom(port.GoalMonthlyFunding)}}
+		require.NoError(t, svc.Update(context.Background(), input, dafi.FilterBy("id", dafi.Equal, current.ID)...))
+
+		require.NotNil(t, repo.goal)
+		assert.Equal(t, port.GoalMonthlyFunding, repo.goal.GoalType.String)
+		assert.Equal(t, int64(120000), repo.goal.GoalAmount.Int64)
+		assert.Nil(t, repo.goal.GoalTargetDate)
+		assert.True(t, uow.committed)
+	})
+
+	t.Run("clear goal", func(t *testing.T) {
+		repo := &stubCategoryRepo{category: current}
+		svc := New(repo, &stubUnitOfWork{}, noopLogger{})
+
+		input := port.UpdateCategory{ClearGoal: true}
+		require.NoError(t, svc.Update(context.Background(), input, dafi.FilterBy("id", dafi.Equal, current.ID)...))
+
+		require.NotNil(t, repo.goal)
+		assert.Equal(t, port.Goal{}, *repo.goal)
+	})
+
+	t.Run("incomplete goal is rejected", func(t *testing.T) {
+		repo := &stubCategoryRepo{category: port.Category{ID: uuid.New()}}
+		svc := New(repo, &stubUnitOfWork{}, noopLogger{})
+
+		input := port.UpdateCategory{Goal: port.Goal{GoalType: null.StringFrom(port.GoalSpendingCap)}}
+		err := svc.Update(context.Background(), input)
+		require.Error(t, err)
+
+		oopsErr, ok := oops.AsOops(err)
+		require.True(t, ok)
+		assert.Equal(t, apperrors.CodeValidation, oopsErr.Code())
+		assert.Nil(t, repo.goal)
+	})
+}
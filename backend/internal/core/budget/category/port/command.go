@@ -16,23 +16,35 @@ type CreateCategory struct {
 	Icon           null.String `json:"icon"`
 	Color          null.String `json:"color"`
 	IsActive       bool        `json:"isActive"`
+	RolloverPolicy string      `json:"rolloverPolicy"`
+	Goal
 }
 
 func (c CreateCategory) Validate(ctx context.Context) error {
-	return validation.ValidateStruct(ctx, &c,
+	err := validation.ValidateStruct(ctx, &c,
 		validation.Field(&c.ID, validation.Required, validation.IsUUID),
 		validation.Field(&c.Name, validation.Required, validation.Length(2, 255)),
 		validation.Field(&c.Icon, validation.NilOrNotEmpty, validation.Length(1, 50)),
 		validation.Field(&c.Color, validation.NilOrNotEmpty, validation.Length(4, 7)),
+		validation.Field(&c.RolloverPolicy, validation.In(RolloverCarry, RolloverReset, RolloverCarryPositive)),
 	)
+	if err != nil {
+		return err
+	}
+
+	return c.Goal.Validate(ctx)
 }
 
 type UpdateCategory struct {
-	ParentID *uuid.UUID  `json:"parentId"`
-	Name     null.String `json:"name"`
-	Icon     null.String `json:"icon"`
-	Color    null.String `json:"color"`
-	IsActive null.Bool   `json:"isActive"`
+	ParentID       *uuid.UUID  `json:"parentId"`
+	Name           null.String `json:"name"`
+	Icon           null.String `json:"icon"`
+	Color          null.String `json:"color"`
+	IsActive       null.Bool   `json:"isActive"`
+	RolloverPolicy null.String `json:"rolloverPolicy"`
+	Goal
+	// ClearGoal removes the goal of the category; it cannot be combined with goal fields.
+	ClearGoal bool `json:"clearGoal"`
 }
 
 func (u UpdateCategory) Validate(ctx context.Context) error {
@@ -40,5 +52,52 @@ func (u UpdateCategory) Validate(ctx context.Context) error {
 		validation.Field(&u.Name, validation.NilOrNotEmpty, validation.Length(2, 255)),
 		validation.Field(&u.Icon, validation.NilOrNotEmpty, validation.Length(1, 50)),
 		validation.Field(&u.Color, validation.NilOrNotEmpty, validation.Length(4, 7)),
+		validation.Field(&u.RolloverPolicy, validation.NilOrNotEmpty, validation.In(RolloverCarry, RolloverReset, RolloverCarryPositive)),
+		validation.Field(&u.ClearGoal, validation.When(u.HasGoalChanges(), validation.Empty)),
+	)
+}
+
+// HasGoalChanges reports whether the update sets any goal field.
+func (u UpdateCategory) HasGoalChanges() bool {
+	return u.Goal != Goal{}
+}
+
+// Merge returns the goal left on a category with the given current goal once the
+// update is applied.
+func (u UpdateCategory) Merge(current Goal) Goal {
+	if u.ClearGoal {
+		return Goal{}
+	}
+
+	merged := current
+	if u.GoalType.Valid {
+		merged.GoalType = u.GoalType
+	}
+	if u.GoalAmount.Valid {
+		merged.GoalAmount = u.GoalAmount
+	}
+	if u.GoalCurrencyCode.Valid {
+		merged.GoalCurrencyCode = u.GoalCurrencyCode
+	}
+	if u.GoalTargetDate != nil {
+		merged.GoalTargetDate = u.GoalTargetDate
+	}
+	if merged.GoalType.String != GoalTargetBalance {
+		merged.GoalTargetDate = nil
+	}
+
+	return merged
+}
+
+// Validate checks that a goal is either fully unset or complete for its type. Updates
+// validate the goal they leave on the category, see Merge.
+func (g Goal) Validate(ctx context.Context) error {
+	hasGoal := g.GoalType.Valid
+
+	return validation.ValidateStruct(ctx, &g,
+		validation.Field(&g.GoalType, validation.NilOrNotEmpty, validation.In(GoalTargetBalance, GoalMonthlyFunding, GoalSpendingCap)),
+		validation.Field(&g.GoalAmount, validation.When(hasGoal, validation.Required, validation.Min(int64(1))).Else(validation.Empty)),
+		validation.Field(&g.GoalCurrencyCode, validation.When(hasGoal, validation.Required, validation.Length(3, 3)).Else(validation.Empty)),
+		validation.Field(&g.GoalTargetDate, validation.When(g.GoalType.String == GoalTargetBalance, validation.Required).Else(validation.Nil)),
 	)
 }
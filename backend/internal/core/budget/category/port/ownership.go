@@ -0,0 +1,56 @@
+package port
+
+import (
+	"context"
+
+	"backend/infra/dafi"
+	apperrors "backend/port/errors"
+
+	"github.com/google/uuid"
+	"github.com/samber/oops"
+)
+
+// CheckOwnership makes sure categoryID is a category of organizationID and, when given,
+// subcategoryID is a subcategory of it. Features that file money under a category
+// share it so a foreign or mismatched category is rejected the same way everywhere.
+func CheckOwnership(ctx context.Context, repo Repository, organizationID string, categoryID uuid.UUID, subcategoryID *uuid.UUID) error {
+	category, err := findInOrganization(ctx, repo, categoryID, organizationID)
+	if err != nil {
+		return err
+	}
+
+	if subcategoryID == nil {
+		return nil
+	}
+
+	subcategory, err := findInOrganization(ctx, repo, *subcategoryID, organizationID)
+	if err != nil {
+		return err
+	}
+
+	if subcategory.ParentID == nil || *subcategory.ParentID != category.ID {
+		return oops.WithContext(ctx).In(apperrors.LayerService).
+			Code(apperrors.CodeValidation).
+			Public("The subcategory must belong to the given category.").
+			Errorf("category %s is not a subcategory of %s", subcategory.ID, category.ID)
+	}
+
+	return nil
+}
+
+func findInOrganization(ctx context.Context, repo Repository, id uuid.UUID, organizationID string) (Category, error) {
+	criteria := dafi.Where("id", dafi.Equal, id.String()).And("organizationId", dafi.Equal, organizationID)
+
+	category, err := repo.FindOne(ctx, criteria)
+	if err != nil {
+		if oopsErr, ok := oops.AsOops(err); ok && oopsErr.Code() == apperrors.CodeNotFound {
+			return Category{}, oops.WithContext(ctx).In(apperrors.LayerService).
+				Code(apperrors.CodeValidation).
+				Public("The category does not belong to this organization.").
+				Errorf("category %s not found in organization %s", id, organizationID)
+		}
+		return Category{}, err
+	}
+
+	return category, nil
+}
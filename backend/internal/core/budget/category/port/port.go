@@ -1,15 +1,29 @@
 package port
 
-import basedomain "backend/port"
+import (
+	"context"
+	"time"
+
+	"backend/infra/dafi"
+	basedomain "backend/port"
+
+	"github.com/google/uuid"
+)
 
 type Repository interface {
 	basedomain.RepositoryCommand[CreateCategory, UpdateCategory]
 	basedomain.RepositoryQuery[Category]
 	basedomain.RepositoryTx[Repository]
+	// UpdateGoal replaces the goal of the matching categories, nulls included.
+	UpdateGoal(ctx context.Context, goal Goal, filters ...dafi.Filter) error
+	// GoalActivity totals the allocations and transactions of a category and its
+	// subcategories in currencyCode, split at monthStart.
+	GoalActivity(ctx context.Context, categoryID uuid.UUID, currencyCode string, monthStart time.Time) (GoalActivity, error)
 }
 
 type Service interface {
 	basedomain.UseCaseCommand[CreateCategory, UpdateCategory]
 	basedomain.UseCaseQuery[Category]
 	basedomain.UseCaseTx[Service]
+	GoalProgress(ctx context.Context, id uuid.UUID, asOf time.Time) (GoalProgress, error)
 }
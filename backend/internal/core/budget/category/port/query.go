@@ -3,10 +3,26 @@ package port
 import (
 	"time"
 
+	"backend/infra/money"
 	"github.com/google/uuid"
 	"github.com/guregu/null/v6"
 )
 
+// Rollover policies decide what happens to the amount left on a category when the
+// next budget month is opened.
+const (
+	RolloverCarry         = "carry"          // carry the balance as is, overspending included
+	RolloverReset         = "reset"          // start the next month from zero
+	RolloverCarryPositive = "carry_positive" // carry unspent money, drop overspending
+)
+
+// Goal types a category can be given. Goal amounts are minor units of GoalCurrencyCode.
+const (
+	GoalTargetBalance  = "target_balance"  // save GoalAmount by GoalTargetDate
+	GoalMonthlyFunding = "monthly_funding" // assign GoalAmount every month
+	GoalSpendingCap    = "spending_cap"    // spend up to GoalAmount a month
+)
+
 type Category struct {
 	ID             uuid.UUID   `json:"id"`
 	OrganizationID string      `json:"organizationId"`
@@ -15,6 +31,48 @@ type Category struct {
 	Icon           null.String `json:"icon"`
 	Color          null.String `json:"color"`
 	IsActive       bool        `json:"isActive"`
+	RolloverPolicy string      `json:"rolloverPolicy"`
 	CreatedAt      time.Time   `json:"createdAt"`
 	UpdatedAt      time.Time   `json:"updatedAt"`
+	Goal
+}
+
+// Goal is the optional funding goal of a category. Every field is null when the
+// category has no goal; GoalTargetDate is only set for target_balance goals.
+type Goal struct {
+	GoalType         null.String `json:"goalType"`
+	GoalAmount       null.Int    `json:"goalAmount"`
+	GoalCurrencyCode null.String `json:"goalCurrencyCode"`
+	GoalTargetDate   *time.Time  `json:"goalTargetDate"`
+}
+
+// GoalProgress tells how a category stands against its goal in the month of Month and
+// Year. Amounts are minor units of CurrencyCode: Balance is what was assigned to the
+// category and its subcategories minus what they spent before the month, Assigned and
+// Activity are the same totals for the month itself. Needed is what is still to be
+// assigned this month to stay on track and Remaining what is left to reach the goal
+// (or, for a spending cap, what can still be spent this month).
+type GoalProgress struct {
+	CategoryID   uuid.UUID   `json:"categoryId"`
+	GoalType     string      `json:"goalType"`
+	CurrencyCode string      `json:"currencyCode"`
+	GoalAmount   money.Minor `json:"goalAmount"`
+	TargetDate   *time.Time  `json:"targetDate"`
+	Month        int16       `json:"month"`
+	Year         int16       `json:"year"`
+	Balance      money.Minor `json:"balance"`
+	Assigned     money.Minor `json:"assigned"`
+	Activity     money.Minor `json:"activity"`
+	Needed       money.Minor `json:"needed"`
+	Remaining    money.Minor `json:"remaining"`
+	OnTrack      bool        `json:"onTrack"`
+}
+
+// GoalActivity is the history of a category and its subcategories, split at the start
+// of a month, in the currency of its goal.
+type GoalActivity struct {
+	AssignedBefore money.Minor
+	ActivityBefore money.Minor
+	Assigned       money.Minor
+	Activity       money.Minor
 }
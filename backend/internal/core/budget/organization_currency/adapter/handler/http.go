@@ -6,6 +6,7 @@ import (
 	apperrors "backend/port/errors"
 	"backend/infra/dafi"
 	"backend/infra/httpresponse"
+	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
 	"github.com/samber/oops"
 )
@@ -95,3 +96,39 @@ func (h HTTP) Delete(c echo.Context) error {
 
 	return httpresponse.NoContent(c)
 }
+
+func (h HTTP) FindRates(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return oops.WithContext(ctx).In(apperrors.LayerHandler).Code(apperrors.CodeBadRequest).Wrap(err)
+	}
+
+	rates, err := h.svc.FindRates(ctx, id)
+	if err != nil {
+		return oops.WithContext(ctx).In(apperrors.LayerHandler).Wrap(err)
+	}
+
+	return httpresponse.OK(c, rates)
+}
+
+func (h HTTP) AddRate(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return oops.WithContext(ctx).In(apperrors.LayerHandler).Code(apperrors.CodeBadRequest).Wrap(err)
+	}
+
+	var input port.CreateHistoricalRate
+	if err := c.Bind(&input); err != nil {
+		return oops.WithContext(ctx).In(apperrors.LayerHandler).Code(apperrors.CodeBadRequest).Wrap(err)
+	}
+
+	if err := h.svc.AddRate(ctx, id, input); err != nil {
+		return oops.WithContext(ctx).In(apperrors.LayerHandler).Wrap(err)
+	}
+
+	return httpresponse.Created(c, nil)
+}
@@ -8,6 +8,7 @@ import (
 	"backend/adapter/database"
 	"backend/core/budget/organization_currency/port"
 	"backend/infra/dafi"
+	"backend/infra/money"
 	"backend/infra/sqlcraft"
 	basedomain "backend/port"
 	apperrors "backend/port/errors"
@@ -45,6 +46,24 @@ var sqlColumnByDomainField = map[string]string{
 	"updatedAt":      "updated_at",
 }
 
+const rateTableName = "budget.exchange_rates"
+
+var rateColumns = []string{
+	"id",
+	"organization_id",
+	"currency_code",
+	"rate",
+	"effective_from",
+	"created_at",
+	"updated_at",
+}
+
+var rateSQLColumnByDomainField = map[string]string{
+	"organizationId": "organization_id",
+	"currencyCode":   "currency_code",
+	"effectiveFrom":  "effective_from",
+}
+
 type postgres struct {
 	db     dbConn
 	logger basedomain.Logger
@@ -254,3 +273,84 @@ func (r postgres) Delete(ctx context.Context, filters ...dafi.Filter) error {
 
 	return nil
 }
+
+func (r postgres) FindRates(ctx context.Context, organizationID, currencyCode string) (basedomain.List[port.HistoricalRate], error) {
+	query := sqlcraft.Select(rateColumns...).
+		From(rateTableName).
+		Where(dafi.FilterBy("organizationId", dafi.Equal, organizationID).And("currencyCode", dafi.Equal, currencyCode)...).
+		OrderBy(dafi.Sort{Field: "effectiveFrom", Type: dafi.Desc}).
+		SQLColumnByDomainField(rateSQLColumnByDomainField)
+
+	result, err := query.ToSQL()
+	if err != nil {
+		return nil, oops.WithContext(ctx).In(apperrors.LayerRepository).Wrap(err)
+	}
+
+	r.logger.WithContext(ctx).Debug("executing query", "sql", result.SQL)
+
+	rows, err := r.db.Query(ctx, result.SQL, result.Args...)
+	if err != nil {
+		return nil, oops.WithContext(ctx).In(apperrors.LayerRepository).Wrap(err)
+	}
+	defer rows.Close()
+
+	var rates basedomain.List[port.HistoricalRate]
+	for rows.Next() {
+		var rate port.HistoricalRate
+		err = rows.Scan(
+			&rate.ID,
+			&rate.OrganizationID,
+			&rate.CurrencyCode,
+			&rate.Rate,
+			&rate.EffectiveFrom,
+			&rate.CreatedAt,
+			&rate.UpdatedAt,
+		)
+		if err != nil {
+			return nil, oops.WithContext(ctx).In(apperrors.LayerRepository).Wrap(err)
+		}
+		rates = append(rates, rate)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, oops.WithContext(ctx).In(apperrors.LayerRepository).Wrap(err)
+	}
+
+	return rates, nil
+}
+
+const saveRateQuery = `
+INSERT INTO budget.exchange_rates (id, organization_id, currency_code, rate, effective_from, created_at, updated_at)
+VALUES ($1, $2, $3, $4, $5, $6, $6)
+ON CONFLICT (organization_id, currency_code, effective_from)
+DO UPDATE SET rate = EXCLUDED.rate, updated_at = EXCLUDED.updated_at`
+
+func (r postgres) SaveRate(ctx context.Context, organizationID, currencyCode string, input port.CreateHistoricalRate) error {
+	r.logger.WithContext(ctx).Debug("executing query", "sql", saveRateQuery)
+
+	_, err := r.db.Exec(ctx, saveRateQuery, input.ID, organizationID, currencyCode, input.Rate, input.EffectiveFrom, time.Now())
+	if err != nil {
+		return oops.WithContext(ctx).In(apperrors.LayerRepository).Wrap(err)
+	}
+
+	return nil
+}
+
+const rateOnQuery = `SELECT budget.rate_on($1, $2, $3)`
+
+func (r postgres) RateOn(ctx context.Context, organizationID, currencyCode string, on time.Time) (money.ExchangeRate, error) {
+	r.logger.WithContext(ctx).Debug("executing query", "sql", rateOnQuery)
+
+	var rate money.NullExchangeRate
+	if err := r.db.QueryRow(ctx, rateOnQuery, organizationID, currencyCode, on).Scan(&rate); err != nil {
+		return 0, oops.WithContext(ctx).In(apperrors.LayerRepository).Wrap(err)
+	}
+
+	if !rate.Valid {
+		return 0, oops.WithContext(ctx).In(apperrors.LayerRepository).
+			Code(apperrors.CodeNotFound).
+			Errorf("organization %s has no rate for %s", organizationID, currencyCode)
+	}
+
+	return rate.Rate, nil
+}
@@ -10,6 +10,7 @@ import (
 	"backend/infra/money"
 	basedomain "backend/port"
 	apperrors "backend/port/errors"
+	"github.com/google/uuid"
 	"github.com/samber/oops"
 )
 
@@ -21,23 +22,32 @@ type service struct {
 	repo         port.Repository
 	currencyRepo currencypkg.Repository
 	txnRepo      txnport.Repository
+	uow          basedomain.UnitOfWork
+	tx           basedomain.Transaction
 	logger       basedomain.Logger
 }
 
-func New(repo port.Repository, currencyRepo currencypkg.Repository, txnRepo txnport.Repository, logger basedomain.Logger) port.Service {
+func New(repo port.Repository, currencyRepo currencypkg.Repository, txnRepo txnport.Repository, uow basedomain.UnitOfWork, logger basedomain.Logger) port.Service {
 	return service{
 		repo:         repo,
 		currencyRepo: currencyRepo,
 		txnRepo:      txnRepo,
+		uow:          uow,
 		logger:       logger.With("component", "organization_currency.service"),
 	}
 }
 
 func (s service) WithTx(tx basedomain.Transaction) port.Service {
+	return s.withTx(tx)
+}
+
+func (s service) withTx(tx basedomain.Transaction) service {
 	return service{
 		repo:         s.repo.WithTx(tx),
 		currencyRepo: s.currencyRepo,
 		txnRepo:      s.txnRepo,
+		uow:          s.uow,
+		tx:           tx,
 		logger:       s.logger,
 	}
 }
@@ -157,7 +167,14 @@ func (s service) Create(ctx context.Context, input port.CreateOrganizationCurren
 		return oops.WithContext(ctx).In(apperrors.LayerService).Wrap(err)
 	}
 
-	if err := s.repo.Create(ctx, input); err != nil {
+	err := basedomain.Atomically(ctx, s.uow, s.tx, s.withTx, func(txSvc service) error {
+		if err := txSvc.repo.Create(ctx, input); err != nil {
+			return err
+		}
+
+		return txSvc.repo.SaveRate(ctx, input.OrganizationID, input.CurrencyCode, initialRate(input))
+	})
+	if err != nil {
 		return oops.WithContext(ctx).In(apperrors.LayerService).Wrap(err)
 	}
 
@@ -176,7 +193,20 @@ func (s service) CreateBulk(ctx context.Context, inputs basedomain.List[port.Cre
 		}
 	}
 
-	if err := s.repo.CreateBulk(ctx, inputs); err != nil {
+	err := basedomain.Atomically(ctx, s.uow, s.tx, s.withTx, func(txSvc service) error {
+		if err := txSvc.repo.CreateBulk(ctx, inputs); err != nil {
+			return err
+		}
+
+		for _, in := range inputs {
+			if err := txSvc.repo.SaveRate(ctx, in.OrganizationID, in.CurrencyCode, initialRate(in)); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
 		return oops.WithContext(ctx).In(apperrors.LayerService).Wrap(err)
 	}
 
@@ -229,7 +259,23 @@ func (s service) Update(ctx context.Context, input port.UpdateOrganizationCurren
 		}
 	}
 
-	if err := s.repo.Update(ctx, patched, filters...); err != nil {
+	err = basedomain.Atomically(ctx, s.uow, s.tx, s.withTx, func(txSvc service) error {
+		if err := txSvc.repo.Update(ctx, patched, filters...); err != nil {
+			return err
+		}
+
+		if !patched.Rate.Valid {
+			return nil
+		}
+
+		// A rate set directly applies from today on; earlier dates keep their rate.
+		return txSvc.repo.SaveRate(ctx, current.OrganizationID, current.CurrencyCode, port.CreateHistoricalRate{
+			ID:            uuid.New(),
+			Rate:          patched.Rate.Rate,
+			EffectiveFrom: today(),
+		})
+	})
+	if err != nil {
 		return oops.WithContext(ctx).In(apperrors.LayerService).Wrap(err)
 	}
 
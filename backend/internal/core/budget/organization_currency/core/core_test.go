@@ -58,6 +58,36 @@ func (m *mockOrgRepo) WithTx(tx basedomain.Transaction) port.Repository {
 	return args.Get(0).(port.Repository)
 }
 
+func (m *mockOrgRepo) FindRates(ctx context.Context, organizationID, currencyCode string) (basedomain.List[port.HistoricalRate], error) {
+	args := m.Called(ctx, organizationID, currencyCode)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(basedomain.List[port.HistoricalRate]), args.Error(1)
+}
+
+func (m *mockOrgRepo) SaveRate(ctx context.Context, organizationID, currencyCode string, input port.CreateHistoricalRate) error {
+	return m.Called(ctx, organizationID, currencyCode, input).Error(0)
+}
+
+func (m *mockOrgRepo) RateOn(ctx context.Context, organizationID, currencyCode string, on time.Time) (money.ExchangeRate, error) {
+	args := m.Called(ctx, organizationID, currencyCode, on)
+	return args.Get(0).(money.ExchangeRate), args.Error(1)
+}
+
+type stubTransaction struct{}
+
+func (stubTransaction) GetTx() basedomain.Tx { return nil }
+
+type stubUnitOfWork struct{}
+
+func (stubUnitOfWork) Begin(context.Context) (basedomain.Transaction, error) {
+	return stubTransaction{}, nil
+}
+
+func (stubUnitOfWork) Commit(context.Context, basedomain.Transaction) error   { return nil }
+func (stubUnitOfWork) Rollback(context.Context, basedomain.Transaction) error { return nil }
+
 type mockCurrencyRepo struct {
 	mock.Mock
 }
@@ -132,11 +162,50 @@ func (s *stubTxnRepo) Delete(ctx context.Context, filters ...dafi.Filter) error
 	return nil
 }
 
+func (s *stubTxnRepo) ReplaceSplits(ctx context.Context, transactionID uuid.UUID, organizationID string, splits []transactionport.SplitLine) error {
+	_ = ctx
+	_ = transactionID
+	_ = organizationID
+	_ = splits
+	return nil
+}
+
+func (s *stubTxnRepo) ReplaceTags(ctx context.Context, transactionID uuid.UUID, organizationID string, tagIDs []uuid.UUID) error {
+	_ = ctx
+	_ = transactionID
+	_ = organizationID
+	_ = tagIDs
+	return nil
+}
+
+func (s *stubTxnRepo) MarkReconciled(ctx context.Context, accountID, reconciliationID uuid.UUID, through time.Time) (int64, error) {
+	_ = ctx
+	_ = accountID
+	_ = reconciliationID
+	_ = through
+	return 0, nil
+}
+
+func (s *stubTxnRepo) Unlock(ctx context.Context, id uuid.UUID) error {
+	_ = ctx
+	_ = id
+	return nil
+}
+
+func (s *stubTxnRepo) Categorize(ctx context.Context, id uuid.UUID, categoryID uuid.UUID, subcategoryID, budgetID *uuid.UUID) error {
+	_ = ctx
+	_ = id
+	_ = categoryID
+	_ = subcategoryID
+	_ = budgetID
+	return nil
+}
+
 func (s *stubTxnRepo) WithTx(basedomain.Transaction) transactionport.Repository { return s }
 
-func mustExchangeRate(t *testing.T, f float64) money.ExchangeRate {
+func mustExchangeRate(t *testing.T, s string) money.ExchangeRate {
 	t.Helper()
-	r, err := money.ParseExchangeRate(f)
+	r, err := money.ParseExchangeRate(s)
 	require.NoError(t, err)
 	return r
 }
@@ -145,7 +214,7 @@ func TestService_FindAll_invalidRelation(t *testing.T) {
 	org := new(mockOrgRepo)
 	cur := new(mockCurrencyRepo)
 	txn := &stubTxnRepo{}
-	svc := New(org, cur, txn, noopLogger{})
+	svc := New(org, cur, txn, stubUnitOfWork{}, noopLogger{})
 
 	_, err := svc.FindAll(context.Background(), dafi.Criteria{
 		Relations: []string{"unknown"},
@@ -159,7 +228,7 @@ func TestService_FindAll_noRelations_currencyNil(t *testing.T) {
 	org := new(mockOrgRepo)
 	cur := new(mockCurrencyRepo)
 	txn := &stubTxnRepo{}
-	svc := New(org, cur, txn, noopLogger{})
+	svc := New(org, cur, txn, stubUnitOfWork{}, noopLogger{})
 
 	now := time.Now()
 	row := port.OrganizationCurrency{
@@ -187,7 +256,7 @@ func TestService_FindAll_withCurrencies_mapsNested(t *testing.T) {
 	org := new(mockOrgRepo)
 	cur := new(mockCurrencyRepo)
 	txn := &stubTxnRepo{}
-	svc := New(org, cur, txn, noopLogger{})
+	svc := New(org, cur, txn, stubUnitOfWork{}, noopLogger{})
 
 	now := time.Now()
 	usd := port.OrganizationCurrency{
@@ -204,7 +273,7 @@ func TestService_FindAll_withCurrencies_mapsNested(t *testing.T) {
 		OrganizationID: "org1",
 		CurrencyCode:   "EUR",
 		IsBase:         false,
-		Rate:           mustExchangeRate(t, 0.92),
+		Rate:           mustExchangeRate(t, "0.92"),
 		CreatedAt:      now,
 		UpdatedAt:      now,
 	}
@@ -244,7 +313,7 @@ func TestService_FindOne_withCurrencies(t *testing.T) {
 	org := new(mockOrgRepo)
 	cur := new(mockCurrencyRepo)
 	txn := &stubTxnRepo{}
-	svc := New(org, cur, txn, noopLogger{})
+	svc := New(org, cur, txn, stubUnitOfWork{}, noopLogger{})
 
 	now := time.Now()
 	row := port.OrganizationCurrency{
@@ -279,7 +348,7 @@ func TestService_Update_isBase_change_blocked_when_org_has_transactions(t *testi
 	org := new(mockOrgRepo)
 	cur := new(mockCurrencyRepo)
 	txn := &stubTxnRepo{orgHasTransactions: true}
-	svc := New(org, cur, txn, noopLogger{})
+	svc := New(org, cur, txn, stubUnitOfWork{}, noopLogger{})
 
 	now := time.Now()
 	id := uuid.MustParse("11111111-1111-1111-1111-111111111111")
@@ -288,7 +357,7 @@ func TestService_Update_isBase_change_blocked_when_org_has_transactions(t *testi
 		OrganizationID: "org1",
 		CurrencyCode:   "EUR",
 		IsBase:         false,
-		Rate:           mustExchangeRate(t, 0.92),
+		Rate:           mustExchangeRate(t, "0.92"),
 		CreatedAt:      now,
 		UpdatedAt:      now,
 	}
@@ -310,7 +379,7 @@ func TestService_Update_isBase_change_allowed_when_no_transactions(t *testing.T)
 	org := new(mockOrgRepo)
 	cur := new(mockCurrencyRepo)
 	txn := &stubTxnRepo{}
-	svc := New(org, cur, txn, noopLogger{})
+	svc := New(org, cur, txn, stubUnitOfWork{}, noopLogger{})
 
 	now := time.Now()
 	id := uuid.MustParse("11111111-1111-1111-1111-111111111111")
@@ -319,12 +388,16 @@ func TestService_Update_isBase_change_allowed_when_no_transactions(t *testing.T)
 		OrganizationID: "org1",
 		CurrencyCode:   "EUR",
 		IsBase:         false,
-		Rate:           mustExchangeRate(t, 0.92),
+		Rate:           mustExchangeRate(t, "0.92"),
 		CreatedAt:      now,
 		UpdatedAt:      now,
 	}
 	org.On("FindOne", mock.Anything, mock.Anything).Return(current, nil)
+	org.On("WithTx", mock.Anything).Return(org)
 	org.On("Update", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	org.On("SaveRate", mock.Anything, "org1", "EUR", mock.MatchedBy(func(r port.CreateHistoricalRate) bool {
+		return r.Rate.IsOne()
+	})).Return(nil)
 
 	err := svc.Update(context.Background(), port.UpdateOrganizationCurrency{
 		IsBase: null.BoolFrom(true),
@@ -337,7 +410,7 @@ func TestService_Update_base_row_rate_must_be_one(t *testing.T) {
 	org := new(mockOrgRepo)
 	cur := new(mockCurrencyRepo)
 	txn := &stubTxnRepo{}
-	svc := New(org, cur, txn, noopLogger{})
+	svc := New(org, cur, txn, stubUnitOfWork{}, noopLogger{})
 
 	now := time.Now()
 	id := uuid.MustParse("11111111-1111-1111-1111-111111111111")
@@ -352,7 +425,7 @@ func TestService_Update_base_row_rate_must_be_one(t *testing.T) {
 	}
 	org.On("FindOne", mock.Anything, mock.Anything).Return(current, nil)
 
-	twoRate, err := money.ParseExchangeRate(2)
+	twoRate, err := money.ParseExchangeRate("2")
 	require.NoError(t, err)
 	err = svc.Update(context.Background(), port.UpdateOrganizationCurrency{
 		Rate: money.NullExchangeRateFrom(twoRate),
@@ -361,6 +434,67 @@ func TestService_Update_base_row_rate_must_be_one(t *testing.T) {
 	org.AssertNotCalled(t, "Update")
 }
 
+func TestService_AddRate_backdated_refreshes_current_rate(t *testing.T) {
+	org := new(mockOrgRepo)
+	cur := new(mockCurrencyRepo)
+	txn := &stubTxnRepo{}
+	svc := New(org, cur, txn, stubUnitOfWork{}, noopLogger{})
+
+	id := uuid.MustParse("22222222-2222-2222-2222-222222222222")
+	current := port.OrganizationCurrency{
+		ID:             id,
+		OrganizationID: "org1",
+		CurrencyCode:   "EUR",
+		Rate:           mustExchangeRate(t, "0.92"),
+	}
+	input := port.CreateHistoricalRate{
+		ID:            uuid.New(),
+		Rate:          mustExchangeRate(t, "0.9"),
+		EffectiveFrom: time.Date(2026, time.January, 1, 15, 0, 0, 0, time.UTC),
+	}
+	org.On("FindOne", mock.Anything, mock.Anything).Return(current, nil)
+	org.On("WithTx", mock.Anything).Return(org)
+	org.On("SaveRate", mock.Anything, "org1", "EUR", mock.MatchedBy(func(r port.CreateHistoricalRate) bool {
+		return r.EffectiveFrom.Equal(time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC))
+	})).Return(nil)
+	org.On("RateOn", mock.Anything, "org1", "EUR", mock.Anything).Return(mustExchangeRate(t, "0.92"), nil)
+	org.On("Update", mock.Anything, mock.MatchedBy(func(u port.UpdateOrganizationCurrency) bool {
+		return u.Rate.Valid && u.Rate.Rate == mustExchangeRate(t, "0.92")
+	}), mock.Anything).Return(nil)
+
+	err := svc.AddRate(context.Background(), id, input)
+	require.NoError(t, err)
+	org.AssertExpectations(t)
+}
+
+func TestService_AddRate_base_currency_rejected(t *testing.T) {
+	org := new(mockOrgRepo)
+	cur := new(mockCurrencyRepo)
+	txn := &stubTxnRepo{}
+	svc := New(org, cur, txn, stubUnitOfWork{}, noopLogger{})
+
+	id := uuid.MustParse("11111111-1111-1111-1111-111111111111")
+	org.On("FindOne", mock.Anything, mock.Anything).Return(port.OrganizationCurrency{
+		ID:             id,
+		OrganizationID: "org1",
+		CurrencyCode:   "USD",
+		IsBase:         true,
+		Rate:           money.ExchangeRateOne(),
+	}, nil)
+
+	err := svc.AddRate(context.Background(), id, port.CreateHistoricalRate{
+		ID:            uuid.New(),
+		Rate:          mustExchangeRate(t, "1.1"),
+		EffectiveFrom: time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC),
+	})
+	require.Error(t, err)
+	org.AssertNotCalled(t, "SaveRate")
+
+	oopsErr, ok := oops.AsOops(err)
+	require.True(t, ok)
+	assert.Equal(t, apperrors.CodeValidation, oopsErr.Code())
+}
+
 type noopLogger struct{}
 
 func (noopLogger) With(...interface{}) basedomain.Logger { return noopLogger{} }
@@ -0,0 +1,104 @@
+package core
+
+import (
+	"context"
+	"time"
+
+	"backend/core/budget/organization_currency/port"
+	"backend/infra/dafi"
+	"backend/infra/money"
+	basedomain "backend/port"
+	apperrors "backend/port/errors"
+
+	"github.com/google/uuid"
+	"github.com/samber/oops"
+)
+
+// today is the current date in UTC, the day a rate set now takes effect.
+func today() time.Time {
+	return time.Now().UTC().Truncate(24 * time.Hour)
+}
+
+// initialRate is the first entry of the rate history of a new organization currency.
+func initialRate(input port.CreateOrganizationCurrency) port.CreateHistoricalRate {
+	return port.CreateHistoricalRate{
+		ID:            uuid.NewSHA1(input.ID, []byte("rate")),
+		Rate:          input.Rate,
+		EffectiveFrom: today(),
+	}
+}
+
+func (s service) FindRates(ctx context.Context, id uuid.UUID) (basedomain.List[port.HistoricalRate], error) {
+	oc, err := s.repo.FindOne(ctx, dafi.Where("id", dafi.Equal, id))
+	if err != nil {
+		return nil, oops.WithContext(ctx).In(apperrors.LayerService).Wrap(err)
+	}
+
+	rates, err := s.repo.FindRates(ctx, oc.OrganizationID, oc.CurrencyCode)
+	if err != nil {
+		return nil, oops.WithContext(ctx).In(apperrors.LayerService).Wrap(err)
+	}
+
+	return rates, nil
+}
+
+func (s service) AddRate(ctx context.Context, id uuid.UUID, input port.CreateHistoricalRate) error {
+	if err := input.Validate(ctx); err != nil {
+		return oops.WithContext(ctx).In(apperrors.LayerService).Code(apperrors.CodeValidation).Wrap(err)
+	}
+
+	oc, err := s.repo.FindOne(ctx, dafi.Where("id", dafi.Equal, id))
+	if err != nil {
+		return oops.WithContext(ctx).In(apperrors.LayerService).Wrap(err)
+	}
+
+	if oc.IsBase {
+		return oops.WithContext(ctx).In(apperrors.LayerService).
+			Code(apperrors.CodeValidation).
+			Public("The rate of the base currency is always 1.").
+			Errorf("%s is the base currency of organization %s", oc.CurrencyCode, oc.OrganizationID)
+	}
+
+	if !input.Rate.IsPositive() {
+		return oops.WithContext(ctx).In(apperrors.LayerService).Code(apperrors.CodeValidation).
+			Errorf("rate must be a positive number")
+	}
+
+	input.EffectiveFrom = input.EffectiveFrom.UTC().Truncate(24 * time.Hour)
+
+	err = basedomain.Atomically(ctx, s.uow, s.tx, s.withTx, func(txSvc service) error {
+		if err := txSvc.repo.SaveRate(ctx, oc.OrganizationID, oc.CurrencyCode, input); err != nil {
+			return err
+		}
+
+		if input.EffectiveFrom.After(today()) {
+			return nil
+		}
+
+		// A backdated rate may or may not be the latest one; the current rate is
+		// whatever the history says applies today.
+		current, err := txSvc.repo.RateOn(ctx, oc.OrganizationID, oc.CurrencyCode, today())
+		if err != nil {
+			return err
+		}
+
+		update := port.UpdateOrganizationCurrency{Rate: money.NullExchangeRateFrom(current)}
+		return txSvc.repo.Update(ctx, update, dafi.FilterBy("id", dafi.Equal, oc.ID)...)
+	})
+	if err != nil {
+		return oops.WithContext(ctx).In(apperrors.LayerService).Wrap(err)
+	}
+
+	s.logger.WithContext(ctx).Info("organization currency rate added", "currencyCode", oc.CurrencyCode, "effectiveFrom", input.EffectiveFrom)
+
+	return nil
+}
+
+func (s service) RateOn(ctx context.Context, organizationID, currencyCode string, on time.Time) (money.ExchangeRate, error) {
+	rate, err := s.repo.RateOn(ctx, organizationID, currencyCode, on)
+	if err != nil {
+		return 0, oops.WithContext(ctx).In(apperrors.LayerService).Wrap(err)
+	}
+
+	return rate, nil
+}
@@ -24,8 +24,9 @@ func Module(i do.Injector) {
 		repo := di.MustInvoke[port.Repository](i)
 		currencyRepo := di.MustInvoke[currencypkg.Repository](i)
 		txnRepo := di.MustInvoke[txnport.Repository](i)
+		uow := di.MustInvoke[basedomain.UnitOfWork](i)
 		logger := di.MustInvoke[basedomain.Logger](i)
-		return core.New(repo, currencyRepo, txnRepo, logger), nil
+		return core.New(repo, currencyRepo, txnRepo, uow, logger), nil
 	})
 
 	di.Provide(i, func(i do.Injector) (handler.HTTP, error) {
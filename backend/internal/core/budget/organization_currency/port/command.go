@@ -2,6 +2,7 @@ package port
 
 import (
 	"context"
+	"time"
 
 	"backend/adapter/validation"
 	"backend/infra/money"
@@ -32,3 +33,19 @@ type UpdateOrganizationCurrency struct {
 func (u UpdateOrganizationCurrency) Validate(ctx context.Context) error {
 	return validation.ValidateStruct(ctx, &u)
 }
+
+// CreateHistoricalRate records the rate of an organization currency from a date on. A
+// rate recorded again for the same date replaces the previous one.
+type CreateHistoricalRate struct {
+	ID            uuid.UUID          `json:"id"`
+	Rate          money.ExchangeRate `json:"rate"`
+	EffectiveFrom time.Time          `json:"effectiveFrom"`
+}
+
+func (c CreateHistoricalRate) Validate(ctx context.Context) error {
+	return validation.ValidateStruct(ctx, &c,
+		validation.Field(&c.ID, validation.Required, validation.IsUUID),
+		validation.Field(&c.Rate, validation.Required),
+		validation.Field(&c.EffectiveFrom, validation.Required),
+	)
+}
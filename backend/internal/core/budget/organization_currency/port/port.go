@@ -1,15 +1,37 @@
 package port
 
-import basedomain "backend/port"
+import (
+	"context"
+	"time"
+
+	"backend/infra/money"
+	basedomain "backend/port"
+
+	"github.com/google/uuid"
+)
 
 type Repository interface {
 	basedomain.RepositoryCommand[CreateOrganizationCurrency, UpdateOrganizationCurrency]
 	basedomain.RepositoryQuery[OrganizationCurrency]
 	basedomain.RepositoryTx[Repository]
+	// FindRates lists the rate history of a currency of an organization, newest first.
+	FindRates(ctx context.Context, organizationID, currencyCode string) (basedomain.List[HistoricalRate], error)
+	// SaveRate records a rate, replacing the one recorded for the same date if any.
+	SaveRate(ctx context.Context, organizationID, currencyCode string, input CreateHistoricalRate) error
+	// RateOn returns the rate in effect on a date; dates before the first recorded rate
+	// get that first rate.
+	RateOn(ctx context.Context, organizationID, currencyCode string, on time.Time) (money.ExchangeRate, error)
 }
 
 type Service interface {
 	basedomain.UseCaseCommand[CreateOrganizationCurrency, UpdateOrganizationCurrency]
 	basedomain.UseCaseQuery[OrganizationCurrency]
 	basedomain.UseCaseTx[Service]
+	// FindRates lists the rate history of an organization currency, newest first.
+	FindRates(ctx context.Context, id uuid.UUID) (basedomain.List[HistoricalRate], error)
+	// AddRate records the rate of an organization currency from a date on. The current
+	// rate follows when the date is not in the future.
+	AddRate(ctx context.Context, id uuid.UUID, input CreateHistoricalRate) error
+	// RateOn returns the rate of a currency of an organization in effect on a date.
+	RateOn(ctx context.Context, organizationID, currencyCode string, on time.Time) (money.ExchangeRate, error)
 }
@@ -30,3 +30,15 @@ type OrganizationCurrency struct {
 	UpdatedAt time.Time                     `json:"updatedAt"`
 	Currency  *OrganizationCurrencyCurrency `json:"currency,omitempty"`
 }
+
+// HistoricalRate is the rate of an organization currency from EffectiveFrom until the
+// next rate of the same currency takes over.
+type HistoricalRate struct {
+	ID             uuid.UUID          `json:"id"`
+	OrganizationID string             `json:"organizationId"`
+	CurrencyCode   string             `json:"currencyCode"`
+	Rate           money.ExchangeRate `json:"rate"`
+	EffectiveFrom  time.Time          `json:"effectiveFrom"`
+	CreatedAt      time.Time          `json:"createdAt"`
+	UpdatedAt      time.Time          `json:"updatedAt"`
+}
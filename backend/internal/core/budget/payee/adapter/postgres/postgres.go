@@ -0,0 +1,273 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"backend/adapter/database"
+	"backend/core/budget/payee/port"
+	"backend/infra/dafi"
+	"backend/infra/sqlcraft"
+	basedomain "backend/port"
+	apperrors "backend/port/errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/samber/oops"
+)
+
+type dbConn interface {
+	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+}
+
+const tableName = "budget.payees"
+
+var columns = []string{
+	"id",
+	"organization_id",
+	"name",
+	"aliases",
+	"patterns",
+	"default_category_id",
+	"default_subcategory_id",
+	"created_at",
+	"updated_at",
+}
+
+var sqlColumnByDomainField = map[string]string{
+	"id":                   "id",
+	"organizationId":       "organization_id",
+	"name":                 "name",
+	"defaultCategoryId":    "default_category_id",
+	"defaultSubcategoryId": "default_subcategory_id",
+	"createdAt":            "created_at",
+	"updatedAt":            "updated_at",
+}
+
+type postgres struct {
+	db     dbConn
+	logger basedomain.Logger
+}
+
+func NewPostgres(db database.PoolInterface, logger basedomain.Logger) port.Repository {
+	return postgres{
+		db:     db,
+		logger: logger.With("component", "payee.repository"),
+	}
+}
+
+func (r postgres) WithTx(tx basedomain.Transaction) port.Repository {
+	return postgres{
+		db:     tx.GetTx(),
+		logger: r.logger,
+	}
+}
+
+func (r postgres) FindOne(ctx context.Context, criteria dafi.Criteria) (port.Payee, error) {
+	query := sqlcraft.Select(columns...).
+		From(tableName).
+		Where(criteria.Filters...).
+		Limit(1).
+		SQLColumnByDomainField(sqlColumnByDomainField)
+
+	result, err := query.ToSQL()
+	if err != nil {
+		return port.Payee{}, oops.WithContext(ctx).In(apperrors.LayerRepository).Wrap(err)
+	}
+
+	r.logger.WithContext(ctx).Debug("executing query", "sql", result.SQL)
+
+	payee, err := scan(r.db.QueryRow(ctx, result.SQL, result.Args...))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return port.Payee{}, oops.WithContext(ctx).In(apperrors.LayerRepository).Code(apperrors.CodeNotFound).Wrap(err)
+		}
+		return port.Payee{}, oops.WithContext(ctx).In(apperrors.LayerRepository).Wrap(err)
+	}
+
+	return payee, nil
+}
+
+func (r postgres) FindAll(ctx context.Context, criteria dafi.Criteria) (basedomain.List[port.Payee], error) {
+	query := sqlcraft.Select(columns...).
+		From(tableName).
+		Where(criteria.Filters...).
+		OrderBy(criteria.Sorts...).
+		Limit(criteria.Pagination.PageSize).
+		Page(criteria.Pagination.PageNumber).
+		SQLColumnByDomainField(sqlColumnByDomainField)
+
+	result, err := query.ToSQL()
+	if err != nil {
+		return nil, oops.WithContext(ctx).In(apperrors.LayerRepository).Wrap(err)
+	}
+
+	r.logger.WithContext(ctx).Debug("executing query", "sql", result.SQL)
+
+	rows, err := r.db.Query(ctx, result.SQL, result.Args...)
+	if err != nil {
+		return nil, oops.WithContext(ctx).In(apperrors.LayerRepository).Wrap(err)
+	}
+	defer rows.Close()
+
+	var payees basedomain.List[port.Payee]
+	for rows.Next() {
+		payee, err := scan(rows)
+		if err != nil {
+			return nil, oops.WithContext(ctx).In(apperrors.LayerRepository).Wrap(err)
+		}
+		payees = append(payees, payee)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, oops.WithContext(ctx).In(apperrors.LayerRepository).Wrap(err)
+	}
+
+	return payees, nil
+}
+
+func (r postgres) Create(ctx context.Context, input port.CreatePayee) error {
+	return r.CreateBulk(ctx, basedomain.List[port.CreatePayee]{input})
+}
+
+func (r postgres) CreateBulk(ctx context.Context, inputs basedomain.List[port.CreatePayee]) error {
+	if inputs.IsEmpty() {
+		return nil
+	}
+
+	now := time.Now()
+	query := sqlcraft.InsertInto(tableName).WithColumns(columns...)
+	for _, input := range inputs {
+		query = query.WithValues(
+			input.ID,
+			input.OrganizationID,
+			input.Name,
+			orEmpty(input.Aliases),
+			orEmpty(input.Patterns),
+			input.DefaultCategoryID,
+			input.DefaultSubcategoryID,
+			now,
+			now,
+		)
+	}
+
+	result, err := query.ToSQL()
+	if err != nil {
+		return oops.WithContext(ctx).In(apperrors.LayerRepository).Wrap(err)
+	}
+
+	r.logger.WithContext(ctx).Debug("executing query", "sql", result.SQL, "count", len(inputs))
+
+	_, err = r.db.Exec(ctx, result.SQL, result.Args...)
+	if err != nil {
+		return r.wrapWriteError(ctx, err)
+	}
+
+	return nil
+}
+
+func (r postgres) Update(ctx context.Context, input port.UpdatePayee, filters ...dafi.Filter) error {
+	// A partial update cannot set a column to NULL, so the subcategory of the old
+	// category is cleared first.
+	if input.DefaultCategoryID != nil && input.DefaultSubcategoryID == nil {
+		if err := r.exec(ctx, sqlcraft.Update(tableName).
+			WithColumns("default_subcategory_id").
+			WithValues(nil).
+			Where(filters...).
+			SQLColumnByDomainField(sqlColumnByDomainField)); err != nil {
+			return err
+		}
+	}
+
+	return r.exec(ctx, sqlcraft.Update(tableName).
+		WithColumns("name", "aliases", "patterns", "default_category_id", "default_subcategory_id", "updated_at").
+		WithValues(
+			input.Name,
+			input.Aliases,
+			input.Patterns,
+			input.DefaultCategoryID,
+			input.DefaultSubcategoryID,
+			time.Now(),
+		).
+		Where(filters...).
+		SQLColumnByDomainField(sqlColumnByDomainField).
+		WithPartialUpdate())
+}
+
+func (r postgres) exec(ctx context.Context, query sqlcraft.UpdateQuery) error {
+	result, err := query.ToSQL()
+	if err != nil {
+		return oops.WithContext(ctx).In(apperrors.LayerRepository).Wrap(err)
+	}
+
+	r.logger.WithContext(ctx).Debug("executing query", "sql", result.SQL)
+
+	_, err = r.db.Exec(ctx, result.SQL, result.Args...)
+	if err != nil {
+		return r.wrapWriteError(ctx, err)
+	}
+
+	return nil
+}
+
+func (r postgres) Delete(ctx context.Context, filters ...dafi.Filter) error {
+	query := sqlcraft.DeleteFrom(tableName).
+		Where(filters...).
+		SQLColumnByDomainField(sqlColumnByDomainField)
+
+	result, err := query.ToSQL()
+	if err != nil {
+		return oops.WithContext(ctx).In(apperrors.LayerRepository).Wrap(err)
+	}
+
+	r.logger.WithContext(ctx).Debug("executing query", "sql", result.SQL)
+
+	_, err = r.db.Exec(ctx, result.SQL, result.Args...)
+	if err != nil {
+		return oops.WithContext(ctx).In(apperrors.LayerRepository).Wrap(err)
+	}
+
+	return nil
+}
+
+const pgErrUniqueViolation = "23505"
+
+func (r postgres) wrapWriteError(ctx context.Context, err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == pgErrUniqueViolation {
+		return oops.WithContext(ctx).In(apperrors.LayerRepository).
+			Code(apperrors.CodeConflict).
+			Public("A payee with this name already exists.").
+			Wrap(err)
+	}
+
+	return oops.WithContext(ctx).In(apperrors.LayerRepository).Wrap(err)
+}
+
+func scan(row pgx.Row) (port.Payee, error) {
+	var payee port.Payee
+	err := row.Scan(
+		&payee.ID,
+		&payee.OrganizationID,
+		&payee.Name,
+		&payee.Aliases,
+		&payee.Patterns,
+		&payee.DefaultCategoryID,
+		&payee.DefaultSubcategoryID,
+		&payee.CreatedAt,
+		&payee.UpdatedAt,
+	)
+
+	return payee, err
+}
+
+// orEmpty stores a missing list as an empty array rather than NULL.
+func orEmpty(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
@@ -0,0 +1,202 @@
+package core
+
+import (
+	"context"
+	"fmt"
+
+	categoryport "backend/core/budget/category/port"
+	"backend/core/budget/payee/port"
+	"backend/infra/dafi"
+	basedomain "backend/port"
+	apperrors "backend/port/errors"
+
+	"github.com/google/uuid"
+	"github.com/samber/oops"
+)
+
+type service struct {
+	repo         port.Repository
+	categoryRepo categoryport.Repository
+	logger       basedomain.Logger
+}
+
+func New(repo port.Repository, categoryRepo categoryport.Repository, logger basedomain.Logger) port.Service {
+	return service{
+		repo:         repo,
+		categoryRepo: categoryRepo,
+		logger:       logger.With("component", "payee.service"),
+	}
+}
+
+func (s service) WithTx(tx basedomain.Transaction) port.Service {
+	return service{
+		repo:         s.repo.WithTx(tx),
+		categoryRepo: s.categoryRepo.WithTx(tx),
+		logger:       s.logger,
+	}
+}
+
+func (s service) FindOne(ctx context.Context, criteria dafi.Criteria) (port.Payee, error) {
+	payee, err := s.repo.FindOne(ctx, criteria)
+	if err != nil {
+		return port.Payee{}, oops.WithContext(ctx).In(apperrors.LayerService).Wrap(err)
+	}
+
+	return payee, nil
+}
+
+func (s service) FindAll(ctx context.Context, criteria dafi.Criteria) (basedomain.List[port.Payee], error) {
+	payees, err := s.repo.FindAll(ctx, criteria)
+	if err != nil {
+		return nil, oops.WithContext(ctx).In(apperrors.LayerService).Wrap(err)
+	}
+
+	return payees, nil
+}
+
+func (s service) ForOrganization(ctx context.Context, organizationID string) ([]port.Payee, error) {
+	payees, err := s.repo.FindAll(ctx, dafi.Criteria{
+		Filters: dafi.FilterBy("organizationId", dafi.Equal, organizationID),
+		Sorts:   dafi.Sorts{{Field: "name", Type: dafi.Asc}},
+	})
+	if err != nil {
+		return nil, oops.WithContext(ctx).In(apperrors.LayerService).Wrap(err)
+	}
+
+	return payees, nil
+}
+
+func (s service) Create(ctx context.Context, input port.CreatePayee) error {
+	return s.CreateBulk(ctx, basedomain.List[port.CreatePayee]{input})
+}
+
+func (s service) CreateBulk(ctx context.Context, inputs basedomain.List[port.CreatePayee]) error {
+	for _, input := range inputs {
+		if err := input.Validate(ctx); err != nil {
+			return oops.WithContext(ctx).In(apperrors.LayerService).Code(apperrors.CodeValidation).Wrap(err)
+		}
+
+		if err := s.checkCategories(ctx, input.OrganizationID, input.DefaultCategoryID, input.DefaultSubcategoryID); err != nil {
+			return oops.WithContext(ctx).In(apperrors.LayerService).Wrap(err)
+		}
+
+		if err := s.checkNames(ctx, input.OrganizationID, input.ID, input.Name, input.Aliases); err != nil {
+			return oops.WithContext(ctx).In(apperrors.LayerService).Wrap(err)
+		}
+	}
+
+	if err := s.repo.CreateBulk(ctx, inputs); err != nil {
+		return oops.WithContext(ctx).In(apperrors.LayerService).Wrap(err)
+	}
+
+	s.logger.WithContext(ctx).Info("payees created", "count", len(inputs))
+
+	return nil
+}
+
+func (s service) Update(ctx context.Context, input port.UpdatePayee, filters ...dafi.Filter) error {
+	if err := input.Validate(ctx); err != nil {
+		return oops.WithContext(ctx).In(apperrors.LayerService).Code(apperrors.CodeValidation).Wrap(err)
+	}
+
+	payee, err := s.repo.FindOne(ctx, dafi.Criteria{Filters: filters})
+	if err != nil {
+		return oops.WithContext(ctx).In(apperrors.LayerService).Wrap(err)
+	}
+
+	if input.DefaultCategoryID != nil || input.DefaultSubcategoryID != nil {
+		categoryID, subcategoryID := payee.DefaultCategoryID, payee.DefaultSubcategoryID
+		if input.DefaultCategoryID != nil {
+			// A subcategory of the old category cannot stay under the new one.
+			categoryID, subcategoryID = input.DefaultCategoryID, nil
+		}
+		if input.DefaultSubcategoryID != nil {
+			subcategoryID = input.DefaultSubcategoryID
+		}
+
+		if err := s.checkCategories(ctx, payee.OrganizationID, categoryID, subcategoryID); err != nil {
+			return oops.WithContext(ctx).In(apperrors.LayerService).Wrap(err)
+		}
+	}
+
+	if input.Name.Valid || input.Aliases != nil {
+		name, aliases := payee.Name, payee.Aliases
+		if input.Name.Valid {
+			name = input.Name.String
+		}
+		if input.Aliases != nil {
+			aliases = input.Aliases
+		}
+
+		if err := s.checkNames(ctx, payee.OrganizationID, payee.ID, name, aliases); err != nil {
+			return oops.WithContext(ctx).In(apperrors.LayerService).Wrap(err)
+		}
+	}
+
+	if err := s.repo.Update(ctx, input, filters...); err != nil {
+		return oops.WithContext(ctx).In(apperrors.LayerService).Wrap(err)
+	}
+
+	s.logger.WithContext(ctx).Info("payee updated")
+
+	return nil
+}
+
+func (s service) Delete(ctx context.Context, filters ...dafi.Filter) error {
+	if err := s.repo.Delete(ctx, filters...); err != nil {
+		return oops.WithContext(ctx).In(apperrors.LayerService).Wrap(err)
+	}
+
+	s.logger.WithContext(ctx).Info("payee deleted")
+
+	return nil
+}
+
+// checkNames makes sure the name and aliases of a payee, once normalized, name no
+// other payee of the organization, so a description resolves to a single payee.
+func (s service) checkNames(ctx context.Context, organizationID string, id uuid.UUID, name string, aliases []string) error {
+	payees, err := s.ForOrganization(ctx, organizationID)
+	if err != nil {
+		return err
+	}
+
+	taken := map[string]string{}
+	for _, payee := range payees {
+		if payee.ID == id {
+			continue
+		}
+		taken[port.Normalize(payee.Name)] = payee.Name
+		for _, alias := range payee.Aliases {
+			taken[port.Normalize(alias)] = payee.Name
+		}
+	}
+
+	for _, n := range append([]string{name}, aliases...) {
+		normalized := port.Normalize(n)
+		if normalized == "" {
+			return oops.WithContext(ctx).In(apperrors.LayerService).
+				Code(apperrors.CodeValidation).
+				Public(fmt.Sprintf("%q has no letters or digits to match a description with.", n)).
+				Errorf("payee name %q normalizes to nothing", n)
+		}
+
+		if other, ok := taken[normalized]; ok {
+			return oops.WithContext(ctx).In(apperrors.LayerService).
+				Code(apperrors.CodeConflict).
+				Public(fmt.Sprintf("%q already names the payee %s.", n, other)).
+				Errorf("payee name %q is taken by %s", n, other)
+		}
+	}
+
+	return nil
+}
+
+// checkCategories makes sure a payee defaults to a category of its own organization,
+// and a subcategory of that category.
+func (s service) checkCategories(ctx context.Context, organizationID string, categoryID, subcategoryID *uuid.UUID) error {
+	if categoryID == nil {
+		return nil
+	}
+
+	return categoryport.CheckOwnership(ctx, s.categoryRepo, organizationID, *categoryID, subcategoryID)
+}
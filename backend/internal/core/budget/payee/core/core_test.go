@@ -0,0 +1,198 @@
+package core
+
+import (
+	"context"
+	"testing"
+
+	"backend/core/budget/category/categorytest"
+	categoryport "backend/core/budget/category/port"
+	"backend/core/budget/payee/port"
+	"backend/infra/dafi"
+	basedomain "backend/port"
+	apperrors "backend/port/errors"
+
+	"github.com/google/uuid"
+	"github.com/samber/oops"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type noopLogger struct{}
+
+func (noopLogger) Debug(string, ...any)                          {}
+func (noopLogger) Info(string, ...any)                           {}
+func (noopLogger) Warn(string, ...any)                           {}
+func (noopLogger) Error(string, ...any)                          {}
+func (noopLogger) With(...any) basedomain.Logger                 { return noopLogger{} }
+func (noopLogger) WithContext(context.Context) basedomain.Logger { return noopLogger{} }
+
+// stubPayeeRepo keeps payees in memory. FindOne returns the first of them.
+type stubPayeeRepo struct {
+	payees  []port.Payee
+	created []port.CreatePayee
+	updated []port.UpdatePayee
+}
+
+func (s *stubPayeeRepo) FindOne(context.Context, dafi.Criteria) (port.Payee, error) {
+	return s.payees[0], nil
+}
+
+func (s *stubPayeeRepo) FindAll(context.Context, dafi.Criteria) (basedomain.List[port.Payee], error) {
+	return s.payees, nil
+}
+
+func (s *stubPayeeRepo) Create(_ context.Context, input port.CreatePayee) error {
+	s.created = append(s.created, input)
+	return nil
+}
+
+func (s *stubPayeeRepo) CreateBulk(_ context.Context, inputs basedomain.List[port.CreatePayee]) error {
+	s.created = append(s.created, inputs...)
+	return nil
+}
+
+func (s *stubPayeeRepo) Update(_ context.Context, input port.UpdatePayee, _ ...dafi.Filter) error {
+	s.updated = append(s.updated, input)
+	return nil
+}
+
+func (s *stubPayeeRepo) Delete(context.Context, ...dafi.Filter) error { return nil }
+
+func (s *stubPayeeRepo) WithTx(basedomain.Transaction) port.Repository { return s }
+
+var (
+	shoppingID  = uuid.MustParse("22222222-2222-2222-2222-222222222222")
+	onlineID    = uuid.MustParse("33333333-3333-3333-3333-333333333333")
+	diningID    = uuid.MustParse("44444444-4444-4444-4444-444444444444")
+	otherOrgCat = uuid.MustParse("55555555-5555-5555-5555-555555555555")
+	amazonID    = uuid.MustParse("66666666-6666-6666-6666-666666666666")
+)
+
+func newTestService(repo *stubPayeeRepo) port.Service {
+	categories := &categorytest.Repository{Categories: []categoryport.Category{
+		{ID: shoppingID, OrganizationID: "org-1", Name: "Shopping"},
+		{ID: onlineID, OrganizationID: "org-1", ParentID: &shoppingID, Name: "Online"},
+		{ID: diningID, OrganizationID: "org-1", Name: "Dining"},
+		{ID: otherOrgCat, OrganizationID: "org-2", Name: "Rent"},
+	}}
+
+	return New(repo, categories, noopLogger{})
+}
+
+func assertCode(t *testing.T, err error, code string) {
+	t.Helper()
+
+	oopsErr, ok := oops.AsOops(err)
+	require.True(t, ok)
+	assert.Equal(t, code, oopsErr.Code())
+}
+
+func amazon() port.Payee {
+	return port.Payee{
+		ID:                   amazonID,
+		OrganizationID:       "org-1",
+		Name:                 "Amazon",
+		Aliases:              []string{"Amazon.com", "AMZN Marketplace"},
+		Patterns:             []string{"AMZN MKTP*", "amazon prime*"},
+		DefaultCategoryID:    &shoppingID,
+		DefaultSubcategoryID: &onlineID,
+	}
+}
+
+func TestService_Create_Creates(t *testing.T) {
+	t.Parallel()
+
+	repo := &stubPayeeRepo{payees: []port.Payee{amazon()}}
+	err := newTestService(repo).Create(context.Background(), port.CreatePayee{
+		ID:                uuid.New(),
+		OrganizationID:    "org-1",
+		Name:              "Starbucks",
+		Patterns:          []string{"STARBUCKS #*"},
+		DefaultCategoryID: &diningID,
+	})
+	require.NoError(t, err)
+	require.Len(t, repo.created, 1)
+}
+
+func TestService_Create_AliasOfAnotherPayee_Conflict(t *testing.T) {
+	t.Parallel()
+
+	repo := &stubPayeeRepo{payees: []port.Payee{amazon()}}
+	err := newTestService(repo).Create(context.Background(), port.CreatePayee{
+		ID:             uuid.New(),
+		OrganizationID: "org-1",
+		Name:           "Amazon Retail",
+		Aliases:        []string{"amazon com"},
+	})
+	assertCode(t, err, apperrors.CodeConflict)
+	assert.Empty(t, repo.created)
+}
+
+func TestService_Create_InvalidInput_Validation(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]port.CreatePayee{
+		"wildcard pattern":       {Name: "Anything", Patterns: []string{"* ?"}},
+		"empty alias":            {Name: "Bakery", Aliases: []string{""}},
+		"name without letters":   {Name: "***"},
+		"subcategory only":       {Name: "Bakery", DefaultSubcategoryID: &onlineID},
+		"other organization":     {Name: "Bakery", DefaultCategoryID: &otherOrgCat},
+		"subcategory of another": {Name: "Bakery", DefaultCategoryID: &diningID, DefaultSubcategoryID: &onlineID},
+	}
+
+	for name, input := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			input.ID, input.OrganizationID = uuid.New(), "org-1"
+			err := newTestService(&stubPayeeRepo{}).Create(context.Background(), input)
+			assertCode(t, err, apperrors.CodeValidation)
+		})
+	}
+}
+
+func TestService_Update_OwnAliases_Updates(t *testing.T) {
+	t.Parallel()
+
+	repo := &stubPayeeRepo{payees: []port.Payee{amazon()}}
+	err := newTestService(repo).Update(context.Background(), port.UpdatePayee{
+		Aliases:           []string{"Amazon.com", "Amazon EU"},
+		DefaultCategoryID: &diningID,
+	}, dafi.FilterBy("id", dafi.Equal, amazonID)...)
+	require.NoError(t, err)
+	require.Len(t, repo.updated, 1)
+}
+
+func TestResolve(t *testing.T) {
+	t.Parallel()
+
+	payees := []port.Payee{
+		amazon(),
+		{Name: "Amazon Prime Video", Patterns: []string{"PRIMEVIDEO*"}},
+		{Name: "Uber", Patterns: []string{"uber *trip*", "UBER   EATS"}},
+	}
+
+	tests := map[string]string{
+		"AMZN MKTP US*2K4":         "Amazon",
+		"Amazon.com":               "Amazon",
+		"amzn  marketplace":        "Amazon",
+		"amazon prime*ab12":        "Amazon",
+		"Amazon Prime Video":       "Amazon Prime Video",
+		"UBER *TRIP HELP.UBER.COM": "Uber",
+		"Uber Eats":                "Uber",
+		"UBER EATS AMSTERDAM":      "",
+		"Bakery":                   "",
+		"":                         "",
+		"AMZN":                     "",
+	}
+
+	for description, want := range tests {
+		t.Run(description, func(t *testing.T) {
+			t.Parallel()
+
+			payee, ok := port.Resolve(payees, description)
+			assert.Equal(t, want != "", ok)
+			assert.Equal(t, want, payee.Name)
+		})
+	}
+}
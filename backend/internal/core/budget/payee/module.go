@@ -0,0 +1,35 @@
+package payee
+
+import (
+	"backend/adapter/database"
+	"backend/adapter/di"
+	categoryport "backend/core/budget/category/port"
+	"backend/core/budget/payee/adapter/handler"
+	"backend/core/budget/payee/adapter/postgres"
+	"backend/core/budget/payee/core"
+	"backend/core/budget/payee/port"
+	basedomain "backend/port"
+
+	"github.com/samber/do/v2"
+)
+
+func Module(i do.Injector) {
+	di.Provide(i, func(i do.Injector) (port.Repository, error) {
+		db := di.MustInvoke[database.PoolInterface](i)
+		logger := di.MustInvoke[basedomain.Logger](i)
+		return postgres.NewPostgres(db, logger), nil
+	})
+
+	di.Provide(i, func(i do.Injector) (port.Service, error) {
+		repo := di.MustInvoke[port.Repository](i)
+		categoryRepo := di.MustInvoke[categoryport.Repository](i)
+		logger := di.MustInvoke[basedomain.Logger](i)
+		return core.New(repo, categoryRepo, logger), nil
+	})
+
+	di.Provide(i, func(i do.Injector) (handler.HTTP, error) {
+		svc := di.MustInvoke[port.Service](i)
+		logger := di.MustInvoke[basedomain.Logger](i)
+		return handler.NewHTTP(svc, logger), nil
+	})
+}
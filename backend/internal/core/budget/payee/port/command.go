@@ -0,0 +1,72 @@
+package port
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"backend/adapter/validation"
+	"github.com/google/uuid"
+	"github.com/guregu/null/v6"
+)
+
+type CreatePayee struct {
+	ID                   uuid.UUID  `json:"id"`
+	OrganizationID       string     `json:"organizationId"`
+	Name                 string     `json:"name"`
+	Aliases              []string   `json:"aliases"`
+	Patterns             []string   `json:"patterns"`
+	DefaultCategoryID    *uuid.UUID `json:"defaultCategoryId"`
+	DefaultSubcategoryID *uuid.UUID `json:"defaultSubcategoryId"`
+}
+
+func (c CreatePayee) Validate(ctx context.Context) error {
+	err := validation.ValidateStruct(ctx, &c,
+		validation.Field(&c.ID, validation.Required, validation.IsUUID),
+		validation.Field(&c.OrganizationID, validation.Required),
+		validation.Field(&c.Name, validation.Required, validation.Length(1, 100)),
+		validation.Field(&c.Aliases, validation.Each(validation.Required, validation.Length(1, 100))),
+		validation.Field(&c.Patterns, validation.Each(validation.Required, validation.Length(1, 100))),
+		validation.Field(&c.DefaultSubcategoryID, validation.When(c.DefaultCategoryID == nil, validation.Nil)),
+	)
+	if err != nil {
+		return err
+	}
+
+	return validatePatterns(c.Patterns)
+}
+
+// UpdatePayee changes a payee. Fields left out keep their value; Aliases and
+// Patterns, when given, replace all of them. A new DefaultCategoryID drops the
+// default subcategory unless a new DefaultSubcategoryID comes with it.
+type UpdatePayee struct {
+	Name                 null.String `json:"name"`
+	Aliases              []string    `json:"aliases"`
+	Patterns             []string    `json:"patterns"`
+	DefaultCategoryID    *uuid.UUID  `json:"defaultCategoryId"`
+	DefaultSubcategoryID *uuid.UUID  `json:"defaultSubcategoryId"`
+}
+
+func (u UpdatePayee) Validate(ctx context.Context) error {
+	err := validation.ValidateStruct(ctx, &u,
+		validation.Field(&u.Name, validation.NilOrNotEmpty, validation.Length(1, 100)),
+		validation.Field(&u.Aliases, validation.Each(validation.Required, validation.Length(1, 100))),
+		validation.Field(&u.Patterns, validation.Each(validation.Required, validation.Length(1, 100))),
+	)
+	if err != nil {
+		return err
+	}
+
+	return validatePatterns(u.Patterns)
+}
+
+// validatePatterns refuses patterns made only of wildcards, which would claim every
+// transaction for one payee.
+func validatePatterns(patterns []string) error {
+	for _, pattern := range patterns {
+		if foldSpaces(strings.NewReplacer("*", "", "?", "").Replace(pattern)) == "" {
+			return errors.New("patterns: a pattern needs more than wildcards")
+		}
+	}
+	return nil
+}
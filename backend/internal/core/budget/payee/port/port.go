@@ -0,0 +1,22 @@
+package port
+
+import (
+	"context"
+
+	basedomain "backend/port"
+)
+
+type Repository interface {
+	basedomain.RepositoryCommand[CreatePayee, UpdatePayee]
+	basedomain.RepositoryQuery[Payee]
+	basedomain.RepositoryTx[Repository]
+}
+
+type Service interface {
+	basedomain.UseCaseCommand[CreatePayee, UpdatePayee]
+	basedomain.UseCaseQuery[Payee]
+	basedomain.UseCaseTx[Service]
+	// ForOrganization returns every payee of an organization in name order, as
+	// Resolve expects them.
+	ForOrganization(ctx context.Context, organizationID string) ([]Payee, error)
+}
@@ -0,0 +1,97 @@
+package port
+
+import (
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/google/uuid"
+)
+
+// Payee is the merchant or person on the other side of a transaction. A transaction
+// recorded without a payee gets the one whose Name or one of its Aliases is its
+// description once normalized, else the first, by name, with a Pattern that matches
+// the description.
+type Payee struct {
+	ID                   uuid.UUID  `json:"id"`
+	OrganizationID       string     `json:"organizationId"`
+	Name                 string     `json:"name"`
+	Aliases              []string   `json:"aliases"`
+	Patterns             []string   `json:"patterns"`
+	DefaultCategoryID    *uuid.UUID `json:"defaultCategoryId"`
+	DefaultSubcategoryID *uuid.UUID `json:"defaultSubcategoryId"`
+	CreatedAt            time.Time  `json:"createdAt"`
+	UpdatedAt            time.Time  `json:"updatedAt"`
+}
+
+// Resolve returns the payee a transaction description names. payees are expected in
+// name order.
+func Resolve(payees []Payee, description string) (Payee, bool) {
+	normalized := Normalize(description)
+	if normalized == "" {
+		return Payee{}, false
+	}
+
+	for _, payee := range payees {
+		if Normalize(payee.Name) == normalized {
+			return payee, true
+		}
+		for _, alias := range payee.Aliases {
+			if Normalize(alias) == normalized {
+				return payee, true
+			}
+		}
+	}
+
+	text := foldSpaces(description)
+	for _, payee := range payees {
+		for _, pattern := range payee.Patterns {
+			if matchPattern(foldSpaces(pattern), text) {
+				return payee, true
+			}
+		}
+	}
+
+	return Payee{}, false
+}
+
+// Normalize reduces a merchant name to lower case letters and digits separated by
+// single spaces, so "Amazon.com" and "AMAZON COM" compare equal.
+func Normalize(s string) string {
+	return strings.Join(strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}), " ")
+}
+
+func foldSpaces(s string) string {
+	return strings.Join(strings.Fields(strings.ToLower(s)), " ")
+}
+
+// matchPattern reports whether text matches pattern, where * stands for any run of
+// characters and ? for exactly one.
+func matchPattern(pattern, text string) bool {
+	p, t := []rune(pattern), []rune(text)
+	star, mark := -1, 0
+
+	i, j := 0, 0
+	for j < len(t) {
+		switch {
+		case i < len(p) && p[i] == '*':
+			star, mark = i, j
+			i++
+		case i < len(p) && (p[i] == '?' || p[i] == t[j]):
+			i++
+			j++
+		case star >= 0:
+			mark++
+			i, j = star+1, mark
+		default:
+			return false
+		}
+	}
+
+	for i < len(p) && p[i] == '*' {
+		i++
+	}
+	return i == len(p)
+}
@@ -0,0 +1,334 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"backend/adapter/database"
+	"backend/core/budget/reconciliation/port"
+	"backend/infra/dafi"
+	"backend/infra/sqlcraft"
+	basedomain "backend/port"
+	apperrors "backend/port/errors"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/samber/oops"
+)
+
+type dbConn interface {
+	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+}
+
+const tableName = "budget.reconciliations"
+
+var columns = []string{
+	"id",
+	"organization_id",
+	"account_id",
+	"statement_date",
+	"statement_balance",
+	"status",
+	"adjustment_transaction_id",
+	"finalized_at",
+	"created_at",
+	"updated_at",
+}
+
+// clearedBalance is the opening balance of the reconciled account plus its cleared
+// transactions up to the statement date.
+const clearedBalance = `a.opening_balance + COALESCE((
+    SELECT SUM(t.amount) FROM budget.transactions t
+    WHERE t.account_id = r.account_id AND t.status <> 'pending' AND t.date <= r.statement_date), 0)`
+
+var selectColumns = []string{
+	"r.id",
+	"r.organization_id",
+	"r.account_id",
+	"r.statement_date",
+	"r.statement_balance",
+	sqlcraft.As(clearedBalance, "cleared_balance"),
+	"r.status",
+	"r.adjustment_transaction_id",
+	"r.finalized_at",
+	"r.created_at",
+	"r.updated_at",
+}
+
+var sqlColumnByDomainField = map[string]string{
+	"id":                      "id",
+	"organizationId":          "organization_id",
+	"accountId":               "account_id",
+	"statementDate":           "statement_date",
+	"statementBalance":        "statement_balance",
+	"status":                  "status",
+	"adjustmentTransactionId": "adjustment_transaction_id",
+	"finalizedAt":             "finalized_at",
+	"createdAt":               "created_at",
+	"updatedAt":               "updated_at",
+}
+
+var selectSQLColumnByDomainField = map[string]string{
+	"id":                      "r.id",
+	"organizationId":          "r.organization_id",
+	"accountId":               "r.account_id",
+	"statementDate":           "r.statement_date",
+	"statementBalance":        "r.statement_balance",
+	"status":                  "r.status",
+	"adjustmentTransactionId": "r.adjustment_transaction_id",
+	"finalizedAt":             "r.finalized_at",
+	"createdAt":               "r.created_at",
+	"updatedAt":               "r.updated_at",
+}
+
+type postgres struct {
+	db     dbConn
+	logger basedomain.Logger
+}
+
+func NewPostgres(db database.PoolInterface, logger basedomain.Logger) port.Repository {
+	return postgres{
+		db:     db,
+		logger: logger.With("component", "reconciliation.repository"),
+	}
+}
+
+func (r postgres) WithTx(tx basedomain.Transaction) port.Repository {
+	return postgres{
+		db:     tx.GetTx(),
+		logger: r.logger,
+	}
+}
+
+func (r postgres) selectQuery(filters ...dafi.Filter) sqlcraft.SelectQuery {
+	return sqlcraft.Select(selectColumns...).
+		From(tableName+" r").
+		InnerJoin("budget.accounts a", "a.id = r.account_id").
+		Where(filters...).
+		SQLColumnByDomainField(selectSQLColumnByDomainField)
+}
+
+func (r postgres) FindOne(ctx context.Context, criteria dafi.Criteria) (port.Reconciliation, error) {
+	result, err := r.selectQuery(criteria.Filters...).Limit(1).ToSQL()
+	if err != nil {
+		return port.Reconciliation{}, oops.WithContext(ctx).In(apperrors.LayerRepository).Wrap(err)
+	}
+
+	r.logger.WithContext(ctx).Debug("executing query", "sql", result.SQL)
+
+	rec, err := scan(r.db.QueryRow(ctx, result.SQL, result.Args...))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return port.Reconciliation{}, oops.WithContext(ctx).In(apperrors.LayerRepository).Code(apperrors.CodeNotFound).Wrap(err)
+		}
+		return port.Reconciliation{}, oops.WithContext(ctx).In(apperrors.LayerRepository).Wrap(err)
+	}
+
+	return rec, nil
+}
+
+func (r postgres) FindAll(ctx context.Context, criteria dafi.Criteria) (basedomain.List[port.Reconciliation], error) {
+	query := r.selectQuery(criteria.Filters...).
+		OrderBy(criteria.Sorts...).
+		Limit(criteria.Pagination.PageSize).
+		Page(criteria.Pagination.PageNumber)
+
+	result, err := query.ToSQL()
+	if err != nil {
+		return nil, oops.WithContext(ctx).In(apperrors.LayerRepository).Wrap(err)
+	}
+
+	r.logger.WithContext(ctx).Debug("executing query", "sql", result.SQL)
+
+	rows, err := r.db.Query(ctx, result.SQL, result.Args...)
+	if err != nil {
+		return nil, oops.WithContext(ctx).In(apperrors.LayerRepository).Wrap(err)
+	}
+	defer rows.Close()
+
+	var recs basedomain.List[port.Reconciliation]
+	for rows.Next() {
+		rec, err := scan(rows)
+		if err != nil {
+			return nil, oops.WithContext(ctx).In(apperrors.LayerRepository).Wrap(err)
+		}
+		recs = append(recs, rec)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, oops.WithContext(ctx).In(apperrors.LayerRepository).Wrap(err)
+	}
+
+	return recs, nil
+}
+
+func (r postgres) Lock(ctx context.Context, id uuid.UUID) (port.Reconciliation, error) {
+	result, err := r.selectQuery(dafi.FilterBy("id", dafi.Equal, id)...).ToSQL()
+	if err != nil {
+		return port.Reconciliation{}, oops.WithContext(ctx).In(apperrors.LayerRepository).Wrap(err)
+	}
+
+	sql := result.SQL + " FOR UPDATE OF r"
+	r.logger.WithContext(ctx).Debug("executing query", "sql", sql)
+
+	rec, err := scan(r.db.QueryRow(ctx, sql, result.Args...))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return port.Reconciliation{}, oops.WithContext(ctx).In(apperrors.LayerRepository).Code(apperrors.CodeNotFound).Wrap(err)
+		}
+		return port.Reconciliation{}, oops.WithContext(ctx).In(apperrors.LayerRepository).Wrap(err)
+	}
+
+	return rec, nil
+}
+
+func (r postgres) Create(ctx context.Context, input port.CreateReconciliation) error {
+	return r.CreateBulk(ctx, basedomain.List[port.CreateReconciliation]{input})
+}
+
+func (r postgres) CreateBulk(ctx context.Context, inputs basedomain.List[port.CreateReconciliation]) error {
+	if inputs.IsEmpty() {
+		return nil
+	}
+
+	now := time.Now()
+	query := sqlcraft.InsertInto(tableName).WithColumns(columns...)
+
+	for _, input := range inputs {
+		query = query.WithValues(
+			input.ID,
+			input.OrganizationID,
+			input.AccountID,
+			input.StatementDate,
+			input.StatementBalance,
+			port.StatusOpen,
+			nil,
+			nil,
+			now,
+			now,
+		)
+	}
+
+	result, err := query.ToSQL()
+	if err != nil {
+		return oops.WithContext(ctx).In(apperrors.LayerRepository).Wrap(err)
+	}
+
+	r.logger.WithContext(ctx).Debug("executing query", "sql", result.SQL, "count", len(inputs))
+
+	_, err = r.db.Exec(ctx, result.SQL, result.Args...)
+	if err != nil {
+		return r.wrapWriteError(ctx, err)
+	}
+
+	return nil
+}
+
+func (r postgres) Update(ctx context.Context, input port.UpdateReconciliation, filters ...dafi.Filter) error {
+	query := sqlcraft.Update(tableName).
+		WithColumns("statement_date", "statement_balance", "updated_at").
+		WithValues(
+			input.StatementDate,
+			input.StatementBalance,
+			time.Now(),
+		).
+		Where(filters...).
+		SQLColumnByDomainField(sqlColumnByDomainField).
+		WithPartialUpdate()
+
+	result, err := query.ToSQL()
+	if err != nil {
+		return oops.WithContext(ctx).In(apperrors.LayerRepository).Wrap(err)
+	}
+
+	r.logger.WithContext(ctx).Debug("executing query", "sql", result.SQL)
+
+	_, err = r.db.Exec(ctx, result.SQL, result.Args...)
+	if err != nil {
+		return oops.WithContext(ctx).In(apperrors.LayerRepository).Wrap(err)
+	}
+
+	return nil
+}
+
+func (r postgres) Finalize(ctx context.Context, id uuid.UUID, adjustmentTransactionID *uuid.UUID) error {
+	now := time.Now()
+
+	query := sqlcraft.Update(tableName).
+		WithColumns("status", "adjustment_transaction_id", "finalized_at", "updated_at").
+		WithValues(port.StatusFinalized, adjustmentTransactionID, now, now).
+		Where(dafi.FilterBy("id", dafi.Equal, id)...).
+		SQLColumnByDomainField(sqlColumnByDomainField)
+
+	result, err := query.ToSQL()
+	if err != nil {
+		return oops.WithContext(ctx).In(apperrors.LayerRepository).Wrap(err)
+	}
+
+	r.logger.WithContext(ctx).Debug("executing query", "sql", result.SQL)
+
+	_, err = r.db.Exec(ctx, result.SQL, result.Args...)
+	if err != nil {
+		return oops.WithContext(ctx).In(apperrors.LayerRepository).Wrap(err)
+	}
+
+	return nil
+}
+
+func (r postgres) Delete(ctx context.Context, filters ...dafi.Filter) error {
+	query := sqlcraft.DeleteFrom(tableName).
+		Where(filters...).
+		SQLColumnByDomainField(sqlColumnByDomainField)
+
+	result, err := query.ToSQL()
+	if err != nil {
+		return oops.WithContext(ctx).In(apperrors.LayerRepository).Wrap(err)
+	}
+
+	r.logger.WithContext(ctx).Debug("executing query", "sql", result.SQL)
+
+	_, err = r.db.Exec(ctx, result.SQL, result.Args...)
+	if err != nil {
+		return oops.WithContext(ctx).In(apperrors.LayerRepository).Wrap(err)
+	}
+
+	return nil
+}
+
+const pgErrUniqueViolation = "23505"
+
+func (r postgres) wrapWriteError(ctx context.Context, err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == pgErrUniqueViolation {
+		return oops.WithContext(ctx).In(apperrors.LayerRepository).
+			Code(apperrors.CodeConflict).
+			Public("This account already has an open reconciliation.").
+			Wrap(err)
+	}
+
+	return oops.WithContext(ctx).In(apperrors.LayerRepository).Wrap(err)
+}
+
+func scan(row pgx.Row) (port.Reconciliation, error) {
+	var rec port.Reconciliation
+	err := row.Scan(
+		&rec.ID,
+		&rec.OrganizationID,
+		&rec.AccountID,
+		&rec.StatementDate,
+		&rec.StatementBalance,
+		&rec.ClearedBalance,
+		&rec.Status,
+		&rec.AdjustmentTransactionID,
+		&rec.FinalizedAt,
+		&rec.CreatedAt,
+		&rec.UpdatedAt,
+	)
+	rec.Difference = rec.StatementBalance - rec.ClearedBalance
+
+	return rec, err
+}
@@ -0,0 +1,281 @@
+package core
+
+import (
+	"context"
+
+	"backend/core/budget/reconciliation/port"
+	transactionport "backend/core/budget/transaction/port"
+	"backend/infra/dafi"
+	basedomain "backend/port"
+	apperrors "backend/port/errors"
+
+	"github.com/google/uuid"
+	"github.com/guregu/null/v6"
+	"github.com/samber/oops"
+)
+
+type service struct {
+	repo            port.Repository
+	transactionRepo transactionport.Repository
+	transactionSvc  transactionport.Service
+	uow             basedomain.UnitOfWork
+	tx              basedomain.Transaction
+	logger          basedomain.Logger
+}
+
+func New(repo port.Repository, transactionRepo transactionport.Repository, transactionSvc transactionport.Service, uow basedomain.UnitOfWork, logger basedomain.Logger) port.Service {
+	return service{
+		repo:            repo,
+		transactionRepo: transactionRepo,
+		transactionSvc:  transactionSvc,
+		uow:             uow,
+		logger:          logger.With("component", "reconciliation.service"),
+	}
+}
+
+func (s service) WithTx(tx basedomain.Transaction) port.Service {
+	return s.withTx(tx)
+}
+
+func (s service) withTx(tx basedomain.Transaction) service {
+	return service{
+		repo:            s.repo.WithTx(tx),
+		transactionRepo: s.transactionRepo.WithTx(tx),
+		transactionSvc:  s.transactionSvc.WithTx(tx),
+		uow:             s.uow,
+		tx:              tx,
+		logger:          s.logger,
+	}
+}
+
+// lockOpen locks a reconciliation until the end of the current transaction and makes
+// sure it can still change.
+func (s service) lockOpen(ctx context.Context, id uuid.UUID) (port.Reconciliation, error) {
+	rec, err := s.repo.Lock(ctx, id)
+	if err != nil {
+		return port.Reconciliation{}, err
+	}
+
+	if !rec.IsOpen() {
+		return port.Reconciliation{}, oops.WithContext(ctx).In(apperrors.LayerService).
+			Code(apperrors.CodeConflict).
+			Public("This reconciliation is finalized and can no longer change.").
+			Errorf("reconciliation %s is %s", rec.ID, rec.Status)
+	}
+
+	return rec, nil
+}
+
+func (s service) FindOne(ctx context.Context, criteria dafi.Criteria) (port.Reconciliation, error) {
+	rec, err := s.repo.FindOne(ctx, criteria)
+	if err != nil {
+		return port.Reconciliation{}, oops.WithContext(ctx).In(apperrors.LayerService).Wrap(err)
+	}
+
+	return rec, nil
+}
+
+func (s service) FindAll(ctx context.Context, criteria dafi.Criteria) (basedomain.List[port.Reconciliation], error) {
+	recs, err := s.repo.FindAll(ctx, criteria)
+	if err != nil {
+		return nil, oops.WithContext(ctx).In(apperrors.LayerService).Wrap(err)
+	}
+
+	return recs, nil
+}
+
+func (s service) Create(ctx context.Context, input port.CreateReconciliation) error {
+	if err := input.Validate(ctx); err != nil {
+		return oops.WithContext(ctx).In(apperrors.LayerService).Code(apperrors.CodeValidation).Wrap(err)
+	}
+
+	if err := s.repo.Create(ctx, input); err != nil {
+		return oops.WithContext(ctx).In(apperrors.LayerService).Wrap(err)
+	}
+
+	s.logger.WithContext(ctx).Info("reconciliation started", "accountId", input.AccountID)
+
+	return nil
+}
+
+func (s service) CreateBulk(ctx context.Context, inputs basedomain.List[port.CreateReconciliation]) error {
+	for _, input := range inputs {
+		if err := input.Validate(ctx); err != nil {
+			return oops.WithContext(ctx).In(apperrors.LayerService).Code(apperrors.CodeValidation).Wrap(err)
+		}
+	}
+
+	if err := s.repo.CreateBulk(ctx, inputs); err != nil {
+		return oops.WithContext(ctx).In(apperrors.LayerService).Wrap(err)
+	}
+
+	s.logger.WithContext(ctx).Info("reconciliations started", "count", len(inputs))
+
+	return nil
+}
+
+func (s service) Update(ctx context.Context, input port.UpdateReconciliation, filters ...dafi.Filter) error {
+	if err := input.Validate(ctx); err != nil {
+		return oops.WithContext(ctx).In(apperrors.LayerService).Code(apperrors.CodeValidation).Wrap(err)
+	}
+
+	recs, err := s.repo.FindAll(ctx, dafi.Criteria{Filters: filters})
+	if err != nil {
+		return oops.WithContext(ctx).In(apperrors.LayerService).Wrap(err)
+	}
+
+	err = basedomain.Atomically(ctx, s.uow, s.tx, s.withTx, func(txSvc service) error {
+		for _, rec := range recs {
+			if _, err := txSvc.lockOpen(ctx, rec.ID); err != nil {
+				return err
+			}
+		}
+
+		return txSvc.repo.Update(ctx, input, filters...)
+	})
+	if err != nil {
+		return oops.WithContext(ctx).In(apperrors.LayerService).Wrap(err)
+	}
+
+	s.logger.WithContext(ctx).Info("reconciliation updated", "count", len(recs))
+
+	return nil
+}
+
+// Delete cancels open reconciliations. The cleared marks they set stay; a finalized
+// reconciliation is kept for the transactions it locked.
+func (s service) Delete(ctx context.Context, filters ...dafi.Filter) error {
+	recs, err := s.repo.FindAll(ctx, dafi.Criteria{Filters: filters})
+	if err != nil {
+		return oops.WithContext(ctx).In(apperrors.LayerService).Wrap(err)
+	}
+
+	err = basedomain.Atomically(ctx, s.uow, s.tx, s.withTx, func(txSvc service) error {
+		for _, rec := range recs {
+			if _, err := txSvc.lockOpen(ctx, rec.ID); err != nil {
+				return err
+			}
+		}
+
+		return txSvc.repo.Delete(ctx, filters...)
+	})
+	if err != nil {
+		return oops.WithContext(ctx).In(apperrors.LayerService).Wrap(err)
+	}
+
+	s.logger.WithContext(ctx).Info("reconciliation deleted", "count", len(recs))
+
+	return nil
+}
+
+func (s service) ClearTransactions(ctx context.Context, id uuid.UUID, input port.ClearTransactions) error {
+	if err := input.Validate(ctx); err != nil {
+		return oops.WithContext(ctx).In(apperrors.LayerService).Code(apperrors.CodeValidation).Wrap(err)
+	}
+
+	ids := make(map[uuid.UUID]struct{}, len(input.TransactionIDs))
+	for _, txnID := range input.TransactionIDs {
+		ids[txnID] = struct{}{}
+	}
+
+	err := basedomain.Atomically(ctx, s.uow, s.tx, s.withTx, func(txSvc service) error {
+		rec, err := txSvc.lockOpen(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		filters := dafi.FilterBy("id", dafi.In, input.TransactionIDs).And("accountId", dafi.Equal, rec.AccountID)
+		txns, err := txSvc.transactionSvc.FindAll(ctx, dafi.Criteria{Filters: filters})
+		if err != nil {
+			return err
+		}
+
+		if len(txns) != len(ids) {
+			return oops.WithContext(ctx).In(apperrors.LayerService).
+				Code(apperrors.CodeValidation).
+				Public("Only transactions of the reconciled account can be cleared.").
+				Errorf("%d of %d transactions belong to account %s", len(txns), len(ids), rec.AccountID)
+		}
+
+		status := transactionport.StatusPending
+		if input.Cleared {
+			status = transactionport.StatusCleared
+		}
+
+		return txSvc.transactionSvc.Update(ctx, transactionport.UpdateTransaction{Status: null.StringFrom(status)}, filters...)
+	})
+	if err != nil {
+		return oops.WithContext(ctx).In(apperrors.LayerService).Wrap(err)
+	}
+
+	s.logger.WithContext(ctx).Info("reconciliation transactions cleared", "reconciliationId", id, "cleared", input.Cleared, "count", len(ids))
+
+	return nil
+}
+
+// Finalize locks the cleared transactions the statement covers. A difference left
+// between the statement and the cleared balance is booked as an adjustment
+// transaction when the caller asks for it, and refused otherwise.
+func (s service) Finalize(ctx context.Context, id uuid.UUID, input port.FinalizeReconciliation) (port.Reconciliation, error) {
+	var (
+		adjustmentID *uuid.UUID
+		locked       int64
+	)
+	err := basedomain.Atomically(ctx, s.uow, s.tx, s.withTx, func(txSvc service) error {
+		rec, err := txSvc.lockOpen(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if rec.Difference != 0 {
+			if !input.PostAdjustment {
+				return oops.WithContext(ctx).In(apperrors.LayerService).
+					Code(apperrors.CodeConflict).
+					Public("The cleared balance does not match the statement balance. Clear the missing transactions or post an adjustment.").
+					Errorf("reconciliation %s is off by %d", rec.ID, rec.Difference)
+			}
+
+			adjustment := adjustmentTransaction(rec)
+			if err := txSvc.transactionSvc.Create(ctx, adjustment); err != nil {
+				return err
+			}
+			adjustmentID = &adjustment.ID
+		}
+
+		locked, err = txSvc.transactionRepo.MarkReconciled(ctx, rec.AccountID, rec.ID, rec.StatementDate)
+		if err != nil {
+			return err
+		}
+
+		return txSvc.repo.Finalize(ctx, rec.ID, adjustmentID)
+	})
+	if err != nil {
+		return port.Reconciliation{}, oops.WithContext(ctx).In(apperrors.LayerService).Wrap(err)
+	}
+
+	s.logger.WithContext(ctx).Info("reconciliation finalized", "reconciliationId", id, "transactions", locked, "adjusted", adjustmentID != nil)
+
+	rec, err := s.repo.FindOne(ctx, dafi.Where("id", dafi.Equal, id))
+	if err != nil {
+		return port.Reconciliation{}, oops.WithContext(ctx).In(apperrors.LayerService).Wrap(err)
+	}
+
+	return rec, nil
+}
+
+// adjustmentTransaction books the difference of a reconciliation on its statement
+// date, already cleared so it is locked together with the rest. Its id derives from
+// the reconciliation so a retried finalize cannot post it twice.
+func adjustmentTransaction(rec port.Reconciliation) transactionport.CreateTransaction {
+	return transactionport.CreateTransaction{
+		ID:             uuid.NewSHA1(rec.ID, []byte(port.TypeAdjustment)),
+		OrganizationID: rec.OrganizationID,
+		AccountID:      rec.AccountID,
+		Type:           port.TypeAdjustment,
+		Amount:         rec.Difference,
+		Description:    null.StringFrom("Reconciliation adjustment"),
+		Date:           rec.StatementDate,
+		Status:         transactionport.StatusCleared,
+		AllowDuplicate: true,
+	}
+}
@@ -0,0 +1,275 @@
+package core
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"backend/core/budget/reconciliation/port"
+	transactionport "backend/core/budget/transaction/port"
+	"backend/infra/dafi"
+	basedomain "backend/port"
+	apperrors "backend/port/errors"
+
+	"github.com/google/uuid"
+	"github.com/guregu/null/v6"
+	"github.com/samber/oops"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type noopLogger struct{}
+
+func (noopLogger) Debug(string, ...any)                          {}
+func (noopLogger) Info(string, ...any)                           {}
+func (noopLogger) Warn(string, ...any)                           {}
+func (noopLogger) Error(string, ...any)                          {}
+func (noopLogger) With(...any) basedomain.Logger                 { return noopLogger{} }
+func (noopLogger) WithContext(context.Context) basedomain.Logger { return noopLogger{} }
+
+type stubTransaction struct{}
+
+func (stubTransaction) GetTx() basedomain.Tx { return nil }
+
+type stubUnitOfWork struct{}
+
+func (stubUnitOfWork) Begin(context.Context) (basedomain.Transaction, error) {
+	return stubTransaction{}, nil
+}
+
+func (stubUnitOfWork) Commit(context.Context, basedomain.Transaction) error   { return nil }
+func (stubUnitOfWork) Rollback(context.Context, basedomain.Transaction) error { return nil }
+
+// stubRepo holds a single reconciliation and computes its cleared balance from the
+// cleared transactions of the ledger.
+type stubRepo struct {
+	port.Repository
+	rec    port.Reconciliation
+	ledger *ledger
+}
+
+func (r *stubRepo) current() port.Reconciliation {
+	rec := r.rec
+	rec.ClearedBalance = r.ledger.clearedBalance(rec.AccountID, rec.StatementDate)
+	rec.Difference = rec.StatementBalance - rec.ClearedBalance
+	return rec
+}
+
+func (r *stubRepo) FindOne(context.Context, dafi.Criteria) (port.Reconciliation, error) {
+	return r.current(), nil
+}
+
+func (r *stubRepo) FindAll(context.Context, dafi.Criteria) (basedomain.List[port.Reconciliation], error) {
+	return basedomain.List[port.Reconciliation]{r.current()}, nil
+}
+
+func (r *stubRepo) Lock(context.Context, uuid.UUID) (port.Reconciliation, error) {
+	return r.current(), nil
+}
+
+func (r *stubRepo) Update(_ context.Context, input port.UpdateReconciliation, _ ...dafi.Filter) error {
+	if input.StatementBalance.Valid {
+		r.rec.StatementBalance = input.StatementBalance.Int64
+	}
+	return nil
+}
+
+func (r *stubRepo) Finalize(_ context.Context, _ uuid.UUID, adjustmentTransactionID *uuid.UUID) error {
+	r.rec.Status = port.StatusFinalized
+	r.rec.AdjustmentTransactionID = adjustmentTransactionID
+	return nil
+}
+
+func (r *stubRepo) WithTx(basedomain.Transaction) port.Repository { return r }
+
+// ledger holds the transactions behind the stub transaction service and repository.
+type ledger struct {
+	txns map[uuid.UUID]transactionport.Transaction
+}
+
+func (l *ledger) clearedBalance(accountID uuid.UUID, through time.Time) int64 {
+	var balance int64
+	for _, txn := range l.txns {
+		if txn.AccountID == accountID && txn.Status != transactionport.StatusPending && !txn.Date.After(through) {
+			balance += txn.Amount
+		}
+	}
+	return balance
+}
+
+// ledgerService stands in for the transaction service.
+type ledgerService struct {
+	transactionport.Service
+	l *ledger
+}
+
+func (s ledgerService) WithTx(basedomain.Transaction) transactionport.Service { return s }
+
+func (s ledgerService) FindAll(_ context.Context, criteria dafi.Criteria) (basedomain.List[transactionport.Transaction], error) {
+	ids, _ := criteria.Filters[0].Value.([]uuid.UUID)
+	accountID, _ := criteria.Filters[1].Value.(uuid.UUID)
+
+	var txns basedomain.List[transactionport.Transaction]
+	for _, id := range ids {
+		if txn, ok := s.l.txns[id]; ok && txn.AccountID == accountID {
+			txns = append(txns, txn)
+		}
+	}
+	return txns, nil
+}
+
+func (s ledgerService) Create(_ context.Context, input transactionport.CreateTransaction) error {
+	s.l.txns[input.ID] = transactionport.Transaction{
+		ID:        input.ID,
+		AccountID: input.AccountID,
+		Type:      input.Type,
+		Amount:    input.Amount,
+		Date:      input.Date,
+		Status:    input.Status,
+	}
+	return nil
+}
+
+func (s ledgerService) Update(_ context.Context, input transactionport.UpdateTransaction, filters ...dafi.Filter) error {
+	ids, _ := filters[0].Value.([]uuid.UUID)
+	for _, id := range ids {
+		txn := s.l.txns[id]
+		txn.Status = input.Status.String
+		s.l.txns[id] = txn
+	}
+	return nil
+}
+
+// ledgerRepository stands in for the transaction repository.
+type ledgerRepository struct {
+	transactionport.Repository
+	l *ledger
+}
+
+func (r ledgerRepository) WithTx(basedomain.Transaction) transactionport.Repository { return r }
+
+func (r ledgerRepository) MarkReconciled(_ context.Context, accountID, reconciliationID uuid.UUID, through time.Time) (int64, error) {
+	var n int64
+	for id, txn := range r.l.txns {
+		if txn.AccountID == accountID && txn.Status == transactionport.StatusCleared && !txn.Date.After(through) {
+			txn.Status, txn.ReconciliationID = transactionport.StatusReconciled, &reconciliationID
+			r.l.txns[id] = txn
+			n++
+		}
+	}
+	return n, nil
+}
+
+type fixture struct {
+	svc    port.Service
+	repo   *stubRepo
+	ledger *ledger
+}
+
+// newFixture starts a reconciliation of an account at the given statement balance,
+// with a 100.00 deposit and a 25.00 payment on the account before the statement date.
+func newFixture(statementBalance int64) (fixture, uuid.UUID, uuid.UUID) {
+	account := uuid.New()
+	statementDate := time.Date(2026, time.April, 30, 0, 0, 0, 0, time.UTC)
+	deposit, payment := uuid.New(), uuid.New()
+
+	l := &ledger{txns: map[uuid.UUID]transactionport.Transaction{
+		deposit: {ID: deposit, AccountID: account, Status: transactionport.StatusPending, Amount: 10000, Date: statementDate.AddDate(0, 0, -10)},
+		payment: {ID: payment, AccountID: account, Status: transactionport.StatusPending, Amount: -2500, Date: statementDate.AddDate(0, 0, -5)},
+	}}
+	repo := &stubRepo{
+		rec: port.Reconciliation{
+			ID:               uuid.New(),
+			OrganizationID:   "org_1",
+			AccountID:        account,
+			StatementDate:    statementDate,
+			StatementBalance: statementBalance,
+			Status:           port.StatusOpen,
+		},
+		ledger: l,
+	}
+
+	return fixture{
+		svc:    New(repo, ledgerRepository{l: l}, ledgerService{l: l}, stubUnitOfWork{}, noopLogger{}),
+		repo:   repo,
+		ledger: l,
+	}, deposit, payment
+}
+
+func requireCode(t *testing.T, code string, err error) {
+	t.Helper()
+	require.Error(t, err)
+
+	oopsErr, ok := oops.AsOops(err)
+	require.True(t, ok)
+	assert.Equal(t, code, oopsErr.Code())
+}
+
+func TestService_ClearTransactionsAndFinalize(t *testing.T) {
+	f, deposit, payment := newFixture(7500)
+	ctx := context.Background()
+	id := f.repo.rec.ID
+
+	require.NoError(t, f.svc.ClearTransactions(ctx, id, port.ClearTransactions{TransactionIDs: []uuid.UUID{deposit}, Cleared: true}))
+	rec, err := f.svc.FindOne(ctx, dafi.Where("id", dafi.Equal, id))
+	require.NoError(t, err)
+	assert.Equal(t, int64(10000), rec.ClearedBalance)
+	assert.Equal(t, int64(-2500), rec.Difference)
+
+	_, err = f.svc.Finalize(ctx, id, port.FinalizeReconciliation{})
+	requireCode(t, apperrors.CodeConflict, err)
+	assert.Nil(t, f.ledger.txns[deposit].ReconciliationID)
+
+	require.NoError(t, f.svc.ClearTransactions(ctx, id, port.ClearTransactions{TransactionIDs: []uuid.UUID{payment}, Cleared: true}))
+	rec, err = f.svc.Finalize(ctx, id, port.FinalizeReconciliation{})
+	require.NoError(t, err)
+
+	assert.Equal(t, port.StatusFinalized, rec.Status)
+	assert.Zero(t, rec.Difference)
+	assert.Nil(t, rec.AdjustmentTransactionID)
+	assert.Equal(t, id, *f.ledger.txns[deposit].ReconciliationID)
+	assert.Equal(t, id, *f.ledger.txns[payment].ReconciliationID)
+
+	err = f.svc.ClearTransactions(ctx, id, port.ClearTransactions{TransactionIDs: []uuid.UUID{payment}})
+	requireCode(t, apperrors.CodeConflict, err)
+}
+
+func TestService_FinalizePostsAdjustment(t *testing.T) {
+	f, deposit, _ := newFixture(9900)
+	ctx := context.Background()
+	id := f.repo.rec.ID
+
+	require.NoError(t, f.svc.ClearTransactions(ctx, id, port.ClearTransactions{TransactionIDs: []uuid.UUID{deposit}, Cleared: true}))
+	rec, err := f.svc.Finalize(ctx, id, port.FinalizeReconciliation{PostAdjustment: true})
+	require.NoError(t, err)
+
+	require.NotNil(t, rec.AdjustmentTransactionID)
+	adjustment := f.ledger.txns[*rec.AdjustmentTransactionID]
+	assert.Equal(t, int64(-100), adjustment.Amount)
+	assert.Equal(t, port.TypeAdjustment, adjustment.Type)
+	assert.Equal(t, id, *adjustment.ReconciliationID)
+	assert.Zero(t, rec.Difference)
+}
+
+func TestService_ClearTransactionsOfAnotherAccount(t *testing.T) {
+	f, deposit, _ := newFixture(0)
+	other := uuid.New()
+	f.ledger.txns[other] = transactionport.Transaction{ID: other, AccountID: uuid.New(), Status: transactionport.StatusPending, Amount: 500}
+
+	err := f.svc.ClearTransactions(context.Background(), f.repo.rec.ID, port.ClearTransactions{
+		TransactionIDs: []uuid.UUID{deposit, other},
+		Cleared:        true,
+	})
+	requireCode(t, apperrors.CodeValidation, err)
+	assert.Equal(t, transactionport.StatusPending, f.ledger.txns[deposit].Status)
+}
+
+func TestService_UpdateFinalized(t *testing.T) {
+	f, _, _ := newFixture(0)
+	f.repo.rec.Status = port.StatusFinalized
+
+	err := f.svc.Update(context.Background(), port.UpdateReconciliation{StatementBalance: null.IntFrom(100)},
+		dafi.FilterBy("id", dafi.Equal, f.repo.rec.ID)...)
+	requireCode(t, apperrors.CodeConflict, err)
+	assert.Zero(t, f.repo.rec.StatementBalance)
+}
@@ -0,0 +1,37 @@
+package reconciliation
+
+import (
+	"backend/adapter/database"
+	"backend/adapter/di"
+	"backend/core/budget/reconciliation/adapter/handler"
+	"backend/core/budget/reconciliation/adapter/postgres"
+	"backend/core/budget/reconciliation/core"
+	"backend/core/budget/reconciliation/port"
+	transactionport "backend/core/budget/transaction/port"
+	basedomain "backend/port"
+
+	"github.com/samber/do/v2"
+)
+
+func Module(i do.Injector) {
+	di.Provide(i, func(i do.Injector) (port.Repository, error) {
+		db := di.MustInvoke[database.PoolInterface](i)
+		logger := di.MustInvoke[basedomain.Logger](i)
+		return postgres.NewPostgres(db, logger), nil
+	})
+
+	di.Provide(i, func(i do.Injector) (port.Service, error) {
+		repo := di.MustInvoke[port.Repository](i)
+		transactionRepo := di.MustInvoke[transactionport.Repository](i)
+		transactionSvc := di.MustInvoke[transactionport.Service](i)
+		uow := di.MustInvoke[basedomain.UnitOfWork](i)
+		logger := di.MustInvoke[basedomain.Logger](i)
+		return core.New(repo, transactionRepo, transactionSvc, uow, logger), nil
+	})
+
+	di.Provide(i, func(i do.Injector) (handler.HTTP, error) {
+		svc := di.MustInvoke[port.Service](i)
+		logger := di.MustInvoke[basedomain.Logger](i)
+		return handler.NewHTTP(svc, logger), nil
+	})
+}
@@ -0,0 +1,63 @@
+package port
+
+import (
+	"context"
+	"time"
+
+	"backend/adapter/validation"
+	"github.com/google/uuid"
+	"github.com/guregu/null/v6"
+)
+
+// TypeAdjustment is the type of the transaction a reconciliation posts to make up for
+// a difference with the statement.
+const TypeAdjustment = "adjustment"
+
+type CreateReconciliation struct {
+	ID               uuid.UUID `json:"id"`
+	OrganizationID   string    `json:"organizationId"`
+	AccountID        uuid.UUID `json:"accountId"`
+	StatementDate    time.Time `json:"statementDate"`
+	StatementBalance int64     `json:"statementBalance"`
+}
+
+func (c CreateReconciliation) Validate(ctx context.Context) error {
+	return validation.ValidateStruct(ctx, &c,
+		validation.Field(&c.ID, validation.Required, validation.IsUUID),
+		validation.Field(&c.OrganizationID, validation.Required),
+		validation.Field(&c.AccountID, validation.Required, validation.IsUUID),
+		validation.Field(&c.StatementDate, validation.Required),
+	)
+}
+
+// UpdateReconciliation corrects the statement of an open reconciliation.
+type UpdateReconciliation struct {
+	StatementDate    *time.Time `json:"statementDate"`
+	StatementBalance null.Int   `json:"statementBalance"`
+}
+
+func (u UpdateReconciliation) Validate(ctx context.Context) error {
+	return validation.ValidateStruct(ctx, &u,
+		validation.Field(&u.StatementDate, validation.NilOrNotEmpty),
+	)
+}
+
+// ClearTransactions marks transactions of the reconciled account as cleared, or
+// clears the mark again.
+type ClearTransactions struct {
+	TransactionIDs []uuid.UUID `json:"transactionIds"`
+	Cleared        bool        `json:"cleared"`
+}
+
+func (c ClearTransactions) Validate(ctx context.Context) error {
+	return validation.ValidateStruct(ctx, &c,
+		validation.Field(&c.TransactionIDs, validation.Required, validation.Length(1, 500)),
+	)
+}
+
+// FinalizeReconciliation closes a reconciliation. A remaining difference is only
+// accepted with PostAdjustment, which books it as an adjustment transaction dated on
+// the statement date.
+type FinalizeReconciliation struct {
+	PostAdjustment bool `json:"postAdjustment"`
+}
@@ -0,0 +1,27 @@
+package port
+
+import (
+	"context"
+
+	basedomain "backend/port"
+
+	"github.com/google/uuid"
+)
+
+type Repository interface {
+	basedomain.RepositoryCommand[CreateReconciliation, UpdateReconciliation]
+	basedomain.RepositoryQuery[Reconciliation]
+	basedomain.RepositoryTx[Repository]
+	// Lock reads a reconciliation and locks it until the end of the current transaction.
+	Lock(ctx context.Context, id uuid.UUID) (Reconciliation, error)
+	// Finalize closes a reconciliation, recording the adjustment transaction it posted.
+	Finalize(ctx context.Context, id uuid.UUID, adjustmentTransactionID *uuid.UUID) error
+}
+
+type Service interface {
+	basedomain.UseCaseCommand[CreateReconciliation, UpdateReconciliation]
+	basedomain.UseCaseQuery[Reconciliation]
+	basedomain.UseCaseTx[Service]
+	ClearTransactions(ctx context.Context, id uuid.UUID, input ClearTransactions) error
+	Finalize(ctx context.Context, id uuid.UUID, input FinalizeReconciliation) (Reconciliation, error)
+}
@@ -0,0 +1,37 @@
+package port
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Statuses of a reconciliation. An account has at most one open reconciliation.
+const (
+	StatusOpen      = "open"
+	StatusFinalized = "finalized"
+)
+
+// Reconciliation checks the cleared transactions of an account against a bank
+// statement. ClearedBalance is the opening balance of the account plus its cleared
+// transactions dated on or before StatementDate, and Difference is what the statement
+// shows on top of it. Amounts are in minor units of the account currency.
+type Reconciliation struct {
+	ID                      uuid.UUID  `json:"id"`
+	OrganizationID          string     `json:"organizationId"`
+	AccountID               uuid.UUID  `json:"accountId"`
+	StatementDate           time.Time  `json:"statementDate"`
+	StatementBalance        int64      `json:"statementBalance"`
+	ClearedBalance          int64      `json:"clearedBalance"`
+	Difference              int64      `json:"difference"`
+	Status                  string     `json:"status"`
+	AdjustmentTransactionID *uuid.UUID `json:"adjustmentTransactionId"`
+	FinalizedAt             *time.Time `json:"finalizedAt"`
+	CreatedAt               time.Time  `json:"createdAt"`
+	UpdatedAt               time.Time  `json:"updatedAt"`
+}
+
+// IsOpen reports whether the reconciliation can still change.
+func (r Reconciliation) IsOpen() bool {
+	return r.Status == StatusOpen
+}
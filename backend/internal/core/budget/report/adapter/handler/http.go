@@ -0,0 +1,56 @@
+package handler
+
+import (
+	"backend/core/budget/report/port"
+	"backend/infra/httpresponse"
+	basedomain "backend/port"
+	apperrors "backend/port/errors"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/samber/oops"
+)
+
+type HTTP struct {
+	svc    port.Service
+	logger basedomain.Logger
+}
+
+func NewHTTP(svc port.Service, logger basedomain.Logger) HTTP {
+	return HTTP{
+		svc:    svc,
+		logger: logger.With("component", "report.handler"),
+	}
+}
+
+func (h HTTP) BudgetVsActual(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	budgetID, err := uuid.Parse(c.QueryParam("budgetId"))
+	if err != nil {
+		return oops.WithContext(ctx).In(apperrors.LayerHandler).Code(apperrors.CodeBadRequest).Wrap(err)
+	}
+
+	report, err := h.svc.BudgetVsActual(ctx, budgetID)
+	if err != nil {
+		return oops.WithContext(ctx).In(apperrors.LayerHandler).Wrap(err)
+	}
+
+	return httpresponse.OK(c, report)
+}
+
+func (h HTTP) TagActivity(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	budgetID, err := uuid.Parse(c.QueryParam("budgetId"))
+	if err != nil {
+		return oops.WithContext(ctx).In(apperrors.LayerHandler).Code(apperrors.CodeBadRequest).Wrap(err)
+	}
+
+	report, err := h.svc.TagActivity(ctx, budgetID)
+	if err != nil {
+		return oops.WithContext(ctx).In(apperrors.LayerHandler).Wrap(err)
+	}
+
+	return httpresponse.OK(c, report)
+}
@@ -0,0 +1,230 @@
+package postgres
+
+import (
+	"context"
+
+	"backend/adapter/database"
+	"backend/core/budget/report/port"
+	"backend/infra/dafi"
+	"backend/infra/sqlcraft"
+	basedomain "backend/port"
+	apperrors "backend/port/errors"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/samber/oops"
+)
+
+type dbConn interface {
+	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+}
+
+// convertedAmount is a transaction amount in the currency of the budget it belongs to,
+// at the rate in effect on the transaction date.
+const convertedAmount = "budget.convert_amount(t.organization_id, t.amount, a.currency_code, b.currency_code, t.date)"
+
+var activitySQLColumnByDomainField = map[string]string{
+	"budgetId":      "t.budget_id",
+	"categoryId":    "t.category_id",
+	"subcategoryId": "t.subcategory_id",
+}
+
+var tagSQLColumnByDomainField = map[string]string{
+	"budgetId": "t.budget_id",
+	"tagId":    "tt.tag_id",
+	"tagName":  "g.name",
+}
+
+var planSQLColumnByDomainField = map[string]string{
+	"budgetId":      "budget_id",
+	"categoryId":    "category_id",
+	"subcategoryId": "subcategory_id",
+}
+
+type postgres struct {
+	db     dbConn
+	logger basedomain.Logger
+}
+
+func NewPostgres(db database.PoolInterface, logger basedomain.Logger) port.Repository {
+	return postgres{
+		db:     db,
+		logger: logger.With("component", "report.repository"),
+	}
+}
+
+func (r postgres) WithTx(tx basedomain.Transaction) port.Repository {
+	return postgres{
+		db:     tx.GetTx(),
+		logger: r.logger,
+	}
+}
+
+func (r postgres) BudgetActivity(ctx context.Context, budgetID uuid.UUID) (basedomain.List[port.LineActivity], error) {
+	query := sqlcraft.Select(
+		"t.category_id",
+		"t.subcategory_id",
+		sqlcraft.As(sqlcraft.Sum(convertedAmount), "activity"),
+		sqlcraft.As(sqlcraft.Count("*"), "transactions"),
+		sqlcraft.As(sqlcraft.Count(convertedAmount), "converted"),
+	).
+		From("budget.transaction_lines t").
+		InnerJoin("budget.accounts a", "a.id = t.account_id").
+		InnerJoin("budget.budgets b", "b.id = t.budget_id").
+		Where(dafi.FilterBy("budgetId", dafi.Equal, budgetID)...).
+		SQLColumnByDomainField(activitySQLColumnByDomainField).
+		GroupBy("categoryId", "subcategoryId")
+
+	result, err := query.ToSQL()
+	if err != nil {
+		return nil, oops.WithContext(ctx).In(apperrors.LayerRepository).Wrap(err)
+	}
+
+	r.logger.WithContext(ctx).Debug("executing query", "sql", result.SQL)
+
+	rows, err := r.db.Query(ctx, result.SQL, result.Args...)
+	if err != nil {
+		return nil, oops.WithContext(ctx).In(apperrors.LayerRepository).Wrap(err)
+	}
+	defer rows.Close()
+
+	var lines basedomain.List[port.LineActivity]
+	for rows.Next() {
+		var (
+			l                       port.LineActivity
+			transactions, converted int64
+		)
+		err = rows.Scan(
+			&l.CategoryID,
+			&l.SubcategoryID,
+			&l.Activity,
+			&transactions,
+			&converted,
+		)
+		if err != nil {
+			return nil, oops.WithContext(ctx).In(apperrors.LayerRepository).Wrap(err)
+		}
+		if converted != transactions {
+			return nil, oops.WithContext(ctx).In(apperrors.LayerRepository).
+				Code(apperrors.CodeConflict).
+				Public("An exchange rate is missing for one of the currencies used in this budget.").
+				Errorf("%d transactions of budget %s could not be converted", transactions-converted, budgetID)
+		}
+		lines = append(lines, l)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, oops.WithContext(ctx).In(apperrors.LayerRepository).Wrap(err)
+	}
+
+	return lines, nil
+}
+
+func (r postgres) BudgetPlan(ctx context.Context, budgetID uuid.UUID) (basedomain.List[port.LinePlan], error) {
+	query := sqlcraft.Select(
+		"category_id",
+		"subcategory_id",
+		sqlcraft.As(sqlcraft.Sum("assigned_amount"), "assigned"),
+		sqlcraft.As(sqlcraft.Sum("carryover_amount"), "carryover"),
+	).
+		From("budget.budget_allocations").
+		Where(dafi.FilterBy("budgetId", dafi.Equal, budgetID)...).
+		SQLColumnByDomainField(planSQLColumnByDomainField).
+		GroupBy("categoryId", "subcategoryId")
+
+	result, err := query.ToSQL()
+	if err != nil {
+		return nil, oops.WithContext(ctx).In(apperrors.LayerRepository).Wrap(err)
+	}
+
+	r.logger.WithContext(ctx).Debug("executing query", "sql", result.SQL)
+
+	rows, err := r.db.Query(ctx, result.SQL, result.Args...)
+	if err != nil {
+		return nil, oops.WithContext(ctx).In(apperrors.LayerRepository).Wrap(err)
+	}
+	defer rows.Close()
+
+	var lines basedomain.List[port.LinePlan]
+	for rows.Next() {
+		var l port.LinePlan
+		err = rows.Scan(
+			&l.CategoryID,
+			&l.SubcategoryID,
+			&l.Assigned,
+			&l.Carryover,
+		)
+		if err != nil {
+			return nil, oops.WithContext(ctx).In(apperrors.LayerRepository).Wrap(err)
+		}
+		lines = append(lines, l)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, oops.WithContext(ctx).In(apperrors.LayerRepository).Wrap(err)
+	}
+
+	return lines, nil
+}
+
+func (r postgres) TagActivity(ctx context.Context, budgetID uuid.UUID) (basedomain.List[port.TagLine], error) {
+	query := sqlcraft.Select(
+		"tt.tag_id",
+		"g.name",
+		sqlcraft.As(sqlcraft.Sum(convertedAmount), "activity"),
+		sqlcraft.As(sqlcraft.Count("*"), "transactions"),
+		sqlcraft.As(sqlcraft.Count(convertedAmount), "converted"),
+	).
+		From("budget.transactions t").
+		InnerJoin("budget.accounts a", "a.id = t.account_id").
+		InnerJoin("budget.budgets b", "b.id = t.budget_id").
+		LeftJoin("budget.transaction_tags tt", "tt.transaction_id = t.id").
+		LeftJoin("budget.tags g", "g.id = tt.tag_id").
+		Where(dafi.FilterBy("budgetId", dafi.Equal, budgetID)...).
+		SQLColumnByDomainField(tagSQLColumnByDomainField).
+		GroupBy("tagId", "tagName")
+
+	result, err := query.ToSQL()
+	if err != nil {
+		return nil, oops.WithContext(ctx).In(apperrors.LayerRepository).Wrap(err)
+	}
+
+	r.logger.WithContext(ctx).Debug("executing query", "sql", result.SQL)
+
+	rows, err := r.db.Query(ctx, result.SQL, result.Args...)
+	if err != nil {
+		return nil, oops.WithContext(ctx).In(apperrors.LayerRepository).Wrap(err)
+	}
+	defer rows.Close()
+
+	var lines basedomain.List[port.TagLine]
+	for rows.Next() {
+		var (
+			l         port.TagLine
+			converted int64
+		)
+		err = rows.Scan(
+			&l.TagID,
+			&l.Name,
+			&l.Activity,
+			&l.Transactions,
+			&converted,
+		)
+		if err != nil {
+			return nil, oops.WithContext(ctx).In(apperrors.LayerRepository).Wrap(err)
+		}
+		if converted != l.Transactions {
+			return nil, oops.WithContext(ctx).In(apperrors.LayerRepository).
+				Code(apperrors.CodeConflict).
+				Public("An exchange rate is missing for one of the currencies used in this budget.").
+				Errorf("%d transactions of budget %s could not be converted", l.Transactions-converted, budgetID)
+		}
+		lines = append(lines, l)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, oops.WithContext(ctx).In(apperrors.LayerRepository).Wrap(err)
+	}
+
+	return lines, nil
+}
@@ -0,0 +1,222 @@
+package core
+
+import (
+	"context"
+	"math"
+	"sort"
+
+	budgetport "backend/core/budget/budget/port"
+	categoryport "backend/core/budget/category/port"
+	"backend/core/budget/report/port"
+	"backend/infra/dafi"
+	"backend/infra/money"
+	basedomain "backend/port"
+	apperrors "backend/port/errors"
+
+	"github.com/google/uuid"
+	"github.com/guregu/null/v6"
+	"github.com/samber/oops"
+)
+
+type service struct {
+	repo         port.Repository
+	budgetRepo   budgetport.Repository
+	categoryRepo categoryport.Repository
+	logger       basedomain.Logger
+}
+
+func New(repo port.Repository, budgetRepo budgetport.Repository, categoryRepo categoryport.Repository, logger basedomain.Logger) port.Service {
+	return service{
+		repo:         repo,
+		budgetRepo:   budgetRepo,
+		categoryRepo: categoryRepo,
+		logger:       logger.With("component", "report.service"),
+	}
+}
+
+func (s service) BudgetVsActual(ctx context.Context, budgetID uuid.UUID) (port.BudgetVsActual, error) {
+	b, err := s.budgetRepo.FindOne(ctx, dafi.Where("id", dafi.Equal, budgetID))
+	if err != nil {
+		return port.BudgetVsActual{}, oops.WithContext(ctx).In(apperrors.LayerService).Wrap(err)
+	}
+
+	categories, err := s.categoryRepo.FindAll(ctx, dafi.Where("organizationId", dafi.Equal, b.OrganizationID))
+	if err != nil {
+		return port.BudgetVsActual{}, oops.WithContext(ctx).In(apperrors.LayerService).Wrap(err)
+	}
+
+	plan, err := s.repo.BudgetPlan(ctx, budgetID)
+	if err != nil {
+		return port.BudgetVsActual{}, oops.WithContext(ctx).In(apperrors.LayerService).Wrap(err)
+	}
+
+	activity, err := s.repo.BudgetActivity(ctx, budgetID)
+	if err != nil {
+		return port.BudgetVsActual{}, oops.WithContext(ctx).In(apperrors.LayerService).Wrap(err)
+	}
+
+	report := newRollup(categories)
+	for _, p := range plan {
+		line := report.line(&p.CategoryID, p.SubcategoryID)
+		line.Planned += p.Assigned
+		line.Carryover += p.Carryover
+	}
+
+	var uncategorized money.Minor
+	for _, a := range activity {
+		line := report.line(a.CategoryID, a.SubcategoryID)
+		if line == nil {
+			uncategorized += a.Activity
+			continue
+		}
+		line.Activity += a.Activity
+	}
+
+	return port.BudgetVsActual{
+		BudgetID:      b.ID,
+		CurrencyCode:  b.CurrencyCode,
+		Categories:    report.lines(),
+		Uncategorized: uncategorized,
+	}, nil
+}
+
+func (s service) TagActivity(ctx context.Context, budgetID uuid.UUID) (port.TagActivity, error) {
+	b, err := s.budgetRepo.FindOne(ctx, dafi.Where("id", dafi.Equal, budgetID))
+	if err != nil {
+		return port.TagActivity{}, oops.WithContext(ctx).In(apperrors.LayerService).Wrap(err)
+	}
+
+	activity, err := s.repo.TagActivity(ctx, budgetID)
+	if err != nil {
+		return port.TagActivity{}, oops.WithContext(ctx).In(apperrors.LayerService).Wrap(err)
+	}
+
+	report := port.TagActivity{
+		BudgetID:     b.ID,
+		CurrencyCode: b.CurrencyCode,
+		Tags:         make([]port.TagActivityLine, 0, len(activity)),
+	}
+	for _, a := range activity {
+		if a.TagID == nil {
+			report.Untagged += a.Activity
+			continue
+		}
+		report.Tags = append(report.Tags, port.TagActivityLine{
+			TagID:        *a.TagID,
+			Name:         a.Name.String,
+			Activity:     a.Activity,
+			Transactions: a.Transactions,
+		})
+	}
+
+	sort.Slice(report.Tags, func(i, j int) bool {
+		if report.Tags[i].Name != report.Tags[j].Name {
+			return report.Tags[i].Name < report.Tags[j].Name
+		}
+		return report.Tags[i].TagID.String() < report.Tags[j].TagID.String()
+	})
+
+	return report, nil
+}
+
+// rollup accumulates report lines per top-level category, with one nested line per
+// subcategory that has plan or activity.
+type rollup struct {
+	categories map[uuid.UUID]categoryport.Category
+	parents    map[uuid.UUID]*port.BudgetVsActualLine
+	children   map[uuid.UUID]map[uuid.UUID]*port.BudgetVsActualLine
+}
+
+func newRollup(categories basedomain.List[categoryport.Category]) rollup {
+	r := rollup{
+		categories: make(map[uuid.UUID]categoryport.Category, len(categories)),
+		parents:    make(map[uuid.UUID]*port.BudgetVsActualLine),
+		children:   make(map[uuid.UUID]map[uuid.UUID]*port.BudgetVsActualLine),
+	}
+	for _, c := range categories {
+		r.categories[c.ID] = c
+	}
+
+	return r
+}
+
+// line returns the line amounts of a category line are added to. A line booked
+// directly on a subcategory, without one in SubcategoryID, is moved under its parent.
+// It returns nil for uncategorized lines.
+func (r rollup) line(categoryID, subcategoryID *uuid.UUID) *port.BudgetVsActualLine {
+	if categoryID == nil {
+		return nil
+	}
+
+	parentID, childID := *categoryID, subcategoryID
+	if childID == nil {
+		if c, ok := r.categories[parentID]; ok && c.ParentID != nil {
+			parentID, childID = *c.ParentID, categoryID
+		}
+	}
+
+	if _, ok := r.parents[parentID]; !ok {
+		r.parents[parentID] = &port.BudgetVsActualLine{CategoryID: parentID, Name: r.categories[parentID].Name}
+	}
+	if childID == nil {
+		return r.parents[parentID]
+	}
+
+	if r.children[parentID] == nil {
+		r.children[parentID] = make(map[uuid.UUID]*port.BudgetVsActualLine)
+	}
+	if _, ok := r.children[parentID][*childID]; !ok {
+		r.children[parentID][*childID] = &port.BudgetVsActualLine{CategoryID: *childID, Name: r.categories[*childID].Name}
+	}
+
+	return r.children[parentID][*childID]
+}
+
+// lines returns the top-level lines sorted by name, each holding the totals of its
+// own amounts plus those of its subcategories.
+func (r rollup) lines() []port.BudgetVsActualLine {
+	lines := make([]port.BudgetVsActualLine, 0, len(r.parents))
+	for id, parent := range r.parents {
+		line := *parent
+		for _, child := range r.children[id] {
+			sub := complete(*child)
+			line.Planned += sub.Planned
+			line.Carryover += sub.Carryover
+			line.Activity += sub.Activity
+			line.Subcategories = append(line.Subcategories, sub)
+		}
+		sortByName(line.Subcategories)
+		lines = append(lines, complete(line))
+	}
+	sortByName(lines)
+
+	return lines
+}
+
+func complete(line port.BudgetVsActualLine) port.BudgetVsActualLine {
+	line.Remaining = line.Carryover + line.Planned + line.Activity
+	line.PercentUsed = percentUsed(line.Carryover+line.Planned, line.Activity)
+
+	return line
+}
+
+// percentUsed is the share of available spent by activity, rounded to two decimals.
+// Inflows lower it and it can go beyond 100 when the category is overspent.
+func percentUsed(available, activity money.Minor) null.Float {
+	if available <= 0 {
+		return null.Float{}
+	}
+
+	pct := float64(-activity) / float64(available) * 100
+
+	return null.FloatFrom(math.Round(pct*100) / 100)
+}
+
+func sortByName(lines []port.BudgetVsActualLine) {
+	sort.Slice(lines, func(i, j int) bool {
+		if lines[i].Name != lines[j].Name {
+			return lines[i].Name < lines[j].Name
+		}
+		return lines[i].CategoryID.String() < lines[j].CategoryID.String()
+	})
+}
@@ -0,0 +1,187 @@
+package core
+
+import (
+	"context"
+	"testing"
+
+	budgetport "backend/core/budget/budget/port"
+	categoryport "backend/core/budget/category/port"
+	"backend/core/budget/report/port"
+	"backend/infra/dafi"
+	"backend/infra/money"
+	basedomain "backend/port"
+
+	"github.com/google/uuid"
+	"github.com/guregu/null/v6"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type noopLogger struct{}
+
+func (noopLogger) Debug(string, ...any)                          {}
+func (noopLogger) Info(string, ...any)                           {}
+func (noopLogger) Warn(string, ...any)                           {}
+func (noopLogger) Error(string, ...any)                          {}
+func (noopLogger) With(...any) basedomain.Logger                 { return noopLogger{} }
+func (noopLogger) WithContext(context.Context) basedomain.Logger { return noopLogger{} }
+
+type stubReportRepo struct {
+	plan     basedomain.List[port.LinePlan]
+	activity basedomain.List[port.LineActivity]
+	tags     basedomain.List[port.TagLine]
+}
+
+func (s stubReportRepo) BudgetActivity(context.Context, uuid.UUID) (basedomain.List[port.LineActivity], error) {
+	return s.activity, nil
+}
+
+func (s stubReportRepo) BudgetPlan(context.Context, uuid.UUID) (basedomain.List[port.LinePlan], error) {
+	return s.plan, nil
+}
+
+func (s stubReportRepo) TagActivity(context.Context, uuid.UUID) (basedomain.List[port.TagLine], error) {
+	return s.tags, nil
+}
+
+func (s stubReportRepo) WithTx(basedomain.Transaction) port.Repository { return s }
+
+type stubBudgetRepo struct {
+	budgetport.Repository
+	budget budgetport.Budget
+}
+
+func (s stubBudgetRepo) FindOne(context.Context, dafi.Criteria) (budgetport.Budget, error) {
+	return s.budget, nil
+}
+
+type stubCategoryRepo struct {
+	categoryport.Repository
+	categories basedomain.List[categoryport.Category]
+}
+
+func (s stubCategoryRepo) FindAll(context.Context, dafi.Criteria) (basedomain.List[categoryport.Category], error) {
+	return s.categories, nil
+}
+
+func TestService_BudgetVsActual(t *testing.T) {
+	budgetID := uuid.New()
+	food := uuid.New()
+	groceries := uuid.New()
+	dining := uuid.New()
+	rent := uuid.New()
+
+	categories := basedomain.List[categoryport.Category]{
+		{ID: food, Name: "Food"},
+		{ID: groceries, ParentID: &food, Name: "Groceries"},
+		{ID: dining, ParentID: &food, Name: "Dining"},
+		{ID: rent, Name: "Rent"},
+	}
+
+	repo := stubReportRepo{
+		plan: basedomain.List[port.LinePlan]{
+			{CategoryID: food, SubcategoryID: &groceries, Assigned: 30000, Carryover: 2000},
+			{CategoryID: food, SubcategoryID: &dining, Assigned: 10000},
+			{CategoryID: rent, Assigned: 100000},
+		},
+		activity: basedomain.List[port.LineActivity]{
+			{CategoryID: &food, SubcategoryID: &groceries, Activity: -16000},
+			// Booked on the subcategory itself, without a parent category.
+			{CategoryID: &dining, Activity: -12500},
+			{CategoryID: &rent, Activity: -100000},
+			{Activity: -4200},
+		},
+	}
+	budgets := stubBudgetRepo{budget: budgetport.Budget{ID: budgetID, OrganizationID: "org_1", CurrencyCode: "USD"}}
+
+	svc := New(repo, budgets, stubCategoryRepo{categories: categories}, noopLogger{})
+
+	report, err := svc.BudgetVsActual(context.Background(), budgetID)
+	require.NoError(t, err)
+
+	assert.Equal(t, budgetID, report.BudgetID)
+	assert.Equal(t, "USD", report.CurrencyCode)
+	assert.Equal(t, money.Minor(-4200), report.Uncategorized)
+	require.Len(t, report.Categories, 2)
+
+	foodLine := report.Categories[0]
+	assert.Equal(t, "Food", foodLine.Name)
+	assert.Equal(t, money.Minor(40000), foodLine.Planned)
+	assert.Equal(t, money.Minor(2000), foodLine.Carryover)
+	assert.Equal(t, money.Minor(-28500), foodLine.Activity)
+	assert.Equal(t, money.Minor(13500), foodLine.Remaining)
+	assert.Equal(t, null.FloatFrom(67.86), foodLine.PercentUsed)
+
+	require.Len(t, foodLine.Subcategories, 2)
+	assert.Equal(t, "Dining", foodLine.Subcategories[0].Name)
+	assert.Equal(t, money.Minor(-2500), foodLine.Subcategories[0].Remaining)
+	assert.Equal(t, null.FloatFrom(125), foodLine.Subcategories[0].PercentUsed)
+	assert.Equal(t, "Groceries", foodLine.Subcategories[1].Name)
+	assert.Equal(t, money.Minor(16000), foodLine.Subcategories[1].Remaining)
+	assert.Equal(t, null.FloatFrom(50), foodLine.Subcategories[1].PercentUsed)
+
+	rentLine := report.Categories[1]
+	assert.Equal(t, "Rent", rentLine.Name)
+	assert.Equal(t, money.Minor(0), rentLine.Remaining)
+	assert.Equal(t, null.FloatFrom(100), rentLine.PercentUsed)
+	assert.Empty(t, rentLine.Subcategories)
+}
+
+func TestService_TagActivity(t *testing.T) {
+	budgetID := uuid.New()
+	vacation := uuid.New()
+	work := uuid.New()
+
+	repo := stubReportRepo{
+		tags: basedomain.List[port.TagLine]{
+			{TagID: &work, Name: null.StringFrom("Work"), Activity: -3000, Transactions: 1},
+			{TagID: &vacation, Name: null.StringFrom("Vacation"), Activity: -52000, Transactions: 4},
+			{Activity: -9100, Transactions: 7},
+		},
+	}
+	budgets := stubBudgetRepo{budget: budgetport.Budget{ID: budgetID, OrganizationID: "org_1", CurrencyCode: "EUR"}}
+
+	svc := New(repo, budgets, stubCategoryRepo{}, noopLogger{})
+
+	report, err := svc.TagActivity(context.Background(), budgetID)
+	require.NoError(t, err)
+
+	assert.Equal(t, budgetID, report.BudgetID)
+	assert.Equal(t, "EUR", report.CurrencyCode)
+	assert.Equal(t, money.Minor(-9100), report.Untagged)
+	assert.Equal(t, []port.TagActivityLine{
+		{TagID: vacation, Name: "Vacation", Activity: -52000, Transactions: 4},
+		{TagID: work, Name: "Work", Activity: -3000, Transactions: 1},
+	}, report.Tags)
+}
+
+func TestService_TagActivityWithoutTags(t *testing.T) {
+	budgets := stubBudgetRepo{budget: budgetport.Budget{ID: uuid.New(), CurrencyCode: "EUR"}}
+	svc := New(stubReportRepo{}, budgets, stubCategoryRepo{}, noopLogger{})
+
+	report, err := svc.TagActivity(context.Background(), budgets.budget.ID)
+	require.NoError(t, err)
+
+	assert.NotNil(t, report.Tags)
+	assert.Empty(t, report.Tags)
+}
+
+func TestPercentUsed(t *testing.T) {
+	tests := []struct {
+		name      string
+		available money.Minor
+		activity  money.Minor
+		want      null.Float
+	}{
+		{name: "nothing planned", available: 0, activity: -500, want: null.Float{}},
+		{name: "overspent carryover", available: -1000, activity: -500, want: null.Float{}},
+		{name: "partially spent", available: 3000, activity: -1000, want: null.FloatFrom(33.33)},
+		{name: "refund only", available: 1000, activity: 250, want: null.FloatFrom(-25)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, percentUsed(tt.available, tt.activity))
+		})
+	}
+}
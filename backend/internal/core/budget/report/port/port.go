@@ -0,0 +1,25 @@
+package port
+
+import (
+	"context"
+
+	basedomain "backend/port"
+
+	"github.com/google/uuid"
+)
+
+type Repository interface {
+	basedomain.RepositoryTx[Repository]
+	// BudgetActivity sums the transactions of a budget per category line, converted to
+	// the budget currency. Split transactions count through their split lines.
+	BudgetActivity(ctx context.Context, budgetID uuid.UUID) (basedomain.List[LineActivity], error)
+	BudgetPlan(ctx context.Context, budgetID uuid.UUID) (basedomain.List[LinePlan], error)
+	// TagActivity sums the transactions of a budget per tag, converted to the budget
+	// currency.
+	TagActivity(ctx context.Context, budgetID uuid.UUID) (basedomain.List[TagLine], error)
+}
+
+type Service interface {
+	BudgetVsActual(ctx context.Context, budgetID uuid.UUID) (BudgetVsActual, error)
+	TagActivity(ctx context.Context, budgetID uuid.UUID) (TagActivity, error)
+}
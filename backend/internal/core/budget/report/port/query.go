@@ -0,0 +1,77 @@
+package port
+
+import (
+	"backend/infra/money"
+	"github.com/google/uuid"
+	"github.com/guregu/null/v6"
+)
+
+// BudgetVsActual compares what was planned for each category of a budget month with
+// what actually happened, in minor units of the budget currency. Categories are
+// rolled up to their parent: a parent line includes its subcategories.
+type BudgetVsActual struct {
+	BudgetID      uuid.UUID            `json:"budgetId"`
+	CurrencyCode  string               `json:"currencyCode"`
+	Categories    []BudgetVsActualLine `json:"categories"`
+	Uncategorized money.Minor          `json:"uncategorized"`
+}
+
+// BudgetVsActualLine is the report line of one category. Activity is the net of its
+// transactions (spending is negative), Remaining what is left of Carryover + Planned
+// after it, and PercentUsed the share of Carryover + Planned spent, null when
+// nothing was planned.
+type BudgetVsActualLine struct {
+	CategoryID    uuid.UUID            `json:"categoryId"`
+	Name          string               `json:"name"`
+	Planned       money.Minor          `json:"planned"`
+	Carryover     money.Minor          `json:"carryover"`
+	Activity      money.Minor          `json:"activity"`
+	Remaining     money.Minor          `json:"remaining"`
+	PercentUsed   null.Float           `json:"percentUsed"`
+	Subcategories []BudgetVsActualLine `json:"subcategories,omitempty"`
+}
+
+// LineActivity is the net of the transactions of a budget on one category line. A
+// nil CategoryID groups the uncategorized transactions.
+type LineActivity struct {
+	CategoryID    *uuid.UUID
+	SubcategoryID *uuid.UUID
+	Activity      money.Minor
+}
+
+// LinePlan is what was planned for a budget on one category line.
+type LinePlan struct {
+	CategoryID    uuid.UUID
+	SubcategoryID *uuid.UUID
+	Assigned      money.Minor
+	Carryover     money.Minor
+}
+
+// TagActivity sums the transactions of a budget per tag, in minor units of the budget
+// currency. A transaction carrying several tags counts under each of them, so the
+// lines may add up to more than the budget activity. Untagged is the net of the
+// transactions without any tag.
+type TagActivity struct {
+	BudgetID     uuid.UUID         `json:"budgetId"`
+	CurrencyCode string            `json:"currencyCode"`
+	Tags         []TagActivityLine `json:"tags"`
+	Untagged     money.Minor       `json:"untagged"`
+}
+
+// TagActivityLine is the report line of one tag: the net of the transactions carrying
+// it (spending is negative) and how many they are.
+type TagActivityLine struct {
+	TagID        uuid.UUID   `json:"tagId"`
+	Name         string      `json:"name"`
+	Activity     money.Minor `json:"activity"`
+	Transactions int64       `json:"transactions"`
+}
+
+// TagLine is the net of the transactions of a budget carrying one tag. A nil TagID
+// groups the transactions without tags.
+type TagLine struct {
+	TagID        *uuid.UUID
+	Name         null.String
+	Activity     money.Minor
+	Transactions int64
+}
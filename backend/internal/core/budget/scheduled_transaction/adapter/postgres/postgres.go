@@ -0,0 +1,402 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"backend/adapter/database"
+	"backend/core/budget/scheduled_transaction/port"
+	"backend/infra/dafi"
+	"backend/infra/sqlcraft"
+	basedomain "backend/port"
+	apperrors "backend/port/errors"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/samber/oops"
+)
+
+type dbConn interface {
+	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+}
+
+const tableName = "budget.scheduled_transactions"
+
+var columns = []string{
+	"id",
+	"organization_id",
+	"account_id",
+	"category_id",
+	"subcategory_id",
+	"type",
+	"amount",
+	"description",
+	"frequency",
+	"interval_count",
+	"start_date",
+	"end_date",
+	"occurrence_count",
+	"occurrences_generated",
+	"next_occurrence",
+	"is_active",
+	"failed_attempts",
+	"retry_after",
+	"created_at",
+	"updated_at",
+}
+
+var sqlColumnByDomainField = map[string]string{
+	"id":                   "id",
+	"organizationId":       "organization_id",
+	"accountId":            "account_id",
+	"categoryId":           "category_id",
+	"subcategoryId":        "subcategory_id",
+	"type":                 "type",
+	"amount":               "amount",
+	"description":          "description",
+	"frequency":            "frequency",
+	"interval":             "interval_count",
+	"startDate":            "start_date",
+	"endDate":              "end_date",
+	"occurrenceCount":      "occurrence_count",
+	"occurrencesGenerated": "occurrences_generated",
+	"nextOccurrence":       "next_occurrence",
+	"isActive":             "is_active",
+	"failedAttempts":       "failed_attempts",
+	"retryAfter":           "retry_after",
+	"createdAt":            "created_at",
+	"updatedAt":            "updated_at",
+}
+
+type postgres struct {
+	db     dbConn
+	logger basedomain.Logger
+}
+
+func NewPostgres(db database.PoolInterface, logger basedomain.Logger) port.Repository {
+	return postgres{
+		db:     db,
+		logger: logger.With("component", "scheduled_transaction.repository"),
+	}
+}
+
+func (r postgres) WithTx(tx basedomain.Transaction) port.Repository {
+	return postgres{
+		db:     tx.GetTx(),
+		logger: r.logger,
+	}
+}
+
+func (r postgres) FindOne(ctx context.Context, criteria dafi.Criteria) (port.ScheduledTransaction, error) {
+	query := sqlcraft.Select(columns...).
+		From(tableName).
+		Where(criteria.Filters...).
+		SQLColumnByDomainField(sqlColumnByDomainField).
+		Limit(1)
+
+	result, err := query.ToSQL()
+	if err != nil {
+		return port.ScheduledTransaction{}, oops.WithContext(ctx).In(apperrors.LayerRepository).Wrap(err)
+	}
+
+	r.logger.WithContext(ctx).Debug("executing query", "sql", result.SQL)
+
+	st, err := scan(r.db.QueryRow(ctx, result.SQL, result.Args...))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return port.ScheduledTransaction{}, oops.WithContext(ctx).In(apperrors.LayerRepository).Code(apperrors.CodeNotFound).Wrap(err)
+		}
+		return port.ScheduledTransaction{}, oops.WithContext(ctx).In(apperrors.LayerRepository).Wrap(err)
+	}
+
+	return st, nil
+}
+
+func (r postgres) FindAll(ctx context.Context, criteria dafi.Criteria) (basedomain.List[port.ScheduledTransaction], error) {
+	query := sqlcraft.Select(columns...).
+		From(tableName).
+		Where(criteria.Filters...).
+		OrderBy(criteria.Sorts...).
+		Limit(criteria.Pagination.PageSize).
+		Page(criteria.Pagination.PageNumber).
+		SQLColumnByDomainField(sqlColumnByDomainField)
+
+	result, err := query.ToSQL()
+	if err != nil {
+		return nil, oops.WithContext(ctx).In(apperrors.LayerRepository).Wrap(err)
+	}
+
+	r.logger.WithContext(ctx).Debug("executing query", "sql", result.SQL)
+
+	rows, err := r.db.Query(ctx, result.SQL, result.Args...)
+	if err != nil {
+		return nil, oops.WithContext(ctx).In(apperrors.LayerRepository).Wrap(err)
+	}
+	defer rows.Close()
+
+	var sts basedomain.List[port.ScheduledTransaction]
+	for rows.Next() {
+		st, err := scan(rows)
+		if err != nil {
+			return nil, oops.WithContext(ctx).In(apperrors.LayerRepository).Wrap(err)
+		}
+		sts = append(sts, st)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, oops.WithContext(ctx).In(apperrors.LayerRepository).Wrap(err)
+	}
+
+	return sts, nil
+}
+
+func (r postgres) Create(ctx context.Context, input port.CreateScheduledTransaction) error {
+	return r.CreateBulk(ctx, basedomain.List[port.CreateScheduledTransaction]{input})
+}
+
+func (r postgres) CreateBulk(ctx context.Context, inputs basedomain.List[port.CreateScheduledTransaction]) error {
+	if inputs.IsEmpty() {
+		return nil
+	}
+
+	now := time.Now()
+	query := sqlcraft.InsertInto(tableName).WithColumns(columns...)
+
+	for _, input := range inputs {
+		query = query.WithValues(
+			input.ID,
+			input.OrganizationID,
+			input.AccountID,
+			input.CategoryID,
+			input.SubcategoryID,
+			input.Type,
+			input.Amount,
+			input.Description,
+			input.Frequency,
+			input.Interval,
+			input.StartDate,
+			input.EndDate,
+			input.OccurrenceCount,
+			int32(0),
+			input.NextOccurrence,
+			input.IsActive,
+			int32(0),
+			nil,
+			now,
+			now,
+		)
+	}
+
+	result, err := query.ToSQL()
+	if err != nil {
+		return oops.WithContext(ctx).In(apperrors.LayerRepository).Wrap(err)
+	}
+
+	r.logger.WithContext(ctx).Debug("executing query", "sql", result.SQL, "count", len(inputs))
+
+	_, err = r.db.Exec(ctx, result.SQL, result.Args...)
+	if err != nil {
+		return oops.WithContext(ctx).In(apperrors.LayerRepository).Wrap(err)
+	}
+
+	return nil
+}
+
+func (r postgres) Update(ctx context.Context, input port.UpdateScheduledTransaction, filters ...dafi.Filter) error {
+	query := sqlcraft.Update(tableName).
+		WithColumns("account_id", "category_id", "subcategory_id", "type", "amount", "description", "end_date", "occurrence_count", "is_active", "updated_at").
+		WithValues(
+			input.AccountID,
+			input.CategoryID,
+			input.SubcategoryID,
+			input.Type,
+			input.Amount,
+			input.Description,
+			input.EndDate,
+			input.OccurrenceCount,
+			input.IsActive,
+			time.Now(),
+		).
+		Where(filters...).
+		SQLColumnByDomainField(sqlColumnByDomainField).
+		WithPartialUpdate()
+
+	result, err := query.ToSQL()
+	if err != nil {
+		return oops.WithContext(ctx).In(apperrors.LayerRepository).Wrap(err)
+	}
+
+	r.logger.WithContext(ctx).Debug("executing query", "sql", result.SQL)
+
+	_, err = r.db.Exec(ctx, result.SQL, result.Args...)
+	if err != nil {
+		return oops.WithContext(ctx).In(apperrors.LayerRepository).Wrap(err)
+	}
+
+	return nil
+}
+
+func (r postgres) Delete(ctx context.Context, filters ...dafi.Filter) error {
+	query := sqlcraft.DeleteFrom(tableName).
+		Where(filters...).
+		SQLColumnByDomainField(sqlColumnByDomainField)
+
+	result, err := query.ToSQL()
+	if err != nil {
+		return oops.WithContext(ctx).In(apperrors.LayerRepository).Wrap(err)
+	}
+
+	r.logger.WithContext(ctx).Debug("executing query", "sql", result.SQL)
+
+	_, err = r.db.Exec(ctx, result.SQL, result.Args...)
+	if err != nil {
+		return oops.WithContext(ctx).In(apperrors.LayerRepository).Wrap(err)
+	}
+
+	return nil
+}
+
+func (r postgres) FindDueIDs(ctx context.Context, today, now time.Time, limit uint) ([]uuid.UUID, error) {
+	filters := dafi.FilterBy("isActive", dafi.Equal, true).
+		And("nextOccurrence", dafi.LessOrEqual, today).
+		AndGroup(dafi.FilterBy("retryAfter", dafi.IsNull, nil).Or("retryAfter", dafi.LessOrEqual, now)...)
+
+	query := sqlcraft.Select("id").
+		From(tableName).
+		Where(filters...).
+		OrderBy(
+			dafi.Sort{Field: "failedAttempts", Type: dafi.Asc},
+			dafi.Sort{Field: "nextOccurrence", Type: dafi.Asc},
+		).
+		Limit(limit).
+		SQLColumnByDomainField(sqlColumnByDomainField)
+
+	result, err := query.ToSQL()
+	if err != nil {
+		return nil, oops.WithContext(ctx).In(apperrors.LayerRepository).Wrap(err)
+	}
+
+	r.logger.WithContext(ctx).Debug("executing query", "sql", result.SQL)
+
+	rows, err := r.db.Query(ctx, result.SQL, result.Args...)
+	if err != nil {
+		return nil, oops.WithContext(ctx).In(apperrors.LayerRepository).Wrap(err)
+	}
+	defer rows.Close()
+
+	var ids []uuid.UUID
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, oops.WithContext(ctx).In(apperrors.LayerRepository).Wrap(err)
+		}
+		ids = append(ids, id)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, oops.WithContext(ctx).In(apperrors.LayerRepository).Wrap(err)
+	}
+
+	return ids, nil
+}
+
+func (r postgres) Lock(ctx context.Context, id uuid.UUID) (port.ScheduledTransaction, error) {
+	query := sqlcraft.Select(columns...).
+		From(tableName).
+		Where(dafi.FilterBy("id", dafi.Equal, id)...).
+		SQLColumnByDomainField(sqlColumnByDomainField)
+
+	result, err := query.ToSQL()
+	if err != nil {
+		return port.ScheduledTransaction{}, oops.WithContext(ctx).In(apperrors.LayerRepository).Wrap(err)
+	}
+
+	sql := result.SQL + " FOR UPDATE"
+	r.logger.WithContext(ctx).Debug("executing query", "sql", sql)
+
+	st, err := scan(r.db.QueryRow(ctx, sql, result.Args...))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return port.ScheduledTransaction{}, oops.WithContext(ctx).In(apperrors.LayerRepository).Code(apperrors.CodeNotFound).Wrap(err)
+		}
+		return port.ScheduledTransaction{}, oops.WithContext(ctx).In(apperrors.LayerRepository).Wrap(err)
+	}
+
+	return st, nil
+}
+
+func (r postgres) Advance(ctx context.Context, id uuid.UUID, occurrencesGenerated int32, nextOccurrence *time.Time) error {
+	query := sqlcraft.Update(tableName).
+		WithColumns("occurrences_generated", "next_occurrence", "failed_attempts", "retry_after", "updated_at").
+		WithValues(occurrencesGenerated, nextOccurrence, int32(0), nil, time.Now()).
+		Where(dafi.FilterBy("id", dafi.Equal, id)...).
+		SQLColumnByDomainField(sqlColumnByDomainField)
+
+	result, err := query.ToSQL()
+	if err != nil {
+		return oops.WithContext(ctx).In(apperrors.LayerRepository).Wrap(err)
+	}
+
+	r.logger.WithContext(ctx).Debug("executing query", "sql", result.SQL)
+
+	_, err = r.db.Exec(ctx, result.SQL, result.Args...)
+	if err != nil {
+		return oops.WithContext(ctx).In(apperrors.LayerRepository).Wrap(err)
+	}
+
+	return nil
+}
+
+func (r postgres) RecordFailure(ctx context.Context, id uuid.UUID, failedAttempts int32, retryAfter time.Time) error {
+	query := sqlcraft.Update(tableName).
+		WithColumns("failed_attempts", "retry_after", "updated_at").
+		WithValues(failedAttempts, retryAfter, time.Now()).
+		Where(dafi.FilterBy("id", dafi.Equal, id)...).
+		SQLColumnByDomainField(sqlColumnByDomainField)
+
+	result, err := query.ToSQL()
+	if err != nil {
+		return oops.WithContext(ctx).In(apperrors.LayerRepository).Wrap(err)
+	}
+
+	r.logger.WithContext(ctx).Debug("executing query", "sql", result.SQL)
+
+	_, err = r.db.Exec(ctx, result.SQL, result.Args...)
+	if err != nil {
+		return oops.WithContext(ctx).In(apperrors.LayerRepository).Wrap(err)
+	}
+
+	return nil
+}
+
+func scan(row pgx.Row) (port.ScheduledTransaction, error) {
+	var st port.ScheduledTransaction
+	err := row.Scan(
+		&st.ID,
+		&st.OrganizationID,
+		&st.AccountID,
+		&st.CategoryID,
+		&st.SubcategoryID,
+		&st.Type,
+		&st.Amount,
+		&st.Description,
+		&st.Frequency,
+		&st.Interval,
+		&st.StartDate,
+		&st.EndDate,
+		&st.OccurrenceCount,
+		&st.OccurrencesGenerated,
+		&st.NextOccurrence,
+		&st.IsActive,
+		&st.FailedAttempts,
+		&st.RetryAfter,
+		&st.CreatedAt,
+		&st.UpdatedAt,
+	)
+
+	return st, err
+}
@@ -0,0 +1,324 @@
+package core
+
+import (
+	"context"
+	"time"
+
+	"backend/core/budget/scheduled_transaction/port"
+	transactionport "backend/core/budget/transaction/port"
+	"backend/infra/dafi"
+	basedomain "backend/port"
+	apperrors "backend/port/errors"
+
+	"github.com/google/uuid"
+	"github.com/samber/oops"
+)
+
+const (
+	// dueBatchSize caps how many schedules a single GenerateDue call processes.
+	dueBatchSize = 100
+	// maxOccurrencesPerRun caps how many transactions one schedule generates per call,
+	// so a schedule started far in the past catches up over several runs.
+	maxOccurrencesPerRun = 100
+	// firstRetryDelay is how long a schedule that failed to generate waits before it
+	// is tried again. The wait doubles with every further failure in a row, up to
+	// maxRetryDelay.
+	firstRetryDelay = 15 * time.Minute
+	maxRetryDelay   = 24 * time.Hour
+)
+
+type service struct {
+	repo           port.Repository
+	transactionSvc transactionport.Service
+	uow            basedomain.UnitOfWork
+	tx             basedomain.Transaction
+	logger         basedomain.Logger
+}
+
+func New(repo port.Repository, transactionSvc transactionport.Service, uow basedomain.UnitOfWork, logger basedomain.Logger) port.Service {
+	return service{
+		repo:           repo,
+		transactionSvc: transactionSvc,
+		uow:            uow,
+		logger:         logger.With("component", "scheduled_transaction.service"),
+	}
+}
+
+func (s service) WithTx(tx basedomain.Transaction) port.Service {
+	return s.withTx(tx)
+}
+
+func (s service) withTx(tx basedomain.Transaction) service {
+	return service{
+		repo:           s.repo.WithTx(tx),
+		transactionSvc: s.transactionSvc.WithTx(tx),
+		uow:            s.uow,
+		tx:             tx,
+		logger:         s.logger,
+	}
+}
+
+func (s service) FindOne(ctx context.Context, criteria dafi.Criteria) (port.ScheduledTransaction, error) {
+	st, err := s.repo.FindOne(ctx, criteria)
+	if err != nil {
+		return port.ScheduledTransaction{}, oops.WithContext(ctx).In(apperrors.LayerService).Wrap(err)
+	}
+
+	return st, nil
+}
+
+func (s service) FindAll(ctx context.Context, criteria dafi.Criteria) (basedomain.List[port.ScheduledTransaction], error) {
+	sts, err := s.repo.FindAll(ctx, criteria)
+	if err != nil {
+		return nil, oops.WithContext(ctx).In(apperrors.LayerService).Wrap(err)
+	}
+
+	return sts, nil
+}
+
+func (s service) Create(ctx context.Context, input port.CreateScheduledTransaction) error {
+	if err := input.Validate(ctx); err != nil {
+		return oops.WithContext(ctx).In(apperrors.LayerService).Code(apperrors.CodeValidation).Wrap(err)
+	}
+
+	input.NextOccurrence = occurrence(scheduleOf(input), 0)
+
+	if err := s.repo.Create(ctx, input); err != nil {
+		return oops.WithContext(ctx).In(apperrors.LayerService).Wrap(err)
+	}
+
+	s.logger.WithContext(ctx).Info("scheduled transaction created", "frequency", input.Frequency, "interval", input.Interval)
+
+	return nil
+}
+
+func (s service) CreateBulk(ctx context.Context, inputs basedomain.List[port.CreateScheduledTransaction]) error {
+	for i := range inputs {
+		if err := inputs[i].Validate(ctx); err != nil {
+			return oops.WithContext(ctx).In(apperrors.LayerService).Code(apperrors.CodeValidation).Wrap(err)
+		}
+		inputs[i].NextOccurrence = occurrence(scheduleOf(inputs[i]), 0)
+	}
+
+	if err := s.repo.CreateBulk(ctx, inputs); err != nil {
+		return oops.WithContext(ctx).In(apperrors.LayerService).Wrap(err)
+	}
+
+	s.logger.WithContext(ctx).Info("scheduled transactions created", "count", len(inputs))
+
+	return nil
+}
+
+func (s service) Update(ctx context.Context, input port.UpdateScheduledTransaction, filters ...dafi.Filter) error {
+	if err := input.Validate(ctx); err != nil {
+		return oops.WithContext(ctx).In(apperrors.LayerService).Code(apperrors.CodeValidation).Wrap(err)
+	}
+
+	if input.EndDate == nil && !input.OccurrenceCount.Valid {
+		if err := s.repo.Update(ctx, input, filters...); err != nil {
+			return oops.WithContext(ctx).In(apperrors.LayerService).Wrap(err)
+		}
+
+		s.logger.WithContext(ctx).Info("scheduled transaction updated")
+
+		return nil
+	}
+
+	// Moving the end of the schedule moves its next occurrence too, so it is
+	// recomputed under the same lock the generator takes.
+	current, err := s.repo.FindOne(ctx, dafi.Criteria{Filters: filters})
+	if err != nil {
+		return oops.WithContext(ctx).In(apperrors.LayerService).Wrap(err)
+	}
+
+	var next *time.Time
+	err = basedomain.Atomically(ctx, s.uow, s.tx, s.withTx, func(txSvc service) error {
+		locked, err := txSvc.repo.Lock(ctx, current.ID)
+		if err != nil {
+			return err
+		}
+
+		schedule := locked.Schedule()
+		if input.EndDate != nil {
+			if input.EndDate.Before(schedule.StartDate) {
+				return oops.WithContext(ctx).In(apperrors.LayerService).
+					Code(apperrors.CodeValidation).
+					Public("The end date cannot be before the start date.").
+					Errorf("end date %s is before start date %s", input.EndDate.Format(time.DateOnly), schedule.StartDate.Format(time.DateOnly))
+			}
+			schedule.EndDate = input.EndDate
+		}
+		if input.OccurrenceCount.Valid {
+			schedule.OccurrenceCount = input.OccurrenceCount
+		}
+
+		if err := txSvc.repo.Update(ctx, input, filters...); err != nil {
+			return err
+		}
+
+		next = occurrence(schedule, int(locked.OccurrencesGenerated))
+		return txSvc.repo.Advance(ctx, locked.ID, locked.OccurrencesGenerated, next)
+	})
+	if err != nil {
+		return oops.WithContext(ctx).In(apperrors.LayerService).Wrap(err)
+	}
+
+	s.logger.WithContext(ctx).Info("scheduled transaction updated", "nextOccurrence", next)
+
+	return nil
+}
+
+func (s service) Delete(ctx context.Context, filters ...dafi.Filter) error {
+	if err := s.repo.Delete(ctx, filters...); err != nil {
+		return oops.WithContext(ctx).In(apperrors.LayerService).Wrap(err)
+	}
+
+	s.logger.WithContext(ctx).Info("scheduled transaction deleted")
+
+	return nil
+}
+
+func (s service) GenerateDue(ctx context.Context, today time.Time) (int, error) {
+	ids, err := s.repo.FindDueIDs(ctx, today, time.Now(), dueBatchSize)
+	if err != nil {
+		return 0, oops.WithContext(ctx).In(apperrors.LayerService).Wrap(err)
+	}
+
+	created := 0
+	for _, id := range ids {
+		n, err := s.generate(ctx, id, today)
+		if err != nil {
+			// One broken schedule must not hold back the others; it backs off and is
+			// retried on a later run.
+			s.logger.WithContext(ctx).Error("failed to generate scheduled transactions", "scheduledTransactionId", id, "error", err)
+			s.recordFailure(ctx, id)
+			continue
+		}
+		created += n
+	}
+
+	if created > 0 {
+		s.logger.WithContext(ctx).Info("scheduled transactions generated", "schedules", len(ids), "transactions", created)
+	}
+
+	return created, nil
+}
+
+// recordFailure backs a schedule off after it failed to generate, so it stops taking
+// the place of healthy schedules in the due queue.
+func (s service) recordFailure(ctx context.Context, id uuid.UUID) {
+	st, err := s.repo.FindOne(ctx, dafi.Where("id", dafi.Equal, id))
+	if err != nil {
+		s.logger.WithContext(ctx).Error("failed to record scheduled transaction failure", "scheduledTransactionId", id, "error", err)
+		return
+	}
+
+	attempts := st.FailedAttempts + 1
+	retryAfter := time.Now().Add(retryDelay(attempts))
+	if err := s.repo.RecordFailure(ctx, id, attempts, retryAfter); err != nil {
+		s.logger.WithContext(ctx).Error("failed to record scheduled transaction failure", "scheduledTransactionId", id, "error", err)
+		return
+	}
+
+	s.logger.WithContext(ctx).Warn("scheduled transaction backed off", "scheduledTransactionId", id, "failedAttempts", attempts, "retryAfter", retryAfter)
+}
+
+// retryDelay is how long a schedule waits after its attempts-th failure in a row.
+func retryDelay(attempts int32) time.Duration {
+	delay := firstRetryDelay
+	for i := int32(1); i < attempts && delay < maxRetryDelay; i++ {
+		delay *= 2
+	}
+	return min(delay, maxRetryDelay)
+}
+
+// generate creates the transactions of one schedule due on or before today and
+// advances it, in a single database transaction holding the schedule lock.
+// Transaction IDs are derived from the schedule and the occurrence date, so an
+// occurrence that already has its transaction is skipped instead of duplicated.
+func (s service) generate(ctx context.Context, id uuid.UUID, today time.Time) (int, error) {
+	created := 0
+	err := basedomain.Atomically(ctx, s.uow, s.tx, s.withTx, func(txSvc service) error {
+		st, err := txSvc.repo.Lock(ctx, id)
+		if err != nil {
+			return err
+		}
+		if !st.IsActive {
+			return nil
+		}
+
+		schedule := st.Schedule()
+		generated := st.OccurrencesGenerated
+		next := occurrence(schedule, int(generated))
+
+		for runs := 0; next != nil && !next.After(today) && runs < maxOccurrencesPerRun; runs++ {
+			input := transactionFor(st, *next)
+
+			exists, err := txSvc.transactionExists(ctx, input.ID)
+			if err != nil {
+				return err
+			}
+			if !exists {
+				if err := txSvc.transactionSvc.Create(ctx, input); err != nil {
+					return err
+				}
+				created++
+			}
+
+			generated++
+			next = occurrence(schedule, int(generated))
+		}
+
+		return txSvc.repo.Advance(ctx, st.ID, generated, next)
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return created, nil
+}
+
+func (s service) transactionExists(ctx context.Context, id uuid.UUID) (bool, error) {
+	_, err := s.transactionSvc.FindOne(ctx, dafi.Where("id", dafi.Equal, id))
+	if err == nil {
+		return true, nil
+	}
+	if oopsErr, ok := oops.AsOops(err); ok && oopsErr.Code() == apperrors.CodeNotFound {
+		return false, nil
+	}
+
+	return false, err
+}
+
+// transactionFor builds the transaction a schedule generates on date.
+func transactionFor(st port.ScheduledTransaction, date time.Time) transactionport.CreateTransaction {
+	return transactionport.CreateTransaction{
+		ID:             occurrenceID(st.ID, date),
+		OrganizationID: st.OrganizationID,
+		AccountID:      st.AccountID,
+		CategoryID:     st.CategoryID,
+		SubcategoryID:  st.SubcategoryID,
+		Type:           st.Type,
+		Amount:         st.Amount,
+		Description:    st.Description,
+		Date:           date,
+		// Occurrences of a daily schedule look alike by design; the occurrence ID
+		// already keeps each one from being generated twice.
+		AllowDuplicate: true,
+	}
+}
+
+// occurrenceID is the ID of the transaction generated by a schedule on date.
+func occurrenceID(scheduleID uuid.UUID, date time.Time) uuid.UUID {
+	return uuid.NewSHA1(scheduleID, []byte(date.Format(time.DateOnly)))
+}
+
+func scheduleOf(input port.CreateScheduledTransaction) port.Schedule {
+	return port.Schedule{
+		Frequency:       input.Frequency,
+		Interval:        input.Interval,
+		StartDate:       input.StartDate,
+		EndDate:         input.EndDate,
+		OccurrenceCount: input.OccurrenceCount,
+	}
+}
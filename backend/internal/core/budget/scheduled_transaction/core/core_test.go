@@ -0,0 +1,248 @@
+package core
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"backend/core/budget/scheduled_transaction/port"
+	transactionport "backend/core/budget/transaction/port"
+	"backend/infra/dafi"
+	basedomain "backend/port"
+	apperrors "backend/port/errors"
+
+	"github.com/google/uuid"
+	"github.com/samber/oops"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type noopLogger struct{}
+
+func (noopLogger) Debug(string, ...any)                          {}
+func (noopLogger) Info(string, ...any)                           {}
+func (noopLogger) Warn(string, ...any)                           {}
+func (noopLogger) Error(string, ...any)                          {}
+func (noopLogger) With(...any) basedomain.Logger                 { return noopLogger{} }
+func (noopLogger) WithContext(context.Context) basedomain.Logger { return noopLogger{} }
+
+type stubTransaction struct{}
+
+func (stubTransaction) GetTx() basedomain.Tx { return nil }
+
+type stubUnitOfWork struct{}
+
+func (stubUnitOfWork) Begin(context.Context) (basedomain.Transaction, error) {
+	return stubTransaction{}, nil
+}
+
+func (stubUnitOfWork) Commit(context.Context, basedomain.Transaction) error   { return nil }
+func (stubUnitOfWork) Rollback(context.Context, basedomain.Transaction) error { return nil }
+
+type stubScheduleRepo struct {
+	port.Repository
+	schedules map[uuid.UUID]*port.ScheduledTransaction
+}
+
+func (s *stubScheduleRepo) FindDueIDs(_ context.Context, today, now time.Time, limit uint) ([]uuid.UUID, error) {
+	var due []*port.ScheduledTransaction
+	for _, st := range s.schedules {
+		if st.IsActive && st.NextOccurrence != nil && !st.NextOccurrence.After(today) && (st.RetryAfter == nil || !st.RetryAfter.After(now)) {
+			due = append(due, st)
+		}
+	}
+	slices.SortFunc(due, func(a, b *port.ScheduledTransaction) int { return cmp.Compare(a.FailedAttempts, b.FailedAttempts) })
+
+	var ids []uuid.UUID
+	for _, st := range due[:min(len(due), int(limit))] {
+		ids = append(ids, st.ID)
+	}
+	return ids, nil
+}
+
+func (s *stubScheduleRepo) FindOne(_ context.Context, criteria dafi.Criteria) (port.ScheduledTransaction, error) {
+	id, _ := criteria.Filters[0].Value.(uuid.UUID)
+	return *s.schedules[id], nil
+}
+
+func (s *stubScheduleRepo) Lock(_ context.Context, id uuid.UUID) (port.ScheduledTransaction, error) {
+	return *s.schedules[id], nil
+}
+
+func (s *stubScheduleRepo) Advance(_ context.Context, id uuid.UUID, generated int32, next *time.Time) error {
+	s.schedules[id].OccurrencesGenerated = generated
+	s.schedules[id].NextOccurrence = next
+	s.schedules[id].FailedAttempts = 0
+	s.schedules[id].RetryAfter = nil
+	return nil
+}
+
+func (s *stubScheduleRepo) RecordFailure(_ context.Context, id uuid.UUID, failedAttempts int32, retryAfter time.Time) error {
+	s.schedules[id].FailedAttempts = failedAttempts
+	s.schedules[id].RetryAfter = &retryAfter
+	return nil
+}
+
+func (s *stubScheduleRepo) WithTx(basedomain.Transaction) port.Repository { return s }
+
+// stubTransactionService refuses the transactions of the brokenAccount, as if it had
+// been closed.
+type stubTransactionService struct {
+	transactionport.Service
+	created       map[uuid.UUID]transactionport.CreateTransaction
+	brokenAccount uuid.UUID
+	attempts      int
+}
+
+func (s *stubTransactionService) FindOne(_ context.Context, criteria dafi.Criteria) (transactionport.Transaction, error) {
+	id, _ := criteria.Filters[0].Value.(uuid.UUID)
+	if _, ok := s.created[id]; ok {
+		return transactionport.Transaction{ID: id}, nil
+	}
+	return transactionport.Transaction{}, oops.Code(apperrors.CodeNotFound).Errorf("transaction not found")
+}
+
+func (s *stubTransactionService) Create(_ context.Context, input transactionport.CreateTransaction) error {
+	s.attempts++
+	if input.AccountID == s.brokenAccount {
+		return oops.Code(apperrors.CodeValidation).Errorf("account is inactive")
+	}
+	s.created[input.ID] = input
+	return nil
+}
+
+func (s *stubTransactionService) WithTx(basedomain.Transaction) transactionport.Service { return s }
+
+func TestService_GenerateDue(t *testing.T) {
+	id := uuid.New()
+	start := date(2026, time.January, 31)
+	repo := &stubScheduleRepo{schedules: map[uuid.UUID]*port.ScheduledTransaction{
+		id: {
+			ID:             id,
+			OrganizationID: "org_1",
+			AccountID:      uuid.New(),
+			Type:           "expense",
+			Amount:         -120000,
+			Frequency:      port.FrequencyMonthly,
+			Interval:       1,
+			StartDate:      start,
+			NextOccurrence: &start,
+			IsActive:       true,
+		},
+	}}
+	txns := &stubTransactionService{created: map[uuid.UUID]transactionport.CreateTransaction{}}
+	svc := New(repo, txns, stubUnitOfWork{}, noopLogger{})
+
+	created, err := svc.GenerateDue(context.Background(), date(2026, time.April, 10))
+	require.NoError(t, err)
+	assert.Equal(t, 3, created)
+
+	var dates []time.Time
+	for _, txn := range txns.created {
+		assert.Equal(t, int64(-120000), txn.Amount)
+		assert.Equal(t, "org_1", txn.OrganizationID)
+		dates = append(dates, txn.Date)
+	}
+	assert.ElementsMatch(t, []time.Time{start, date(2026, time.February, 28), date(2026, time.March, 31)}, dates)
+
+	schedule := repo.schedules[id]
+	assert.Equal(t, int32(3), schedule.OccurrencesGenerated)
+	assert.Equal(t, date(2026, time.April, 30), *schedule.NextOccurrence)
+
+	t.Run("running again the same day creates nothing", func(t *testing.T) {
+		created, err := svc.GenerateDue(context.Background(), date(2026, time.April, 10))
+		require.NoError(t, err)
+		assert.Zero(t, created)
+		assert.Len(t, txns.created, 3)
+	})
+
+	t.Run("a lost advance does not duplicate transactions", func(t *testing.T) {
+		// As if the schedule had not recorded its progress, e.g. restored from a backup.
+		schedule.OccurrencesGenerated = 0
+		schedule.NextOccurrence = &start
+
+		created, err := svc.GenerateDue(context.Background(), date(2026, time.April, 10))
+		require.NoError(t, err)
+		assert.Zero(t, created)
+		assert.Len(t, txns.created, 3)
+		assert.Equal(t, int32(3), schedule.OccurrencesGenerated)
+	})
+}
+
+func TestService_GenerateDueBacksOffFailingSchedules(t *testing.T) {
+	start := date(2026, time.April, 1)
+	brokenAccount := uuid.New()
+	schedule := func(accountID uuid.UUID) *port.ScheduledTransaction {
+		next := start
+		return &port.ScheduledTransaction{
+			ID:             uuid.New(),
+			OrganizationID: "org_1",
+			AccountID:      accountID,
+			Type:           "expense",
+			Amount:         -1500,
+			Frequency:      port.FrequencyMonthly,
+			Interval:       1,
+			StartDate:      start,
+			NextOccurrence: &next,
+			IsActive:       true,
+		}
+	}
+	broken, healthy := schedule(brokenAccount), schedule(uuid.New())
+	repo := &stubScheduleRepo{schedules: map[uuid.UUID]*port.ScheduledTransaction{broken.ID: broken, healthy.ID: healthy}}
+	txns := &stubTransactionService{created: map[uuid.UUID]transactionport.CreateTransaction{}, brokenAccount: brokenAccount}
+	svc := New(repo, txns, stubUnitOfWork{}, noopLogger{})
+
+	before := time.Now()
+	created, err := svc.GenerateDue(context.Background(), date(2026, time.April, 10))
+	require.NoError(t, err)
+	assert.Equal(t, 1, created)
+	assert.Equal(t, 2, txns.attempts)
+
+	assert.Equal(t, int32(1), broken.FailedAttempts)
+	require.NotNil(t, broken.RetryAfter)
+	assert.False(t, broken.RetryAfter.Before(before.Add(firstRetryDelay)))
+	assert.Equal(t, start, *broken.NextOccurrence)
+	assert.Zero(t, healthy.FailedAttempts)
+
+	t.Run("a backed off schedule is not tried again before its retry", func(t *testing.T) {
+		next := date(2026, time.April, 1)
+		healthy.NextOccurrence = &next
+		healthy.OccurrencesGenerated = 0
+		txns.created = map[uuid.UUID]transactionport.CreateTransaction{}
+		txns.attempts = 0
+
+		created, err := svc.GenerateDue(context.Background(), date(2026, time.April, 10))
+		require.NoError(t, err)
+		assert.Equal(t, 1, created)
+		assert.Equal(t, 1, txns.attempts)
+		assert.Equal(t, int32(1), broken.FailedAttempts)
+	})
+
+	t.Run("a retried schedule that fails again waits longer", func(t *testing.T) {
+		past := time.Now().Add(-time.Minute)
+		broken.RetryAfter = &past
+
+		before := time.Now()
+		_, err := svc.GenerateDue(context.Background(), date(2026, time.April, 10))
+		require.NoError(t, err)
+		assert.Equal(t, int32(2), broken.FailedAttempts)
+		assert.False(t, broken.RetryAfter.Before(before.Add(2*firstRetryDelay)))
+	})
+}
+
+func TestRetryDelay(t *testing.T) {
+	assert.Equal(t, firstRetryDelay, retryDelay(1))
+	assert.Equal(t, 2*firstRetryDelay, retryDelay(2))
+	assert.Equal(t, 8*firstRetryDelay, retryDelay(4))
+	assert.Equal(t, maxRetryDelay, retryDelay(20))
+	assert.Equal(t, maxRetryDelay, retryDelay(1<<30))
+}
+
+func TestOccurrenceID_IsStable(t *testing.T) {
+	id := uuid.New()
+
+	assert.Equal(t, occurrenceID(id, date(2026, time.May, 1)), occurrenceID(id, date(2026, time.May, 1)))
+	assert.NotEqual(t, occurrenceID(id, date(2026, time.May, 1)), occurrenceID(id, date(2026, time.June, 1)))
+}
@@ -0,0 +1,52 @@
+package core
+
+import (
+	"time"
+
+	"backend/core/budget/scheduled_transaction/port"
+)
+
+// occurrence returns the date of the nth occurrence of the schedule, counting from
+// zero, or nil when the schedule has ended by then. Monthly and yearly schedules
+// starting at the end of a month fall on the last day of shorter months.
+func occurrence(s port.Schedule, n int) *time.Time {
+	if s.OccurrenceCount.Valid && int64(n) >= s.OccurrenceCount.Int64 {
+		return nil
+	}
+
+	steps := n * int(s.Interval)
+	start := s.StartDate
+	var date time.Time
+	switch s.Frequency {
+	case port.FrequencyDaily:
+		date = start.AddDate(0, 0, steps)
+	case port.FrequencyWeekly:
+		date = start.AddDate(0, 0, 7*steps)
+	case port.FrequencyMonthly:
+		date = addMonths(start, steps)
+	case port.FrequencyYearly:
+		date = addMonths(start, 12*steps)
+	default:
+		return nil
+	}
+
+	if s.EndDate != nil && date.After(*s.EndDate) {
+		return nil
+	}
+
+	return &date
+}
+
+// addMonths adds months to date, clamping the day to the length of the target month
+// instead of overflowing into the next one like time.AddDate does.
+func addMonths(date time.Time, months int) time.Time {
+	firstOfMonth := time.Date(date.Year(), date.Month(), 1, 0, 0, 0, 0, date.Location()).AddDate(0, months, 0)
+	lastDay := firstOfMonth.AddDate(0, 1, -1).Day()
+
+	day := date.Day()
+	if day > lastDay {
+		day = lastDay
+	}
+
+	return time.Date(firstOfMonth.Year(), firstOfMonth.Month(), day, 0, 0, 0, 0, date.Location())
+}
@@ -0,0 +1,85 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"backend/core/budget/scheduled_transaction/port"
+
+	"github.com/guregu/null/v6"
+	"github.com/stretchr/testify/assert"
+)
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func TestOccurrence(t *testing.T) {
+	endDate := date(2026, time.March, 31)
+
+	tests := []struct {
+		name     string
+		schedule port.Schedule
+		n        int
+		want     *time.Time
+	}{
+		{
+			name:     "daily every 3 days",
+			schedule: port.Schedule{Frequency: port.FrequencyDaily, Interval: 3, StartDate: date(2026, time.January, 30)},
+			n:        2,
+			want:     ptr(date(2026, time.February, 5)),
+		},
+		{
+			name:     "weekly every 2 weeks",
+			schedule: port.Schedule{Frequency: port.FrequencyWeekly, Interval: 2, StartDate: date(2026, time.January, 5)},
+			n:        3,
+			want:     ptr(date(2026, time.February, 16)),
+		},
+		{
+			name:     "monthly from the 31st clamps to shorter months",
+			schedule: port.Schedule{Frequency: port.FrequencyMonthly, Interval: 1, StartDate: date(2026, time.January, 31)},
+			n:        1,
+			want:     ptr(date(2026, time.February, 28)),
+		},
+		{
+			name:     "monthly from the 31st does not drift",
+			schedule: port.Schedule{Frequency: port.FrequencyMonthly, Interval: 1, StartDate: date(2026, time.January, 31)},
+			n:        2,
+			want:     ptr(date(2026, time.March, 31)),
+		},
+		{
+			name:     "yearly from a leap day",
+			schedule: port.Schedule{Frequency: port.FrequencyYearly, Interval: 1, StartDate: date(2028, time.February, 29)},
+			n:        1,
+			want:     ptr(date(2029, time.February, 28)),
+		},
+		{
+			name:     "past the end date",
+			schedule: port.Schedule{Frequency: port.FrequencyMonthly, Interval: 1, StartDate: date(2026, time.January, 15), EndDate: &endDate},
+			n:        3,
+			want:     nil,
+		},
+		{
+			name:     "on the end date",
+			schedule: port.Schedule{Frequency: port.FrequencyMonthly, Interval: 1, StartDate: date(2026, time.January, 31), EndDate: &endDate},
+			n:        2,
+			want:     ptr(date(2026, time.March, 31)),
+		},
+		{
+			name:     "after the occurrence count",
+			schedule: port.Schedule{Frequency: port.FrequencyDaily, Interval: 1, StartDate: date(2026, time.January, 1), OccurrenceCount: null.IntFrom(3)},
+			n:        3,
+			want:     nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, occurrence(tt.schedule, tt.n))
+		})
+	}
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
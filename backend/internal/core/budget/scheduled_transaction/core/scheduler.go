@@ -0,0 +1,76 @@
+package core
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"backend/core/budget/scheduled_transaction/port"
+	basedomain "backend/port"
+)
+
+// DefaultSchedulerInterval is how often the scheduler looks for due schedules.
+// Occurrences are whole days, so this only bounds how late in the day they appear.
+const DefaultSchedulerInterval = 15 * time.Minute
+
+type scheduler struct {
+	svc      port.Service
+	interval time.Duration
+	logger   basedomain.Logger
+	done     chan struct{}
+	once     sync.Once
+	wg       sync.WaitGroup
+}
+
+func NewScheduler(svc port.Service, interval time.Duration, logger basedomain.Logger) port.Scheduler {
+	return &scheduler{
+		svc:      svc,
+		interval: interval,
+		logger:   logger.With("component", "scheduled_transaction.scheduler"),
+		done:     make(chan struct{}),
+	}
+}
+
+// Start runs a first generation right away, catching up on what was due while the
+// service was down, then one every interval until ctx is done or Shutdown is called.
+func (s *scheduler) Start(ctx context.Context) {
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+
+		ticker := time.NewTicker(s.interval)
+		defer ticker.Stop()
+
+		for {
+			s.run(ctx)
+
+			select {
+			case <-ticker.C:
+			case <-ctx.Done():
+				return
+			case <-s.done:
+				return
+			}
+		}
+	}()
+}
+
+func (s *scheduler) Shutdown() {
+	s.once.Do(func() { close(s.done) })
+	s.wg.Wait()
+}
+
+func (s *scheduler) run(ctx context.Context) {
+	defer func() {
+		if r := recover(); r != nil {
+			s.logger.Error("scheduler run panicked", "panic", r)
+		}
+	}()
+
+	now := time.Now().UTC()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	if _, err := s.svc.GenerateDue(ctx, today); err != nil {
+		s.logger.Error("failed to generate scheduled transactions", "error", err)
+	}
+}
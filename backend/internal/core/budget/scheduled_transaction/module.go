@@ -0,0 +1,42 @@
+package scheduled_transaction
+
+import (
+	"backend/adapter/database"
+	"backend/adapter/di"
+	"backend/core/budget/scheduled_transaction/adapter/handler"
+	"backend/core/budget/scheduled_transaction/adapter/postgres"
+	"backend/core/budget/scheduled_transaction/core"
+	"backend/core/budget/scheduled_transaction/port"
+	transactionport "backend/core/budget/transaction/port"
+	basedomain "backend/port"
+
+	"github.com/samber/do/v2"
+)
+
+func Module(i do.Injector) {
+	di.Provide(i, func(i do.Injector) (port.Repository, error) {
+		db := di.MustInvoke[database.PoolInterface](i)
+		logger := di.MustInvoke[basedomain.Logger](i)
+		return postgres.NewPostgres(db, logger), nil
+	})
+
+	di.Provide(i, func(i do.Injector) (port.Service, error) {
+		repo := di.MustInvoke[port.Repository](i)
+		transactionSvc := di.MustInvoke[transactionport.Service](i)
+		uow := di.MustInvoke[basedomain.UnitOfWork](i)
+		logger := di.MustInvoke[basedomain.Logger](i)
+		return core.New(repo, transactionSvc, uow, logger), nil
+	})
+
+	di.Provide(i, func(i do.Injector) (port.Scheduler, error) {
+		svc := di.MustInvoke[port.Service](i)
+		logger := di.MustInvoke[basedomain.Logger](i)
+		return core.NewScheduler(svc, core.DefaultSchedulerInterval, logger), nil
+	})
+
+	di.Provide(i, func(i do.Injector) (handler.HTTP, error) {
+		svc := di.MustInvoke[port.Service](i)
+		logger := di.MustInvoke[basedomain.Logger](i)
+		return handler.NewHTTP(svc, logger), nil
+	})
+}
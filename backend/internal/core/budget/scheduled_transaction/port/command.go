@@ -0,0 +1,66 @@
+package port
+
+import (
+	"context"
+	"time"
+
+	"backend/adapter/validation"
+	"github.com/google/uuid"
+	"github.com/guregu/null/v6"
+)
+
+type CreateScheduledTransaction struct {
+	ID              uuid.UUID   `json:"id"`
+	OrganizationID  string      `json:"organizationId"`
+	AccountID       uuid.UUID   `json:"accountId"`
+	CategoryID      *uuid.UUID  `json:"categoryId"`
+	SubcategoryID   *uuid.UUID  `json:"subcategoryId"`
+	Type            string      `json:"type"`
+	Amount          int64       `json:"amount"`
+	Description     null.String `json:"description"`
+	Frequency       string      `json:"frequency"`
+	Interval        int16       `json:"interval"`
+	StartDate       time.Time   `json:"startDate"`
+	EndDate         *time.Time  `json:"endDate"`
+	OccurrenceCount null.Int    `json:"occurrenceCount"`
+	IsActive        bool        `json:"isActive"`
+	// NextOccurrence is set by the service from the schedule.
+	NextOccurrence *time.Time `json:"-"`
+}
+
+func (c CreateScheduledTransaction) Validate(ctx context.Context) error {
+	return validation.ValidateStruct(ctx, &c,
+		validation.Field(&c.ID, validation.Required, validation.IsUUID),
+		validation.Field(&c.OrganizationID, validation.Required),
+		validation.Field(&c.AccountID, validation.Required, validation.IsUUID),
+		validation.Field(&c.Type, validation.Required, validation.Length(1, 20)),
+		validation.Field(&c.Amount, validation.Required),
+		validation.Field(&c.Frequency, validation.Required, validation.In(FrequencyDaily, FrequencyWeekly, FrequencyMonthly, FrequencyYearly)),
+		validation.Field(&c.Interval, validation.Required, validation.Min(int16(1)), validation.Max(int16(366))),
+		validation.Field(&c.StartDate, validation.Required),
+		validation.Field(&c.EndDate, validation.Min(c.StartDate)),
+		validation.Field(&c.OccurrenceCount, validation.Min(int64(1))),
+	)
+}
+
+// UpdateScheduledTransaction changes the generated transactions from the next
+// occurrence on. The recurrence itself (frequency, interval and start date) is fixed;
+// EndDate and OccurrenceCount may move the end of the schedule.
+type UpdateScheduledTransaction struct {
+	AccountID       *uuid.UUID  `json:"accountId"`
+	CategoryID      *uuid.UUID  `json:"categoryId"`
+	SubcategoryID   *uuid.UUID  `json:"subcategoryId"`
+	Type            null.String `json:"type"`
+	Amount          null.Int    `json:"amount"`
+	Description     null.String `json:"description"`
+	EndDate         *time.Time  `json:"endDate"`
+	OccurrenceCount null.Int    `json:"occurrenceCount"`
+	IsActive        null.Bool   `json:"isActive"`
+}
+
+func (u UpdateScheduledTransaction) Validate(ctx context.Context) error {
+	return validation.ValidateStruct(ctx, &u,
+		validation.Field(&u.Type, validation.NilOrNotEmpty, validation.Length(1, 20)),
+		validation.Field(&u.OccurrenceCount, validation.Min(int64(1))),
+	)
+}
@@ -0,0 +1,43 @@
+package port
+
+import (
+	"context"
+	"time"
+
+	basedomain "backend/port"
+
+	"github.com/google/uuid"
+)
+
+type Repository interface {
+	basedomain.RepositoryCommand[CreateScheduledTransaction, UpdateScheduledTransaction]
+	basedomain.RepositoryQuery[ScheduledTransaction]
+	basedomain.RepositoryTx[Repository]
+	// FindDueIDs returns the active schedules with an occurrence on or before today,
+	// leaving out those that failed and may not be retried before now. Schedules with
+	// fewer failures come first.
+	FindDueIDs(ctx context.Context, today, now time.Time, limit uint) ([]uuid.UUID, error)
+	// Lock reads a schedule and locks it until the end of the current transaction.
+	Lock(ctx context.Context, id uuid.UUID) (ScheduledTransaction, error)
+	// Advance records how many transactions a schedule generated and its next
+	// occurrence, nil once it has ended, and clears its failures.
+	Advance(ctx context.Context, id uuid.UUID, occurrencesGenerated int32, nextOccurrence *time.Time) error
+	// RecordFailure records that a schedule failed to generate for the failedAttempts-th
+	// time in a row and may not be tried again before retryAfter.
+	RecordFailure(ctx context.Context, id uuid.UUID, failedAttempts int32, retryAfter time.Time) error
+}
+
+type Service interface {
+	basedomain.UseCaseCommand[CreateScheduledTransaction, UpdateScheduledTransaction]
+	basedomain.UseCaseQuery[ScheduledTransaction]
+	basedomain.UseCaseTx[Service]
+	// GenerateDue creates the transactions of every schedule due on or before today and
+	// returns how many were created.
+	GenerateDue(ctx context.Context, today time.Time) (int, error)
+}
+
+// Scheduler periodically generates the transactions of due schedules in the background.
+type Scheduler interface {
+	Start(ctx context.Context)
+	Shutdown()
+}
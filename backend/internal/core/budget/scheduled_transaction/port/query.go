@@ -0,0 +1,65 @@
+package port
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/guregu/null/v6"
+)
+
+// Frequencies a schedule repeats at, every Interval units.
+const (
+	FrequencyDaily   = "daily"
+	FrequencyWeekly  = "weekly"
+	FrequencyMonthly = "monthly"
+	FrequencyYearly  = "yearly"
+)
+
+// ScheduledTransaction is a transaction template repeated on a schedule. It starts on
+// StartDate and ends after EndDate or once OccurrenceCount transactions were
+// generated, whichever comes first. NextOccurrence is the date of the next transaction
+// to generate, null once the schedule has ended. FailedAttempts counts the runs in a
+// row that failed to generate it, and RetryAfter is when the next one may try again.
+type ScheduledTransaction struct {
+	ID                   uuid.UUID   `json:"id"`
+	OrganizationID       string      `json:"organizationId"`
+	AccountID            uuid.UUID   `json:"accountId"`
+	CategoryID           *uuid.UUID  `json:"categoryId"`
+	SubcategoryID        *uuid.UUID  `json:"subcategoryId"`
+	Type                 string      `json:"type"`
+	Amount               int64       `json:"amount"`
+	Description          null.String `json:"description"`
+	Frequency            string      `json:"frequency"`
+	Interval             int16       `json:"interval"`
+	StartDate            time.Time   `json:"startDate"`
+	EndDate              *time.Time  `json:"endDate"`
+	OccurrenceCount      null.Int    `json:"occurrenceCount"`
+	OccurrencesGenerated int32       `json:"occurrencesGenerated"`
+	NextOccurrence       *time.Time  `json:"nextOccurrence"`
+	IsActive             bool        `json:"isActive"`
+	FailedAttempts       int32       `json:"failedAttempts"`
+	RetryAfter           *time.Time  `json:"retryAfter"`
+	CreatedAt            time.Time   `json:"createdAt"`
+	UpdatedAt            time.Time   `json:"updatedAt"`
+}
+
+// Schedule returns the recurrence rule of the scheduled transaction.
+func (s ScheduledTransaction) Schedule() Schedule {
+	return Schedule{
+		Frequency:       s.Frequency,
+		Interval:        s.Interval,
+		StartDate:       s.StartDate,
+		EndDate:         s.EndDate,
+		OccurrenceCount: s.OccurrenceCount,
+	}
+}
+
+// Schedule is an RRULE-like recurrence: every Interval days, weeks, months or years
+// from StartDate, until EndDate or OccurrenceCount occurrences.
+type Schedule struct {
+	Frequency       string
+	Interval        int16
+	StartDate       time.Time
+	EndDate         *time.Time
+	OccurrenceCount null.Int
+}
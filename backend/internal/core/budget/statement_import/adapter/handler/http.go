@@ -0,0 +1,158 @@
+package handler
+
+import (
+	"strconv"
+
+	"backend/core/budget/statement_import/port"
+	"backend/infra/dafi"
+	"backend/infra/httpresponse"
+	basedomain "backend/port"
+	apperrors "backend/port/errors"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/samber/oops"
+)
+
+// maxStatementSize bounds the statement files read into memory.
+const maxStatementSize = 10 << 20
+
+type HTTP struct {
+	svc    port.Service
+	logger basedomain.Logger
+}
+
+func NewHTTP(svc port.Service, logger basedomain.Logger) HTTP {
+	return HTTP{
+		svc:    svc,
+		logger: logger.With("component", "statement_import.handler"),
+	}
+}
+
+func (h HTTP) FindOne(c echo.Context) error {
+	ctx := c.Request().Context()
+	id := c.Param("id")
+
+	criteria := dafi.Where("id", dafi.Equal, id)
+	profile, err := h.svc.FindOne(ctx, criteria)
+	if err != nil {
+		return oops.WithContext(ctx).In(apperrors.LayerHandler).Wrap(err)
+	}
+
+	return httpresponse.OK(c, profile)
+}
+
+func (h HTTP) FindAll(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	parser := dafi.NewQueryParser()
+	criteria, err := parser.Parse(c.QueryParams())
+	if err != nil {
+		return oops.WithContext(ctx).In(apperrors.LayerHandler).Code(apperrors.CodeBadRequest).Wrap(err)
+	}
+
+	profiles, err := h.svc.FindAll(ctx, criteria)
+	if err != nil {
+		return oops.WithContext(ctx).In(apperrors.LayerHandler).Wrap(err)
+	}
+
+	return httpresponse.OK(c, profiles)
+}
+
+func (h HTTP) Create(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	var input port.CreateProfile
+	if err := c.Bind(&input); err != nil {
+		return oops.WithContext(ctx).In(apperrors.LayerHandler).Code(apperrors.CodeBadRequest).Wrap(err)
+	}
+
+	if err := h.svc.Create(ctx, input); err != nil {
+		return oops.WithContext(ctx).In(apperrors.LayerHandler).Wrap(err)
+	}
+
+	return httpresponse.Created(c, nil)
+}
+
+func (h HTTP) Update(c echo.Context) error {
+	ctx := c.Request().Context()
+	id := c.Param("id")
+
+	var input port.UpdateProfile
+	if err := c.Bind(&input); err != nil {
+		return oops.WithContext(ctx).In(apperrors.LayerHandler).Code(apperrors.CodeBadRequest).Wrap(err)
+	}
+
+	filters := dafi.FilterBy("id", dafi.Equal, id)
+	if err := h.svc.Update(ctx, input, filters...); err != nil {
+		return oops.WithContext(ctx).In(apperrors.LayerHandler).Wrap(err)
+	}
+
+	return httpresponse.NoContent(c)
+}
+
+func (h HTTP) Delete(c echo.Context) error {
+	ctx := c.Request().Context()
+	id := c.Param("id")
+
+	filters := dafi.FilterBy("id", dafi.Equal, id)
+	if err := h.svc.Delete(ctx, filters...); err != nil {
+		return oops.WithContext(ctx).In(apperrors.LayerHandler).Wrap(err)
+	}
+
+	return httpresponse.NoContent(c)
+}
+
+// Import reads a statement uploaded as the multipart field "file". The query parameter
+// preview=true only returns the rows read from it.
+func (h HTTP) Import(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	accountID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return oops.WithContext(ctx).In(apperrors.LayerHandler).Code(apperrors.CodeBadRequest).Wrap(err)
+	}
+
+	input := port.ImportStatement{AccountID: accountID, Format: c.FormValue("format")}
+	if input.Format == "" {
+		input.Format = port.FormatCSV
+	}
+
+	if profileID := c.FormValue("profileId"); profileID != "" {
+		id, err := uuid.Parse(profileID)
+		if err != nil {
+			return oops.WithContext(ctx).In(apperrors.LayerHandler).Code(apperrors.CodeBadRequest).Wrap(err)
+		}
+		input.ProfileID = &id
+	}
+
+	if preview := c.QueryParam("preview"); preview != "" {
+		input.Preview, err = strconv.ParseBool(preview)
+		if err != nil {
+			return oops.WithContext(ctx).In(apperrors.LayerHandler).Code(apperrors.CodeBadRequest).Wrap(err)
+		}
+	}
+
+	if allowDuplicates := c.FormValue("allowDuplicates"); allowDuplicates != "" {
+		input.AllowDuplicates, err = strconv.ParseBool(allowDuplicates)
+		if err != nil {
+			return oops.WithContext(ctx).In(apperrors.LayerHandler).Code(apperrors.CodeBadRequest).Wrap(err)
+		}
+	}
+
+	_, input.Content, err = httpresponse.FormFile(c, "file", maxStatementSize)
+	if err != nil {
+		return oops.WithContext(ctx).In(apperrors.LayerHandler).Code(apperrors.CodeBadRequest).Wrap(err)
+	}
+
+	result, err := h.svc.Import(ctx, input)
+	if err != nil {
+		return oops.WithContext(ctx).In(apperrors.LayerHandler).Wrap(err)
+	}
+
+	if input.Preview {
+		return httpresponse.OK(c, result)
+	}
+
+	return httpresponse.Created(c, result)
+}
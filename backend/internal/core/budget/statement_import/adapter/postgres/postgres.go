@@ -0,0 +1,291 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"backend/adapter/database"
+	"backend/core/budget/statement_import/port"
+	"backend/infra/dafi"
+	"backend/infra/sqlcraft"
+	basedomain "backend/port"
+	apperrors "backend/port/errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/samber/oops"
+)
+
+type dbConn interface {
+	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+}
+
+const tableName = "budget.import_profiles"
+
+var columns = []string{
+	"id",
+	"organization_id",
+	"name",
+	"delimiter",
+	"skip_rows",
+	"date_column",
+	"date_format",
+	"amount_column",
+	"debit_column",
+	"credit_column",
+	"description_column",
+	"reference_column",
+	"decimal_separator",
+	"created_at",
+	"updated_at",
+}
+
+// mappingColumns are the columns an update replaces.
+var mappingColumns = []string{
+	"name",
+	"delimiter",
+	"skip_rows",
+	"date_column",
+	"date_format",
+	"amount_column",
+	"debit_column",
+	"credit_column",
+	"description_column",
+	"reference_column",
+	"decimal_separator",
+	"updated_at",
+}
+
+var sqlColumnByDomainField = map[string]string{
+	"id":             "id",
+	"organizationId": "organization_id",
+	"name":           "name",
+	"createdAt":      "created_at",
+	"updatedAt":      "updated_at",
+}
+
+type postgres struct {
+	db     dbConn
+	logger basedomain.Logger
+}
+
+func NewPostgres(db database.PoolInterface, logger basedomain.Logger) port.Repository {
+	return postgres{
+		db:     db,
+		logger: logger.With("component", "statement_import.repository"),
+	}
+}
+
+func (r postgres) WithTx(tx basedomain.Transaction) port.Repository {
+	return postgres{
+		db:     tx.GetTx(),
+		logger: r.logger,
+	}
+}
+
+func (r postgres) FindOne(ctx context.Context, criteria dafi.Criteria) (port.Profile, error) {
+	query := sqlcraft.Select(columns...).
+		From(tableName).
+		Where(criteria.Filters...).
+		Limit(1).
+		SQLColumnByDomainField(sqlColumnByDomainField)
+
+	result, err := query.ToSQL()
+	if err != nil {
+		return port.Profile{}, oops.WithContext(ctx).In(apperrors.LayerRepository).Wrap(err)
+	}
+
+	r.logger.WithContext(ctx).Debug("executing query", "sql", result.SQL)
+
+	profile, err := scan(r.db.QueryRow(ctx, result.SQL, result.Args...))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return port.Profile{}, oops.WithContext(ctx).In(apperrors.LayerRepository).Code(apperrors.CodeNotFound).Wrap(err)
+		}
+		return port.Profile{}, oops.WithContext(ctx).In(apperrors.LayerRepository).Wrap(err)
+	}
+
+	return profile, nil
+}
+
+func (r postgres) FindAll(ctx context.Context, criteria dafi.Criteria) (basedomain.List[port.Profile], error) {
+	query := sqlcraft.Select(columns...).
+		From(tableName).
+		Where(criteria.Filters...).
+		OrderBy(criteria.Sorts...).
+		Limit(criteria.Pagination.PageSize).
+		Page(criteria.Pagination.PageNumber).
+		SQLColumnByDomainField(sqlColumnByDomainField)
+
+	result, err := query.ToSQL()
+	if err != nil {
+		return nil, oops.WithContext(ctx).In(apperrors.LayerRepository).Wrap(err)
+	}
+
+	r.logger.WithContext(ctx).Debug("executing query", "sql", result.SQL)
+
+	rows, err := r.db.Query(ctx, result.SQL, result.Args...)
+	if err != nil {
+		return nil, oops.WithContext(ctx).In(apperrors.LayerRepository).Wrap(err)
+	}
+	defer rows.Close()
+
+	var profiles basedomain.List[port.Profile]
+	for rows.Next() {
+		profile, err := scan(rows)
+		if err != nil {
+			return nil, oops.WithContext(ctx).In(apperrors.LayerRepository).Wrap(err)
+		}
+		profiles = append(profiles, profile)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, oops.WithContext(ctx).In(apperrors.LayerRepository).Wrap(err)
+	}
+
+	return profiles, nil
+}
+
+func (r postgres) Create(ctx context.Context, input port.CreateProfile) error {
+	return r.CreateBulk(ctx, basedomain.List[port.CreateProfile]{input})
+}
+
+func (r postgres) CreateBulk(ctx context.Context, inputs basedomain.List[port.CreateProfile]) error {
+	if inputs.IsEmpty() {
+		return nil
+	}
+
+	now := time.Now()
+	query := sqlcraft.InsertInto(tableName).WithColumns(columns...)
+	for _, input := range inputs {
+		m := input.Mapping
+		query = query.WithValues(
+			input.ID,
+			input.OrganizationID,
+			input.Name,
+			m.Delimiter,
+			m.SkipRows,
+			m.DateColumn,
+			m.DateFormat,
+			m.AmountColumn,
+			m.DebitColumn,
+			m.CreditColumn,
+			m.DescriptionColumn,
+			m.ReferenceColumn,
+			m.DecimalSeparator,
+			now,
+			now,
+		)
+	}
+
+	result, err := query.ToSQL()
+	if err != nil {
+		return oops.WithContext(ctx).In(apperrors.LayerRepository).Wrap(err)
+	}
+
+	r.logger.WithContext(ctx).Debug("executing query", "sql", result.SQL, "count", len(inputs))
+
+	_, err = r.db.Exec(ctx, result.SQL, result.Args...)
+	if err != nil {
+		return r.wrapWriteError(ctx, err)
+	}
+
+	return nil
+}
+
+func (r postgres) Update(ctx context.Context, input port.UpdateProfile, filters ...dafi.Filter) error {
+	m := input.Mapping
+	query := sqlcraft.Update(tableName).
+		WithColumns(mappingColumns...).
+		WithValues(
+			input.Name,
+			m.Delimiter,
+			m.SkipRows,
+			m.DateColumn,
+			m.DateFormat,
+			m.AmountColumn,
+			m.DebitColumn,
+			m.CreditColumn,
+			m.DescriptionColumn,
+			m.ReferenceColumn,
+			m.DecimalSeparator,
+			time.Now(),
+		).
+		Where(filters...).
+		SQLColumnByDomainField(sqlColumnByDomainField)
+
+	result, err := query.ToSQL()
+	if err != nil {
+		return oops.WithContext(ctx).In(apperrors.LayerRepository).Wrap(err)
+	}
+
+	r.logger.WithContext(ctx).Debug("executing query", "sql", result.SQL)
+
+	_, err = r.db.Exec(ctx, result.SQL, result.Args...)
+	if err != nil {
+		return r.wrapWriteError(ctx, err)
+	}
+
+	return nil
+}
+
+func (r postgres) Delete(ctx context.Context, filters ...dafi.Filter) error {
+	query := sqlcraft.DeleteFrom(tableName).
+		Where(filters...).
+		SQLColumnByDomainField(sqlColumnByDomainField)
+
+	result, err := query.ToSQL()
+	if err != nil {
+		return oops.WithContext(ctx).In(apperrors.LayerRepository).Wrap(err)
+	}
+
+	r.logger.WithContext(ctx).Debug("executing query", "sql", result.SQL)
+
+	_, err = r.db.Exec(ctx, result.SQL, result.Args...)
+	if err != nil {
+		return oops.WithContext(ctx).In(apperrors.LayerRepository).Wrap(err)
+	}
+
+	return nil
+}
+
+const pgErrUniqueViolation = "23505"
+
+func (r postgres) wrapWriteError(ctx context.Context, err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == pgErrUniqueViolation {
+		return oops.WithContext(ctx).In(apperrors.LayerRepository).
+			Code(apperrors.CodeConflict).
+			Public("An import profile with this name already exists.").
+			Wrap(err)
+	}
+
+	return oops.WithContext(ctx).In(apperrors.LayerRepository).Wrap(err)
+}
+
+func scan(row pgx.Row) (port.Profile, error) {
+	var profile port.Profile
+	m := &profile.Mapping
+	err := row.Scan(
+		&profile.ID,
+		&profile.OrganizationID,
+		&profile.Name,
+		&m.Delimiter,
+		&m.SkipRows,
+		&m.DateColumn,
+		&m.DateFormat,
+		&m.AmountColumn,
+		&m.DebitColumn,
+		&m.CreditColumn,
+		&m.DescriptionColumn,
+		&m.ReferenceColumn,
+		&m.DecimalSeparator,
+		&profile.CreatedAt,
+		&profile.UpdatedAt,
+	)
+
+	return profile, err
+}
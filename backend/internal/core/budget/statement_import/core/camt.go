@@ -0,0 +1,297 @@
+package core
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"backend/core/budget/statement_import/port"
+
+	"github.com/guregu/null/v6"
+)
+
+type camtAmount struct {
+	Value    string `xml:",chardata"`
+	Currency string `xml:"Ccy,attr"`
+}
+
+type camtDate struct {
+	Date     string `xml:"Dt"`
+	DateTime string `xml:"DtTm"`
+}
+
+// camtStatus is the entry status, written as text up to version 04 and as a code
+// element from version 05 on.
+type camtStatus struct {
+	Value string `xml:",chardata"`
+	Code  string `xml:"Cd"`
+}
+
+type camtAccount struct {
+	Currency string `xml:"Ccy"`
+}
+
+type camtBalance struct {
+	Type        string     `xml:"Tp>CdOrPrtry>Cd"`
+	Amount      camtAmount `xml:"Amt"`
+	CreditDebit string     `xml:"CdtDbtInd"`
+	Date        camtDate   `xml:"Dt"`
+}
+
+// camtTransaction holds the details of a transaction booked in an entry. Related
+// parties are named directly up to version 07 and under Pty from version 08 on.
+type camtTransaction struct {
+	ServicerReference string   `xml:"Refs>AcctSvcrRef"`
+	EndToEndID        string   `xml:"Refs>EndToEndId"`
+	Creditor          string   `xml:"RltdPties>Cdtr>Nm"`
+	CreditorParty     string   `xml:"RltdPties>Cdtr>Pty>Nm"`
+	Debtor            string   `xml:"RltdPties>Dbtr>Nm"`
+	DebtorParty       string   `xml:"RltdPties>Dbtr>Pty>Nm"`
+	Remittance        []string `xml:"RmtInf>Ustrd"`
+}
+
+type camtEntry struct {
+	Amount            camtAmount        `xml:"Amt"`
+	CreditDebit       string            `xml:"CdtDbtInd"`
+	Status            camtStatus        `xml:"Sts"`
+	BookingDate       camtDate          `xml:"BookgDt"`
+	ValueDate         camtDate          `xml:"ValDt"`
+	EntryReference    string            `xml:"NtryRef"`
+	ServicerReference string            `xml:"AcctSvcrRef"`
+	Transactions      []camtTransaction `xml:"NtryDtls>TxDtls"`
+	AdditionalInfo    string            `xml:"AddtlNtryInf"`
+}
+
+// camtPendingEntry is an entry kept until the statement currency is known, which the
+// balances after it may tell.
+type camtPendingEntry struct {
+	line  int
+	entry camtEntry
+}
+
+// parseCAMT053 reads a camt.053 BankToCustomerStatement. Any version of the message is
+// read, as elements are matched by name regardless of namespace. The file must hold a
+// single statement. Pending entries have not been booked yet and are left out; the
+// closing booked balance (CLBD) is the ledger balance.
+//
+// Acct/Ccy is optional and often left out. The statement currency is then the one of
+// the closing or opening balance, and entries of a statement that states neither are
+// checked against accountCurrency.
+func parseCAMT053(content []byte, accountCurrency string, decimalPlaces int16) (statement, error) {
+	d := xml.NewDecoder(bytes.NewReader(bytes.TrimPrefix(content, utf8BOM)))
+
+	var (
+		st              statement
+		stack           []string
+		statements      int
+		entries         []camtPendingEntry
+		balanceCurrency string
+	)
+
+	for {
+		token, err := d.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return statement{}, err
+		}
+
+		switch t := token.(type) {
+		case xml.EndElement:
+			stack = stack[:len(stack)-1]
+
+		case xml.StartElement:
+			inStatement := len(stack) > 0 && stack[len(stack)-1] == "Stmt"
+
+			switch {
+			case t.Name.Local == "Stmt":
+				statements++
+			case inStatement && t.Name.Local == "Acct":
+				var account camtAccount
+				if err := d.DecodeElement(&account, &t); err != nil {
+					return statement{}, err
+				}
+				st.currency = strings.ToUpper(strings.TrimSpace(account.Currency))
+				continue
+			case inStatement && t.Name.Local == "Bal":
+				var balance camtBalance
+				if err := d.DecodeElement(&balance, &t); err != nil {
+					return statement{}, err
+				}
+				if (balance.Type == "CLBD" || balance.Type == "OPBD") && balanceCurrency == "" {
+					balanceCurrency = strings.ToUpper(strings.TrimSpace(balance.Amount.Currency))
+				}
+				if balance.Type == "CLBD" {
+					ledger, err := balance.read(decimalPlaces)
+					if err != nil {
+						return statement{}, err
+					}
+					st.ledger = &ledger
+				}
+				continue
+			case inStatement && t.Name.Local == "Ntry":
+				line, _ := d.InputPos()
+				var entry camtEntry
+				if err := d.DecodeElement(&entry, &t); err != nil {
+					return statement{}, err
+				}
+				if entry.pending() {
+					continue
+				}
+				entries = append(entries, camtPendingEntry{line: line, entry: entry})
+				continue
+			}
+
+			stack = append(stack, t.Name.Local)
+		}
+	}
+
+	switch {
+	case statements == 0:
+		return statement{}, errors.New("no Stmt element")
+	case statements > 1:
+		return statement{}, fmt.Errorf("%d statements in one file", statements)
+	}
+
+	if st.currency == "" {
+		st.currency = balanceCurrency
+	}
+	entryCurrency := st.currency
+	if entryCurrency == "" {
+		entryCurrency = accountCurrency
+	}
+	for _, e := range entries {
+		st.rows = append(st.rows, e.entry.row(e.line, entryCurrency, decimalPlaces))
+	}
+
+	return st, nil
+}
+
+func (b camtBalance) read(decimalPlaces int16) (ledgerBalance, error) {
+	amount, err := camtSignedAmount(b.Amount.Value, b.CreditDebit, decimalPlaces)
+	if err != nil {
+		return ledgerBalance{}, fmt.Errorf("closing balance: %w", err)
+	}
+
+	date, err := b.Date.parse()
+	if err != nil {
+		return ledgerBalance{}, fmt.Errorf("closing balance: %w", err)
+	}
+
+	return ledgerBalance{amount: amount, asOf: date}, nil
+}
+
+func (e camtEntry) pending() bool {
+	status := strings.TrimSpace(e.Status.Code)
+	if status == "" {
+		status = strings.TrimSpace(e.Status.Value)
+	}
+	return status == "PDNG"
+}
+
+func (e camtEntry) row(line int, currency string, decimalPlaces int16) port.ImportRow {
+	row := port.ImportRow{Line: line}
+	if err := e.read(currency, decimalPlaces, &row); err != nil {
+		row.Error = err.Error()
+	}
+	return row
+}
+
+// read fills row from the entry. The reference is the one the bank gave the entry,
+// falling back to those of its first transaction; the description is the counterparty,
+// falling back to the remittance information and then to the entry information.
+func (e camtEntry) read(currency string, decimalPlaces int16, row *port.ImportRow) error {
+	if entryCurrency := strings.ToUpper(e.Amount.Currency); entryCurrency != "" && entryCurrency != currency {
+		return fmt.Errorf("entry is in %s, not in the statement currency %s", entryCurrency, currency)
+	}
+
+	var err error
+	date := e.BookingDate
+	if date.Date == "" && date.DateTime == "" {
+		date = e.ValueDate
+	}
+	row.Date, err = date.parse()
+	if err != nil {
+		return err
+	}
+
+	row.Amount, err = camtSignedAmount(e.Amount.Value, e.CreditDebit, decimalPlaces)
+	if err != nil {
+		return err
+	}
+	if row.Amount == 0 {
+		return errors.New("amount is zero")
+	}
+
+	var details camtTransaction
+	if len(e.Transactions) > 0 {
+		details = e.Transactions[0]
+	}
+
+	counterparty := firstNonEmpty(details.Creditor, details.CreditorParty)
+	if row.Amount > 0 {
+		counterparty = firstNonEmpty(details.Debtor, details.DebtorParty)
+	}
+	description := firstNonEmpty(counterparty, strings.Join(details.Remittance, " "), e.AdditionalInfo)
+	row.Description = null.NewString(description, description != "")
+
+	endToEndID := details.EndToEndID
+	if endToEndID == "NOTPROVIDED" {
+		endToEndID = ""
+	}
+	reference := firstNonEmpty(e.ServicerReference, e.EntryReference, details.ServicerReference, endToEndID)
+	row.Reference = null.NewString(reference, reference != "")
+
+	return nil
+}
+
+// camtSignedAmount reads an amount, which camt.053 always writes unsigned with a
+// decimal point, and signs it: debits are money out. The indicator of a reversal is
+// already the direction of the reversing entry.
+func camtSignedAmount(value, creditDebit string, decimalPlaces int16) (int64, error) {
+	amount, err := parseAmount(strings.TrimSpace(value), port.DecimalPoint, decimalPlaces)
+	if err != nil {
+		return 0, err
+	}
+
+	switch creditDebit {
+	case "CRDT":
+	case "DBIT":
+		amount = -amount
+	default:
+		return 0, fmt.Errorf("credit/debit indicator %q is neither CRDT nor DBIT", creditDebit)
+	}
+
+	return amount, nil
+}
+
+func (d camtDate) parse() (time.Time, error) {
+	s := strings.TrimSpace(d.Date)
+	if s == "" {
+		s = strings.TrimSpace(d.DateTime)
+	}
+	if len(s) < len(time.DateOnly) {
+		return time.Time{}, fmt.Errorf("date %q is not an ISO date", s)
+	}
+
+	date, err := time.Parse(time.DateOnly, s[:len(time.DateOnly)])
+	if err != nil {
+		return time.Time{}, fmt.Errorf("date %q is not an ISO date", s)
+	}
+
+	return date, nil
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v = strings.TrimSpace(v); v != "" {
+			return v
+		}
+	}
+	return ""
+}
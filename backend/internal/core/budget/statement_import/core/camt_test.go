@@ -0,0 +1,120 @@
+package core
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const camtStatement = `<?xml version="1.0" encoding="UTF-8"?>
+<Document xmlns="urn:iso:std:iso:20022:tech:xsd:camt.053.001.08">
+  <BkToCstmrStmt>
+    <GrpHdr><MsgId>MSG1</MsgId><CreDtTm>2026-04-30T18:00:00+02:00</CreDtTm></GrpHdr>
+    <Stmt>
+      <Id>STMT1</Id>
+      <Acct><Id><IBAN>DE89370400440532013000</IBAN></Id><Ccy>EUR</Ccy></Acct>
+      <Bal>
+        <Tp><CdOrPrtry><Cd>OPBD</Cd></CdOrPrtry></Tp>
+        <Amt Ccy="EUR">100.00</Amt><CdtDbtInd>CRDT</CdtDbtInd><Dt><Dt>2026-04-01</Dt></Dt>
+      </Bal>
+      <Bal>
+        <Tp><CdOrPrtry><Cd>CLBD</Cd></CdOrPrtry></Tp>
+        <Amt Ccy="EUR">2053.50</Amt><CdtDbtInd>CRDT</CdtDbtInd><Dt><Dt>2026-04-30</Dt></Dt>
+      </Bal>
+      <Ntry>
+        <Amt Ccy="EUR">46.50</Amt>
+        <CdtDbtInd>DBIT</CdtDbtInd>
+        <Sts><Cd>BOOK</Cd></Sts>
+        <BookgDt><Dt>2026-04-28</Dt></BookgDt>
+        <ValDt><Dt>2026-04-27</Dt></ValDt>
+        <AcctSvcrRef>BANKREF1</AcctSvcrRef>
+        <NtryDtls><TxDtls>
+          <Refs><EndToEndId>NOTPROVIDED</EndToEndId></Refs>
+          <RltdPties><Cdtr><Pty><Nm>Stadtwerke</Nm></Pty></Cdtr></RltdPties>
+          <RmtInf><Ustrd>Strom April</Ustrd></RmtInf>
+        </TxDtls></NtryDtls>
+      </Ntry>
+      <Ntry>
+        <Amt Ccy="EUR">2000.00</Amt>
+        <CdtDbtInd>CRDT</CdtDbtInd>
+        <Sts>BOOK</Sts>
+        <BookgDt><DtTm>2026-04-29T09:15:00+02:00</DtTm></BookgDt>
+        <NtryDtls><TxDtls>
+          <Refs><EndToEndId>E2E-42</EndToEndId></Refs>
+          <RmtInf><Ustrd>Gehalt</Ustrd></RmtInf>
+        </TxDtls></NtryDtls>
+      </Ntry>
+      <Ntry>
+        <Amt Ccy="USD">10.00</Amt>
+        <CdtDbtInd>DBIT</CdtDbtInd>
+        <Sts><Cd>BOOK</Cd></Sts>
+        <BookgDt><Dt>2026-04-29</Dt></BookgDt>
+        <AcctSvcrRef>BANKREF3</AcctSvcrRef>
+      </Ntry>
+      <Ntry>
+        <Amt Ccy="EUR">5.00</Amt>
+        <CdtDbtInd>DBIT</CdtDbtInd>
+        <Sts><Cd>PDNG</Cd></Sts>
+        <BookgDt><Dt>2026-04-30</Dt></BookgDt>
+      </Ntry>
+    </Stmt>
+  </BkToCstmrStmt>
+</Document>
+`
+
+func TestParseCAMT053(t *testing.T) {
+	st, err := parseCAMT053([]byte(camtStatement), "EUR", 2)
+	require.NoError(t, err)
+
+	assert.Equal(t, "EUR", st.currency)
+	require.Len(t, st.rows, 3)
+
+	utilities := st.rows[0]
+	assert.Equal(t, time.Date(2026, time.April, 28, 0, 0, 0, 0, time.UTC), utilities.Date)
+	assert.Equal(t, int64(-4650), utilities.Amount)
+	assert.Equal(t, "Stadtwerke", utilities.Description.String)
+	assert.Equal(t, "BANKREF1", utilities.Reference.String)
+	assert.Equal(t, 16, utilities.Line)
+
+	salary := st.rows[1]
+	assert.Equal(t, time.Date(2026, time.April, 29, 0, 0, 0, 0, time.UTC), salary.Date)
+	assert.Equal(t, int64(200000), salary.Amount)
+	assert.Equal(t, "Gehalt", salary.Description.String)
+	assert.Equal(t, "E2E-42", salary.Reference.String)
+
+	assert.Contains(t, st.rows[2].Error, "USD")
+
+	require.NotNil(t, st.ledger)
+	assert.Equal(t, int64(205350), st.ledger.amount)
+}
+
+func TestParseCAMT053_withoutAccountCurrency(t *testing.T) {
+	content := strings.Replace(camtStatement, "<Ccy>EUR</Ccy>", "", 1)
+
+	st, err := parseCAMT053([]byte(content), "USD", 2)
+	require.NoError(t, err)
+	assert.Equal(t, "EUR", st.currency, "currency of the balances")
+	require.Len(t, st.rows, 3)
+	assert.Empty(t, st.rows[0].Error)
+	assert.Contains(t, st.rows[2].Error, "USD")
+
+	content = regexp.MustCompile(`(?s)<Bal>.*</Bal>`).ReplaceAllString(content, "")
+
+	st, err = parseCAMT053([]byte(content), "USD", 2)
+	require.NoError(t, err)
+	assert.Empty(t, st.currency)
+	assert.Contains(t, st.rows[0].Error, "not in the statement currency USD")
+	assert.Empty(t, st.rows[2].Error)
+}
+
+func TestParseCAMT053_rejects(t *testing.T) {
+	_, err := parseCAMT053([]byte("<Document><BkToCstmrStmt></BkToCstmrStmt></Document>"), "EUR", 2)
+	require.Error(t, err)
+
+	_, err = parseCAMT053([]byte(strings.Repeat("not xml <", 2)), "EUR", 2)
+	require.Error(t, err)
+}
@@ -0,0 +1,398 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	accountport "backend/core/budget/account/port"
+	currencyport "backend/core/budget/currency/port"
+	"backend/core/budget/statement_import/port"
+	transactionport "backend/core/budget/transaction/port"
+	"backend/infra/dafi"
+	basedomain "backend/port"
+	apperrors "backend/port/errors"
+
+	"github.com/google/uuid"
+	"github.com/samber/oops"
+)
+
+type service struct {
+	repo           port.Repository
+	accountRepo    accountport.Repository
+	currencyRepo   currencyport.Repository
+	transactionSvc transactionport.Service
+	logger         basedomain.Logger
+}
+
+func New(repo port.Repository, accountRepo accountport.Repository, currencyRepo currencyport.Repository, transactionSvc transactionport.Service, logger basedomain.Logger) port.Service {
+	return service{
+		repo:           repo,
+		accountRepo:    accountRepo,
+		currencyRepo:   currencyRepo,
+		transactionSvc: transactionSvc,
+		logger:         logger.With("component", "statement_import.service"),
+	}
+}
+
+func (s service) WithTx(tx basedomain.Transaction) port.Service {
+	return service{
+		repo:           s.repo.WithTx(tx),
+		accountRepo:    s.accountRepo.WithTx(tx),
+		currencyRepo:   s.currencyRepo,
+		transactionSvc: s.transactionSvc.WithTx(tx),
+		logger:         s.logger,
+	}
+}
+
+func (s service) FindOne(ctx context.Context, criteria dafi.Criteria) (port.Profile, error) {
+	profile, err := s.repo.FindOne(ctx, criteria)
+	if err != nil {
+		return port.Profile{}, oops.WithContext(ctx).In(apperrors.LayerService).Wrap(err)
+	}
+
+	return profile, nil
+}
+
+func (s service) FindAll(ctx context.Context, criteria dafi.Criteria) (basedomain.List[port.Profile], error) {
+	profiles, err := s.repo.FindAll(ctx, criteria)
+	if err != nil {
+		return nil, oops.WithContext(ctx).In(apperrors.LayerService).Wrap(err)
+	}
+
+	return profiles, nil
+}
+
+func (s service) Create(ctx context.Context, input port.CreateProfile) error {
+	if err := input.Validate(ctx); err != nil {
+		return oops.WithContext(ctx).In(apperrors.LayerService).Code(apperrors.CodeValidation).Wrap(err)
+	}
+
+	if err := s.repo.Create(ctx, input); err != nil {
+		return oops.WithContext(ctx).In(apperrors.LayerService).Wrap(err)
+	}
+
+	s.logger.WithContext(ctx).Info("import profile created", "name", input.Name)
+
+	return nil
+}
+
+func (s service) CreateBulk(ctx context.Context, inputs basedomain.List[port.CreateProfile]) error {
+	for _, input := range inputs {
+		if err := input.Validate(ctx); err != nil {
+			return oops.WithContext(ctx).In(apperrors.LayerService).Code(apperrors.CodeValidation).Wrap(err)
+		}
+	}
+
+	if err := s.repo.CreateBulk(ctx, inputs); err != nil {
+		return oops.WithContext(ctx).In(apperrors.LayerService).Wrap(err)
+	}
+
+	s.logger.WithContext(ctx).Info("import profiles created", "count", len(inputs))
+
+	return nil
+}
+
+func (s service) Update(ctx context.Context, input port.UpdateProfile, filters ...dafi.Filter) error {
+	if err := input.Validate(ctx); err != nil {
+		return oops.WithContext(ctx).In(apperrors.LayerService).Code(apperrors.CodeValidation).Wrap(err)
+	}
+
+	if err := s.repo.Update(ctx, input, filters...); err != nil {
+		return oops.WithContext(ctx).In(apperrors.LayerService).Wrap(err)
+	}
+
+	s.logger.WithContext(ctx).Info("import profile updated", "name", input.Name)
+
+	return nil
+}
+
+func (s service) Delete(ctx context.Context, filters ...dafi.Filter) error {
+	if err := s.repo.Delete(ctx, filters...); err != nil {
+		return oops.WithContext(ctx).In(apperrors.LayerService).Wrap(err)
+	}
+
+	s.logger.WithContext(ctx).Info("import profile deleted")
+
+	return nil
+}
+
+func (s service) Import(ctx context.Context, input port.ImportStatement) (port.ImportResult, error) {
+	if err := input.Validate(ctx); err != nil {
+		return port.ImportResult{}, oops.WithContext(ctx).In(apperrors.LayerService).Code(apperrors.CodeValidation).Wrap(err)
+	}
+
+	account, err := s.accountRepo.FindOne(ctx, dafi.Where("id", dafi.Equal, input.AccountID))
+	if err != nil {
+		return port.ImportResult{}, oops.WithContext(ctx).In(apperrors.LayerService).Wrap(err)
+	}
+
+	currency, err := s.currencyRepo.FindOne(ctx, dafi.Where("code", dafi.Equal, account.CurrencyCode))
+	if err != nil {
+		return port.ImportResult{}, oops.WithContext(ctx).In(apperrors.LayerService).Wrap(err)
+	}
+
+	st, err := s.parse(ctx, input, account, currency)
+	if err != nil {
+		return port.ImportResult{}, oops.WithContext(ctx).In(apperrors.LayerService).Wrap(err)
+	}
+	rows := st.rows
+
+	if err := s.markDuplicates(ctx, account, rows, input.AllowDuplicates); err != nil {
+		return port.ImportResult{}, oops.WithContext(ctx).In(apperrors.LayerService).Wrap(err)
+	}
+
+	result := port.ImportResult{
+		AccountID: account.ID,
+		Format:    input.Format,
+		Preview:   input.Preview,
+		Rows:      rows,
+		Skipped:   countDuplicates(rows),
+		Balance:   st.checkBalance(account),
+	}
+	if input.Preview {
+		return result, nil
+	}
+
+	if invalid := result.Invalid(); invalid > 0 {
+		return port.ImportResult{}, oops.WithContext(ctx).In(apperrors.LayerService).
+			Code(apperrors.CodeValidation).
+			Public(fmt.Sprintf("%d rows of the statement could not be read. Preview the import to see why.", invalid)).
+			Errorf("statement for account %s has %d invalid rows", account.ID, invalid)
+	}
+
+	txns := make(basedomain.List[transactionport.CreateTransaction], 0, len(rows))
+	for i := range rows {
+		if rows[i].Duplicate {
+			continue
+		}
+		txn := newTransaction(account, rows[i])
+		txn.AllowDuplicate = input.AllowDuplicates
+		rows[i].TransactionID = &txn.ID
+		txns = append(txns, txn)
+	}
+
+	if len(txns) > 0 {
+		if err := s.transactionSvc.CreateBulk(ctx, txns); err != nil {
+			return port.ImportResult{}, oops.WithContext(ctx).In(apperrors.LayerService).Wrap(err)
+		}
+	}
+	result.Imported = len(txns)
+
+	s.logger.WithContext(ctx).Info("statement imported", "accountId", account.ID, "format", input.Format, "count", len(txns), "skipped", result.Skipped)
+	if result.Balance != nil && !result.Balance.Matches() {
+		s.logger.WithContext(ctx).Warn("account balance differs from statement", "accountId", account.ID, "difference", result.Balance.Difference)
+	}
+
+	return result, nil
+}
+
+// statement is what a parser reads from a file: its entries and, for formats that
+// carry them, the currency and the closing balance of the statement.
+type statement struct {
+	rows     []port.ImportRow
+	currency string
+	ledger   *ledgerBalance
+}
+
+// ledgerBalance is the booked balance of the account at the end of the statement.
+type ledgerBalance struct {
+	amount int64
+	asOf   time.Time
+}
+
+// checkBalance compares the closing balance of the statement with the balance of the
+// account once the readable rows that are not duplicates are imported.
+func (st statement) checkBalance(account accountport.Account) *port.BalanceCheck {
+	if st.ledger == nil {
+		return nil
+	}
+
+	balance := account.CurrentBalance.Int64()
+	for _, row := range st.rows {
+		if row.Error == "" && !row.Duplicate {
+			balance += row.Amount
+		}
+	}
+
+	return &port.BalanceCheck{
+		AsOf:       st.ledger.asOf,
+		Statement:  st.ledger.amount,
+		Account:    balance,
+		Difference: st.ledger.amount - balance,
+	}
+}
+
+// parse reads a statement in the format of input.
+func (s service) parse(ctx context.Context, input port.ImportStatement, account accountport.Account, currency currencyport.Currency) (statement, error) {
+	var (
+		st  statement
+		err error
+	)
+
+	switch input.Format {
+	case port.FormatOFX:
+		st, err = parseOFX(input.Content, currency.DecimalPlaces)
+	case port.FormatCAMT053:
+		st, err = parseCAMT053(input.Content, account.CurrencyCode, currency.DecimalPlaces)
+	case port.FormatMT940:
+		st, err = parseMT940(input.Content, currency.DecimalPlaces)
+	default:
+		var profile port.Profile
+		profile, err = s.repo.FindOne(ctx, dafi.Where("id", dafi.Equal, *input.ProfileID).And("organizationId", dafi.Equal, account.OrganizationID))
+		if err != nil {
+			return statement{}, err
+		}
+		st.rows, err = parseCSV(input.Content, profile.Mapping, currency.DecimalPlaces)
+	}
+	if err != nil {
+		return statement{}, oops.WithContext(ctx).In(apperrors.LayerService).
+			Code(apperrors.CodeValidation).
+			Public(fmt.Sprintf("The file is not a %s statement that can be read.", strings.ToUpper(input.Format))).
+			Wrap(err)
+	}
+
+	if st.currency != "" && st.currency != account.CurrencyCode {
+		return statement{}, oops.WithContext(ctx).In(apperrors.LayerService).
+			Code(apperrors.CodeValidation).
+			Public(fmt.Sprintf("The statement is in %s but the account is in %s.", st.currency, account.CurrencyCode)).
+			Errorf("statement currency %s differs from account %s currency %s", st.currency, account.ID, account.CurrencyCode)
+	}
+
+	return st, nil
+}
+
+// markDuplicates flags the rows whose reference the account already has, or an earlier
+// row of the statement has. Unless duplicates are allowed, it then flags the rows the
+// transaction service would refuse as duplicates, such as look-alikes of transactions
+// entered by hand, so that a preview shows what a commit will skip.
+func (s service) markDuplicates(ctx context.Context, account accountport.Account, rows []port.ImportRow, allowDuplicates bool) error {
+	if err := s.markReferenceDuplicates(ctx, account, rows); err != nil {
+		return err
+	}
+	if allowDuplicates {
+		return nil
+	}
+
+	return s.markLookAlikes(ctx, account, rows)
+}
+
+// markReferenceDuplicates flags the rows whose reference the account already has, or
+// an earlier row of the statement has.
+func (s service) markReferenceDuplicates(ctx context.Context, account accountport.Account, rows []port.ImportRow) error {
+	var references []string
+	for _, row := range rows {
+		if row.Error == "" && row.Reference.Valid {
+			references = append(references, row.Reference.String)
+		}
+	}
+	if len(references) == 0 {
+		return nil
+	}
+
+	existing, err := s.transactionSvc.FindAll(ctx, dafi.Criteria{
+		Filters: dafi.FilterBy("accountId", dafi.Equal, account.ID).And("externalReferenceNumber", dafi.In, references),
+	})
+	if err != nil {
+		return err
+	}
+
+	byReference := make(map[string]uuid.UUID, len(existing))
+	for _, txn := range existing {
+		byReference[txn.ExternalReferenceNumber.String] = txn.ID
+	}
+
+	seen := make(map[string]bool, len(references))
+	for i := range rows {
+		row := &rows[i]
+		if row.Error != "" || !row.Reference.Valid {
+			continue
+		}
+
+		if id, ok := byReference[row.Reference.String]; ok {
+			row.Duplicate, row.DuplicateOf = true, &id
+		} else if seen[row.Reference.String] {
+			row.Duplicate = true
+		}
+		seen[row.Reference.String] = true
+	}
+
+	return nil
+}
+
+// markLookAlikes flags the rows left that the transaction service finds duplicates of
+// recorded transactions, or of earlier rows of the statement.
+func (s service) markLookAlikes(ctx context.Context, account accountport.Account, rows []port.ImportRow) error {
+	var (
+		candidates basedomain.List[transactionport.CreateTransaction]
+		indexes    []int
+	)
+	for i, row := range rows {
+		if row.Error == "" && !row.Duplicate {
+			candidates = append(candidates, newTransaction(account, row))
+			indexes = append(indexes, i)
+		}
+	}
+	if candidates.IsEmpty() {
+		return nil
+	}
+
+	duplicates, err := s.transactionSvc.FindDuplicates(ctx, candidates)
+	if err != nil {
+		return err
+	}
+
+	fromStatement := make(map[uuid.UUID]bool, len(candidates))
+	for _, candidate := range candidates {
+		fromStatement[candidate.ID] = true
+	}
+
+	for k, ids := range duplicates {
+		if len(ids) == 0 {
+			continue
+		}
+
+		row := &rows[indexes[k]]
+		row.Duplicate = true
+		for _, id := range ids {
+			if !fromStatement[id] {
+				row.DuplicateOf = &id
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+func countDuplicates(rows []port.ImportRow) int {
+	n := 0
+	for _, row := range rows {
+		if row.Duplicate {
+			n++
+		}
+	}
+	return n
+}
+
+// newTransaction records a statement row as a cleared transaction: it comes from the
+// bank, so it has already posted.
+func newTransaction(account accountport.Account, row port.ImportRow) transactionport.CreateTransaction {
+	txnType := "income"
+	if row.Amount < 0 {
+		txnType = "expense"
+	}
+
+	return transactionport.CreateTransaction{
+		ID:                      uuid.New(),
+		OrganizationID:          account.OrganizationID,
+		AccountID:               account.ID,
+		Type:                    txnType,
+		Amount:                  row.Amount,
+		Description:             row.Description,
+		ExternalReferenceNumber: row.Reference,
+		Date:                    row.Date,
+		Status:                  transactionport.StatusCleared,
+	}
+}
@@ -0,0 +1,309 @@
+package core
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	accountport "backend/core/budget/account/port"
+	currencyport "backend/core/budget/currency/port"
+	"backend/core/budget/statement_import/port"
+	transactionport "backend/core/budget/transaction/port"
+	"backend/infra/dafi"
+	basedomain "backend/port"
+	apperrors "backend/port/errors"
+
+	"github.com/google/uuid"
+	"github.com/guregu/null/v6"
+	"github.com/samber/oops"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type noopLogger struct{}
+
+func (noopLogger) Debug(string, ...any)                          {}
+func (noopLogger) Info(string, ...any)                           {}
+func (noopLogger) Warn(string, ...any)                           {}
+func (noopLogger) Error(string, ...any)                          {}
+func (noopLogger) With(...any) basedomain.Logger                 { return noopLogger{} }
+func (noopLogger) WithContext(context.Context) basedomain.Logger { return noopLogger{} }
+
+type stubProfileRepo struct {
+	port.Repository
+	profile port.Profile
+}
+
+func (r stubProfileRepo) FindOne(_ context.Context, criteria dafi.Criteria) (port.Profile, error) {
+	id, _ := criteria.Filters[0].Value.(uuid.UUID)
+	if id != r.profile.ID {
+		return port.Profile{}, oops.Code(apperrors.CodeNotFound).Errorf("profile not found")
+	}
+	return r.profile, nil
+}
+
+type stubAccountRepo struct {
+	accountport.Repository
+	account accountport.Account
+}
+
+func (r stubAccountRepo) FindOne(context.Context, dafi.Criteria) (accountport.Account, error) {
+	return r.account, nil
+}
+
+type stubCurrencyRepo struct {
+	currencyport.Repository
+}
+
+func (stubCurrencyRepo) FindOne(_ context.Context, criteria dafi.Criteria) (currencyport.Currency, error) {
+	code, _ := criteria.Filters[0].Value.(string)
+	return currencyport.Currency{Code: code, DecimalPlaces: map[string]int16{"USD": 2, "CLP": 0}[code]}, nil
+}
+
+type stubTransactionSvc struct {
+	transactionport.Service
+	existing basedomain.List[transactionport.Transaction]
+	created  basedomain.List[transactionport.CreateTransaction]
+}
+
+func (s *stubTransactionSvc) FindAll(_ context.Context, criteria dafi.Criteria) (basedomain.List[transactionport.Transaction], error) {
+	references, _ := criteria.Filters[1].Value.([]string)
+
+	var txns basedomain.List[transactionport.Transaction]
+	for _, txn := range s.existing {
+		for _, reference := range references {
+			if txn.ExternalReferenceNumber.String == reference {
+				txns = append(txns, txn)
+			}
+		}
+	}
+	return txns, nil
+}
+
+// FindDuplicates takes a transaction for a duplicate of an existing one or an earlier
+// input with the same reference or, without one, the same amount, date and
+// description regardless of case.
+func (s *stubTransactionSvc) FindDuplicates(_ context.Context, inputs basedomain.List[transactionport.CreateTransaction]) ([][]uuid.UUID, error) {
+	alike := func(input transactionport.CreateTransaction, reference null.String, amount int64, date time.Time, description null.String) bool {
+		if input.ExternalReferenceNumber.String != "" {
+			return input.ExternalReferenceNumber.String == reference.String
+		}
+		return input.Amount == amount && input.Date.Equal(date) && strings.EqualFold(input.Description.String, description.String)
+	}
+
+	duplicates := make([][]uuid.UUID, len(inputs))
+	var kept basedomain.List[transactionport.CreateTransaction]
+	for i, input := range inputs {
+		for _, earlier := range kept {
+			if alike(input, earlier.ExternalReferenceNumber, earlier.Amount, earlier.Date, earlier.Description) {
+				duplicates[i] = append(duplicates[i], earlier.ID)
+			}
+		}
+		for _, txn := range s.existing {
+			if alike(input, txn.ExternalReferenceNumber, txn.Amount, txn.Date, txn.Description) {
+				duplicates[i] = append(duplicates[i], txn.ID)
+			}
+		}
+		if len(duplicates[i]) == 0 {
+			kept = append(kept, input)
+		}
+	}
+	return duplicates, nil
+}
+
+func (s *stubTransactionSvc) CreateBulk(_ context.Context, inputs basedomain.List[transactionport.CreateTransaction]) error {
+	s.created = append(s.created, inputs...)
+	return nil
+}
+
+type fixture struct {
+	svc          port.Service
+	transactions *stubTransactionSvc
+	account      accountport.Account
+	profile      port.Profile
+}
+
+func newFixture(currencyCode string) fixture {
+	account := accountport.Account{ID: uuid.New(), OrganizationID: "org_1", CurrencyCode: currencyCode}
+	profile := port.Profile{
+		ID:             uuid.New(),
+		OrganizationID: "org_1",
+		Name:           "Bank",
+		Mapping: port.Mapping{
+			Delimiter:         ",",
+			SkipRows:          1,
+			DateColumn:        1,
+			DateFormat:        "YYYY-MM-DD",
+			AmountColumn:      null.IntFrom(3),
+			DescriptionColumn: null.IntFrom(2),
+			ReferenceColumn:   null.IntFrom(4),
+			DecimalSeparator:  port.DecimalPoint,
+		},
+	}
+	transactions := &stubTransactionSvc{}
+
+	return fixture{
+		svc:          New(stubProfileRepo{profile: profile}, stubAccountRepo{account: account}, stubCurrencyRepo{}, transactions, noopLogger{}),
+		transactions: transactions,
+		account:      account,
+		profile:      profile,
+	}
+}
+
+func (f fixture) input(content string, preview bool) port.ImportStatement {
+	return port.ImportStatement{
+		AccountID: f.account.ID,
+		Format:    port.FormatCSV,
+		ProfileID: &f.profile.ID,
+		Content:   []byte(content),
+		Preview:   preview,
+	}
+}
+
+const csvStatement = "Date,Description,Amount,Reference\n" +
+	"2026-04-28,Coffee,-4.50,T1\n" +
+	"2026-04-29,Salary,2500,T2\n"
+
+func TestService_Import_preview(t *testing.T) {
+	f := newFixture("USD")
+
+	result, err := f.svc.Import(context.Background(), f.input(csvStatement, true))
+	require.NoError(t, err)
+
+	assert.True(t, result.Preview)
+	require.Len(t, result.Rows, 2)
+	assert.Equal(t, int64(-450), result.Rows[0].Amount)
+	assert.Nil(t, result.Rows[0].TransactionID)
+	assert.Zero(t, result.Imported)
+	assert.Empty(t, f.transactions.created)
+}
+
+func TestService_Import_commit(t *testing.T) {
+	f := newFixture("USD")
+
+	result, err := f.svc.Import(context.Background(), f.input(csvStatement, false))
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, result.Imported)
+	require.Len(t, f.transactions.created, 2)
+
+	coffee := f.transactions.created[0]
+	assert.Equal(t, f.account.ID, coffee.AccountID)
+	assert.Equal(t, "org_1", coffee.OrganizationID)
+	assert.Equal(t, "expense", coffee.Type)
+	assert.Equal(t, int64(-450), coffee.Amount)
+	assert.Equal(t, "T1", coffee.ExternalReferenceNumber.String)
+	assert.Equal(t, transactionport.StatusCleared, coffee.Status)
+	assert.Equal(t, coffee.ID, *result.Rows[0].TransactionID)
+
+	assert.Equal(t, "income", f.transactions.created[1].Type)
+	assert.Equal(t, int64(250000), f.transactions.created[1].Amount)
+}
+
+func TestService_Import_amountInAccountCurrency(t *testing.T) {
+	f := newFixture("CLP")
+
+	result, err := f.svc.Import(context.Background(), f.input("Date,Description,Amount\n2026-04-28,Almuerzo,-8500\n", true))
+	require.NoError(t, err)
+	require.Len(t, result.Rows, 1)
+	assert.Equal(t, int64(-8500), result.Rows[0].Amount)
+}
+
+func TestService_Import_invalidRowsAreNotCommitted(t *testing.T) {
+	f := newFixture("USD")
+
+	_, err := f.svc.Import(context.Background(), f.input(csvStatement+"2026-04-30,Fee,abc,T3\n", false))
+	require.Error(t, err)
+
+	oopsErr, ok := oops.AsOops(err)
+	require.True(t, ok)
+	assert.Equal(t, apperrors.CodeValidation, oopsErr.Code())
+	assert.Empty(t, f.transactions.created)
+}
+
+func TestMapping_Validate(t *testing.T) {
+	valid := port.Mapping{
+		Delimiter:        ",",
+		DateColumn:       1,
+		DateFormat:       "DD/MM/YYYY",
+		AmountColumn:     null.IntFrom(2),
+		DecimalSeparator: port.DecimalPoint,
+	}
+	require.NoError(t, valid.Validate(context.Background()))
+
+	both := valid
+	both.DebitColumn, both.CreditColumn = null.IntFrom(3), null.IntFrom(4)
+	require.Error(t, both.Validate(context.Background()))
+
+	debitOnly := valid
+	debitOnly.AmountColumn, debitOnly.DebitColumn = null.Int{}, null.IntFrom(3)
+	require.Error(t, debitOnly.Validate(context.Background()))
+
+	badFormat := valid
+	badFormat.DateFormat = "%d/%m/%Y"
+	require.Error(t, badFormat.Validate(context.Background()))
+}
+
+func TestService_Import_skipsDuplicates(t *testing.T) {
+	f := newFixture("USD")
+	existing := uuid.New()
+	f.transactions.existing = basedomain.List[transactionport.Transaction]{
+		{ID: existing, AccountID: f.account.ID, ExternalReferenceNumber: null.StringFrom("T1")},
+	}
+
+	result, err := f.svc.Import(context.Background(), f.input(csvStatement+"2026-04-29,Salary,2500,T2\n", false))
+	require.NoError(t, err)
+
+	require.Len(t, result.Rows, 3)
+	assert.True(t, result.Rows[0].Duplicate)
+	assert.Equal(t, existing, *result.Rows[0].DuplicateOf)
+	assert.False(t, result.Rows[1].Duplicate)
+	assert.True(t, result.Rows[2].Duplicate)
+	assert.Nil(t, result.Rows[2].DuplicateOf)
+
+	assert.Equal(t, 1, result.Imported)
+	assert.Equal(t, 2, result.Skipped)
+	require.Len(t, f.transactions.created, 1)
+	assert.Equal(t, "T2", f.transactions.created[0].ExternalReferenceNumber.String)
+}
+
+func TestService_Import_previewFlagsLookAlikes(t *testing.T) {
+	f := newFixture("USD")
+	existing := uuid.New()
+	f.transactions.existing = basedomain.List[transactionport.Transaction]{
+		{ID: existing, AccountID: f.account.ID, Amount: -450, Date: time.Date(2026, time.April, 28, 0, 0, 0, 0, time.UTC), Description: null.StringFrom("coffee")},
+	}
+	content := "Date,Description,Amount,Reference\n" +
+		"2026-04-28,Coffee,-4.50,\n" +
+		"2026-04-29,Lunch,-12.00,\n" +
+		"2026-04-29,LUNCH,-12.00,\n"
+
+	result, err := f.svc.Import(context.Background(), f.input(content, true))
+	require.NoError(t, err)
+
+	require.Len(t, result.Rows, 3)
+	assert.True(t, result.Rows[0].Duplicate)
+	assert.Equal(t, existing, *result.Rows[0].DuplicateOf)
+	assert.False(t, result.Rows[1].Duplicate)
+	assert.True(t, result.Rows[2].Duplicate)
+	assert.Nil(t, result.Rows[2].DuplicateOf)
+	assert.Equal(t, 2, result.Skipped)
+
+	t.Run("commit skips what the preview flagged", func(t *testing.T) {
+		result, err := f.svc.Import(context.Background(), f.input(content, false))
+		require.NoError(t, err)
+		assert.Equal(t, 1, result.Imported)
+		require.Len(t, f.transactions.created, 1)
+		assert.Equal(t, "Lunch", f.transactions.created[0].Description.String)
+	})
+
+	t.Run("allowed duplicates are all imported", func(t *testing.T) {
+		input := f.input(content, true)
+		input.AllowDuplicates = true
+
+		result, err := f.svc.Import(context.Background(), input)
+		require.NoError(t, err)
+		assert.Zero(t, result.Skipped)
+	})
+}
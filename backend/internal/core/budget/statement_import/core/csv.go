@@ -0,0 +1,186 @@
+package core
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"backend/core/budget/statement_import/port"
+	"backend/infra/money"
+
+	"github.com/guregu/null/v6"
+)
+
+var utf8BOM = []byte("\xef\xbb\xbf")
+
+// parseCSV reads the entries of a CSV export laid out as mapping describes. Amounts are
+// converted to minor units of a currency with decimalPlaces. An entry that cannot be
+// read is returned with its Error set; only a file that is not CSV at all fails.
+func parseCSV(content []byte, mapping port.Mapping, decimalPlaces int16) ([]port.ImportRow, error) {
+	r := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(content, utf8BOM)))
+	r.Comma = []rune(mapping.Delimiter)[0]
+	r.FieldsPerRecord = -1
+	r.LazyQuotes = true
+	r.TrimLeadingSpace = true
+
+	var rows []port.ImportRow
+	for skipped := 0; ; {
+		record, err := r.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if skipped < mapping.SkipRows {
+			skipped++
+			continue
+		}
+
+		line, _ := r.FieldPos(0)
+		row := port.ImportRow{Line: line}
+		if err := readRecord(record, mapping, decimalPlaces, &row); err != nil {
+			row.Error = err.Error()
+		}
+		rows = append(rows, row)
+	}
+
+	return rows, nil
+}
+
+func readRecord(record []string, mapping port.Mapping, decimalPlaces int16, row *port.ImportRow) error {
+	field := func(column int64) (string, error) {
+		if int(column) > len(record) {
+			return "", fmt.Errorf("column %d is missing", column)
+		}
+		return strings.TrimSpace(record[column-1]), nil
+	}
+
+	date, err := field(int64(mapping.DateColumn))
+	if err != nil {
+		return err
+	}
+	row.Date, err = time.Parse(mapping.DateLayout(), date)
+	if err != nil {
+		return fmt.Errorf("date %q does not match %s", date, mapping.DateFormat)
+	}
+
+	if mapping.AmountColumn.Valid {
+		amount, err := field(mapping.AmountColumn.Int64)
+		if err != nil {
+			return err
+		}
+		row.Amount, err = parseAmount(amount, mapping.DecimalSeparator, decimalPlaces)
+		if err != nil {
+			return err
+		}
+	} else {
+		debit, err := field(mapping.DebitColumn.Int64)
+		if err != nil {
+			return err
+		}
+		credit, err := field(mapping.CreditColumn.Int64)
+		if err != nil {
+			return err
+		}
+		if debit == "" && credit == "" {
+			return fmt.Errorf("both debit and credit are empty")
+		}
+
+		// Some banks sign the debit column, others do not; it is money out either way.
+		out, err := parseOptionalAmount(debit, mapping.DecimalSeparator, decimalPlaces)
+		if err != nil {
+			return err
+		}
+		in, err := parseOptionalAmount(credit, mapping.DecimalSeparator, decimalPlaces)
+		if err != nil {
+			return err
+		}
+		row.Amount = in - abs(out)
+	}
+
+	if row.Amount == 0 {
+		return fmt.Errorf("amount is zero")
+	}
+
+	if mapping.DescriptionColumn.Valid {
+		description, err := field(mapping.DescriptionColumn.Int64)
+		if err != nil {
+			return err
+		}
+		row.Description = null.NewString(description, description != "")
+	}
+
+	if mapping.ReferenceColumn.Valid {
+		reference, err := field(mapping.ReferenceColumn.Int64)
+		if err != nil {
+			return err
+		}
+		row.Reference = null.NewString(reference, reference != "")
+	}
+
+	return nil
+}
+
+func parseOptionalAmount(s, decimalSeparator string, decimalPlaces int16) (int64, error) {
+	if s == "" {
+		return 0, nil
+	}
+	return parseAmount(s, decimalSeparator, decimalPlaces)
+}
+
+// parseAmount reads an amount the way banks print them: with thousands separators,
+// "1.234,56" or "1,234.56", and negatives as "-12.30", "12.30-" or "(12.30)".
+func parseAmount(s, decimalSeparator string, decimalPlaces int16) (int64, error) {
+	text := strings.Map(func(r rune) rune {
+		if r == ' ' || r == '\u00a0' || r == '\'' {
+			return -1
+		}
+		return r
+	}, s)
+
+	negative := false
+	switch {
+	case strings.HasPrefix(text, "(") && strings.HasSuffix(text, ")"):
+		negative, text = true, text[1:len(text)-1]
+	case strings.HasSuffix(text, "-"):
+		negative, text = true, strings.TrimSuffix(text, "-")
+	case strings.HasPrefix(text, "-"):
+		negative, text = true, strings.TrimPrefix(text, "-")
+	case strings.HasPrefix(text, "+"):
+		text = strings.TrimPrefix(text, "+")
+	}
+
+	thousandsSeparator := port.DecimalComma
+	if decimalSeparator == port.DecimalComma {
+		thousandsSeparator = port.DecimalPoint
+	}
+	text = strings.ReplaceAll(text, thousandsSeparator, "")
+	text = strings.Replace(text, decimalSeparator, ".", 1)
+
+	if text == "" || strings.ContainsFunc(text, func(r rune) bool { return (r < '0' || r > '9') && r != '.' }) {
+		return 0, fmt.Errorf("amount %q is not a number", s)
+	}
+
+	amount, err := money.ParseMajor(text, decimalPlaces)
+	if err != nil {
+		return 0, fmt.Errorf("amount %q is not a number", s)
+	}
+
+	if negative {
+		return -amount.Int64(), nil
+	}
+	return amount.Int64(), nil
+}
+
+func abs(n int64) int64 {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
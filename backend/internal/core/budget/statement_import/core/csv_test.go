@@ -0,0 +1,121 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"backend/core/budget/statement_import/port"
+
+	"github.com/guregu/null/v6"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCSV_signedAmount(t *testing.T) {
+	content := "\xef\xbb\xbfFecha;Descripción;Monto;Ref\n" +
+		"28/04/2026;Supermercado;-1.234,50;A1\n" +
+		"29/04/2026;\"Sueldo; abril\";2.500.000,00;\n"
+
+	rows, err := parseCSV([]byte(content), port.Mapping{
+		Delimiter:         ";",
+		SkipRows:          1,
+		DateColumn:        1,
+		DateFormat:        "DD/MM/YYYY",
+		AmountColumn:      null.IntFrom(3),
+		DescriptionColumn: null.IntFrom(2),
+		ReferenceColumn:   null.IntFrom(4),
+		DecimalSeparator:  port.DecimalComma,
+	}, 2)
+	require.NoError(t, err)
+	require.Len(t, rows, 2)
+
+	assert.Equal(t, port.ImportRow{
+		Line:        2,
+		Date:        time.Date(2026, time.April, 28, 0, 0, 0, 0, time.UTC),
+		Amount:      -123450,
+		Description: null.StringFrom("Supermercado"),
+		Reference:   null.StringFrom("A1"),
+	}, rows[0])
+	assert.Equal(t, int64(250000000), rows[1].Amount)
+	assert.Equal(t, "Sueldo; abril", rows[1].Description.String)
+	assert.False(t, rows[1].Reference.Valid)
+}
+
+func TestParseCSV_debitAndCredit(t *testing.T) {
+	content := "Date,Description,Debit,Credit\n" +
+		"2026-04-28,Coffee,4.50,\n" +
+		"2026-04-29,Refund,,12.00\n" +
+		"2026-04-30,Broken,,\n" +
+		"30.04.2026,Bad date,1.00,\n"
+
+	rows, err := parseCSV([]byte(content), port.Mapping{
+		Delimiter:         ",",
+		SkipRows:          1,
+		DateColumn:        1,
+		DateFormat:        "YYYY-MM-DD",
+		DebitColumn:       null.IntFrom(3),
+		CreditColumn:      null.IntFrom(4),
+		DescriptionColumn: null.IntFrom(2),
+		DecimalSeparator:  port.DecimalPoint,
+	}, 2)
+	require.NoError(t, err)
+	require.Len(t, rows, 4)
+
+	assert.Equal(t, int64(-450), rows[0].Amount)
+	assert.Empty(t, rows[0].Error)
+	assert.Equal(t, int64(1200), rows[1].Amount)
+	assert.Equal(t, "both debit and credit are empty", rows[2].Error)
+	assert.Contains(t, rows[3].Error, "does not match YYYY-MM-DD")
+	assert.Equal(t, 5, rows[3].Line)
+}
+
+func TestParseCSV_missingColumn(t *testing.T) {
+	rows, err := parseCSV([]byte("2026-04-28,Coffee\n"), port.Mapping{
+		Delimiter:        ",",
+		DateColumn:       1,
+		DateFormat:       "YYYY-MM-DD",
+		AmountColumn:     null.IntFrom(3),
+		DecimalSeparator: port.DecimalPoint,
+	}, 2)
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+	assert.Equal(t, "column 3 is missing", rows[0].Error)
+}
+
+func TestParseAmount(t *testing.T) {
+	tests := []struct {
+		in               string
+		decimalSeparator string
+		decimalPlaces    int16
+		want             int64
+	}{
+		{in: "1,234.56", decimalSeparator: ".", decimalPlaces: 2, want: 123456},
+		{in: "1.234,56", decimalSeparator: ",", decimalPlaces: 2, want: 123456},
+		{in: "1 234,56", decimalSeparator: ",", decimalPlaces: 2, want: 123456},
+		{in: "1'234.56", decimalSeparator: ".", decimalPlaces: 2, want: 123456},
+		{in: "(12.30)", decimalSeparator: ".", decimalPlaces: 2, want: -1230},
+		{in: "12.30-", decimalSeparator: ".", decimalPlaces: 2, want: -1230},
+		{in: "+5", decimalSeparator: ".", decimalPlaces: 2, want: 500},
+		{in: "15.000", decimalSeparator: ",", decimalPlaces: 0, want: 15000},
+		{in: "1500", decimalSeparator: ".", decimalPlaces: 0, want: 1500},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := parseAmount(tt.in, tt.decimalSeparator, tt.decimalPlaces)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	for _, in := range []string{"", "USD 12", "1e3", "--1"} {
+		_, err := parseAmount(in, ".", 2)
+		assert.Error(t, err, in)
+	}
+}
+
+func TestMapping_DateLayout(t *testing.T) {
+	assert.Equal(t, "02/01/2006", port.Mapping{DateFormat: "DD/MM/YYYY"}.DateLayout())
+	assert.Equal(t, "1/2/06", port.Mapping{DateFormat: "M/D/YY"}.DateLayout())
+	assert.Equal(t, "20060102", port.Mapping{DateFormat: "YYYYMMDD"}.DateLayout())
+}
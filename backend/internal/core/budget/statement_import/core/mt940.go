@@ -0,0 +1,263 @@
+package core
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"backend/core/budget/statement_import/port"
+
+	"github.com/guregu/null/v6"
+)
+
+var (
+	mt940Tag = regexp.MustCompile(`^:(\d{2}[A-Z]?):`)
+	// mt940Balance is a balance field: D/C mark, date YYMMDD, currency and amount.
+	mt940Balance = regexp.MustCompile(`^([CD])(\d{6})([A-Z]{3})([\d,]+)$`)
+	// mt940Line is a statement line: value date YYMMDD, optional entry date MMDD,
+	// D/C mark (RC and RD are reversals), optional funds code, amount, transaction type,
+	// customer reference and, after //, the bank reference.
+	mt940Line = regexp.MustCompile(`^(\d{6})(\d{4})?(RC|RD|C|D)([A-Z])?([\d,]+)([A-Z]\w{3})([^/\n]*(?:/[^/\n]+)*)(?://([^\n]*))?`)
+	// mt940Subfield is a ?NN subfield of a structured :86: field.
+	mt940Subfield = regexp.MustCompile(`\?(\d{2})`)
+)
+
+type mt940Field struct {
+	tag   string
+	value string
+	line  int
+}
+
+// parseMT940 reads a SWIFT MT940 customer statement, with or without the SWIFT block
+// headers. A file may hold several statements, usually one per day, as long as they are
+// of the same account; the currency is the one of the first opening balance and the
+// ledger balance the last closing balance. Entries of a statement in another currency
+// are rejected.
+func parseMT940(content []byte, decimalPlaces int16) (statement, error) {
+	fields := mt940Fields(string(bytes.TrimPrefix(content, utf8BOM)))
+
+	var (
+		st       statement
+		account  string
+		currency string
+		entry    *port.ImportRow
+	)
+
+	for _, f := range fields {
+		if f.tag != "86" {
+			entry = nil
+		}
+
+		switch f.tag {
+		case "25":
+			if account != "" && f.value != account {
+				return statement{}, fmt.Errorf("statements of accounts %s and %s in one file", account, f.value)
+			}
+			account = f.value
+
+		case "60F", "60M":
+			balance, err := readMT940Balance(f.value, decimalPlaces)
+			if err != nil {
+				return statement{}, fmt.Errorf("opening balance at line %d: %w", f.line, err)
+			}
+			currency = balance.currency
+			if st.currency == "" {
+				st.currency = currency
+			}
+
+		case "61":
+			st.rows = append(st.rows, readMT940Line(f, currency, st.currency, decimalPlaces))
+			entry = &st.rows[len(st.rows)-1]
+
+		case "86":
+			if entry != nil && entry.Error == "" {
+				description := mt940Description(f.value)
+				entry.Description = null.NewString(description, description != "")
+			}
+
+		case "62F":
+			balance, err := readMT940Balance(f.value, decimalPlaces)
+			if err != nil {
+				return statement{}, fmt.Errorf("closing balance at line %d: %w", f.line, err)
+			}
+			st.ledger = &ledgerBalance{amount: balance.amount, asOf: balance.date}
+		}
+	}
+
+	if account == "" {
+		return statement{}, errors.New("no account identification (:25:)")
+	}
+
+	return st, nil
+}
+
+// mt940Fields splits the text blocks of a file into their fields. A line that does not
+// start with a tag continues the field above it.
+func mt940Fields(text string) []mt940Field {
+	var fields []mt940Field
+	for i, line := range strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n") {
+		if start := strings.Index(line, "{4:"); start >= 0 {
+			line = line[start+len("{4:"):]
+		}
+		if line == "-" || strings.HasPrefix(line, "-}") || strings.HasPrefix(line, "{") {
+			continue
+		}
+
+		if tag := mt940Tag.FindStringSubmatch(line); tag != nil {
+			fields = append(fields, mt940Field{tag: tag[1], value: strings.TrimSpace(line[len(tag[0]):]), line: i + 1})
+			continue
+		}
+
+		if len(fields) > 0 && strings.TrimSpace(line) != "" {
+			last := &fields[len(fields)-1]
+			last.value += "\n" + strings.TrimSpace(line)
+		}
+	}
+	return fields
+}
+
+type mt940BalanceValue struct {
+	date     time.Time
+	currency string
+	amount   int64
+}
+
+func readMT940Balance(value string, decimalPlaces int16) (mt940BalanceValue, error) {
+	m := mt940Balance.FindStringSubmatch(value)
+	if m == nil {
+		return mt940BalanceValue{}, fmt.Errorf("balance %q is not a D/C mark, date, currency and amount", value)
+	}
+
+	date, err := time.Parse("060102", m[2])
+	if err != nil {
+		return mt940BalanceValue{}, fmt.Errorf("date %q is not a YYMMDD date", m[2])
+	}
+
+	amount, err := parseMT940Amount(m[4], decimalPlaces)
+	if err != nil {
+		return mt940BalanceValue{}, err
+	}
+	if m[1] == "D" {
+		amount = -amount
+	}
+
+	return mt940BalanceValue{date: date, currency: m[3], amount: amount}, nil
+}
+
+func readMT940Line(f mt940Field, currency, statementCurrency string, decimalPlaces int16) port.ImportRow {
+	row := port.ImportRow{Line: f.line}
+	if err := readMT940Entry(f.value, currency, statementCurrency, decimalPlaces, &row); err != nil {
+		row.Error = err.Error()
+	}
+	return row
+}
+
+// readMT940Entry fills row from a statement line. The entry date is the booking date;
+// lines without one are dated on their value date. The bank reference is preferred
+// over the customer reference, which banks fill with NONREF when there is none.
+func readMT940Entry(value, currency, statementCurrency string, decimalPlaces int16, row *port.ImportRow) error {
+	if currency != statementCurrency {
+		return fmt.Errorf("entry is in %s, not in the statement currency %s", currency, statementCurrency)
+	}
+
+	m := mt940Line.FindStringSubmatch(value)
+	if m == nil {
+		return fmt.Errorf("statement line %q cannot be read", strings.SplitN(value, "\n", 2)[0])
+	}
+
+	valueDate, err := time.Parse("060102", m[1])
+	if err != nil {
+		return fmt.Errorf("value date %q is not a YYMMDD date", m[1])
+	}
+	row.Date = valueDate
+	if m[2] != "" {
+		row.Date, err = mt940EntryDate(valueDate, m[2])
+		if err != nil {
+			return err
+		}
+	}
+
+	row.Amount, err = parseMT940Amount(m[5], decimalPlaces)
+	if err != nil {
+		return err
+	}
+	// RC reverses a credit and so takes money out; RD reverses a debit.
+	if m[3] == "D" || m[3] == "RC" {
+		row.Amount = -row.Amount
+	}
+	if row.Amount == 0 {
+		return errors.New("amount is zero")
+	}
+
+	customerReference := strings.TrimSpace(m[7])
+	if customerReference == "NONREF" {
+		customerReference = ""
+	}
+	reference := firstNonEmpty(m[8], customerReference)
+	row.Reference = null.NewString(reference, reference != "")
+
+	return nil
+}
+
+// mt940EntryDate places an MMDD entry date in the year of the value date, or in the one
+// next to it when the entry is booked across a new year.
+func mt940EntryDate(valueDate time.Time, mmdd string) (time.Time, error) {
+	date, err := time.Parse("20060102", fmt.Sprintf("%04d%s", valueDate.Year(), mmdd))
+	if err != nil {
+		return time.Time{}, fmt.Errorf("entry date %q is not a MMDD date", mmdd)
+	}
+
+	switch {
+	case valueDate.Month() == time.December && date.Month() == time.January:
+		date = date.AddDate(1, 0, 0)
+	case valueDate.Month() == time.January && date.Month() == time.December:
+		date = date.AddDate(-1, 0, 0)
+	}
+
+	return date, nil
+}
+
+// parseMT940Amount reads an amount, which MT940 writes unsigned with a decimal comma
+// that may end it, as in "100,".
+func parseMT940Amount(s string, decimalPlaces int16) (int64, error) {
+	if strings.HasSuffix(s, ",") {
+		s += "0"
+	}
+	return parseAmount(s, port.DecimalComma, decimalPlaces)
+}
+
+// mt940Description reads the information to the account owner. Structured fields, as
+// German banks write them, put the counterparty in subfields ?32 and ?33 and the
+// purpose in ?20 to ?29; the counterparty is preferred. Other fields are taken as
+// they are.
+func mt940Description(value string) string {
+	indexes := mt940Subfield.FindAllStringSubmatchIndex(value, -1)
+	if len(indexes) == 0 {
+		return strings.TrimSpace(strings.ReplaceAll(value, "\n", " "))
+	}
+
+	// Subfields run on across lines wherever the bank broke them.
+	value = strings.ReplaceAll(value, "\n", "")
+	indexes = mt940Subfield.FindAllStringSubmatchIndex(value, -1)
+
+	var name, purpose strings.Builder
+	for i, idx := range indexes {
+		end := len(value)
+		if i+1 < len(indexes) {
+			end = indexes[i+1][0]
+		}
+		text := value[idx[1]:end]
+
+		switch code := value[idx[2]:idx[3]]; {
+		case code == "32" || code == "33":
+			name.WriteString(text)
+		case code >= "20" && code <= "29":
+			purpose.WriteString(text)
+		}
+	}
+
+	return firstNonEmpty(name.String(), purpose.String())
+}
@@ -0,0 +1,73 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const mt940Statement = "{1:F01BANKDEFFAXXX0000000000}{2:O9400000000000BANKDEFFXXXX00000000000000000000N}{4:\r\n" +
+	":20:STMT260428\r\n" +
+	":25:37040044/0532013000\r\n" +
+	":28C:00042/001\r\n" +
+	":60F:C260427EUR100,00\r\n" +
+	":61:2604280428D46,50NDDTNONREF//BANKREF1\r\n" +
+	":86:105?00LASTSCHRIFT?20Strom April?21Vertrag 12\r\n" +
+	"3?32Stadtwerke\r\n" +
+	":61:2604290429C2000,NTRFSALARY-04\r\n" +
+	":86:Gehalt April\r\n" +
+	"Arbeitgeber GmbH\r\n" +
+	":62F:C260429EUR2053,50\r\n" +
+	"-}\r\n" +
+	"{1:F01BANKDEFFAXXX0000000000}{2:O9400000000000BANKDEFFXXXX00000000000000000000N}{4:\r\n" +
+	":20:STMT260430\r\n" +
+	":25:37040044/0532013000\r\n" +
+	":28C:00043/001\r\n" +
+	":60F:C260429EUR2053,50\r\n" +
+	":61:2512310102RC3,00NMSCNONREF//REV1\r\n" +
+	":62F:C260430EUR2050,50\r\n" +
+	"-}\r\n"
+
+func TestParseMT940(t *testing.T) {
+	st, err := parseMT940([]byte(mt940Statement), 2)
+	require.NoError(t, err)
+
+	assert.Equal(t, "EUR", st.currency)
+	require.Len(t, st.rows, 3)
+
+	utilities := st.rows[0]
+	assert.Empty(t, utilities.Error)
+	assert.Equal(t, 6, utilities.Line)
+	assert.Equal(t, time.Date(2026, time.April, 28, 0, 0, 0, 0, time.UTC), utilities.Date)
+	assert.Equal(t, int64(-4650), utilities.Amount)
+	assert.Equal(t, "BANKREF1", utilities.Reference.String)
+	assert.Equal(t, "Stadtwerke", utilities.Description.String)
+
+	salary := st.rows[1]
+	assert.Equal(t, int64(200000), salary.Amount)
+	assert.Equal(t, "SALARY-04", salary.Reference.String)
+	assert.Equal(t, "Gehalt April Arbeitgeber GmbH", salary.Description.String)
+
+	reversal := st.rows[2]
+	assert.Equal(t, int64(-300), reversal.Amount)
+	assert.Equal(t, time.Date(2026, time.January, 2, 0, 0, 0, 0, time.UTC), reversal.Date)
+
+	require.NotNil(t, st.ledger)
+	assert.Equal(t, int64(205050), st.ledger.amount)
+	assert.Equal(t, time.Date(2026, time.April, 30, 0, 0, 0, 0, time.UTC), st.ledger.asOf)
+}
+
+func TestParseMT940_rejectsOtherCurrencyAndAccounts(t *testing.T) {
+	content := ":20:A\n:25:1\n:60F:C260427USD0,\n:61:2604280428D1,00NTRFNONREF\n:62F:D260428USD1,00\n" +
+		":20:B\n:25:1\n:60F:C260428EUR0,\n:61:2604290429D1,00NTRFNONREF\n:62F:D260429EUR1,00\n"
+	st, err := parseMT940([]byte(content), 2)
+	require.NoError(t, err)
+	require.Len(t, st.rows, 2)
+	assert.Empty(t, st.rows[0].Error)
+	assert.Contains(t, st.rows[1].Error, "EUR")
+
+	_, err = parseMT940([]byte(":20:A\n:25:1\n:20:B\n:25:2\n"), 2)
+	require.Error(t, err)
+}
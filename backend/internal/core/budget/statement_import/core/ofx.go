@@ -0,0 +1,278 @@
+package core
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"html"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"backend/core/budget/statement_import/port"
+
+	"github.com/guregu/null/v6"
+)
+
+// ofxTransaction holds the leaf elements of a STMTTRN aggregate as written in the file.
+type ofxTransaction struct {
+	line     int
+	fields   map[string]string
+	currency string
+}
+
+// ofxAggregates are the aggregates read from a statement. They open one even when
+// the file never closes them.
+var ofxAggregates = map[string]bool{
+	"OFX":          true,
+	"STMTTRNRS":    true,
+	"STMTRS":       true,
+	"CCSTMTTRNRS":  true,
+	"CCSTMTRS":     true,
+	"BANKTRANLIST": true,
+	"STMTTRN":      true,
+	"CURRENCY":     true,
+	"ORIGCURRENCY": true,
+	"LEDGERBAL":    true,
+	"AVAILBAL":     true,
+}
+
+// parseOFX reads a bank or credit card statement in OFX 1.x, which is SGML and leaves
+// the closing tags of leaf elements out, or OFX 2.x, which is XML. Both are read the
+// same way: a tag followed by text is a leaf element, and a tag without text opens an
+// aggregate when it is a known one or its end tag appears in the file. Any other
+// tag without text is an empty leaf element, such as a bare <MEMO> in OFX 1.x. The
+// file must hold a single statement; its entries are read like the rows of a CSV
+// export.
+func parseOFX(content []byte, decimalPlaces int16) (statement, error) {
+	text := decodeOFX(content)
+	start := strings.Index(text, "<OFX>")
+	if start < 0 {
+		return statement{}, errors.New("no OFX element")
+	}
+
+	var (
+		st         statement
+		stack      []string
+		txn        *ofxTransaction
+		balance    = map[string]string{}
+		statements int
+		line       = 1 + strings.Count(text[:start], "\n")
+		counted    = start
+		closed     = endTags(text[start:])
+	)
+
+	for pos := start; ; {
+		open := strings.IndexByte(text[pos:], '<')
+		if open < 0 {
+			break
+		}
+		open += pos
+
+		end := strings.IndexByte(text[open:], '>')
+		if end < 0 {
+			return statement{}, fmt.Errorf("tag at line %d is not closed", line)
+		}
+		end += open
+
+		pos = len(text)
+		if next := strings.IndexByte(text[end+1:], '<'); next >= 0 {
+			pos = end + 1 + next
+		}
+
+		line += strings.Count(text[counted:open], "\n")
+		counted = open
+
+		tag := strings.ToUpper(strings.TrimSpace(text[open+1 : end]))
+		value := strings.TrimSpace(html.UnescapeString(text[end+1 : pos]))
+		parent := ""
+		if len(stack) > 0 {
+			parent = stack[len(stack)-1]
+		}
+
+		switch {
+		case tag == "" || strings.HasPrefix(tag, "?") || strings.HasPrefix(tag, "!") || strings.HasSuffix(tag, "/"):
+			continue
+
+		case strings.HasPrefix(tag, "/"):
+			// End tags of leaf elements, which only OFX 2.x writes, match no open
+			// aggregate and are skipped.
+			name := tag[1:]
+			i := len(stack) - 1
+			for i >= 0 && stack[i] != name {
+				i--
+			}
+			if i < 0 {
+				continue
+			}
+			stack = stack[:i]
+
+			if name == "STMTTRN" && txn != nil {
+				st.rows = append(st.rows, txn.row(st.currency, decimalPlaces))
+				txn = nil
+			}
+
+		case value == "" && (ofxAggregates[tag] || closed[tag]):
+			stack = append(stack, tag)
+
+			switch tag {
+			case "STMTRS", "CCSTMTRS":
+				statements++
+			case "STMTTRN":
+				txn = &ofxTransaction{line: line, fields: map[string]string{}}
+			}
+
+		default:
+			switch {
+			case parent == "STMTTRN" && txn != nil:
+				txn.fields[tag] = value
+			case parent == "CURRENCY" && tag == "CURSYM" && txn != nil:
+				txn.currency = strings.ToUpper(value)
+			case parent == "LEDGERBAL":
+				balance[tag] = value
+			case (parent == "STMTRS" || parent == "CCSTMTRS") && tag == "CURDEF":
+				st.currency = strings.ToUpper(value)
+			}
+		}
+	}
+
+	switch {
+	case statements == 0:
+		return statement{}, errors.New("no bank or credit card statement")
+	case statements > 1:
+		return statement{}, fmt.Errorf("%d statements in one file", statements)
+	}
+
+	if amount, ok := balance["BALAMT"]; ok {
+		ledger, err := readLedgerBalance(amount, balance["DTASOF"], decimalPlaces)
+		if err != nil {
+			return statement{}, err
+		}
+		st.ledger = &ledger
+	}
+
+	return st, nil
+}
+
+// endTags returns the names of the elements closed somewhere in text.
+func endTags(text string) map[string]bool {
+	names := map[string]bool{}
+	for {
+		open := strings.Index(text, "</")
+		if open < 0 {
+			return names
+		}
+		text = text[open+2:]
+
+		end := strings.IndexByte(text, '>')
+		if end < 0 {
+			return names
+		}
+		names[strings.ToUpper(strings.TrimSpace(text[:end]))] = true
+		text = text[end+1:]
+	}
+}
+
+func (t ofxTransaction) row(currency string, decimalPlaces int16) port.ImportRow {
+	row := port.ImportRow{Line: t.line}
+	if err := t.read(currency, decimalPlaces, &row); err != nil {
+		row.Error = err.Error()
+	}
+	return row
+}
+
+// read fills row from the entry. NAME is the payee as the bank shows it and is taken
+// as the description; MEMO is used when there is no NAME.
+func (t ofxTransaction) read(currency string, decimalPlaces int16, row *port.ImportRow) error {
+	if t.currency != "" && t.currency != currency {
+		return fmt.Errorf("entry is in %s, not in the statement currency %s", t.currency, currency)
+	}
+
+	var err error
+	row.Date, err = parseOFXDate(t.fields["DTPOSTED"])
+	if err != nil {
+		return err
+	}
+
+	amount, ok := t.fields["TRNAMT"]
+	if !ok {
+		return errors.New("TRNAMT is missing")
+	}
+	row.Amount, err = parseOFXAmount(amount, decimalPlaces)
+	if err != nil {
+		return err
+	}
+	if row.Amount == 0 {
+		return errors.New("amount is zero")
+	}
+
+	description := t.fields["NAME"]
+	if description == "" {
+		description = t.fields["MEMO"]
+	}
+	row.Description = null.NewString(description, description != "")
+
+	fitID := t.fields["FITID"]
+	if fitID == "" {
+		return errors.New("FITID is missing")
+	}
+	row.Reference = null.StringFrom(fitID)
+
+	return nil
+}
+
+func readLedgerBalance(amount, asOf string, decimalPlaces int16) (ledgerBalance, error) {
+	balance, err := parseOFXAmount(amount, decimalPlaces)
+	if err != nil {
+		return ledgerBalance{}, fmt.Errorf("ledger balance: %w", err)
+	}
+
+	date, err := parseOFXDate(asOf)
+	if err != nil {
+		return ledgerBalance{}, fmt.Errorf("ledger balance: %w", err)
+	}
+
+	return ledgerBalance{amount: balance, asOf: date}, nil
+}
+
+// parseOFXDate reads the date of an OFX datetime, written YYYYMMDD optionally followed
+// by the time and a time zone, e.g. 20260428120000.000[-5:EST]. Only the date the bank
+// printed is kept.
+func parseOFXDate(s string) (time.Time, error) {
+	if len(s) < 8 {
+		return time.Time{}, fmt.Errorf("date %q is not an OFX date", s)
+	}
+
+	date, err := time.Parse("20060102", s[:8])
+	if err != nil {
+		return time.Time{}, fmt.Errorf("date %q is not an OFX date", s)
+	}
+
+	return date, nil
+}
+
+// parseOFXAmount reads an OFX amount. The specification allows either a point or a
+// comma as decimal separator, never a thousands separator.
+func parseOFXAmount(s string, decimalPlaces int16) (int64, error) {
+	separator := port.DecimalPoint
+	if strings.Contains(s, port.DecimalComma) {
+		separator = port.DecimalComma
+	}
+	return parseAmount(s, separator, decimalPlaces)
+}
+
+// decodeOFX returns content as text. OFX 2.x is UTF-8; OFX 1.x files are usually
+// written in a single-byte charset, which is read as Latin-1 when content is not
+// valid UTF-8.
+func decodeOFX(content []byte) string {
+	content = bytes.TrimPrefix(content, utf8BOM)
+	if utf8.Valid(content) {
+		return string(content)
+	}
+
+	runes := make([]rune, len(content))
+	for i, b := range content {
+		runes[i] = rune(b)
+	}
+	return string(runes)
+}
@@ -0,0 +1,180 @@
+package core
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"backend/core/budget/statement_import/port"
+	"backend/infra/money"
+	apperrors "backend/port/errors"
+
+	"github.com/guregu/null/v6"
+	"github.com/samber/oops"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const sgmlStatement = `OFXHEADER:100
+DATA:OFXSGML
+VERSION:102
+ENCODING:USASCII
+CHARSET:1252
+
+<OFX>
+<SIGNONMSGSRSV1><SONRS><STATUS><CODE>0<SEVERITY>INFO</STATUS><DTSERVER>20260430120000<LANGUAGE>ENG</SONRS></SIGNONMSGSRSV1>
+<BANKMSGSRSV1>
+<STMTTRNRS>
+<TRNUID>1
+<STMTRS>
+<CURDEF>USD
+<BANKACCTFROM><BANKID>121000248<ACCTID>1234<ACCTTYPE>CHECKING</BANKACCTFROM>
+<BANKTRANLIST>
+<DTSTART>20260401<DTEND>20260430
+<STMTTRN>
+<TRNTYPE>DEBIT
+<DTPOSTED>20260428120000.000[-5:EST]
+<TRNAMT>-4.50
+<FITID>2026042801
+<NAME>Coffee &amp; Co
+</STMTTRN>
+<STMTTRN>
+<TRNTYPE>CREDIT
+<DTPOSTED>20260429
+<TRNAMT>2500.00
+<FITID>2026042901
+<MEMO>Salary April
+</STMTTRN>
+</BANKTRANLIST>
+<LEDGERBAL><BALAMT>3495.50<DTASOF>20260430</LEDGERBAL>
+</STMTRS>
+</STMTTRNRS>
+</BANKMSGSRSV1>
+</OFX>
+`
+
+const xmlStatement = `<?xml version="1.0" encoding="UTF-8" standalone="no"?>
+<?OFX OFXHEADER="200" VERSION="220" SECURITY="NONE" OLDFILEUID="NONE" NEWFILEUID="NONE"?>
+<OFX>
+  <CREDITCARDMSGSRSV1>
+    <CCSTMTTRNRS>
+      <TRNUID>1</TRNUID>
+      <CCSTMTRS>
+        <CURDEF>EUR</CURDEF>
+        <CCACCTFROM><ACCTID>5555</ACCTID></CCACCTFROM>
+        <BANKTRANLIST>
+          <STMTTRN>
+            <TRNTYPE>DEBIT</TRNTYPE>
+            <DTPOSTED>20260428</DTPOSTED>
+            <TRNAMT>-12,30</TRNAMT>
+            <FITID>X1</FITID>
+            <NAME>Librería</NAME>
+            <MEMO></MEMO>
+          </STMTTRN>
+          <STMTTRN>
+            <TRNTYPE>DEBIT</TRNTYPE>
+            <DTPOSTED>20260429</DTPOSTED>
+            <TRNAMT>-20.00</TRNAMT>
+            <FITID>X2</FITID>
+            <CURRENCY><CURRATE>1.08</CURRATE><CURSYM>USD</CURSYM></CURRENCY>
+          </STMTTRN>
+        </BANKTRANLIST>
+        <LEDGERBAL><BALAMT>-12.30</BALAMT><DTASOF>20260430000000</DTASOF></LEDGERBAL>
+      </CCSTMTRS>
+    </CCSTMTTRNRS>
+  </CREDITCARDMSGSRSV1>
+</OFX>
+`
+
+func TestParseOFX_sgml(t *testing.T) {
+	st, err := parseOFX([]byte(sgmlStatement), 2)
+	require.NoError(t, err)
+
+	assert.Equal(t, "USD", st.currency)
+	require.Len(t, st.rows, 2)
+	assert.Equal(t, port.ImportRow{
+		Line:        17,
+		Date:        time.Date(2026, time.April, 28, 0, 0, 0, 0, time.UTC),
+		Amount:      -450,
+		Description: null.StringFrom("Coffee & Co"),
+		Reference:   null.StringFrom("2026042801"),
+	}, st.rows[0])
+	assert.Equal(t, int64(250000), st.rows[1].Amount)
+	assert.Equal(t, "Salary April", st.rows[1].Description.String)
+
+	require.NotNil(t, st.ledger)
+	assert.Equal(t, int64(349550), st.ledger.amount)
+	assert.Equal(t, time.Date(2026, time.April, 30, 0, 0, 0, 0, time.UTC), st.ledger.asOf)
+}
+
+func TestParseOFX_sgmlEmptyLeaf(t *testing.T) {
+	content := strings.Replace(sgmlStatement, "<TRNAMT>-4.50\n", "<TRNAMT>-4.50\n<MEMO>\n", 1)
+
+	st, err := parseOFX([]byte(content), 2)
+	require.NoError(t, err)
+	require.Len(t, st.rows, 2)
+	assert.Empty(t, st.rows[0].Error)
+	assert.Equal(t, "2026042801", st.rows[0].Reference.String)
+	assert.Equal(t, "Coffee & Co", st.rows[0].Description.String)
+}
+
+func TestParseOFX_xml(t *testing.T) {
+	st, err := parseOFX([]byte(xmlStatement), 2)
+	require.NoError(t, err)
+
+	assert.Equal(t, "EUR", st.currency)
+	require.Len(t, st.rows, 2)
+	assert.Equal(t, int64(-1230), st.rows[0].Amount)
+	assert.Equal(t, "Librería", st.rows[0].Description.String)
+	assert.Equal(t, "X1", st.rows[0].Reference.String)
+	assert.Empty(t, st.rows[0].Error)
+	assert.Contains(t, st.rows[1].Error, "USD")
+	assert.Equal(t, int64(-1230), st.ledger.amount)
+}
+
+func TestParseOFX_latin1(t *testing.T) {
+	content := strings.Replace(sgmlStatement, "Coffee &amp; Co", "Caf\xe9", 1)
+
+	st, err := parseOFX([]byte(content), 2)
+	require.NoError(t, err)
+	assert.Equal(t, "Café", st.rows[0].Description.String)
+}
+
+func TestParseOFX_rejects(t *testing.T) {
+	_, err := parseOFX([]byte("Date,Amount\n2026-04-28,1.00\n"), 2)
+	require.Error(t, err)
+
+	twice := strings.Replace(sgmlStatement, "</STMTTRNRS>", "</STMTTRNRS><STMTTRNRS><STMTRS><CURDEF>USD</STMTRS></STMTTRNRS>", 1)
+	_, err = parseOFX([]byte(twice), 2)
+	require.Error(t, err)
+}
+
+func TestService_Import_ofxChecksBalance(t *testing.T) {
+	f := newFixture("USD")
+	f.account.CurrentBalance = money.Minor(100000)
+	f.svc = New(stubProfileRepo{profile: f.profile}, stubAccountRepo{account: f.account}, stubCurrencyRepo{}, f.transactions, noopLogger{})
+
+	input := port.ImportStatement{AccountID: f.account.ID, Format: port.FormatOFX, Content: []byte(sgmlStatement)}
+	result, err := f.svc.Import(context.Background(), input)
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, result.Imported)
+	assert.Equal(t, "2026042801", f.transactions.created[0].ExternalReferenceNumber.String)
+	require.NotNil(t, result.Balance)
+	assert.Equal(t, int64(349550), result.Balance.Statement)
+	assert.Equal(t, int64(349550), result.Balance.Account)
+	assert.True(t, result.Balance.Matches())
+}
+
+func TestService_Import_ofxRejectsOtherCurrency(t *testing.T) {
+	f := newFixture("CLP")
+
+	input := port.ImportStatement{AccountID: f.account.ID, Format: port.FormatOFX, Content: []byte(sgmlStatement), Preview: true}
+	_, err := f.svc.Import(context.Background(), input)
+	require.Error(t, err)
+
+	oopsErr, ok := oops.AsOops(err)
+	require.True(t, ok)
+	assert.Equal(t, apperrors.CodeValidation, oopsErr.Code())
+}
@@ -0,0 +1,39 @@
+package statement_import
+
+import (
+	"backend/adapter/database"
+	"backend/adapter/di"
+	accountport "backend/core/budget/account/port"
+	currencyport "backend/core/budget/currency/port"
+	"backend/core/budget/statement_import/adapter/handler"
+	"backend/core/budget/statement_import/adapter/postgres"
+	"backend/core/budget/statement_import/core"
+	"backend/core/budget/statement_import/port"
+	transactionport "backend/core/budget/transaction/port"
+	basedomain "backend/port"
+
+	"github.com/samber/do/v2"
+)
+
+func Module(i do.Injector) {
+	di.Provide(i, func(i do.Injector) (port.Repository, error) {
+		db := di.MustInvoke[database.PoolInterface](i)
+		logger := di.MustInvoke[basedomain.Logger](i)
+		return postgres.NewPostgres(db, logger), nil
+	})
+
+	di.Provide(i, func(i do.Injector) (port.Service, error) {
+		repo := di.MustInvoke[port.Repository](i)
+		accountRepo := di.MustInvoke[accountport.Repository](i)
+		currencyRepo := di.MustInvoke[currencyport.Repository](i)
+		transactionSvc := di.MustInvoke[transactionport.Service](i)
+		logger := di.MustInvoke[basedomain.Logger](i)
+		return core.New(repo, accountRepo, currencyRepo, transactionSvc, logger), nil
+	})
+
+	di.Provide(i, func(i do.Injector) (handler.HTTP, error) {
+		svc := di.MustInvoke[port.Service](i)
+		logger := di.MustInvoke[basedomain.Logger](i)
+		return handler.NewHTTP(svc, logger), nil
+	})
+}
@@ -0,0 +1,129 @@
+package port
+
+import (
+	"context"
+	"regexp"
+	"strings"
+
+	"backend/adapter/validation"
+	"github.com/google/uuid"
+	"github.com/guregu/null/v6"
+)
+
+// Statement formats. FormatCSV is a delimited text export read with a saved [Profile].
+// FormatOFX reads OFX 1.x (SGML) and 2.x (XML) files, including the QFX files of
+// Quicken, which are OFX with extra tags. FormatCAMT053 is the ISO 20022
+// BankToCustomerStatement and FormatMT940 the SWIFT customer statement.
+const (
+	FormatCSV     = "csv"
+	FormatOFX     = "ofx"
+	FormatCAMT053 = "camt.053"
+	FormatMT940   = "mt940"
+)
+
+// Decimal separators of amounts in a CSV export; the other one is taken as the
+// thousands separator.
+const (
+	DecimalPoint = "."
+	DecimalComma = ","
+)
+
+var dateFormatPattern = regexp.MustCompile(`^(YYYY|YY|MM|M|DD|D|[-/. ])+$`)
+
+// Mapping tells where a bank puts each field of a CSV export. Columns are numbered
+// from 1. A row carries either a signed Amount or separate Debit and Credit columns,
+// money out and money in. DateFormat is written with YYYY, YY, MM, M, DD and D, e.g.
+// DD/MM/YYYY. SkipRows is the number of header lines before the first entry.
+type Mapping struct {
+	Delimiter         string   `json:"delimiter"`
+	SkipRows          int      `json:"skipRows"`
+	DateColumn        int      `json:"dateColumn"`
+	DateFormat        string   `json:"dateFormat"`
+	AmountColumn      null.Int `json:"amountColumn"`
+	DebitColumn       null.Int `json:"debitColumn"`
+	CreditColumn      null.Int `json:"creditColumn"`
+	DescriptionColumn null.Int `json:"descriptionColumn"`
+	ReferenceColumn   null.Int `json:"referenceColumn"`
+	DecimalSeparator  string   `json:"decimalSeparator"`
+}
+
+// DateLayout translates DateFormat into a time layout.
+func (m Mapping) DateLayout() string {
+	return strings.NewReplacer("YYYY", "2006", "YY", "06", "MM", "01", "M", "1", "DD", "02", "D", "2").Replace(m.DateFormat)
+}
+
+func (m Mapping) Validate(ctx context.Context) error {
+	split := m.DebitColumn.Valid || m.CreditColumn.Valid
+	column := validation.Min(int64(1))
+
+	return validation.ValidateStruct(ctx, &m,
+		validation.Field(&m.Delimiter, validation.Required, validation.In(",", ";", "\t", "|")),
+		validation.Field(&m.SkipRows, validation.Min(0)),
+		validation.Field(&m.DateColumn, validation.Required, validation.Min(1)),
+		validation.Field(&m.DateFormat, validation.Required, validation.Length(1, 20), validation.Match(dateFormatPattern)),
+		validation.Field(&m.AmountColumn, validation.When(!split, validation.Required, column), validation.When(split, validation.Nil)),
+		validation.Field(&m.DebitColumn, validation.When(split, validation.Required, column)),
+		validation.Field(&m.CreditColumn, validation.When(split, validation.Required, column)),
+		validation.Field(&m.DescriptionColumn, validation.When(m.DescriptionColumn.Valid, column)),
+		validation.Field(&m.ReferenceColumn, validation.When(m.ReferenceColumn.Valid, column)),
+		validation.Field(&m.DecimalSeparator, validation.Required, validation.In(DecimalPoint, DecimalComma)),
+	)
+}
+
+type CreateProfile struct {
+	ID             uuid.UUID `json:"id"`
+	OrganizationID string    `json:"organizationId"`
+	Name           string    `json:"name"`
+	Mapping        Mapping   `json:"mapping"`
+}
+
+func (c CreateProfile) Validate(ctx context.Context) error {
+	err := validation.ValidateStruct(ctx, &c,
+		validation.Field(&c.ID, validation.Required, validation.IsUUID),
+		validation.Field(&c.OrganizationID, validation.Required),
+		validation.Field(&c.Name, validation.Required, validation.Length(1, 100)),
+	)
+	if err != nil {
+		return err
+	}
+
+	return c.Mapping.Validate(ctx)
+}
+
+// UpdateProfile renames a profile and replaces its whole mapping.
+type UpdateProfile struct {
+	Name    string  `json:"name"`
+	Mapping Mapping `json:"mapping"`
+}
+
+func (u UpdateProfile) Validate(ctx context.Context) error {
+	err := validation.ValidateStruct(ctx, &u,
+		validation.Field(&u.Name, validation.Required, validation.Length(1, 100)),
+	)
+	if err != nil {
+		return err
+	}
+
+	return u.Mapping.Validate(ctx)
+}
+
+// ImportStatement reads a statement file into transactions of an account. With
+// Preview the rows are only read and returned. Rows that look like transactions
+// entered by hand are skipped as duplicates unless AllowDuplicates is set.
+type ImportStatement struct {
+	AccountID       uuid.UUID
+	Format          string
+	ProfileID       *uuid.UUID
+	Content         []byte
+	Preview         bool
+	AllowDuplicates bool
+}
+
+func (i ImportStatement) Validate(ctx context.Context) error {
+	return validation.ValidateStruct(ctx, &i,
+		validation.Field(&i.AccountID, validation.Required, validation.IsUUID),
+		validation.Field(&i.Format, validation.Required, validation.In(FormatCSV, FormatOFX, FormatCAMT053, FormatMT940)),
+		validation.Field(&i.ProfileID, validation.When(i.Format == FormatCSV, validation.Required)),
+		validation.Field(&i.Content, validation.Required),
+	)
+}
@@ -0,0 +1,22 @@
+package port
+
+import (
+	"context"
+
+	basedomain "backend/port"
+)
+
+type Repository interface {
+	basedomain.RepositoryCommand[CreateProfile, UpdateProfile]
+	basedomain.RepositoryQuery[Profile]
+	basedomain.RepositoryTx[Repository]
+}
+
+type Service interface {
+	basedomain.UseCaseCommand[CreateProfile, UpdateProfile]
+	basedomain.UseCaseQuery[Profile]
+	basedomain.UseCaseTx[Service]
+	// Import reads a statement file and, unless previewed, records its rows as
+	// cleared transactions of the account.
+	Import(ctx context.Context, input ImportStatement) (ImportResult, error)
+}
@@ -0,0 +1,77 @@
+package port
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/guregu/null/v6"
+)
+
+// Profile is a saved CSV mapping of an organization, named after the bank whose
+// export layout it describes.
+type Profile struct {
+	ID             uuid.UUID `json:"id"`
+	OrganizationID string    `json:"organizationId"`
+	Name           string    `json:"name"`
+	Mapping        Mapping   `json:"mapping"`
+	CreatedAt      time.Time `json:"createdAt"`
+	UpdatedAt      time.Time `json:"updatedAt"`
+}
+
+// ImportRow is a statement entry as read from the file. Amount is in minor units of
+// the account currency. Error explains why the entry could not be read; such a file is
+// only previewed, never committed. A Duplicate row carries a reference the account
+// already has, or one an earlier row of the file has, or, unless duplicates are
+// allowed, looks like a recorded transaction or an earlier row; it is skipped on
+// commit and DuplicateOf names the existing transaction, if any. TransactionID is set
+// once the row is committed.
+type ImportRow struct {
+	Line          int         `json:"line"`
+	Date          time.Time   `json:"date"`
+	Amount        int64       `json:"amount"`
+	Description   null.String `json:"description"`
+	Reference     null.String `json:"reference"`
+	Duplicate     bool        `json:"duplicate"`
+	DuplicateOf   *uuid.UUID  `json:"duplicateOf,omitempty"`
+	TransactionID *uuid.UUID  `json:"transactionId"`
+	Error         string      `json:"error,omitempty"`
+}
+
+// BalanceCheck compares the closing balance printed on a statement with the balance
+// the account has once the statement is imported. Both are in minor units of the
+// account currency; Difference is Statement minus Account.
+type BalanceCheck struct {
+	AsOf       time.Time `json:"asOf"`
+	Statement  int64     `json:"statement"`
+	Account    int64     `json:"account"`
+	Difference int64     `json:"difference"`
+}
+
+// Matches reports whether the account agrees with the statement.
+func (b BalanceCheck) Matches() bool {
+	return b.Difference == 0
+}
+
+// ImportResult reports the rows read from a statement and, unless previewed, the
+// transactions created from them. Skipped counts the duplicate rows left out. Balance
+// is only set for formats that carry a closing balance.
+type ImportResult struct {
+	AccountID uuid.UUID     `json:"accountId"`
+	Format    string        `json:"format"`
+	Preview   bool          `json:"preview"`
+	Rows      []ImportRow   `json:"rows"`
+	Imported  int           `json:"imported"`
+	Skipped   int           `json:"skipped"`
+	Balance   *BalanceCheck `json:"balance,omitempty"`
+}
+
+// Invalid counts the rows that could not be read.
+func (r ImportResult) Invalid() int {
+	n := 0
+	for _, row := range r.Rows {
+		if row.Error != "" {
+			n++
+		}
+	}
+	return n
+}
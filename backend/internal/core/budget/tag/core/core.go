@@ -0,0 +1,95 @@
+package core
+
+import (
+	"context"
+
+	"backend/core/budget/tag/port"
+	"backend/infra/dafi"
+	basedomain "backend/port"
+	apperrors "backend/port/errors"
+
+	"github.com/samber/oops"
+)
+
+type service struct {
+	repo   port.Repository
+	logger basedomain.Logger
+}
+
+func New(repo port.Repository, logger basedomain.Logger) port.Service {
+	return service{
+		repo:   repo,
+		logger: logger.With("component", "tag.service"),
+	}
+}
+
+func (s service) WithTx(tx basedomain.Transaction) port.Service {
+	return service{
+		repo:   s.repo.WithTx(tx),
+		logger: s.logger,
+	}
+}
+
+func (s service) FindOne(ctx context.Context, criteria dafi.Criteria) (port.Tag, error) {
+	tag, err := s.repo.FindOne(ctx, criteria)
+	if err != nil {
+		return port.Tag{}, oops.WithContext(ctx).In(apperrors.LayerService).Wrap(err)
+	}
+
+	return tag, nil
+}
+
+func (s service) FindAll(ctx context.Context, criteria dafi.Criteria) (basedomain.List[port.Tag], error) {
+	tags, err := s.repo.FindAll(ctx, criteria)
+	if err != nil {
+		return nil, oops.WithContext(ctx).In(apperrors.LayerService).Wrap(err)
+	}
+
+	return tags, nil
+}
+
+func (s service) Create(ctx context.Context, input port.CreateTag) error {
+	return s.CreateBulk(ctx, basedomain.List[port.CreateTag]{input})
+}
+
+func (s service) CreateBulk(ctx context.Context, inputs basedomain.List[port.CreateTag]) error {
+	for _, input := range inputs {
+		if err := input.Validate(ctx); err != nil {
+			return oops.WithContext(ctx).In(apperrors.LayerService).Code(apperrors.CodeValidation).Wrap(err)
+		}
+	}
+
+	if err := s.repo.CreateBulk(ctx, inputs); err != nil {
+		return oops.WithContext(ctx).In(apperrors.LayerService).Wrap(err)
+	}
+
+	s.logger.WithContext(ctx).Info("tags created", "count", len(inputs))
+
+	return nil
+}
+
+func (s service) Update(ctx context.Context, input port.UpdateTag, filters ...dafi.Filter) error {
+	if err := input.Validate(ctx); err != nil {
+		return oops.WithContext(ctx).In(apperrors.LayerService).Code(apperrors.CodeValidation).Wrap(err)
+	}
+
+	if err := s.repo.Update(ctx, input, filters...); err != nil {
+		return oops.WithContext(ctx).In(apperrors.LayerService).Wrap(err)
+	}
+
+	s.logger.WithContext(ctx).Info("tag updated")
+
+	return nil
+}
+
+// Delete removes tags, and with them their place on the transactions that carried
+// them.
+func (s service) Delete(ctx context.Context, filters ...dafi.Filter) error {
+	if err := s.repo.Delete(ctx, filters...); err != nil {
+		return oops.WithContext(ctx).In(apperrors.LayerService).Wrap(err)
+	}
+
+	s.logger.WithContext(ctx).Info("tag deleted")
+
+	return nil
+}
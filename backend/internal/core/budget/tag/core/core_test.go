@@ -0,0 +1,98 @@
+package core
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"backend/core/budget/tag/port"
+	"backend/infra/dafi"
+	basedomain "backend/port"
+	apperrors "backend/port/errors"
+
+	"github.com/google/uuid"
+	"github.com/guregu/null/v6"
+	"github.com/samber/oops"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type noopLogger struct{}
+
+func (noopLogger) Debug(string, ...any)                          {}
+func (noopLogger) Info(string, ...any)                           {}
+func (noopLogger) Warn(string, ...any)                           {}
+func (noopLogger) Error(string, ...any)                          {}
+func (noopLogger) With(...any) basedomain.Logger                 { return noopLogger{} }
+func (noopLogger) WithContext(context.Context) basedomain.Logger { return noopLogger{} }
+
+// stubTagRepo records what the service writes.
+type stubTagRepo struct {
+	created []port.CreateTag
+	updated []port.UpdateTag
+}
+
+func (s *stubTagRepo) FindOne(context.Context, dafi.Criteria) (port.Tag, error) {
+	return port.Tag{}, nil
+}
+
+func (s *stubTagRepo) FindAll(context.Context, dafi.Criteria) (basedomain.List[port.Tag], error) {
+	return nil, nil
+}
+
+func (s *stubTagRepo) Create(_ context.Context, input port.CreateTag) error {
+	s.created = append(s.created, input)
+	return nil
+}
+
+func (s *stubTagRepo) CreateBulk(_ context.Context, inputs basedomain.List[port.CreateTag]) error {
+	s.created = append(s.created, inputs...)
+	return nil
+}
+
+func (s *stubTagRepo) Update(_ context.Context, input port.UpdateTag, _ ...dafi.Filter) error {
+	s.updated = append(s.updated, input)
+	return nil
+}
+
+func (s *stubTagRepo) Delete(context.Context, ...dafi.Filter) error { return nil }
+
+func (s *stubTagRepo) WithTx(basedomain.Transaction) port.Repository { return s }
+
+func requireCode(t *testing.T, err error, code string) {
+	t.Helper()
+	require.Error(t, err)
+	oopsErr, ok := oops.AsOops(err)
+	require.True(t, ok)
+	assert.Equal(t, code, oopsErr.Code())
+}
+
+func TestService_Create(t *testing.T) {
+	repo := &stubTagRepo{}
+	svc := New(repo, noopLogger{})
+
+	err := svc.Create(context.Background(), port.CreateTag{ID: uuid.New(), OrganizationID: "org_1", Name: "vacation"})
+	require.NoError(t, err)
+	require.Len(t, repo.created, 1)
+	assert.Equal(t, "vacation", repo.created[0].Name)
+}
+
+func TestService_CreateRejectsInvalidName(t *testing.T) {
+	for _, name := range []string{"", strings.Repeat("x", 51)} {
+		repo := &stubTagRepo{}
+		svc := New(repo, noopLogger{})
+
+		err := svc.Create(context.Background(), port.CreateTag{ID: uuid.New(), OrganizationID: "org_1", Name: name})
+		requireCode(t, err, apperrors.CodeValidation)
+		assert.Empty(t, repo.created)
+	}
+}
+
+func TestService_UpdateRejectsEmptyName(t *testing.T) {
+	repo := &stubTagRepo{}
+	svc := New(repo, noopLogger{})
+
+	err := svc.Update(context.Background(), port.UpdateTag{Name: null.StringFrom("")}, dafi.FilterBy("id", dafi.Equal, uuid.New())...)
+	requireCode(t, err, apperrors.CodeValidation)
+	assert.Empty(t, repo.updated)
+}
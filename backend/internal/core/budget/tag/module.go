@@ -0,0 +1,33 @@
+package tag
+
+import (
+	"backend/adapter/database"
+	"backend/adapter/di"
+	"backend/core/budget/tag/adapter/handler"
+	"backend/core/budget/tag/adapter/postgres"
+	"backend/core/budget/tag/core"
+	"backend/core/budget/tag/port"
+	basedomain "backend/port"
+
+	"github.com/samber/do/v2"
+)
+
+func Module(i do.Injector) {
+	di.Provide(i, func(i do.Injector) (port.Repository, error) {
+		db := di.MustInvoke[database.PoolInterface](i)
+		logger := di.MustInvoke[basedomain.Logger](i)
+		return postgres.NewPostgres(db, logger), nil
+	})
+
+	di.Provide(i, func(i do.Injector) (port.Service, error) {
+		repo := di.MustInvoke[port.Repository](i)
+		logger := di.MustInvoke[basedomain.Logger](i)
+		return core.New(repo, logger), nil
+	})
+
+	di.Provide(i, func(i do.Injector) (handler.HTTP, error) {
+		svc := di.MustInvoke[port.Service](i)
+		logger := di.MustInvoke[basedomain.Logger](i)
+		return handler.NewHTTP(svc, logger), nil
+	})
+}
@@ -0,0 +1,33 @@
+package port
+
+import (
+	"context"
+
+	"backend/adapter/validation"
+	"github.com/google/uuid"
+	"github.com/guregu/null/v6"
+)
+
+type CreateTag struct {
+	ID             uuid.UUID `json:"id"`
+	OrganizationID string    `json:"organizationId"`
+	Name           string    `json:"name"`
+}
+
+func (c CreateTag) Validate(ctx context.Context) error {
+	return validation.ValidateStruct(ctx, &c,
+		validation.Field(&c.ID, validation.Required, validation.IsUUID),
+		validation.Field(&c.OrganizationID, validation.Required),
+		validation.Field(&c.Name, validation.Required, validation.Length(1, 50)),
+	)
+}
+
+type UpdateTag struct {
+	Name null.String `json:"name"`
+}
+
+func (u UpdateTag) Validate(ctx context.Context) error {
+	return validation.ValidateStruct(ctx, &u,
+		validation.Field(&u.Name, validation.NilOrNotEmpty, validation.Length(1, 50)),
+	)
+}
@@ -0,0 +1,17 @@
+package port
+
+import (
+	basedomain "backend/port"
+)
+
+type Repository interface {
+	basedomain.RepositoryCommand[CreateTag, UpdateTag]
+	basedomain.RepositoryQuery[Tag]
+	basedomain.RepositoryTx[Repository]
+}
+
+type Service interface {
+	basedomain.UseCaseCommand[CreateTag, UpdateTag]
+	basedomain.UseCaseQuery[Tag]
+	basedomain.UseCaseTx[Service]
+}
@@ -0,0 +1,17 @@
+package port
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Tag is a free label put on transactions. Unlike categories, a transaction can carry
+// any number of tags and they are not tied to a budget.
+type Tag struct {
+	ID             uuid.UUID `json:"id"`
+	OrganizationID string    `json:"organizationId"`
+	Name           string    `json:"name"`
+	CreatedAt      time.Time `json:"createdAt"`
+	UpdatedAt      time.Time `json:"updatedAt"`
+}
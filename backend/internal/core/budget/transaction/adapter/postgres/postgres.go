@@ -33,15 +33,50 @@ var columns = []string{
 	"category_id",
 	"subcategory_id",
 	"budget_id",
+	"payee_id",
 	"type",
 	"amount",
+	"original_currency_code",
+	"original_amount",
+	"exchange_rate",
 	"description",
 	"external_reference_number",
 	"date",
+	"transfer_id",
+	"counterpart_id",
+	"status",
+	"reconciliation_id",
 	"created_at",
 	"updated_at",
 }
 
+const splitTableName = "budget.transaction_splits"
+
+var splitColumns = []string{
+	"id",
+	"transaction_id",
+	"category_id",
+	"subcategory_id",
+	"amount",
+	"memo",
+}
+
+var splitSQLColumnByDomainField = map[string]string{
+	"transactionId": "transaction_id",
+	"position":      "position",
+}
+
+const tagTableName = "budget.transaction_tags"
+
+var tagSQLColumnByDomainField = map[string]string{
+	"transactionId": "transaction_id",
+	"tagId":         "tag_id",
+}
+
+// tagsColumn lists the tags of the transaction in a row, as text so they compare
+// with the values of a filter.
+const tagsColumn = "ARRAY(SELECT tt.tag_id::text FROM budget.transaction_tags tt WHERE tt.transaction_id = transactions.id)"
+
 var sqlColumnByDomainField = map[string]string{
 	"id":                      "id",
 	"organizationId":          "organization_id",
@@ -49,11 +84,20 @@ var sqlColumnByDomainField = map[string]string{
 	"categoryId":              "category_id",
 	"subcategoryId":           "subcategory_id",
 	"budgetId":                "budget_id",
+	"payeeId":                 "payee_id",
 	"type":                    "type",
 	"amount":                  "amount",
+	"originalCurrencyCode":    "original_currency_code",
+	"originalAmount":          "original_amount",
+	"exchangeRate":            "exchange_rate",
 	"description":             "description",
 	"externalReferenceNumber": "external_reference_number",
 	"date":                    "date",
+	"transferId":              "transfer_id",
+	"counterpartId":           "counterpart_id",
+	"status":                  "status",
+	"reconciliationId":        "reconciliation_id",
+	"tags":                    tagsColumn,
 	"createdAt":               "created_at",
 	"updatedAt":               "updated_at",
 }
@@ -78,6 +122,10 @@ func (r postgres) WithTx(tx basedomain.Transaction) port.Repository {
 }
 
 func (r postgres) FindOne(ctx context.Context, criteria dafi.Criteria) (port.Transaction, error) {
+	if err := checkTagFilters(criteria.Filters); err != nil {
+		return port.Transaction{}, oops.WithContext(ctx).In(apperrors.LayerRepository).Code(apperrors.CodeBadRequest).Wrap(err)
+	}
+
 	query := sqlcraft.Select(columns...).
 		From(tableName).
 		Where(criteria.Filters...).
@@ -101,11 +149,19 @@ func (r postgres) FindOne(ctx context.Context, criteria dafi.Criteria) (port.Tra
 		&txn.CategoryID,
 		&txn.SubcategoryID,
 		&txn.BudgetID,
+		&txn.PayeeID,
 		&txn.Type,
 		&txn.Amount,
+		&txn.OriginalCurrencyCode,
+		&txn.OriginalAmount,
+		&txn.ExchangeRate,
 		&txn.Description,
 		&txn.ExternalReferenceNumber,
 		&txn.Date,
+		&txn.TransferID,
+		&txn.CounterpartID,
+		&txn.Status,
+		&txn.ReconciliationID,
 		&txn.CreatedAt,
 		&txn.UpdatedAt,
 	)
@@ -116,10 +172,26 @@ func (r postgres) FindOne(ctx context.Context, criteria dafi.Criteria) (port.Tra
 		return port.Transaction{}, oops.WithContext(ctx).In(apperrors.LayerRepository).Wrap(err)
 	}
 
+	splits, err := r.findSplits(ctx, []uuid.UUID{txn.ID})
+	if err != nil {
+		return port.Transaction{}, oops.WithContext(ctx).In(apperrors.LayerRepository).Wrap(err)
+	}
+	txn.Splits = splits[txn.ID]
+
+	tags, err := r.findTags(ctx, []uuid.UUID{txn.ID})
+	if err != nil {
+		return port.Transaction{}, oops.WithContext(ctx).In(apperrors.LayerRepository).Wrap(err)
+	}
+	txn.TagIDs = tags[txn.ID]
+
 	return txn, nil
 }
 
 func (r postgres) FindAll(ctx context.Context, criteria dafi.Criteria) (basedomain.List[port.Transaction], error) {
+	if err := checkTagFilters(criteria.Filters); err != nil {
+		return nil, oops.WithContext(ctx).In(apperrors.LayerRepository).Code(apperrors.CodeBadRequest).Wrap(err)
+	}
+
 	query := sqlcraft.Select(columns...).
 		From(tableName).
 		Where(criteria.Filters...).
@@ -151,11 +223,19 @@ func (r postgres) FindAll(ctx context.Context, criteria dafi.Criteria) (basedoma
 			&txn.CategoryID,
 			&txn.SubcategoryID,
 			&txn.BudgetID,
+			&txn.PayeeID,
 			&txn.Type,
 			&txn.Amount,
+			&txn.OriginalCurrencyCode,
+			&txn.OriginalAmount,
+			&txn.ExchangeRate,
 			&txn.Description,
 			&txn.ExternalReferenceNumber,
 			&txn.Date,
+			&txn.TransferID,
+			&txn.CounterpartID,
+			&txn.Status,
+			&txn.ReconciliationID,
 			&txn.CreatedAt,
 			&txn.UpdatedAt,
 		)
@@ -165,9 +245,124 @@ func (r postgres) FindAll(ctx context.Context, criteria dafi.Criteria) (basedoma
 		txns = append(txns, txn)
 	}
 
+	if err := rows.Err(); err != nil {
+		return nil, oops.WithContext(ctx).In(apperrors.LayerRepository).Wrap(err)
+	}
+
+	if txns.IsEmpty() {
+		return txns, nil
+	}
+
+	ids := make([]uuid.UUID, 0, len(txns))
+	for _, txn := range txns {
+		ids = append(ids, txn.ID)
+	}
+
+	splits, err := r.findSplits(ctx, ids)
+	if err != nil {
+		return nil, oops.WithContext(ctx).In(apperrors.LayerRepository).Wrap(err)
+	}
+
+	tags, err := r.findTags(ctx, ids)
+	if err != nil {
+		return nil, oops.WithContext(ctx).In(apperrors.LayerRepository).Wrap(err)
+	}
+
+	for i := range txns {
+		txns[i].Splits = splits[txns[i].ID]
+		txns[i].TagIDs = tags[txns[i].ID]
+	}
+
 	return txns, nil
 }
 
+// findSplits loads the split lines of the given transactions, in their original order.
+func (r postgres) findSplits(ctx context.Context, transactionIDs []uuid.UUID) (map[uuid.UUID][]port.Split, error) {
+	query := sqlcraft.Select(splitColumns...).
+		From(splitTableName).
+		Where(dafi.FilterBy("transactionId", dafi.In, transactionIDs)...).
+		OrderBy(dafi.Sort{Field: "transactionId"}, dafi.Sort{Field: "position"}).
+		SQLColumnByDomainField(splitSQLColumnByDomainField)
+
+	result, err := query.ToSQL()
+	if err != nil {
+		return nil, err
+	}
+
+	r.logger.WithContext(ctx).Debug("executing query", "sql", result.SQL)
+
+	rows, err := r.db.Query(ctx, result.SQL, result.Args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	splits := make(map[uuid.UUID][]port.Split)
+	for rows.Next() {
+		var split port.Split
+		err = rows.Scan(
+			&split.ID,
+			&split.TransactionID,
+			&split.CategoryID,
+			&split.SubcategoryID,
+			&split.Amount,
+			&split.Memo,
+		)
+		if err != nil {
+			return nil, err
+		}
+		splits[split.TransactionID] = append(splits[split.TransactionID], split)
+	}
+
+	return splits, rows.Err()
+}
+
+// checkTagFilters makes sure transactions are only filtered by tags with the list
+// operators: any for those carrying one of the tags, all for those carrying every one.
+func checkTagFilters(filters dafi.Filters) error {
+	for _, filter := range filters {
+		if filter.Field == "tags" && filter.Operator != dafi.Any && filter.Operator != dafi.All {
+			return oops.Public("Filter tags with the any or all operator.").
+				Errorf("operator %q is not supported on tags", filter.Operator)
+		}
+	}
+
+	return nil
+}
+
+// findTags loads the tags of the given transactions.
+func (r postgres) findTags(ctx context.Context, transactionIDs []uuid.UUID) (map[uuid.UUID][]uuid.UUID, error) {
+	query := sqlcraft.Select("transaction_id", "tag_id").
+		From(tagTableName).
+		Where(dafi.FilterBy("transactionId", dafi.In, transactionIDs)...).
+		OrderBy(dafi.Sort{Field: "transactionId"}, dafi.Sort{Field: "tagId"}).
+		SQLColumnByDomainField(tagSQLColumnByDomainField)
+
+	result, err := query.ToSQL()
+	if err != nil {
+		return nil, err
+	}
+
+	r.logger.WithContext(ctx).Debug("executing query", "sql", result.SQL)
+
+	rows, err := r.db.Query(ctx, result.SQL, result.Args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tags := make(map[uuid.UUID][]uuid.UUID)
+	for rows.Next() {
+		var transactionID, tagID uuid.UUID
+		if err := rows.Scan(&transactionID, &tagID); err != nil {
+			return nil, err
+		}
+		tags[transactionID] = append(tags[transactionID], tagID)
+	}
+
+	return tags, rows.Err()
+}
+
 func (r postgres) Create(ctx context.Context, input port.CreateTransaction) error {
 	now := time.Now()
 
@@ -180,11 +375,19 @@ func (r postgres) Create(ctx context.Context, input port.CreateTransaction) erro
 			input.CategoryID,
 			input.SubcategoryID,
 			input.BudgetID,
+			input.PayeeID,
 			input.Type,
 			input.Amount,
+			input.OriginalCurrencyCode,
+			input.OriginalAmount,
+			input.ExchangeRate,
 			input.Description,
 			input.ExternalReferenceNumber,
 			input.Date,
+			input.TransferID,
+			input.CounterpartID,
+			input.Status,
+			nil,
 			now,
 			now,
 		)
@@ -220,11 +423,19 @@ func (r postgres) CreateBulk(ctx context.Context, inputs basedomain.List[port.Cr
 			input.CategoryID,
 			input.SubcategoryID,
 			input.BudgetID,
+			input.PayeeID,
 			input.Type,
 			input.Amount,
+			input.OriginalCurrencyCode,
+			input.OriginalAmount,
+			input.ExchangeRate,
 			input.Description,
 			input.ExternalReferenceNumber,
 			input.Date,
+			input.TransferID,
+			input.CounterpartID,
+			input.Status,
+			nil,
 			now,
 			now,
 		)
@@ -247,16 +458,20 @@ func (r postgres) CreateBulk(ctx context.Context, inputs basedomain.List[port.Cr
 
 func (r postgres) Update(ctx context.Context, input port.UpdateTransaction, filters ...dafi.Filter) error {
 	query := sqlcraft.Update(tableName).
-		WithColumns("category_id", "subcategory_id", "budget_id", "type", "amount", "description", "external_reference_number", "date", "updated_at").
+		WithColumns("category_id", "subcategory_id", "budget_id", "payee_id", "type", "amount", "original_amount", "exchange_rate", "description", "external_reference_number", "date", "status", "updated_at").
 		WithValues(
 			input.CategoryID,
 			input.SubcategoryID,
 			input.BudgetID,
+			input.PayeeID,
 			input.Type,
 			input.Amount,
+			input.OriginalAmount,
+			input.ExchangeRate,
 			input.Description,
 			input.ExternalReferenceNumber,
 			input.Date,
+			input.Status,
 			time.Now(),
 		).
 		Where(filters...).
@@ -306,6 +521,186 @@ func (r postgres) ExistsForOrganization(ctx context.Context, organizationID stri
 	return exists, nil
 }
 
+// clearCategoryQuery drops the category of a transaction whose amount is now spread
+// over split lines.
+const clearCategoryQuery = `
+UPDATE budget.transactions
+SET category_id = NULL, subcategory_id = NULL, updated_at = $2
+WHERE id = $1`
+
+func (r postgres) ReplaceSplits(ctx context.Context, transactionID uuid.UUID, organizationID string, splits []port.SplitLine) error {
+	deleteQuery := sqlcraft.DeleteFrom(splitTableName).
+		Where(dafi.FilterBy("transactionId", dafi.Equal, transactionID)...).
+		SQLColumnByDomainField(splitSQLColumnByDomainField)
+
+	result, err := deleteQuery.ToSQL()
+	if err != nil {
+		return oops.WithContext(ctx).In(apperrors.LayerRepository).Wrap(err)
+	}
+
+	r.logger.WithContext(ctx).Debug("executing query", "sql", result.SQL)
+
+	if _, err := r.db.Exec(ctx, result.SQL, result.Args...); err != nil {
+		return oops.WithContext(ctx).In(apperrors.LayerRepository).Wrap(err)
+	}
+
+	if len(splits) == 0 {
+		return nil
+	}
+
+	now := time.Now()
+	insertQuery := sqlcraft.InsertInto(splitTableName).
+		WithColumns("organization_id", "transaction_id", "position", "category_id", "subcategory_id", "amount", "memo", "created_at", "updated_at")
+
+	for i, split := range splits {
+		insertQuery = insertQuery.WithValues(
+			organizationID,
+			transactionID,
+			i+1,
+			split.CategoryID,
+			split.SubcategoryID,
+			split.Amount,
+			split.Memo,
+			now,
+			now,
+		)
+	}
+
+	result, err = insertQuery.ToSQL()
+	if err != nil {
+		return oops.WithContext(ctx).In(apperrors.LayerRepository).Wrap(err)
+	}
+
+	r.logger.WithContext(ctx).Debug("bulk insert", "sql", result.SQL, "count", len(splits))
+
+	if _, err := r.db.Exec(ctx, result.SQL, result.Args...); err != nil {
+		return oops.WithContext(ctx).In(apperrors.LayerRepository).Wrap(err)
+	}
+
+	r.logger.WithContext(ctx).Debug("executing query", "sql", clearCategoryQuery)
+
+	if _, err := r.db.Exec(ctx, clearCategoryQuery, transactionID, now); err != nil {
+		return oops.WithContext(ctx).In(apperrors.LayerRepository).Wrap(err)
+	}
+
+	return nil
+}
+
+const pgErrForeignKeyViolation = "23503"
+
+func (r postgres) ReplaceTags(ctx context.Context, transactionID uuid.UUID, organizationID string, tagIDs []uuid.UUID) error {
+	deleteQuery := sqlcraft.DeleteFrom(tagTableName).
+		Where(dafi.FilterBy("transactionId", dafi.Equal, transactionID)...).
+		SQLColumnByDomainField(tagSQLColumnByDomainField)
+
+	result, err := deleteQuery.ToSQL()
+	if err != nil {
+		return oops.WithContext(ctx).In(apperrors.LayerRepository).Wrap(err)
+	}
+
+	r.logger.WithContext(ctx).Debug("executing query", "sql", result.SQL)
+
+	if _, err := r.db.Exec(ctx, result.SQL, result.Args...); err != nil {
+		return oops.WithContext(ctx).In(apperrors.LayerRepository).Wrap(err)
+	}
+
+	if len(tagIDs) == 0 {
+		return nil
+	}
+
+	now := time.Now()
+	insertQuery := sqlcraft.InsertInto(tagTableName).
+		WithColumns("transaction_id", "tag_id", "organization_id", "created_at")
+
+	seen := make(map[uuid.UUID]bool, len(tagIDs))
+	for _, tagID := range tagIDs {
+		if seen[tagID] {
+			continue
+		}
+		seen[tagID] = true
+		insertQuery = insertQuery.WithValues(transactionID, tagID, organizationID, now)
+	}
+
+	result, err = insertQuery.ToSQL()
+	if err != nil {
+		return oops.WithContext(ctx).In(apperrors.LayerRepository).Wrap(err)
+	}
+
+	r.logger.WithContext(ctx).Debug("bulk insert", "sql", result.SQL, "count", len(seen))
+
+	if _, err := r.db.Exec(ctx, result.SQL, result.Args...); err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == pgErrForeignKeyViolation {
+			return oops.WithContext(ctx).In(apperrors.LayerRepository).
+				Code(apperrors.CodeValidation).
+				Public("The tag does not belong to this organization.").
+				Wrap(err)
+		}
+		return oops.WithContext(ctx).In(apperrors.LayerRepository).Wrap(err)
+	}
+
+	return nil
+}
+
+// markReconciledQuery locks the cleared transactions of an account that a statement
+// covers.
+const markReconciledQuery = `
+UPDATE budget.transactions
+SET status = 'reconciled', reconciliation_id = $2, updated_at = $4
+WHERE account_id = $1
+  AND status = 'cleared'
+  AND date <= $3`
+
+func (r postgres) MarkReconciled(ctx context.Context, accountID, reconciliationID uuid.UUID, through time.Time) (int64, error) {
+	r.logger.WithContext(ctx).Debug("executing query", "sql", markReconciledQuery)
+
+	tag, err := r.db.Exec(ctx, markReconciledQuery, accountID, reconciliationID, through, time.Now())
+	if err != nil {
+		return 0, oops.WithContext(ctx).In(apperrors.LayerRepository).Wrap(err)
+	}
+
+	return tag.RowsAffected(), nil
+}
+
+const unlockQuery = `
+UPDATE budget.transactions
+SET status = 'cleared', reconciliation_id = NULL, updated_at = $2
+WHERE id = $1
+  AND status = 'reconciled'`
+
+func (r postgres) Unlock(ctx context.Context, id uuid.UUID) error {
+	r.logger.WithContext(ctx).Debug("executing query", "sql", unlockQuery)
+
+	tag, err := r.db.Exec(ctx, unlockQuery, id, time.Now())
+	if err != nil {
+		return oops.WithContext(ctx).In(apperrors.LayerRepository).Wrap(err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return oops.WithContext(ctx).In(apperrors.LayerRepository).
+			Code(apperrors.CodeNotFound).
+			Errorf("no reconciled transaction %s", id)
+	}
+
+	return nil
+}
+
+const categorizeQuery = `
+UPDATE budget.transactions
+SET category_id = $2, subcategory_id = $3, budget_id = $4, updated_at = $5
+WHERE id = $1`
+
+func (r postgres) Categorize(ctx context.Context, id uuid.UUID, categoryID uuid.UUID, subcategoryID, budgetID *uuid.UUID) error {
+	r.logger.WithContext(ctx).Debug("executing query", "sql", categorizeQuery)
+
+	_, err := r.db.Exec(ctx, categorizeQuery, id, categoryID, subcategoryID, budgetID, time.Now())
+	if err != nil {
+		return oops.WithContext(ctx).In(apperrors.LayerRepository).Wrap(err)
+	}
+
+	return nil
+}
+
 func (r postgres) Delete(ctx context.Context, filters ...dafi.Filter) error {
 	query := sqlcraft.DeleteFrom(tableName).
 		Where(filters...).
@@ -0,0 +1,79 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"backend/infra/dafi"
+	apperrors "backend/port/errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/samber/oops"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errStopQuery = errors.New("stop")
+
+// recordingDB keeps the last query it was given and fails it.
+type recordingDB struct {
+	sql  string
+	args []any
+}
+
+func (d *recordingDB) Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error) {
+	panic("unexpected Exec")
+}
+
+func (d *recordingDB) QueryRow(ctx context.Context, sql string, args ...any) pgx.Row {
+	panic("unexpected QueryRow")
+}
+
+func (d *recordingDB) Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error) {
+	d.sql, d.args = sql, args
+	return nil, errStopQuery
+}
+
+func TestPostgres_FindAllByTags(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		operator dafi.FilterOperator
+		want     string
+	}{
+		{name: "any of the tags", operator: dafi.Any, want: tagsColumn + " && ARRAY[$2, $3]"},
+		{name: "all of the tags", operator: dafi.All, want: tagsColumn + " @> ARRAY[$2, $3]"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			db := &recordingDB{}
+			r := postgres{db: db, logger: noopTestLogger{}}
+
+			filters := dafi.FilterBy("organizationId", dafi.Equal, "org_1").And("tags", tt.operator, []string{"a", "b"})
+			_, err := r.FindAll(context.Background(), dafi.Criteria{Filters: filters})
+			require.ErrorIs(t, err, errStopQuery)
+
+			assert.Contains(t, db.sql, " WHERE organization_id = $1 AND "+tt.want)
+			assert.Equal(t, []any{"org_1", "a", "b"}, db.args)
+		})
+	}
+}
+
+func TestPostgres_FindAllRejectsOtherTagOperators(t *testing.T) {
+	t.Parallel()
+
+	r := postgres{db: &recordingDB{}, logger: noopTestLogger{}}
+
+	_, err := r.FindAll(context.Background(), dafi.Where("tags", dafi.In, []string{"a"}))
+	require.Error(t, err)
+
+	oopsErr, ok := oops.AsOops(err)
+	require.True(t, ok)
+	assert.Equal(t, apperrors.CodeBadRequest, oopsErr.Code())
+}
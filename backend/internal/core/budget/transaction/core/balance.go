@@ -0,0 +1,37 @@
+package core
+
+import (
+	"bytes"
+	"context"
+	"slices"
+
+	"github.com/google/uuid"
+)
+
+// balanceDeltas accumulates, per account, the change a write makes to the account
+// balance, so it can be applied in the same database transaction as the write.
+type balanceDeltas map[uuid.UUID]int64
+
+func (d balanceDeltas) add(accountID uuid.UUID, amount int64) {
+	d[accountID] += amount
+}
+
+// applyBalances adjusts the balance of every account with a non-zero delta. Accounts
+// are updated in id order so concurrent writes lock them in the same order.
+func (s service) applyBalances(ctx context.Context, deltas balanceDeltas) error {
+	ids := make([]uuid.UUID, 0, len(deltas))
+	for id, delta := range deltas {
+		if delta != 0 {
+			ids = append(ids, id)
+		}
+	}
+	slices.SortFunc(ids, func(a, b uuid.UUID) int { return bytes.Compare(a[:], b[:]) })
+
+	for _, id := range ids {
+		if err := s.accountRepo.AdjustBalance(ctx, id, deltas[id]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
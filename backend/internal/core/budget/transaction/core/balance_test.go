@@ -0,0 +1,57 @@
+package core
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"backend/core/budget/transaction/port"
+	"backend/infra/dafi"
+	basedomain "backend/port"
+
+	"github.com/google/uuid"
+	"github.com/guregu/null/v6"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestService_KeepsAccountBalance(t *testing.T) {
+	f := newFixture("EUR", "EUR")
+	ctx := context.Background()
+	id := uuid.New()
+
+	require.NoError(t, f.svc.Create(ctx, port.CreateTransaction{
+		ID:             id,
+		OrganizationID: "org_1",
+		AccountID:      f.from,
+		Type:           "expense",
+		Amount:         -4200,
+		Date:           time.Date(2026, time.April, 22, 0, 0, 0, 0, time.UTC),
+	}))
+	assert.Equal(t, int64(-4200), f.accounts.balances[f.from])
+
+	filters := dafi.FilterBy("id", dafi.Equal, id)
+	require.NoError(t, f.svc.Update(ctx, port.UpdateTransaction{Amount: null.IntFrom(-5000)}, filters...))
+	assert.Equal(t, int64(-5000), f.accounts.balances[f.from])
+
+	require.NoError(t, f.svc.Update(ctx, port.UpdateTransaction{Description: null.StringFrom("Fuel")}, filters...))
+	assert.Equal(t, int64(-5000), f.accounts.balances[f.from])
+
+	require.NoError(t, f.svc.Delete(ctx, filters...))
+	assert.Zero(t, f.accounts.balances[f.from])
+}
+
+func TestService_CreateBulkAdjustsEachAccount(t *testing.T) {
+	f := newFixture("EUR", "EUR")
+	date := time.Date(2026, time.April, 22, 0, 0, 0, 0, time.UTC)
+
+	err := f.svc.CreateBulk(context.Background(), basedomain.List[port.CreateTransaction]{
+		{ID: uuid.New(), OrganizationID: "org_1", AccountID: f.from, Type: "expense", Amount: -1000, Date: date},
+		{ID: uuid.New(), OrganizationID: "org_1", AccountID: f.from, Type: "expense", Amount: -250, Date: date},
+		{ID: uuid.New(), OrganizationID: "org_1", AccountID: f.to, Type: "income", Amount: 300000, Date: date},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(-1250), f.accounts.balances[f.from])
+	assert.Equal(t, int64(300000), f.accounts.balances[f.to])
+}
@@ -0,0 +1,194 @@
+package core
+
+import (
+	"context"
+	"slices"
+
+	categorizationruleport "backend/core/budget/categorization_rule/port"
+	payeeport "backend/core/budget/payee/port"
+	"backend/core/budget/transaction/port"
+	"backend/infra/dafi"
+	basedomain "backend/port"
+	apperrors "backend/port/errors"
+
+	"github.com/google/uuid"
+	"github.com/samber/oops"
+)
+
+// lookups holds the categorization rules and payees of the organizations a call has
+// met, so a bulk call loads them once.
+type lookups struct {
+	rules  map[string][]categorizationruleport.Rule
+	payees map[string][]payeeport.Payee
+}
+
+func newLookups() lookups {
+	return lookups{
+		rules:  map[string][]categorizationruleport.Rule{},
+		payees: map[string][]payeeport.Payee{},
+	}
+}
+
+func (s service) rulesOf(ctx context.Context, organizationID string, l lookups) ([]categorizationruleport.Rule, error) {
+	if rules, ok := l.rules[organizationID]; ok {
+		return rules, nil
+	}
+
+	rules, err := s.categorizationRuleSvc.Active(ctx, organizationID)
+	if err != nil {
+		return nil, err
+	}
+	l.rules[organizationID] = rules
+
+	return rules, nil
+}
+
+// categorize files a new transaction the caller left without a category under the
+// first categorization rule that matches it, else under the default category of its
+// payee. A budget given by the caller is kept.
+func (s service) categorize(ctx context.Context, input *port.CreateTransaction, l lookups) error {
+	if input.CategoryID != nil || input.SubcategoryID != nil || len(input.Splits) > 0 || input.Type == port.TypeTransfer {
+		return nil
+	}
+
+	rules, err := s.rulesOf(ctx, input.OrganizationID, l)
+	if err != nil {
+		return err
+	}
+
+	rule, ok := categorizationruleport.Match(rules, categorizationruleport.Subject{
+		AccountID:               input.AccountID,
+		PayeeID:                 input.PayeeID,
+		Type:                    input.Type,
+		Amount:                  input.Amount,
+		Description:             input.Description,
+		ExternalReferenceNumber: input.ExternalReferenceNumber,
+		OriginalCurrencyCode:    input.OriginalCurrencyCode,
+	})
+	if !ok {
+		return s.applyPayeeDefault(ctx, input, l)
+	}
+
+	input.CategoryID, input.SubcategoryID = &rule.CategoryID, rule.SubcategoryID
+	if input.BudgetID == nil {
+		input.BudgetID = rule.BudgetID
+	}
+
+	return nil
+}
+
+// recategorizePageSize bounds how many transactions one database transaction of
+// Recategorize reads and rewrites.
+const recategorizePageSize = 200
+
+// Recategorize walks the selected transactions of one organization in pages ordered
+// by id, each page in its own database transaction, so a large selection neither
+// loads at once nor holds its locks until the end.
+func (s service) Recategorize(ctx context.Context, criteria dafi.Criteria) (port.Recategorization, error) {
+	organizationID, ok := organizationFilter(criteria.Filters)
+	if !ok {
+		return port.Recategorization{}, oops.WithContext(ctx).
+			In(apperrors.LayerService).
+			Code(apperrors.CodeBadRequest).
+			Public("Recategorizing transactions requires an organizationId filter.").
+			Errorf("recategorize requires an organizationId equality filter")
+	}
+
+	var result port.Recategorization
+	l := newLookups()
+	after := uuid.Nil
+	for {
+		page := dafi.Where("organizationId", dafi.Equal, organizationID).
+			And("id", dafi.Greater, after).
+			AndGroup(slices.Clone(criteria.Filters)...).
+			SortBy("id", dafi.Asc).
+			Limit(recategorizePageSize)
+
+		var txns basedomain.List[port.Transaction]
+		err := basedomain.Atomically(ctx, s.uow, s.tx, s.withTx, func(txSvc service) error {
+			var err error
+			txns, err = txSvc.repo.FindAll(ctx, page)
+			if err != nil {
+				return err
+			}
+
+			return txSvc.recategorizePage(ctx, txns, l, &result)
+		})
+		if err != nil {
+			return port.Recategorization{}, oops.WithContext(ctx).In(apperrors.LayerService).Wrap(err)
+		}
+
+		if len(txns) < recategorizePageSize {
+			break
+		}
+		after = txns[len(txns)-1].ID
+	}
+
+	s.logger.WithContext(ctx).Info("transactions recategorized",
+		"organization_id", organizationID, "matched", result.Matched, "updated", result.Updated)
+
+	return result, nil
+}
+
+func (s service) recategorizePage(ctx context.Context, txns basedomain.List[port.Transaction], l lookups, result *port.Recategorization) error {
+	for _, txn := range txns {
+		if txn.IsTransferLeg() || len(txn.Splits) > 0 {
+			continue
+		}
+
+		rules, err := s.rulesOf(ctx, txn.OrganizationID, l)
+		if err != nil {
+			return err
+		}
+
+		rule, ok := categorizationruleport.Match(rules, categorizationruleport.Subject{
+			AccountID:               txn.AccountID,
+			PayeeID:                 txn.PayeeID,
+			Type:                    txn.Type,
+			Amount:                  txn.Amount,
+			Description:             txn.Description,
+			ExternalReferenceNumber: txn.ExternalReferenceNumber,
+			OriginalCurrencyCode:    txn.OriginalCurrencyCode,
+		})
+		if !ok {
+			continue
+		}
+		result.Matched++
+
+		budgetID := rule.BudgetID
+		if budgetID == nil {
+			budgetID = txn.BudgetID
+		}
+
+		if equalID(txn.CategoryID, &rule.CategoryID) && equalID(txn.SubcategoryID, rule.SubcategoryID) && equalID(txn.BudgetID, budgetID) {
+			continue
+		}
+
+		if err := s.repo.Categorize(ctx, txn.ID, rule.CategoryID, rule.SubcategoryID, budgetID); err != nil {
+			return err
+		}
+		result.Updated++
+	}
+
+	return nil
+}
+
+// organizationFilter finds the organization an organizationId equality filter names.
+func organizationFilter(filters dafi.Filters) (string, bool) {
+	for _, filter := range filters {
+		if filter.Field != "organizationId" || filter.Operator != dafi.Equal {
+			continue
+		}
+		if organizationID, ok := filter.Value.(string); ok && organizationID != "" {
+			return organizationID, true
+		}
+	}
+	return "", false
+}
+
+func equalID(a, b *uuid.UUID) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
@@ -0,0 +1,157 @@
+package core
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	categorizationruleport "backend/core/budget/categorization_rule/port"
+	"backend/core/budget/transaction/port"
+	"backend/infra/dafi"
+	basedomain "backend/port"
+	apperrors "backend/port/errors"
+
+	"github.com/google/uuid"
+	"github.com/guregu/null/v6"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	transportID = uuid.MustParse("22222222-2222-2222-2222-222222222222")
+	taxiID      = uuid.MustParse("33333333-3333-3333-3333-333333333333")
+	rentID      = uuid.MustParse("44444444-4444-4444-4444-444444444444")
+	ridesBudget = uuid.MustParse("55555555-5555-5555-5555-555555555555")
+)
+
+// withRules gives the fixture a rule for rides and, after it, one for rent paid from
+// the from account.
+func (f fixture) withRules() fixture {
+	*f.rules = []categorizationruleport.Rule{
+		{
+			Name:          "Rides",
+			Conditions:    categorizationruleport.Conditions{{Field: categorizationruleport.FieldDescription, Operator: dafi.Contains, Value: "uber"}},
+			CategoryID:    transportID,
+			SubcategoryID: &taxiID,
+			BudgetID:      &ridesBudget,
+		},
+		{
+			Name: "Rent",
+			Conditions: categorizationruleport.Conditions{
+				{Field: categorizationruleport.FieldAccountID, Operator: dafi.Equal, Value: f.from.String()},
+				{Field: categorizationruleport.FieldAmount, Operator: dafi.LessOrEqual, Value: float64(-100000)},
+				{Field: categorizationruleport.FieldAmount, Operator: dafi.GreaterOrEqual, Value: float64(-150000)},
+			},
+			CategoryID: rentID,
+		},
+	}
+	return f
+}
+
+func TestService_CreateAppliesFirstMatchingRule(t *testing.T) {
+	f := newFixture("USD", "USD").withRules()
+	date := time.Date(2026, time.May, 4, 0, 0, 0, 0, time.UTC)
+
+	ride := f.record(t, port.CreateTransaction{Amount: -120000, Date: date, Description: null.StringFrom("UBER *TRIP")})
+	rent := f.record(t, port.CreateTransaction{Amount: -120000, Date: date, Description: null.StringFrom("Landlord")})
+	other := f.record(t, port.CreateTransaction{Amount: -900, Date: date, Description: null.StringFrom("Bakery")})
+
+	assert.Equal(t, &transportID, f.repo.txns[ride].CategoryID)
+	assert.Equal(t, &taxiID, f.repo.txns[ride].SubcategoryID)
+	assert.Equal(t, &ridesBudget, f.repo.txns[ride].BudgetID)
+	assert.Equal(t, &rentID, f.repo.txns[rent].CategoryID)
+	assert.Nil(t, f.repo.txns[rent].BudgetID)
+	assert.Nil(t, f.repo.txns[other].CategoryID)
+}
+
+func TestService_CreateKeepsCallerCategoryAndBudget(t *testing.T) {
+	f := newFixture("USD", "USD").withRules()
+	date := time.Date(2026, time.May, 4, 0, 0, 0, 0, time.UTC)
+	budgetID := uuid.New()
+
+	categorized := f.record(t, port.CreateTransaction{Amount: -1500, Date: date, Description: null.StringFrom("Uber"), CategoryID: &rentID})
+	budgeted := f.record(t, port.CreateTransaction{Amount: -1600, Date: date, Description: null.StringFrom("Uber"), BudgetID: &budgetID})
+
+	assert.Equal(t, &rentID, f.repo.txns[categorized].CategoryID)
+	assert.Nil(t, f.repo.txns[categorized].SubcategoryID)
+	assert.Equal(t, &transportID, f.repo.txns[budgeted].CategoryID)
+	assert.Equal(t, &budgetID, f.repo.txns[budgeted].BudgetID)
+}
+
+func TestService_CreateBulkAppliesRules(t *testing.T) {
+	f := newFixture("USD", "USD").withRules()
+	date := time.Date(2026, time.May, 4, 0, 0, 0, 0, time.UTC)
+	first, second := uuid.New(), uuid.New()
+
+	err := f.svc.CreateBulk(context.Background(), basedomain.List[port.CreateTransaction]{
+		{ID: first, OrganizationID: "org_1", AccountID: f.from, Type: "expense", Amount: -1500, Date: date, Description: null.StringFrom("Uber")},
+		{ID: second, OrganizationID: "org_1", AccountID: f.to, Type: "expense", Amount: -120000, Date: date},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, &transportID, f.repo.txns[first].CategoryID)
+	assert.Nil(t, f.repo.txns[second].CategoryID)
+}
+
+func TestService_RecategorizeAppliesRulesToSelectedTransactions(t *testing.T) {
+	f := newFixture("USD", "USD")
+	date := time.Date(2026, time.May, 4, 0, 0, 0, 0, time.UTC)
+
+	ride := f.record(t, port.CreateTransaction{Amount: -1500, Date: date, Description: null.StringFrom("Uber"), CategoryID: &rentID})
+	filed := f.record(t, port.CreateTransaction{Amount: -1700, Date: date, Description: null.StringFrom("uber eats"), CategoryID: &transportID, SubcategoryID: &taxiID, BudgetID: &ridesBudget})
+	unmatched := f.record(t, port.CreateTransaction{Amount: -900, Date: date, Description: null.StringFrom("Bakery"), CategoryID: &rentID})
+	split := f.record(t, port.CreateTransaction{Amount: -2000, Date: date, Description: null.StringFrom("Uber"), Splits: []port.SplitLine{
+		{CategoryID: &rentID, Amount: -1000},
+		{CategoryID: &transportID, Amount: -1000},
+	}})
+	require.NoError(t, f.svc.CreateTransfer(context.Background(), port.CreateTransfer{
+		ID:             uuid.New(),
+		OrganizationID: "org_1",
+		FromAccountID:  f.from,
+		ToAccountID:    f.to,
+		Amount:         500,
+		Description:    null.StringFrom("Uber refund to savings"),
+		Date:           date,
+	}))
+	f.withRules()
+
+	result, err := f.svc.Recategorize(context.Background(), dafi.Where("organizationId", dafi.Equal, "org_1").And("accountId", dafi.Equal, f.from))
+	require.NoError(t, err)
+	assert.Equal(t, port.Recategorization{Matched: 2, Updated: 1}, result)
+
+	assert.Equal(t, &transportID, f.repo.txns[ride].CategoryID)
+	assert.Equal(t, &taxiID, f.repo.txns[ride].SubcategoryID)
+	assert.Equal(t, &ridesBudget, f.repo.txns[ride].BudgetID)
+	assert.Equal(t, &transportID, f.repo.txns[filed].CategoryID)
+	assert.Equal(t, &rentID, f.repo.txns[unmatched].CategoryID)
+	assert.Nil(t, f.repo.txns[split].CategoryID)
+}
+
+func TestService_RecategorizeRequiresOrganization(t *testing.T) {
+	f := newFixture("USD", "USD").withRules()
+	date := time.Date(2026, time.May, 4, 0, 0, 0, 0, time.UTC)
+	ride := f.record(t, port.CreateTransaction{Amount: -1500, Date: date, Description: null.StringFrom("Uber"), CategoryID: &rentID})
+
+	_, err := f.svc.Recategorize(context.Background(), dafi.Where("accountId", dafi.Equal, f.from))
+	requireCode(t, err, apperrors.CodeBadRequest)
+
+	_, err = f.svc.Recategorize(context.Background(), dafi.New())
+	requireCode(t, err, apperrors.CodeBadRequest)
+
+	assert.Equal(t, &rentID, f.repo.txns[ride].CategoryID)
+}
+
+func TestService_RecategorizeWalksPagesOfOneOrganization(t *testing.T) {
+	f := newFixture("USD", "USD").withRules()
+	date := time.Date(2026, time.May, 4, 0, 0, 0, 0, time.UTC)
+	for i := range recategorizePageSize + 5 {
+		f.record(t, port.CreateTransaction{Amount: -1500 - int64(i), Date: date, Description: null.StringFrom("Uber"), CategoryID: &rentID})
+	}
+	other := uuid.New()
+	f.repo.txns[other] = port.Transaction{ID: other, OrganizationID: "org_2", AccountID: f.from, Type: "expense", Amount: -1500, Description: null.StringFrom("Uber"), CategoryID: &rentID}
+
+	result, err := f.svc.Recategorize(context.Background(), dafi.Where("organizationId", dafi.Equal, "org_1"))
+	require.NoError(t, err)
+	assert.Equal(t, port.Recategorization{Matched: recategorizePageSize + 5, Updated: recategorizePageSize + 5}, result)
+	assert.Equal(t, &rentID, f.repo.txns[other].CategoryID)
+}
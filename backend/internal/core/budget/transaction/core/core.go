@@ -3,29 +3,58 @@ package core
 import (
 	"context"
 
+	accountport "backend/core/budget/account/port"
+	categorizationruleport "backend/core/budget/categorization_rule/port"
+	organizationcurrencyport "backend/core/budget/organization_currency/port"
+	payeeport "backend/core/budget/payee/port"
 	"backend/core/budget/transaction/port"
 	basedomain "backend/port"
 	apperrors "backend/port/errors"
 	"backend/infra/dafi"
+	"github.com/google/uuid"
 	"github.com/samber/oops"
 )
 
 type service struct {
-	repo   port.Repository
-	logger basedomain.Logger
+	repo                    port.Repository
+	accountRepo             accountport.Repository
+	organizationCurrencySvc organizationcurrencyport.Service
+	categorizationRuleSvc   categorizationruleport.Service
+	payeeSvc                payeeport.Service
+	attachments             port.AttachmentRemover
+	uow                     basedomain.UnitOfWork
+	tx                      basedomain.Transaction
+	logger                  basedomain.Logger
 }
 
-func New(repo port.Repository, logger basedomain.Logger) port.Service {
+func New(repo port.Repository, accountRepo accountport.Repository, organizationCurrencySvc organizationcurrencyport.Service, categorizationRuleSvc categorizationruleport.Service, payeeSvc payeeport.Service, attachments port.AttachmentRemover, uow basedomain.UnitOfWork, logger basedomain.Logger) port.Service {
 	return service{
-		repo:   repo,
-		logger: logger.With("component", "transaction.service"),
+		repo:                    repo,
+		accountRepo:             accountRepo,
+		organizationCurrencySvc: organizationCurrencySvc,
+		categorizationRuleSvc:   categorizationRuleSvc,
+		payeeSvc:                payeeSvc,
+		attachments:             attachments,
+		uow:                     uow,
+		logger:                  logger.With("component", "transaction.service"),
 	}
 }
 
 func (s service) WithTx(tx basedomain.Transaction) port.Service {
+	return s.withTx(tx)
+}
+
+func (s service) withTx(tx basedomain.Transaction) service {
 	return service{
-		repo:   s.repo.WithTx(tx),
-		logger: s.logger,
+		repo:                    s.repo.WithTx(tx),
+		accountRepo:             s.accountRepo.WithTx(tx),
+		organizationCurrencySvc: s.organizationCurrencySvc.WithTx(tx),
+		categorizationRuleSvc:   s.categorizationRuleSvc.WithTx(tx),
+		payeeSvc:                s.payeeSvc.WithTx(tx),
+		attachments:             s.attachments.WithTx(tx),
+		uow:                     s.uow,
+		tx:                      tx,
+		logger:                  s.logger,
 	}
 }
 
@@ -52,17 +81,113 @@ func (s service) Create(ctx context.Context, input port.CreateTransaction) error
 		return oops.WithContext(ctx).In(apperrors.LayerService).Code(apperrors.CodeValidation).Wrap(err)
 	}
 
-	if err := s.repo.Create(ctx, input); err != nil {
+	if input.Status == "" {
+		input.Status = port.StatusPending
+	}
+
+	if err := s.convertOriginal(ctx, &input); err != nil {
+		return oops.WithContext(ctx).In(apperrors.LayerService).Wrap(err)
+	}
+
+	l := newLookups()
+	if err := s.resolvePayee(ctx, &input, l); err != nil {
+		return oops.WithContext(ctx).In(apperrors.LayerService).Wrap(err)
+	}
+
+	if err := s.categorize(ctx, &input, l); err != nil {
+		return oops.WithContext(ctx).In(apperrors.LayerService).Wrap(err)
+	}
+
+	err := basedomain.Atomically(ctx, s.uow, s.tx, s.withTx, func(txSvc service) error {
+		if err := txSvc.checkDuplicates(ctx, input); err != nil {
+			return err
+		}
+
+		if err := txSvc.repo.Create(ctx, input); err != nil {
+			return err
+		}
+
+		if len(input.Splits) > 0 {
+			if err := txSvc.repo.ReplaceSplits(ctx, input.ID, input.OrganizationID, input.Splits); err != nil {
+				return err
+			}
+		}
+
+		if len(input.TagIDs) > 0 {
+			if err := txSvc.repo.ReplaceTags(ctx, input.ID, input.OrganizationID, input.TagIDs); err != nil {
+				return err
+			}
+		}
+
+		return txSvc.applyBalances(ctx, balanceDeltas{input.AccountID: input.Amount})
+	})
+	if err != nil {
 		return oops.WithContext(ctx).In(apperrors.LayerService).Wrap(err)
 	}
 
-	s.logger.WithContext(ctx).Info("transaction created", "type", input.Type)
+	s.logger.WithContext(ctx).Info("transaction created", "type", input.Type, "splits", len(input.Splits))
 
 	return nil
 }
 
 func (s service) CreateBulk(ctx context.Context, inputs basedomain.List[port.CreateTransaction]) error {
-	if err := s.repo.CreateBulk(ctx, inputs); err != nil {
+	l := newLookups()
+	for i, input := range inputs {
+		if input.Status == "" {
+			inputs[i].Status = port.StatusPending
+		}
+
+		if err := s.convertOriginal(ctx, &inputs[i]); err != nil {
+			return oops.WithContext(ctx).In(apperrors.LayerService).Wrap(err)
+		}
+		input = inputs[i]
+
+		if err := s.resolvePayee(ctx, &inputs[i], l); err != nil {
+			return oops.WithContext(ctx).In(apperrors.LayerService).Wrap(err)
+		}
+
+		if err := s.categorize(ctx, &inputs[i], l); err != nil {
+			return oops.WithContext(ctx).In(apperrors.LayerService).Wrap(err)
+		}
+
+		if len(input.Splits) == 0 {
+			continue
+		}
+
+		if err := port.ValidateSplits(input.Amount, input.Splits); err != nil {
+			return oops.WithContext(ctx).In(apperrors.LayerService).Code(apperrors.CodeValidation).Wrap(err)
+		}
+	}
+
+	err := basedomain.Atomically(ctx, s.uow, s.tx, s.withTx, func(txSvc service) error {
+		if err := txSvc.checkDuplicates(ctx, inputs...); err != nil {
+			return err
+		}
+
+		if err := txSvc.repo.CreateBulk(ctx, inputs); err != nil {
+			return err
+		}
+
+		deltas := balanceDeltas{}
+		for _, input := range inputs {
+			deltas.add(input.AccountID, input.Amount)
+
+			if len(input.Splits) > 0 {
+				if err := txSvc.repo.ReplaceSplits(ctx, input.ID, input.OrganizationID, input.Splits); err != nil {
+					return err
+				}
+			}
+
+			if len(input.TagIDs) > 0 {
+				if err := txSvc.repo.ReplaceTags(ctx, input.ID, input.OrganizationID, input.TagIDs); err != nil {
+					return err
+				}
+			}
+		}
+
+		return txSvc.applyBalances(ctx, deltas)
+	})
+	if err != nil {
 		return oops.WithContext(ctx).In(apperrors.LayerService).Wrap(err)
 	}
 
@@ -76,21 +201,172 @@ func (s service) Update(ctx context.Context, input port.UpdateTransaction, filte
 		return oops.WithContext(ctx).In(apperrors.LayerService).Code(apperrors.CodeValidation).Wrap(err)
 	}
 
-	if err := s.repo.Update(ctx, input, filters...); err != nil {
+	var counterparts map[uuid.UUID]port.UpdateTransaction
+	err := basedomain.Atomically(ctx, s.uow, s.tx, s.withTx, func(txSvc service) error {
+		txns, err := txSvc.repo.FindAll(ctx, dafi.Criteria{Filters: filters})
+		if err != nil {
+			return err
+		}
+
+		// The counterpart of every transfer leg that is touched gets the same date and
+		// description, and the mirrored amount. A transaction charged in another currency
+		// gets its own amount, original amount and rate, converted again.
+		counterparts = make(map[uuid.UUID]port.UpdateTransaction)
+		conversions := make(map[uuid.UUID]port.UpdateTransaction)
+		deltas := balanceDeltas{}
+		for _, txn := range txns {
+			if input.ChangesLedger() {
+				if err := checkNotReconciled(ctx, txn); err != nil {
+					return err
+				}
+			}
+
+			txnInput := input
+			if input.ChangesConversion() {
+				conversion, err := txSvc.reconvert(ctx, txn, input)
+				if err != nil {
+					return err
+				}
+				if txn.OriginalCurrencyCode.Valid {
+					conversions[txn.ID] = conversion
+				}
+				txnInput.Amount = conversion.Amount
+			}
+
+			if err := checkSplitsUpdate(ctx, txn, txnInput); err != nil {
+				return err
+			}
+
+			if txnInput.Amount.Valid {
+				deltas.add(txn.AccountID, txnInput.Amount.Int64-txn.Amount)
+			}
+
+			if !txn.IsTransferLeg() {
+				continue
+			}
+
+			update, err := txSvc.counterpartUpdate(ctx, txn, txnInput, deltas)
+			if err != nil {
+				return err
+			}
+			counterparts[*txn.CounterpartID] = update
+		}
+
+		if err := txSvc.repo.Update(ctx, input, filters...); err != nil {
+			return err
+		}
+
+		for id, update := range conversions {
+			if err := txSvc.repo.Update(ctx, update, dafi.FilterBy("id", dafi.Equal, id)...); err != nil {
+				return err
+			}
+		}
+
+		for id, update := range counterparts {
+			if err := txSvc.repo.Update(ctx, update, dafi.FilterBy("id", dafi.Equal, id)...); err != nil {
+				return err
+			}
+		}
+
+		if input.Splits != nil {
+			for _, txn := range txns {
+				if err := txSvc.repo.ReplaceSplits(ctx, txn.ID, txn.OrganizationID, input.Splits); err != nil {
+					return err
+				}
+			}
+		}
+
+		if input.TagIDs != nil {
+			for _, txn := range txns {
+				if err := txSvc.repo.ReplaceTags(ctx, txn.ID, txn.OrganizationID, input.TagIDs); err != nil {
+					return err
+				}
+			}
+		}
+
+		return txSvc.applyBalances(ctx, deltas)
+	})
+	if err != nil {
 		return oops.WithContext(ctx).In(apperrors.LayerService).Wrap(err)
 	}
 
-	s.logger.WithContext(ctx).Info("transaction updated")
+	s.logger.WithContext(ctx).Info("transaction updated", "counterparts", len(counterparts))
 
 	return nil
 }
 
+// Delete removes the attachments of the transactions along with them. Their content
+// goes once the deletion is committed.
 func (s service) Delete(ctx context.Context, filters ...dafi.Filter) error {
-	if err := s.repo.Delete(ctx, filters...); err != nil {
+	var deleted int
+	var keys []string
+	err := basedomain.Atomically(ctx, s.uow, s.tx, s.withTx, func(txSvc service) error {
+		txns, err := txSvc.repo.FindAll(ctx, dafi.Criteria{Filters: filters})
+		if err != nil {
+			return err
+		}
+
+		// Deleting one side of a transfer deletes the other one too.
+		removed := make(map[uuid.UUID]port.Transaction, len(txns))
+		for _, txn := range txns {
+			if err := checkNotReconciled(ctx, txn); err != nil {
+				return err
+			}
+			removed[txn.ID] = txn
+
+			if !txn.IsTransferLeg() {
+				continue
+			}
+
+			legs, err := txSvc.repo.FindAll(ctx, dafi.Where("transferId", dafi.Equal, *txn.TransferID))
+			if err != nil {
+				return err
+			}
+			for _, leg := range legs {
+				if err := checkNotReconciled(ctx, leg); err != nil {
+					return err
+				}
+				removed[leg.ID] = leg
+			}
+		}
+
+		ids := make([]uuid.UUID, 0, len(removed))
+		for id := range removed {
+			ids = append(ids, id)
+		}
+
+		keys, err = txSvc.attachments.Detach(ctx, ids)
+		if err != nil {
+			return err
+		}
+
+		if err := txSvc.repo.Delete(ctx, filters...); err != nil {
+			return err
+		}
+
+		deltas := balanceDeltas{}
+		for _, txn := range removed {
+			deltas.add(txn.AccountID, -txn.Amount)
+
+			if !txn.IsTransferLeg() {
+				continue
+			}
+
+			if err := txSvc.repo.Delete(ctx, dafi.FilterBy("id", dafi.Equal, txn.ID)...); err != nil {
+				return err
+			}
+		}
+		deleted = len(removed)
+
+		return txSvc.applyBalances(ctx, deltas)
+	})
+	if err != nil {
 		return oops.WithContext(ctx).In(apperrors.LayerService).Wrap(err)
 	}
 
-	s.logger.WithContext(ctx).Info("transaction deleted")
+	s.attachments.Purge(ctx, keys)
+
+	s.logger.WithContext(ctx).Info("transaction deleted", "count", deleted)
 
 	return nil
 }
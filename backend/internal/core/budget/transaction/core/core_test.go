@@ -0,0 +1,384 @@
+package core
+
+import (
+	"bytes"
+	"context"
+	"slices"
+	"time"
+
+	accountport "backend/core/budget/account/port"
+	categorizationruleport "backend/core/budget/categorization_rule/port"
+	organizationcurrencyport "backend/core/budget/organization_currency/port"
+	payeeport "backend/core/budget/payee/port"
+	"backend/core/budget/transaction/port"
+	"backend/infra/dafi"
+	"backend/infra/money"
+	basedomain "backend/port"
+	apperrors "backend/port/errors"
+
+	"github.com/google/uuid"
+	"github.com/samber/oops"
+)
+
+type noopLogger struct{}
+
+func (noopLogger) Debug(string, ...any)                          {}
+func (noopLogger) Info(string, ...any)                           {}
+func (noopLogger) Warn(string, ...any)                           {}
+func (noopLogger) Error(string, ...any)                          {}
+func (noopLogger) With(...any) basedomain.Logger                 { return noopLogger{} }
+func (noopLogger) WithContext(context.Context) basedomain.Logger { return noopLogger{} }
+
+type stubTransaction struct{}
+
+func (stubTransaction) GetTx() basedomain.Tx { return nil }
+
+type stubUnitOfWork struct{}
+
+func (stubUnitOfWork) Begin(context.Context) (basedomain.Transaction, error) {
+	return stubTransaction{}, nil
+}
+
+func (stubUnitOfWork) Commit(context.Context, basedomain.Transaction) error   { return nil }
+func (stubUnitOfWork) Rollback(context.Context, basedomain.Transaction) error { return nil }
+
+// memoryRepo keeps transactions in memory and understands the filters the service
+// uses, all joined with AND, as well as a sort by id and a page size.
+type memoryRepo struct {
+	port.Repository
+	txns map[uuid.UUID]port.Transaction
+}
+
+func (r *memoryRepo) matches(filters dafi.Filters, txn port.Transaction) bool {
+	for _, filter := range filters {
+		if !r.matchesFilter(filter, txn) {
+			return false
+		}
+	}
+	return true
+}
+
+func (r *memoryRepo) matchesFilter(filter dafi.Filter, txn port.Transaction) bool {
+	switch value := filter.Value.(type) {
+	case uuid.UUID:
+		switch filter.Field {
+		case "id":
+			if filter.Operator == dafi.Greater {
+				return bytes.Compare(txn.ID[:], value[:]) > 0
+			}
+			return txn.ID == value
+		case "accountId":
+			return txn.AccountID == value
+		case "transferId":
+			return txn.TransferID != nil && *txn.TransferID == value
+		}
+	case string:
+		switch filter.Field {
+		case "organizationId":
+			return txn.OrganizationID == value
+		case "externalReferenceNumber":
+			return txn.ExternalReferenceNumber.String == value
+		}
+	case int64:
+		return filter.Field == "amount" && txn.Amount == value
+	case time.Time:
+		switch filter.Operator {
+		case dafi.GreaterOrEqual:
+			return !txn.Date.Before(value)
+		case dafi.LessOrEqual:
+			return !txn.Date.After(value)
+		}
+	}
+	return false
+}
+
+func (r *memoryRepo) FindOne(_ context.Context, criteria dafi.Criteria) (port.Transaction, error) {
+	for _, txn := range r.txns {
+		if r.matches(criteria.Filters, txn) {
+			return txn, nil
+		}
+	}
+	return port.Transaction{}, oops.Code(apperrors.CodeNotFound).Errorf("transaction not found")
+}
+
+func (r *memoryRepo) FindAll(_ context.Context, criteria dafi.Criteria) (basedomain.List[port.Transaction], error) {
+	var txns basedomain.List[port.Transaction]
+	for _, txn := range r.txns {
+		if r.matches(criteria.Filters, txn) {
+			txns = append(txns, txn)
+		}
+	}
+	if !criteria.Sorts.IsZero() && criteria.Sorts[0].Field == "id" {
+		slices.SortFunc(txns, func(a, b port.Transaction) int { return bytes.Compare(a.ID[:], b.ID[:]) })
+	}
+	if size := int(criteria.Pagination.PageSize); size > 0 && len(txns) > size {
+		txns = txns[:size]
+	}
+	return txns, nil
+}
+
+func (r *memoryRepo) Create(ctx context.Context, input port.CreateTransaction) error {
+	return r.CreateBulk(ctx, basedomain.List[port.CreateTransaction]{input})
+}
+
+func (r *memoryRepo) CreateBulk(_ context.Context, inputs basedomain.List[port.CreateTransaction]) error {
+	for _, input := range inputs {
+		r.txns[input.ID] = port.Transaction{
+			ID:                      input.ID,
+			OrganizationID:          input.OrganizationID,
+			AccountID:               input.AccountID,
+			CategoryID:              input.CategoryID,
+			SubcategoryID:           input.SubcategoryID,
+			BudgetID:                input.BudgetID,
+			PayeeID:                 input.PayeeID,
+			Type:                    input.Type,
+			Amount:                  input.Amount,
+			OriginalCurrencyCode:    input.OriginalCurrencyCode,
+			OriginalAmount:          input.OriginalAmount,
+			ExchangeRate:            input.ExchangeRate,
+			Description:             input.Description,
+			ExternalReferenceNumber: input.ExternalReferenceNumber,
+			Date:                    input.Date,
+			TransferID:              input.TransferID,
+			CounterpartID:           input.CounterpartID,
+			Status:                  input.Status,
+		}
+	}
+	return nil
+}
+
+func (r *memoryRepo) Update(_ context.Context, input port.UpdateTransaction, filters ...dafi.Filter) error {
+	for id, txn := range r.txns {
+		if !r.matches(filters, txn) {
+			continue
+		}
+		if input.Amount.Valid {
+			txn.Amount = input.Amount.Int64
+		}
+		if input.OriginalAmount.Valid {
+			txn.OriginalAmount = input.OriginalAmount
+		}
+		if input.ExchangeRate.Valid {
+			txn.ExchangeRate = input.ExchangeRate
+		}
+		if input.Description.Valid {
+			txn.Description = input.Description
+		}
+		if input.Date != nil {
+			txn.Date = *input.Date
+		}
+		if input.Status.Valid {
+			txn.Status = input.Status.String
+		}
+		r.txns[id] = txn
+	}
+	return nil
+}
+
+func (r *memoryRepo) Delete(_ context.Context, filters ...dafi.Filter) error {
+	for id, txn := range r.txns {
+		if r.matches(filters, txn) {
+			delete(r.txns, id)
+		}
+	}
+	return nil
+}
+
+func (r *memoryRepo) ReplaceSplits(_ context.Context, transactionID uuid.UUID, _ string, splits []port.SplitLine) error {
+	txn := r.txns[transactionID]
+	txn.Splits = nil
+	for _, line := range splits {
+		txn.Splits = append(txn.Splits, port.Split{
+			ID:            uuid.New(),
+			TransactionID: transactionID,
+			CategoryID:    line.CategoryID,
+			SubcategoryID: line.SubcategoryID,
+			Amount:        line.Amount,
+			Memo:          line.Memo,
+		})
+	}
+	if len(splits) > 0 {
+		txn.CategoryID, txn.SubcategoryID = nil, nil
+	}
+	r.txns[transactionID] = txn
+	return nil
+}
+
+func (r *memoryRepo) ReplaceTags(_ context.Context, transactionID uuid.UUID, _ string, tagIDs []uuid.UUID) error {
+	txn := r.txns[transactionID]
+	txn.TagIDs = tagIDs
+	r.txns[transactionID] = txn
+	return nil
+}
+
+func (r *memoryRepo) Categorize(_ context.Context, id uuid.UUID, categoryID uuid.UUID, subcategoryID, budgetID *uuid.UUID) error {
+	txn := r.txns[id]
+	txn.CategoryID, txn.SubcategoryID, txn.BudgetID = &categoryID, subcategoryID, budgetID
+	r.txns[id] = txn
+	return nil
+}
+
+func (r *memoryRepo) WithTx(basedomain.Transaction) port.Repository { return r }
+
+// stubAccountRepo serves active accounts of org_1 unless organizations or inactive
+// say otherwise.
+type stubAccountRepo struct {
+	accountport.Repository
+	currencies    map[uuid.UUID]string
+	balances      map[uuid.UUID]int64
+	organizations map[uuid.UUID]string
+	inactive      map[uuid.UUID]bool
+}
+
+func (r stubAccountRepo) FindOne(_ context.Context, criteria dafi.Criteria) (accountport.Account, error) {
+	id, _ := criteria.Filters[0].Value.(uuid.UUID)
+	code, ok := r.currencies[id]
+	if !ok {
+		return accountport.Account{}, oops.Code(apperrors.CodeNotFound).Errorf("account not found")
+	}
+	organizationID, ok := r.organizations[id]
+	if !ok {
+		organizationID = "org_1"
+	}
+	return accountport.Account{ID: id, OrganizationID: organizationID, CurrencyCode: code, IsActive: !r.inactive[id]}, nil
+}
+
+func (r stubAccountRepo) AdjustBalance(_ context.Context, accountID uuid.UUID, delta int64) error {
+	r.balances[accountID] += delta
+	return nil
+}
+
+func (r stubAccountRepo) Lock(_ context.Context, accountID uuid.UUID) (accountport.Account, error) {
+	return accountport.Account{ID: accountID, CurrentBalance: money.Minor(r.balances[accountID])}, nil
+}
+
+func (r stubAccountRepo) WithTx(basedomain.Transaction) accountport.Repository { return r }
+
+// stubOrganizationCurrencies serves the organization currencies of a single
+// organization with their rate against the base currency and their decimal places.
+type stubOrganizationCurrencies struct {
+	organizationcurrencyport.Service
+	rates         map[string]money.ExchangeRate
+	decimalPlaces map[string]int16
+	history       map[string]money.ExchangeRate
+}
+
+func (s stubOrganizationCurrencies) FindAll(_ context.Context, criteria dafi.Criteria) (basedomain.List[organizationcurrencyport.OrganizationCurrency], error) {
+	codes, _ := criteria.Filters[1].Value.([]string)
+
+	var ocs basedomain.List[organizationcurrencyport.OrganizationCurrency]
+	for _, code := range codes {
+		rate, ok := s.rates[code]
+		if !ok {
+			continue
+		}
+		ocs = append(ocs, organizationcurrencyport.OrganizationCurrency{
+			CurrencyCode: code,
+			Rate:         rate,
+			Currency:     &organizationcurrencyport.OrganizationCurrencyCurrency{Code: code, DecimalPlaces: s.decimalPlaces[code]},
+		})
+	}
+	return ocs, nil
+}
+
+// RateOn serves the same rate on every date unless history holds one for the date.
+func (s stubOrganizationCurrencies) RateOn(_ context.Context, _ string, currencyCode string, on time.Time) (money.ExchangeRate, error) {
+	if rate, ok := s.history[currencyCode+on.Format(time.DateOnly)]; ok {
+		return rate, nil
+	}
+	return s.rates[currencyCode], nil
+}
+
+func (s stubOrganizationCurrencies) WithTx(basedomain.Transaction) organizationcurrencyport.Service {
+	return s
+}
+
+// stubCategorizationRules serves the same active rules to every organization.
+type stubCategorizationRules struct {
+	categorizationruleport.Service
+	rules *[]categorizationruleport.Rule
+}
+
+func (s stubCategorizationRules) Active(context.Context, string) ([]categorizationruleport.Rule, error) {
+	return *s.rules, nil
+}
+
+func (s stubCategorizationRules) WithTx(basedomain.Transaction) categorizationruleport.Service {
+	return s
+}
+
+// stubPayees serves the same payees to every organization.
+type stubPayees struct {
+	payeeport.Service
+	payees *[]payeeport.Payee
+}
+
+func (s stubPayees) ForOrganization(context.Context, string) ([]payeeport.Payee, error) {
+	return *s.payees, nil
+}
+
+func (s stubPayees) WithTx(basedomain.Transaction) payeeport.Service { return s }
+
+// stubAttachments holds the content keys attached to each transaction.
+type stubAttachments struct {
+	keys   map[uuid.UUID][]string
+	purged []string
+}
+
+func (s *stubAttachments) Detach(_ context.Context, transactionIDs []uuid.UUID) ([]string, error) {
+	var keys []string
+	for _, id := range transactionIDs {
+		keys = append(keys, s.keys[id]...)
+		delete(s.keys, id)
+	}
+	return keys, nil
+}
+
+func (s *stubAttachments) Purge(_ context.Context, keys []string) {
+	s.purged = append(s.purged, keys...)
+}
+
+func (s *stubAttachments) WithTx(basedomain.Transaction) port.AttachmentRemover { return s }
+
+type fixture struct {
+	svc         port.Service
+	repo        *memoryRepo
+	accounts    stubAccountRepo
+	currencies  stubOrganizationCurrencies
+	rules       *[]categorizationruleport.Rule
+	payees      *[]payeeport.Payee
+	attachments *stubAttachments
+	from, to    uuid.UUID
+}
+
+// newFixture returns a service over an in-memory repository and two accounts in the
+// given currencies. No currency is enabled for the organization until a test adds it.
+func newFixture(fromCurrency, toCurrency string) fixture {
+	from, to := uuid.New(), uuid.New()
+	repo := &memoryRepo{txns: map[uuid.UUID]port.Transaction{}}
+	accounts := stubAccountRepo{
+		currencies:    map[uuid.UUID]string{from: fromCurrency, to: toCurrency},
+		balances:      map[uuid.UUID]int64{},
+		organizations: map[uuid.UUID]string{},
+		inactive:      map[uuid.UUID]bool{},
+	}
+	currencies := stubOrganizationCurrencies{
+		rates:         map[string]money.ExchangeRate{},
+		decimalPlaces: map[string]int16{},
+		history:       map[string]money.ExchangeRate{},
+	}
+	rules := &[]categorizationruleport.Rule{}
+	payees := &[]payeeport.Payee{}
+	attachments := &stubAttachments{keys: map[uuid.UUID][]string{}}
+	return fixture{
+		svc:         New(repo, accounts, currencies, stubCategorizationRules{rules: rules}, stubPayees{payees: payees}, attachments, stubUnitOfWork{}, noopLogger{}),
+		repo:        repo,
+		accounts:    accounts,
+		currencies:  currencies,
+		rules:       rules,
+		payees:      payees,
+		attachments: attachments,
+		from:        from,
+		to:          to,
+	}
+}
@@ -0,0 +1,199 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	organizationcurrencyport "backend/core/budget/organization_currency/port"
+	"backend/core/budget/transaction/port"
+	"backend/infra/dafi"
+	"backend/infra/money"
+	apperrors "backend/port/errors"
+
+	"github.com/guregu/null/v6"
+	"github.com/samber/oops"
+)
+
+// convertOriginal resolves the exchange rate and the account amount of a transaction
+// charged in another currency. Both currencies must be enabled for the organization. A
+// rate given by the caller, e.g. the one printed on the card statement, wins over the
+// organization rates in effect on the transaction date; an amount given by the caller
+// is kept as the bank booked it and, without a rate, sets the rate it implies.
+func (s service) convertOriginal(ctx context.Context, input *port.CreateTransaction) error {
+	if !input.IsForeign() {
+		return nil
+	}
+
+	account, err := s.accountRepo.FindOne(ctx, dafi.Where("id", dafi.Equal, input.AccountID))
+	if err != nil {
+		return err
+	}
+
+	from, to := input.OriginalCurrencyCode.String, account.CurrencyCode
+	if from == to {
+		return oops.WithContext(ctx).In(apperrors.LayerService).
+			Code(apperrors.CodeValidation).
+			Public("The original currency must differ from the account currency.").
+			Errorf("transaction %s is already in %s", input.ID, to)
+	}
+
+	filters := dafi.FilterBy("organizationId", dafi.Equal, input.OrganizationID).And("currencyCode", dafi.In, []string{from, to})
+	currencies, err := s.organizationCurrencySvc.FindAll(ctx, dafi.Criteria{
+		Filters:   filters,
+		Relations: []string{organizationcurrencyport.RelationCurrencies},
+	})
+	if err != nil {
+		return err
+	}
+
+	byCode := make(map[string]organizationcurrencyport.OrganizationCurrency, len(currencies))
+	for _, c := range currencies {
+		if c.Currency != nil {
+			byCode[c.CurrencyCode] = c
+		}
+	}
+
+	for _, code := range []string{from, to} {
+		if _, ok := byCode[code]; !ok {
+			return oops.WithContext(ctx).In(apperrors.LayerService).
+				Code(apperrors.CodeValidation).
+				Public(fmt.Sprintf("The currency %s is not enabled for the organization.", code)).
+				Errorf("organization %s has no currency %s", input.OrganizationID, code)
+		}
+	}
+
+	fromCurrency := money.Currency{Code: from, DecimalPlaces: byCode[from].Currency.DecimalPlaces}
+	toCurrency := money.Currency{Code: to, DecimalPlaces: byCode[to].Currency.DecimalPlaces}
+
+	if input.OriginalAmount.Int64 == 0 {
+		return oops.WithContext(ctx).In(apperrors.LayerService).
+			Code(apperrors.CodeValidation).
+			Public("The original amount must not be zero.").
+			Errorf("transaction %s has no original amount", input.ID)
+	}
+
+	if input.Amount != 0 && (input.Amount < 0) != (input.OriginalAmount.Int64 < 0) {
+		return oops.WithContext(ctx).In(apperrors.LayerService).
+			Code(apperrors.CodeValidation).
+			Public("The amount must have the same sign as the original amount.").
+			Errorf("amount %d and original amount %d of transaction %s differ in sign", input.Amount, input.OriginalAmount.Int64, input.ID)
+	}
+
+	rate := input.ExchangeRate.Rate
+	switch {
+	case input.ExchangeRate.Valid:
+	case input.Amount != 0:
+		rate, err = money.ImpliedRate(money.Minor(input.OriginalAmount.Int64), fromCurrency, money.Minor(input.Amount), toCurrency)
+		if err != nil {
+			return oops.WithContext(ctx).In(apperrors.LayerService).Code(apperrors.CodeValidation).Wrap(err)
+		}
+	default:
+		rate, err = s.rateOn(ctx, input.OrganizationID, from, to, input.Date)
+		if err != nil {
+			return err
+		}
+	}
+
+	if !rate.IsPositive() {
+		return oops.WithContext(ctx).In(apperrors.LayerService).
+			Code(apperrors.CodeValidation).
+			Public("The exchange rate must be a positive number.").
+			Errorf("exchange rate %s is not positive", rate)
+	}
+	input.ExchangeRate = money.NullExchangeRateFrom(rate)
+
+	if input.Amount == 0 {
+		amount, err := money.Convert(money.Minor(input.OriginalAmount.Int64), fromCurrency, toCurrency, rate, money.RoundHalfUp)
+		if err != nil {
+			return oops.WithContext(ctx).In(apperrors.LayerService).Code(apperrors.CodeValidation).Wrap(err)
+		}
+		if amount == 0 {
+			return oops.WithContext(ctx).In(apperrors.LayerService).
+				Code(apperrors.CodeValidation).
+				Public("The original amount converts to nothing in the account currency.").
+				Errorf("%d %s converts to 0 %s at %s", input.OriginalAmount.Int64, from, to, rate)
+		}
+		input.Amount = amount.Int64()
+
+		// Split lines could not be checked against an amount that was not known yet.
+		if len(input.Splits) > 0 {
+			if err := port.ValidateSplits(input.Amount, input.Splits); err != nil {
+				return oops.WithContext(ctx).In(apperrors.LayerService).Code(apperrors.CodeValidation).Wrap(err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// reconvert works out how an update that changes the conversion of txn, see
+// [port.UpdateTransaction.ChangesConversion], sets its amount, original amount and
+// exchange rate. Only a transaction charged in another currency has an original amount
+// and a rate to change; any other one just takes the new amount.
+func (s service) reconvert(ctx context.Context, txn port.Transaction, input port.UpdateTransaction) (port.UpdateTransaction, error) {
+	if !txn.OriginalCurrencyCode.Valid {
+		if input.OriginalAmount.Valid || input.ExchangeRate.Valid {
+			return port.UpdateTransaction{}, oops.WithContext(ctx).In(apperrors.LayerService).
+				Code(apperrors.CodeValidation).
+				Public("Only a transaction charged in another currency has an original amount and an exchange rate.").
+				Errorf("transaction %s is in the account currency", txn.ID)
+		}
+		return port.UpdateTransaction{Amount: input.Amount}, nil
+	}
+
+	if input.Amount.Valid && input.Amount.Int64 == 0 {
+		return port.UpdateTransaction{}, oops.WithContext(ctx).In(apperrors.LayerService).
+			Code(apperrors.CodeValidation).
+			Public("The amount of a transaction charged in another currency must not be zero.").
+			Errorf("transaction %s would have no amount", txn.ID)
+	}
+
+	conversion := port.CreateTransaction{
+		ID:                   txn.ID,
+		OrganizationID:       txn.OrganizationID,
+		AccountID:            txn.AccountID,
+		Amount:               input.Amount.Int64,
+		OriginalCurrencyCode: txn.OriginalCurrencyCode,
+		OriginalAmount:       txn.OriginalAmount,
+		ExchangeRate:         input.ExchangeRate,
+		Date:                 txn.Date,
+	}
+	if input.OriginalAmount.Valid {
+		conversion.OriginalAmount = input.OriginalAmount
+	}
+	if !input.Amount.Valid && !input.ExchangeRate.Valid {
+		conversion.ExchangeRate = txn.ExchangeRate
+	}
+
+	if err := s.convertOriginal(ctx, &conversion); err != nil {
+		return port.UpdateTransaction{}, err
+	}
+
+	return port.UpdateTransaction{
+		Amount:         null.IntFrom(conversion.Amount),
+		OriginalAmount: conversion.OriginalAmount,
+		ExchangeRate:   conversion.ExchangeRate,
+	}, nil
+}
+
+// rateOn derives the rate from one currency to another from the organization rates in
+// effect on the given date.
+func (s service) rateOn(ctx context.Context, organizationID, from, to string, on time.Time) (money.ExchangeRate, error) {
+	fromRate, err := s.organizationCurrencySvc.RateOn(ctx, organizationID, from, on)
+	if err != nil {
+		return 0, err
+	}
+
+	toRate, err := s.organizationCurrencySvc.RateOn(ctx, organizationID, to, on)
+	if err != nil {
+		return 0, err
+	}
+
+	rate, err := money.CrossRate(fromRate, toRate)
+	if err != nil {
+		return 0, oops.WithContext(ctx).In(apperrors.LayerService).Wrap(err)
+	}
+
+	return rate, nil
+}
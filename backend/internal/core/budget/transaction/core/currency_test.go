@@ -0,0 +1,197 @@
+package core
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"backend/core/budget/transaction/port"
+	"backend/infra/dafi"
+	"backend/infra/money"
+	apperrors "backend/port/errors"
+
+	"github.com/google/uuid"
+	"github.com/guregu/null/v6"
+	"github.com/samber/oops"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func mustRate(t *testing.T, s string) money.ExchangeRate {
+	t.Helper()
+	rate, err := money.ParseExchangeRate(s)
+	require.NoError(t, err)
+	return rate
+}
+
+func TestService_CreateConvertsOriginalAmount(t *testing.T) {
+	f := newFixture("USD", "USD")
+	f.currencies.rates["USD"], f.currencies.decimalPlaces["USD"] = money.ExchangeRateOne(), 2
+	f.currencies.rates["EUR"], f.currencies.decimalPlaces["EUR"] = mustRate(t, "0.92"), 2
+	id := uuid.New()
+
+	err := f.svc.Create(context.Background(), port.CreateTransaction{
+		ID:                   id,
+		OrganizationID:       "org_1",
+		AccountID:            f.from,
+		Type:                 "expense",
+		OriginalCurrencyCode: null.StringFrom("EUR"),
+		OriginalAmount:       null.IntFrom(-5000),
+		Date:                 time.Date(2026, time.April, 28, 0, 0, 0, 0, time.UTC),
+	})
+	require.NoError(t, err)
+
+	txn := f.repo.txns[id]
+	assert.Equal(t, int64(-5435), txn.Amount)
+	assert.Equal(t, mustRate(t, "1.0869565217"), txn.ExchangeRate.Rate)
+	assert.Equal(t, int64(-5000), txn.OriginalAmount.Int64)
+	assert.Equal(t, int64(-5435), f.accounts.balances[f.from])
+}
+
+func TestService_CreateKeepsGivenRate(t *testing.T) {
+	f := newFixture("USD", "USD")
+	f.currencies.rates["USD"], f.currencies.decimalPlaces["USD"] = money.ExchangeRateOne(), 2
+	f.currencies.rates["JPY"], f.currencies.decimalPlaces["JPY"] = mustRate(t, "150"), 0
+	id := uuid.New()
+
+	err := f.svc.Create(context.Background(), port.CreateTransaction{
+		ID:                   id,
+		OrganizationID:       "org_1",
+		AccountID:            f.from,
+		Type:                 "expense",
+		OriginalCurrencyCode: null.StringFrom("JPY"),
+		OriginalAmount:       null.IntFrom(-1500),
+		ExchangeRate:         money.NullExchangeRateFrom(mustRate(t, "0.0068")),
+		Date:                 time.Date(2026, time.April, 28, 0, 0, 0, 0, time.UTC),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int64(-1020), f.repo.txns[id].Amount)
+}
+
+func TestService_CreateUsesRateOfTransactionDate(t *testing.T) {
+	f := newFixture("USD", "USD")
+	f.currencies.rates["USD"], f.currencies.decimalPlaces["USD"] = money.ExchangeRateOne(), 2
+	f.currencies.rates["EUR"], f.currencies.decimalPlaces["EUR"] = mustRate(t, "0.92"), 2
+	f.currencies.history["EUR2026-01-15"] = mustRate(t, "0.8")
+	id := uuid.New()
+
+	err := f.svc.Create(context.Background(), port.CreateTransaction{
+		ID:                   id,
+		OrganizationID:       "org_1",
+		AccountID:            f.from,
+		Type:                 "expense",
+		OriginalCurrencyCode: null.StringFrom("EUR"),
+		OriginalAmount:       null.IntFrom(-4000),
+		Date:                 time.Date(2026, time.January, 15, 0, 0, 0, 0, time.UTC),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int64(-5000), f.repo.txns[id].Amount)
+	assert.Equal(t, mustRate(t, "1.25"), f.repo.txns[id].ExchangeRate.Rate)
+}
+
+func TestService_CreateRejectsCurrencyNotEnabled(t *testing.T) {
+	f := newFixture("USD", "USD")
+	f.currencies.rates["USD"], f.currencies.decimalPlaces["USD"] = money.ExchangeRateOne(), 2
+
+	err := f.svc.Create(context.Background(), port.CreateTransaction{
+		ID:                   uuid.New(),
+		OrganizationID:       "org_1",
+		AccountID:            f.from,
+		Type:                 "expense",
+		OriginalCurrencyCode: null.StringFrom("GBP"),
+		OriginalAmount:       null.IntFrom(-1000),
+		Date:                 time.Date(2026, time.April, 28, 0, 0, 0, 0, time.UTC),
+	})
+	require.Error(t, err)
+
+	oopsErr, ok := oops.AsOops(err)
+	require.True(t, ok)
+	assert.Equal(t, apperrors.CodeValidation, oopsErr.Code())
+	assert.Empty(t, f.repo.txns)
+}
+
+func TestService_UpdateConvertsForeignAmountAgain(t *testing.T) {
+	f := newFixture("USD", "USD")
+	f.currencies.rates["USD"], f.currencies.decimalPlaces["USD"] = money.ExchangeRateOne(), 2
+	f.currencies.rates["EUR"], f.currencies.decimalPlaces["EUR"] = mustRate(t, "0.92"), 2
+	id := uuid.New()
+	require.NoError(t, f.svc.Create(context.Background(), port.CreateTransaction{
+		ID:                   id,
+		OrganizationID:       "org_1",
+		AccountID:            f.from,
+		Type:                 "expense",
+		OriginalCurrencyCode: null.StringFrom("EUR"),
+		OriginalAmount:       null.IntFrom(-5000),
+		ExchangeRate:         money.NullExchangeRateFrom(mustRate(t, "1.1")),
+		Date:                 time.Date(2026, time.April, 28, 0, 0, 0, 0, time.UTC),
+	}))
+	update := func(input port.UpdateTransaction) port.Transaction {
+		t.Helper()
+		require.NoError(t, f.svc.Update(context.Background(), input, dafi.FilterBy("id", dafi.Equal, id)...))
+		return f.repo.txns[id]
+	}
+
+	t.Run("the amount the bank booked sets the rate", func(t *testing.T) {
+		txn := update(port.UpdateTransaction{Amount: null.IntFrom(-5600)})
+		assert.Equal(t, int64(-5600), txn.Amount)
+		assert.Equal(t, int64(-5000), txn.OriginalAmount.Int64)
+		assert.Equal(t, mustRate(t, "1.12"), txn.ExchangeRate.Rate)
+		assert.Equal(t, int64(-5600), f.accounts.balances[f.from])
+	})
+
+	t.Run("a new original amount is converted at the recorded rate", func(t *testing.T) {
+		txn := update(port.UpdateTransaction{OriginalAmount: null.IntFrom(-2500)})
+		assert.Equal(t, int64(-2800), txn.Amount)
+		assert.Equal(t, mustRate(t, "1.12"), txn.ExchangeRate.Rate)
+		assert.Equal(t, int64(-2800), f.accounts.balances[f.from])
+	})
+
+	t.Run("a new rate converts the original amount again", func(t *testing.T) {
+		txn := update(port.UpdateTransaction{ExchangeRate: money.NullExchangeRateFrom(mustRate(t, "1.2"))})
+		assert.Equal(t, int64(-3000), txn.Amount)
+		assert.Equal(t, int64(-2500), txn.OriginalAmount.Int64)
+		assert.Equal(t, int64(-3000), f.accounts.balances[f.from])
+	})
+
+	t.Run("a zero amount is refused", func(t *testing.T) {
+		err := f.svc.Update(context.Background(), port.UpdateTransaction{Amount: null.IntFrom(0)}, dafi.FilterBy("id", dafi.Equal, id)...)
+		requireCode(t, err, apperrors.CodeValidation)
+		assert.Equal(t, int64(-3000), f.repo.txns[id].Amount)
+	})
+
+	t.Run("an amount of the other sign is refused", func(t *testing.T) {
+		err := f.svc.Update(context.Background(), port.UpdateTransaction{Amount: null.IntFrom(3000)}, dafi.FilterBy("id", dafi.Equal, id)...)
+		requireCode(t, err, apperrors.CodeValidation)
+		assert.Equal(t, int64(-3000), f.repo.txns[id].Amount)
+	})
+}
+
+func TestService_UpdateRejectsConversionOfAccountCurrencyTransaction(t *testing.T) {
+	f := newFixture("USD", "USD")
+	id := f.record(t, port.CreateTransaction{Amount: -450, Date: time.Date(2026, time.April, 28, 0, 0, 0, 0, time.UTC)})
+
+	err := f.svc.Update(context.Background(), port.UpdateTransaction{OriginalAmount: null.IntFrom(-400)}, dafi.FilterBy("id", dafi.Equal, id)...)
+	requireCode(t, err, apperrors.CodeValidation)
+	assert.False(t, f.repo.txns[id].OriginalAmount.Valid)
+}
+
+func TestService_CreateRejectsInconsistentConversion(t *testing.T) {
+	for name, input := range map[string]port.CreateTransaction{
+		"amount of the other sign": {Amount: 1020, OriginalAmount: null.IntFrom(-1500)},
+		"amount rounding to zero":  {OriginalAmount: null.IntFrom(-1), ExchangeRate: money.NullExchangeRateFrom(money.ExchangeRate(10_000_000))},
+		"zero original amount":     {Amount: -1020, OriginalAmount: null.IntFrom(0)},
+	} {
+		t.Run(name, func(t *testing.T) {
+			f := newFixture("USD", "USD")
+			f.currencies.rates["USD"], f.currencies.decimalPlaces["USD"] = money.ExchangeRateOne(), 2
+			f.currencies.rates["JPY"], f.currencies.decimalPlaces["JPY"] = mustRate(t, "150"), 0
+
+			input.ID, input.OrganizationID, input.AccountID, input.Type = uuid.New(), "org_1", f.from, "expense"
+			input.OriginalCurrencyCode = null.StringFrom("JPY")
+			input.Date = time.Date(2026, time.April, 28, 0, 0, 0, 0, time.UTC)
+
+			requireCode(t, f.svc.Create(context.Background(), input), apperrors.CodeValidation)
+			assert.Empty(t, f.repo.txns)
+		})
+	}
+}
@@ -0,0 +1,215 @@
+package core
+
+import (
+	"bytes"
+	"context"
+	"slices"
+	"strings"
+	"time"
+	"unicode"
+
+	"backend/core/budget/transaction/port"
+	"backend/infra/dafi"
+	basedomain "backend/port"
+	apperrors "backend/port/errors"
+
+	"github.com/google/uuid"
+	"github.com/samber/oops"
+)
+
+// duplicateWindow is how many days apart the bank and the person entering a
+// transaction may date it.
+const duplicateWindow = 3 * 24 * time.Hour
+
+// minDescriptionSimilarity is how alike two descriptions must be, from 0 to 1, for
+// transactions of the same amount to be taken for the same one.
+const minDescriptionSimilarity = 0.8
+
+// minFuzzyDescriptionLength is the length, in letters and digits, a description needs
+// before it is compared loosely. Shorter ones are only alike when they are equal.
+const minFuzzyDescriptionLength = 4
+
+// checkDuplicates refuses transactions that look like one already recorded, or like
+// an earlier one of the same batch: one on the same account with the same external
+// reference, the way banks number the entries of an account, or, when there is no
+// reference, one on the same account with the same amount, dated within
+// duplicateWindow and with a similar description.
+//
+// It must run inside the transaction that records them. The accounts are locked first,
+// so that a concurrent create on the same account waits for this one and then sees
+// what it recorded.
+func (s service) checkDuplicates(ctx context.Context, inputs ...port.CreateTransaction) error {
+	accountIDs := make([]uuid.UUID, 0, len(inputs))
+	for _, input := range inputs {
+		if !input.AllowDuplicate && !slices.Contains(accountIDs, input.AccountID) {
+			accountIDs = append(accountIDs, input.AccountID)
+		}
+	}
+	slices.SortFunc(accountIDs, func(a, b uuid.UUID) int { return bytes.Compare(a[:], b[:]) })
+
+	for _, id := range accountIDs {
+		if _, err := s.accountRepo.Lock(ctx, id); err != nil {
+			return err
+		}
+	}
+
+	for i, input := range inputs {
+		if input.AllowDuplicate {
+			continue
+		}
+
+		var batchIDs []uuid.UUID
+		for _, earlier := range inputs[:i] {
+			if looksLikeDuplicate(input, earlier) {
+				batchIDs = append(batchIDs, earlier.ID)
+			}
+		}
+		if len(batchIDs) > 0 {
+			return oops.WithContext(ctx).In(apperrors.LayerService).
+				Code(apperrors.CodeConflict).
+				Public("This transaction looks like another one of the same request. Set allowDuplicate to record it anyway.").
+				Wrap(port.DuplicateError{DuplicateIDs: batchIDs})
+		}
+
+		ids, err := s.findDuplicates(ctx, input)
+		if err != nil {
+			return err
+		}
+		if len(ids) > 0 {
+			return oops.WithContext(ctx).In(apperrors.LayerService).
+				Code(apperrors.CodeConflict).
+				Public("This transaction looks like one already recorded. Set allowDuplicate to record it anyway.").
+				Wrap(port.DuplicateError{DuplicateIDs: ids})
+		}
+	}
+
+	return nil
+}
+
+func (s service) FindDuplicates(ctx context.Context, inputs basedomain.List[port.CreateTransaction]) ([][]uuid.UUID, error) {
+	duplicates := make([][]uuid.UUID, len(inputs))
+	var kept []port.CreateTransaction
+	for i, input := range inputs {
+		if input.AllowDuplicate {
+			kept = append(kept, input)
+			continue
+		}
+
+		for _, earlier := range kept {
+			if looksLikeDuplicate(input, earlier) {
+				duplicates[i] = append(duplicates[i], earlier.ID)
+			}
+		}
+
+		ids, err := s.findDuplicates(ctx, input)
+		if err != nil {
+			return nil, oops.WithContext(ctx).In(apperrors.LayerService).Wrap(err)
+		}
+		duplicates[i] = append(duplicates[i], ids...)
+
+		if len(duplicates[i]) == 0 {
+			kept = append(kept, input)
+		}
+	}
+
+	return duplicates, nil
+}
+
+// looksLikeDuplicate applies the rules of findDuplicates to two transactions not
+// recorded yet.
+func looksLikeDuplicate(a, b port.CreateTransaction) bool {
+	if a.ExternalReferenceNumber.Valid && a.ExternalReferenceNumber.String != "" {
+		return a.AccountID == b.AccountID && a.ExternalReferenceNumber.String == b.ExternalReferenceNumber.String
+	}
+
+	apart := a.Date.Sub(b.Date)
+	return a.AccountID == b.AccountID &&
+		a.Amount == b.Amount &&
+		apart <= duplicateWindow && apart >= -duplicateWindow &&
+		similarDescriptions(a.Description.String, b.Description.String)
+}
+
+func (s service) findDuplicates(ctx context.Context, input port.CreateTransaction) ([]uuid.UUID, error) {
+	if input.ExternalReferenceNumber.Valid && input.ExternalReferenceNumber.String != "" {
+		txns, err := s.repo.FindAll(ctx, dafi.Where("accountId", dafi.Equal, input.AccountID).
+			And("externalReferenceNumber", dafi.Equal, input.ExternalReferenceNumber.String))
+		if err != nil {
+			return nil, err
+		}
+
+		ids := make([]uuid.UUID, 0, len(txns))
+		for _, txn := range txns {
+			ids = append(ids, txn.ID)
+		}
+		return ids, nil
+	}
+
+	txns, err := s.repo.FindAll(ctx, dafi.Where("accountId", dafi.Equal, input.AccountID).
+		And("amount", dafi.Equal, input.Amount).
+		And("date", dafi.GreaterOrEqual, input.Date.Add(-duplicateWindow)).
+		And("date", dafi.LessOrEqual, input.Date.Add(duplicateWindow)))
+	if err != nil {
+		return nil, err
+	}
+
+	var ids []uuid.UUID
+	for _, txn := range txns {
+		if similarDescriptions(input.Description.String, txn.Description.String) {
+			ids = append(ids, txn.ID)
+		}
+	}
+	return ids, nil
+}
+
+// similarDescriptions compares descriptions the way banks garble them: ignoring case,
+// punctuation and spacing, so "AMZN Mktp US*2K4" and "amzn mktp us" are alike, and
+// taking one that contains the other as the same. A missing description is like no
+// other, and one shorter than minFuzzyDescriptionLength only like an equal one.
+func similarDescriptions(a, b string) bool {
+	a, b = normalizeDescription(a), normalizeDescription(b)
+	if a == "" || b == "" {
+		return false
+	}
+
+	ra, rb := []rune(a), []rune(b)
+	if min(len(ra), len(rb)) < minFuzzyDescriptionLength {
+		return a == b
+	}
+	if strings.Contains(a, b) || strings.Contains(b, a) {
+		return true
+	}
+
+	longest := max(len(ra), len(rb))
+	return 1-float64(levenshtein(ra, rb))/float64(longest) >= minDescriptionSimilarity
+}
+
+func normalizeDescription(s string) string {
+	fields := strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	return strings.Join(fields, " ")
+}
+
+// levenshtein counts the single-rune insertions, deletions and substitutions that turn
+// a into b.
+func levenshtein(a, b []rune) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+
+	return previous[len(b)]
+}
@@ -0,0 +1,156 @@
+package core
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"backend/core/budget/transaction/port"
+	basedomain "backend/port"
+	apperrors "backend/port/errors"
+
+	"github.com/google/uuid"
+	"github.com/guregu/null/v6"
+	"github.com/samber/oops"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func requireDuplicateOf(t *testing.T, err error, ids ...uuid.UUID) {
+	t.Helper()
+	require.Error(t, err)
+
+	oopsErr, ok := oops.AsOops(err)
+	require.True(t, ok)
+	assert.Equal(t, apperrors.CodeConflict, oopsErr.Code())
+
+	var duplicate port.DuplicateError
+	require.ErrorAs(t, err, &duplicate)
+	assert.ElementsMatch(t, ids, duplicate.DuplicateIDs)
+	assert.Equal(t, map[string]any{"duplicateIds": duplicate.DuplicateIDs}, duplicate.ProblemExtensions())
+}
+
+func (f fixture) record(t *testing.T, input port.CreateTransaction) uuid.UUID {
+	t.Helper()
+	input.ID, input.OrganizationID, input.AccountID, input.Type = uuid.New(), "org_1", f.from, "expense"
+	require.NoError(t, f.svc.Create(context.Background(), input))
+	return input.ID
+}
+
+func TestService_CreateRejectsSameExternalReference(t *testing.T) {
+	f := newFixture("USD", "USD")
+	date := time.Date(2026, time.April, 28, 0, 0, 0, 0, time.UTC)
+	existing := f.record(t, port.CreateTransaction{Amount: -450, Date: date, ExternalReferenceNumber: null.StringFrom("FIT-1")})
+
+	input := port.CreateTransaction{
+		ID:                      uuid.New(),
+		OrganizationID:          "org_1",
+		AccountID:               f.from,
+		Type:                    "expense",
+		Amount:                  -999,
+		Date:                    date.AddDate(0, 1, 0),
+		ExternalReferenceNumber: null.StringFrom("FIT-1"),
+	}
+	requireDuplicateOf(t, f.svc.Create(context.Background(), input), existing)
+	assert.Len(t, f.repo.txns, 1)
+
+	input.AllowDuplicate = true
+	require.NoError(t, f.svc.Create(context.Background(), input))
+	assert.Len(t, f.repo.txns, 2)
+
+	t.Run("another account may use the same reference", func(t *testing.T) {
+		input.ID, input.AccountID, input.AllowDuplicate = uuid.New(), f.to, false
+		require.NoError(t, f.svc.Create(context.Background(), input))
+		assert.Len(t, f.repo.txns, 3)
+	})
+}
+
+func TestService_CreateRejectsLookAlike(t *testing.T) {
+	f := newFixture("USD", "USD")
+	date := time.Date(2026, time.April, 28, 0, 0, 0, 0, time.UTC)
+	existing := f.record(t, port.CreateTransaction{Amount: -1299, Date: date, Description: null.StringFrom("Amazon")})
+
+	err := f.svc.Create(context.Background(), port.CreateTransaction{
+		ID:             uuid.New(),
+		OrganizationID: "org_1",
+		AccountID:      f.from,
+		Type:           "expense",
+		Amount:         -1299,
+		Date:           date.AddDate(0, 0, 2),
+		Description:    null.StringFrom("AMAZON.COM*2K4"),
+	})
+	requireDuplicateOf(t, err, existing)
+
+	for name, input := range map[string]port.CreateTransaction{
+		"other amount":      {Amount: -1300, Date: date, Description: null.StringFrom("Amazon")},
+		"outside window":    {Amount: -1299, Date: date.AddDate(0, 0, 4), Description: null.StringFrom("Amazon")},
+		"other description": {Amount: -1299, Date: date, Description: null.StringFrom("Netflix")},
+	} {
+		t.Run(name, func(t *testing.T) {
+			f.record(t, input)
+		})
+	}
+}
+
+func TestService_CreateBulkRejectsDuplicates(t *testing.T) {
+	f := newFixture("USD", "USD")
+	date := time.Date(2026, time.April, 28, 0, 0, 0, 0, time.UTC)
+	existing := f.record(t, port.CreateTransaction{Amount: -450, Date: date, ExternalReferenceNumber: null.StringFrom("FIT-1")})
+
+	err := f.svc.CreateBulk(context.Background(), basedomain.List[port.CreateTransaction]{
+		{ID: uuid.New(), OrganizationID: "org_1", AccountID: f.from, Type: "income", Amount: 100, Date: date},
+		{ID: uuid.New(), OrganizationID: "org_1", AccountID: f.from, Type: "expense", Amount: -450, Date: date, ExternalReferenceNumber: null.StringFrom("FIT-1")},
+	})
+	requireDuplicateOf(t, err, existing)
+	assert.Len(t, f.repo.txns, 1)
+}
+
+func TestService_CreateBulkRejectsDuplicatesWithinBatch(t *testing.T) {
+	f := newFixture("USD", "USD")
+	date := time.Date(2026, time.April, 28, 0, 0, 0, 0, time.UTC)
+	first := uuid.New()
+
+	err := f.svc.CreateBulk(context.Background(), basedomain.List[port.CreateTransaction]{
+		{ID: first, OrganizationID: "org_1", AccountID: f.from, Type: "expense", Amount: -1299, Date: date, Description: null.StringFrom("Amazon")},
+		{ID: uuid.New(), OrganizationID: "org_1", AccountID: f.from, Type: "expense", Amount: -1299, Date: date.AddDate(0, 0, 1), Description: null.StringFrom("AMAZON.COM*2K4")},
+	})
+	requireDuplicateOf(t, err, first)
+	assert.Empty(t, f.repo.txns)
+}
+
+func TestService_FindDuplicates(t *testing.T) {
+	f := newFixture("USD", "USD")
+	date := time.Date(2026, time.April, 28, 0, 0, 0, 0, time.UTC)
+	existing := f.record(t, port.CreateTransaction{Amount: -1299, Date: date, Description: null.StringFrom("Amazon")})
+	first, second := uuid.New(), uuid.New()
+
+	duplicates, err := f.svc.FindDuplicates(context.Background(), basedomain.List[port.CreateTransaction]{
+		{ID: uuid.New(), OrganizationID: "org_1", AccountID: f.from, Type: "expense", Amount: -1299, Date: date.AddDate(0, 0, 1), Description: null.StringFrom("AMAZON.COM*2K4")},
+		{ID: first, OrganizationID: "org_1", AccountID: f.from, Type: "expense", Amount: -800, Date: date, Description: null.StringFrom("Bakery")},
+		{ID: second, OrganizationID: "org_1", AccountID: f.from, Type: "expense", Amount: -800, Date: date, Description: null.StringFrom("BAKERY 42")},
+		{ID: uuid.New(), OrganizationID: "org_1", AccountID: f.from, Type: "expense", Amount: -800, Date: date, Description: null.StringFrom("Bakery"), AllowDuplicate: true},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, [][]uuid.UUID{{existing}, nil, {first}, nil}, duplicates)
+	assert.Len(t, f.repo.txns, 1)
+}
+
+func TestService_CreateAllowsLookAlikesWithoutDescription(t *testing.T) {
+	f := newFixture("USD", "USD")
+	date := time.Date(2026, time.April, 28, 0, 0, 0, 0, time.UTC)
+
+	f.record(t, port.CreateTransaction{Amount: -500, Date: date})
+	f.record(t, port.CreateTransaction{Amount: -500, Date: date})
+	assert.Len(t, f.repo.txns, 2)
+}
+
+func TestSimilarDescriptions(t *testing.T) {
+	assert.False(t, similarDescriptions("", ""))
+	assert.False(t, similarDescriptions("A", "Amazon"))
+	assert.True(t, similarDescriptions("ATM", "atm"))
+	assert.True(t, similarDescriptions("Starbucks #123", "STARBUCKS 123"))
+	assert.True(t, similarDescriptions("Uber Trip", "UBER *TRIP HELP.UBER.COM"))
+	assert.True(t, similarDescriptions("Whole Foods Mkt", "Whole Foods Mkts"))
+	assert.False(t, similarDescriptions("Coffee", ""))
+	assert.False(t, similarDescriptions("Shell", "Spotify"))
+}
@@ -0,0 +1,62 @@
+package core
+
+import (
+	"context"
+
+	payeeport "backend/core/budget/payee/port"
+	"backend/core/budget/transaction/port"
+)
+
+func (s service) payeesOf(ctx context.Context, organizationID string, l lookups) ([]payeeport.Payee, error) {
+	if payees, ok := l.payees[organizationID]; ok {
+		return payees, nil
+	}
+
+	payees, err := s.payeeSvc.ForOrganization(ctx, organizationID)
+	if err != nil {
+		return nil, err
+	}
+	l.payees[organizationID] = payees
+
+	return payees, nil
+}
+
+// resolvePayee gives a new transaction the caller left without a payee the one its
+// description names, by name, alias or pattern.
+func (s service) resolvePayee(ctx context.Context, input *port.CreateTransaction, l lookups) error {
+	if input.PayeeID != nil || !input.Description.Valid || input.Type == port.TypeTransfer {
+		return nil
+	}
+
+	payees, err := s.payeesOf(ctx, input.OrganizationID, l)
+	if err != nil {
+		return err
+	}
+
+	if payee, ok := payeeport.Resolve(payees, input.Description.String); ok {
+		input.PayeeID = &payee.ID
+	}
+
+	return nil
+}
+
+// applyPayeeDefault files a transaction under the default category of its payee.
+func (s service) applyPayeeDefault(ctx context.Context, input *port.CreateTransaction, l lookups) error {
+	if input.PayeeID == nil {
+		return nil
+	}
+
+	payees, err := s.payeesOf(ctx, input.OrganizationID, l)
+	if err != nil {
+		return err
+	}
+
+	for _, payee := range payees {
+		if payee.ID == *input.PayeeID && payee.DefaultCategoryID != nil {
+			input.CategoryID, input.SubcategoryID = payee.DefaultCategoryID, payee.DefaultSubcategoryID
+			return nil
+		}
+	}
+
+	return nil
+}
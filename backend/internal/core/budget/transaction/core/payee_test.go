@@ -0,0 +1,86 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	categorizationruleport "backend/core/budget/categorization_rule/port"
+	payeeport "backend/core/budget/payee/port"
+	"backend/core/budget/transaction/port"
+	"backend/infra/dafi"
+
+	"github.com/google/uuid"
+	"github.com/guregu/null/v6"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	amazonID   = uuid.MustParse("66666666-6666-6666-6666-666666666666")
+	shoppingID = uuid.MustParse("77777777-7777-7777-7777-777777777777")
+	booksID    = uuid.MustParse("88888888-8888-8888-8888-888888888888")
+)
+
+// withPayees gives the fixture an Amazon payee that defaults to shopping.
+func (f fixture) withPayees() fixture {
+	*f.payees = []payeeport.Payee{
+		{ID: uuid.New(), Name: "Amazon Prime Video", Patterns: []string{"PRIMEVIDEO*"}},
+		{
+			ID:                amazonID,
+			Name:              "Amazon",
+			Aliases:           []string{"Amazon.com"},
+			Patterns:          []string{"AMZN MKTP*"},
+			DefaultCategoryID: &shoppingID,
+		},
+	}
+	return f
+}
+
+func TestService_CreateResolvesPayeeAndItsDefaultCategory(t *testing.T) {
+	f := newFixture("USD", "USD").withPayees()
+	date := time.Date(2026, time.May, 6, 0, 0, 0, 0, time.UTC)
+
+	byPattern := f.record(t, port.CreateTransaction{Amount: -2599, Date: date, Description: null.StringFrom("AMZN Mktp US*2K4")})
+	byAlias := f.record(t, port.CreateTransaction{Amount: -1099, Date: date, Description: null.StringFrom("AMAZON COM")})
+	unknown := f.record(t, port.CreateTransaction{Amount: -450, Date: date, Description: null.StringFrom("Corner bakery")})
+
+	for _, id := range []uuid.UUID{byPattern, byAlias} {
+		assert.Equal(t, &amazonID, f.repo.txns[id].PayeeID)
+		assert.Equal(t, &shoppingID, f.repo.txns[id].CategoryID)
+	}
+	assert.Nil(t, f.repo.txns[unknown].PayeeID)
+	assert.Nil(t, f.repo.txns[unknown].CategoryID)
+}
+
+func TestService_CreateKeepsGivenPayee(t *testing.T) {
+	f := newFixture("USD", "USD").withPayees()
+	other := uuid.New()
+
+	id := f.record(t, port.CreateTransaction{
+		Amount:      -2599,
+		Date:        time.Date(2026, time.May, 6, 0, 0, 0, 0, time.UTC),
+		Description: null.StringFrom("Amazon.com"),
+		PayeeID:     &other,
+	})
+
+	assert.Equal(t, &other, f.repo.txns[id].PayeeID)
+	assert.Nil(t, f.repo.txns[id].CategoryID)
+}
+
+func TestService_CreatePrefersRuleOverPayeeDefault(t *testing.T) {
+	f := newFixture("USD", "USD").withPayees()
+	*f.rules = []categorizationruleport.Rule{{
+		Name: "Amazon books",
+		Conditions: categorizationruleport.Conditions{
+			{Field: categorizationruleport.FieldPayeeID, Operator: dafi.Equal, Value: amazonID.String()},
+			{Field: categorizationruleport.FieldDescription, Operator: dafi.Contains, Value: "books"},
+		},
+		CategoryID: booksID,
+	}}
+	date := time.Date(2026, time.May, 6, 0, 0, 0, 0, time.UTC)
+
+	books := f.record(t, port.CreateTransaction{Amount: -1500, Date: date, Description: null.StringFrom("AMZN MKTP BOOKS")})
+	other := f.record(t, port.CreateTransaction{Amount: -1500, Date: date, Description: null.StringFrom("AMZN MKTP DE")})
+
+	assert.Equal(t, &booksID, f.repo.txns[books].CategoryID)
+	assert.Equal(t, &shoppingID, f.repo.txns[other].CategoryID)
+}
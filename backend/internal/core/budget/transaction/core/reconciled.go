@@ -0,0 +1,54 @@
+package core
+
+import (
+	"context"
+
+	"backend/core/budget/transaction/port"
+	"backend/infra/dafi"
+	basedomain "backend/port"
+	apperrors "backend/port/errors"
+
+	"github.com/google/uuid"
+	"github.com/samber/oops"
+)
+
+// checkNotReconciled rejects a change to what a finalized reconciliation locked: the
+// transaction must stay as it was on the reconciled statement until it is unlocked.
+func checkNotReconciled(ctx context.Context, txn port.Transaction) error {
+	if !txn.IsReconciled() {
+		return nil
+	}
+
+	return oops.WithContext(ctx).In(apperrors.LayerService).
+		Code(apperrors.CodeConflict).
+		Public("This transaction is reconciled. Unlock it before changing its amount, date or status.").
+		Errorf("transaction %s is locked by reconciliation %s", txn.ID, *txn.ReconciliationID)
+}
+
+func (s service) Unlock(ctx context.Context, id uuid.UUID) error {
+	var reconciliationID *uuid.UUID
+	err := basedomain.Atomically(ctx, s.uow, s.tx, s.withTx, func(txSvc service) error {
+		txn, err := txSvc.repo.FindOne(ctx, dafi.Where("id", dafi.Equal, id))
+		if err != nil {
+			return err
+		}
+
+		if !txn.IsReconciled() {
+			return oops.WithContext(ctx).In(apperrors.LayerService).
+				Code(apperrors.CodeConflict).
+				Public("Only a reconciled transaction can be unlocked.").
+				Errorf("transaction %s is %s", txn.ID, txn.Status)
+		}
+		reconciliationID = txn.ReconciliationID
+
+		return txSvc.repo.Unlock(ctx, id)
+	})
+	if err != nil {
+		return oops.WithContext(ctx).In(apperrors.LayerService).Wrap(err)
+	}
+
+	// Unlocking is an explicit override of a reconciliation, so it leaves a trace.
+	s.logger.WithContext(ctx).Warn("reconciled transaction unlocked", "transactionId", id, "reconciliationId", reconciliationID)
+
+	return nil
+}
@@ -0,0 +1,141 @@
+package core
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"backend/core/budget/transaction/port"
+	"backend/infra/dafi"
+	apperrors "backend/port/errors"
+
+	"github.com/google/uuid"
+	"github.com/guregu/null/v6"
+	"github.com/samber/oops"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// reconcile marks a stored transaction as locked by a finalized reconciliation.
+func (r *memoryRepo) reconcile(id uuid.UUID) {
+	txn := r.txns[id]
+	reconciliationID := uuid.New()
+	txn.Status, txn.ReconciliationID = port.StatusReconciled, &reconciliationID
+	r.txns[id] = txn
+}
+
+func (r *memoryRepo) Unlock(_ context.Context, id uuid.UUID) error {
+	txn := r.txns[id]
+	txn.Status, txn.ReconciliationID = port.StatusCleared, nil
+	r.txns[id] = txn
+	return nil
+}
+
+func requireConflict(t *testing.T, err error) {
+	t.Helper()
+	require.Error(t, err)
+
+	oopsErr, ok := oops.AsOops(err)
+	require.True(t, ok)
+	assert.Equal(t, apperrors.CodeConflict, oopsErr.Code())
+}
+
+func TestService_ReconciledTransactionIsLocked(t *testing.T) {
+	f := newFixture("EUR", "EUR")
+	ctx := context.Background()
+	id := uuid.New()
+	require.NoError(t, f.svc.Create(ctx, port.CreateTransaction{
+		ID:             id,
+		OrganizationID: "org_1",
+		AccountID:      f.from,
+		Type:           "expense",
+		Amount:         -4200,
+		Date:           time.Date(2026, time.April, 24, 0, 0, 0, 0, time.UTC),
+		Status:         port.StatusCleared,
+	}))
+	f.repo.reconcile(id)
+	filters := dafi.FilterBy("id", dafi.Equal, id)
+
+	requireConflict(t, f.svc.Update(ctx, port.UpdateTransaction{Amount: null.IntFrom(-5000)}, filters...))
+	requireConflict(t, f.svc.Update(ctx, port.UpdateTransaction{Status: null.StringFrom(port.StatusPending)}, filters...))
+	requireConflict(t, f.svc.Delete(ctx, filters...))
+	assert.Equal(t, int64(-4200), f.repo.txns[id].Amount)
+	assert.Equal(t, int64(-4200), f.accounts.balances[f.from])
+
+	require.NoError(t, f.svc.Update(ctx, port.UpdateTransaction{Description: null.StringFrom("Groceries")}, filters...))
+	assert.Equal(t, "Groceries", f.repo.txns[id].Description.String)
+}
+
+func TestService_ReconciledCounterpartIsLocked(t *testing.T) {
+	f := newFixture("EUR", "EUR")
+	ctx := context.Background()
+	id := uuid.New()
+	require.NoError(t, f.svc.CreateTransfer(ctx, port.CreateTransfer{
+		ID:             id,
+		OrganizationID: "org_1",
+		FromAccountID:  f.from,
+		ToAccountID:    f.to,
+		Amount:         5000,
+		Date:           time.Date(2026, time.April, 24, 0, 0, 0, 0, time.UTC),
+	}))
+	f.repo.reconcile(legID(id, inflowLeg))
+
+	date := time.Date(2026, time.April, 25, 0, 0, 0, 0, time.UTC)
+	outflow := dafi.FilterBy("id", dafi.Equal, legID(id, outflowLeg))
+	requireConflict(t, f.svc.Update(ctx, port.UpdateTransaction{Date: &date}, outflow...))
+	requireConflict(t, f.svc.UpdateTransfer(ctx, id, port.UpdateTransfer{Amount: null.IntFrom(6000)}))
+	requireConflict(t, f.svc.DeleteTransfer(ctx, id))
+	requireConflict(t, f.svc.Delete(ctx, outflow...))
+	assert.Len(t, f.repo.txns, 2)
+
+	require.NoError(t, f.svc.Update(ctx, port.UpdateTransaction{Status: null.StringFrom(port.StatusCleared)}, outflow...))
+	assert.Equal(t, port.StatusCleared, f.repo.txns[legID(id, outflowLeg)].Status)
+}
+
+func TestService_Unlock(t *testing.T) {
+	f := newFixture("EUR", "EUR")
+	ctx := context.Background()
+	id := uuid.New()
+	require.NoError(t, f.svc.Create(ctx, port.CreateTransaction{
+		ID:             id,
+		OrganizationID: "org_1",
+		AccountID:      f.from,
+		Type:           "expense",
+		Amount:         -4200,
+		Date:           time.Date(2026, time.April, 26, 0, 0, 0, 0, time.UTC),
+	}))
+	assert.Equal(t, port.StatusPending, f.repo.txns[id].Status)
+
+	requireConflict(t, f.svc.Unlock(ctx, id))
+
+	f.repo.reconcile(id)
+	require.NoError(t, f.svc.Unlock(ctx, id))
+	assert.Equal(t, port.StatusCleared, f.repo.txns[id].Status)
+	assert.Nil(t, f.repo.txns[id].ReconciliationID)
+
+	filters := dafi.FilterBy("id", dafi.Equal, id)
+	require.NoError(t, f.svc.Update(ctx, port.UpdateTransaction{Amount: null.IntFrom(-4000)}, filters...))
+	assert.Equal(t, int64(-4000), f.accounts.balances[f.from])
+}
+
+func TestService_StatusCannotBeSetToReconciled(t *testing.T) {
+	f := newFixture("EUR", "EUR")
+	ctx := context.Background()
+	id := uuid.New()
+
+	err := f.svc.Create(ctx, port.CreateTransaction{
+		ID:             id,
+		OrganizationID: "org_1",
+		AccountID:      f.from,
+		Type:           "expense",
+		Amount:         -4200,
+		Date:           time.Date(2026, time.April, 26, 0, 0, 0, 0, time.UTC),
+		Status:         port.StatusReconciled,
+	})
+	require.Error(t, err)
+	assert.Empty(t, f.repo.txns)
+
+	err = f.svc.Update(ctx, port.UpdateTransaction{Status: null.StringFrom(port.StatusReconciled)},
+		dafi.FilterBy("id", dafi.Equal, id)...)
+	require.Error(t, err)
+}
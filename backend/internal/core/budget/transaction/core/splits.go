@@ -0,0 +1,51 @@
+package core
+
+import (
+	"context"
+
+	"backend/core/budget/transaction/port"
+	apperrors "backend/port/errors"
+
+	"github.com/samber/oops"
+)
+
+// checkSplitsUpdate makes sure an update keeps the split lines of txn consistent with
+// its amount: the lines either come with the update or the amount stays the same.
+func checkSplitsUpdate(ctx context.Context, txn port.Transaction, input port.UpdateTransaction) error {
+	amount := txn.Amount
+	if input.Amount.Valid {
+		amount = input.Amount.Int64
+	}
+
+	if input.Splits != nil {
+		if len(input.Splits) == 0 {
+			return nil
+		}
+
+		if err := port.ValidateSplits(amount, input.Splits); err != nil {
+			return oops.WithContext(ctx).In(apperrors.LayerService).Code(apperrors.CodeValidation).Wrap(err)
+		}
+
+		return nil
+	}
+
+	if len(txn.Splits) == 0 {
+		return nil
+	}
+
+	if amount != txn.Amount {
+		return oops.WithContext(ctx).In(apperrors.LayerService).
+			Code(apperrors.CodeValidation).
+			Public("Update the split lines together with the amount.").
+			Errorf("new amount %d of split transaction %s comes without split lines", amount, txn.ID)
+	}
+
+	if input.CategoryID != nil || input.SubcategoryID != nil {
+		return oops.WithContext(ctx).In(apperrors.LayerService).
+			Code(apperrors.CodeValidation).
+			Public("A split transaction takes its categories from its split lines.").
+			Errorf("category given for split transaction %s", txn.ID)
+	}
+
+	return nil
+}
@@ -0,0 +1,166 @@
+package core
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"backend/core/budget/transaction/port"
+	"backend/infra/dafi"
+	apperrors "backend/port/errors"
+
+	"github.com/google/uuid"
+	"github.com/guregu/null/v6"
+	"github.com/samber/oops"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createSplitTransaction(t *testing.T, svc port.Service, accountID uuid.UUID, amount int64, splits ...port.SplitLine) uuid.UUID {
+	t.Helper()
+
+	id := uuid.New()
+	err := svc.Create(context.Background(), port.CreateTransaction{
+		ID:             id,
+		OrganizationID: "org_1",
+		AccountID:      accountID,
+		Type:           "expense",
+		Amount:         amount,
+		Date:           time.Date(2026, time.April, 20, 0, 0, 0, 0, time.UTC),
+		Splits:         splits,
+	})
+	require.NoError(t, err)
+
+	return id
+}
+
+func requireCode(t *testing.T, err error, code string) {
+	t.Helper()
+
+	require.Error(t, err)
+	oopsErr, ok := oops.AsOops(err)
+	require.True(t, ok)
+	assert.Equal(t, code, oopsErr.Code())
+}
+
+func TestService_CreateWithSplits(t *testing.T) {
+	f := newFixture("EUR", "EUR")
+	svc, repo, account := f.svc, f.repo, f.from
+	groceries, household := uuid.New(), uuid.New()
+
+	id := createSplitTransaction(t, svc, account, -8000,
+		port.SplitLine{CategoryID: &groceries, Amount: -5500},
+		port.SplitLine{CategoryID: &household, Amount: -2500, Memo: null.StringFrom("Detergent")},
+	)
+
+	txn := repo.txns[id]
+	require.Len(t, txn.Splits, 2)
+	assert.Equal(t, groceries, *txn.Splits[0].CategoryID)
+	assert.Equal(t, int64(-2500), txn.Splits[1].Amount)
+}
+
+func TestService_CreateRejectsUnbalancedSplits(t *testing.T) {
+	f := newFixture("EUR", "EUR")
+	svc, repo, account := f.svc, f.repo, f.from
+	category := uuid.New()
+
+	err := svc.Create(context.Background(), port.CreateTransaction{
+		ID:             uuid.New(),
+		OrganizationID: "org_1",
+		AccountID:      account,
+		Type:           "expense",
+		Amount:         -8000,
+		Date:           time.Date(2026, time.April, 20, 0, 0, 0, 0, time.UTC),
+		Splits: []port.SplitLine{
+			{CategoryID: &category, Amount: -5000},
+			{Amount: -2000},
+		},
+	})
+
+	requireCode(t, err, apperrors.CodeValidation)
+	assert.Empty(t, repo.txns)
+}
+
+func TestService_UpdateSplitTransaction(t *testing.T) {
+	f := newFixture("EUR", "EUR")
+	svc, repo, account := f.svc, f.repo, f.from
+	groceries, household := uuid.New(), uuid.New()
+	id := createSplitTransaction(t, svc, account, -8000,
+		port.SplitLine{CategoryID: &groceries, Amount: -5500},
+		port.SplitLine{CategoryID: &household, Amount: -2500},
+	)
+	filters := dafi.FilterBy("id", dafi.Equal, id)
+
+	t.Run("amount without lines", func(t *testing.T) {
+		err := svc.Update(context.Background(), port.UpdateTransaction{Amount: null.IntFrom(-9000)}, filters...)
+		requireCode(t, err, apperrors.CodeValidation)
+	})
+
+	t.Run("category without removing lines", func(t *testing.T) {
+		err := svc.Update(context.Background(), port.UpdateTransaction{CategoryID: &groceries}, filters...)
+		requireCode(t, err, apperrors.CodeValidation)
+	})
+
+	t.Run("amount with lines", func(t *testing.T) {
+		err := svc.Update(context.Background(), port.UpdateTransaction{
+			Amount: null.IntFrom(-9000),
+			Splits: []port.SplitLine{
+				{CategoryID: &groceries, Amount: -6500},
+				{CategoryID: &household, Amount: -2500},
+			},
+		}, filters...)
+		require.NoError(t, err)
+		assert.Equal(t, int64(-6500), repo.txns[id].Splits[0].Amount)
+	})
+
+	t.Run("remove lines", func(t *testing.T) {
+		err := svc.Update(context.Background(), port.UpdateTransaction{Splits: []port.SplitLine{}}, filters...)
+		require.NoError(t, err)
+		assert.Empty(t, repo.txns[id].Splits)
+	})
+}
+
+func TestService_UpdateRejectsSplitTransfer(t *testing.T) {
+	f := newFixture("EUR", "EUR")
+	svc, from, to := f.svc, f.from, f.to
+	id := uuid.New()
+	require.NoError(t, svc.CreateTransfer(context.Background(), port.CreateTransfer{
+		ID:             id,
+		OrganizationID: "org_1",
+		FromAccountID:  from,
+		ToAccountID:    to,
+		Amount:         5000,
+		Date:           time.Date(2026, time.April, 18, 0, 0, 0, 0, time.UTC),
+	}))
+
+	err := svc.Update(context.Background(), port.UpdateTransaction{
+		Splits: []port.SplitLine{{Amount: -2000}, {Amount: -3000}},
+	}, dafi.FilterBy("id", dafi.Equal, legID(id, outflowLeg))...)
+	requireCode(t, err, apperrors.CodeValidation)
+}
+
+func TestValidateSplits(t *testing.T) {
+	tests := []struct {
+		name    string
+		amount  int64
+		splits  []port.SplitLine
+		wantErr bool
+	}{
+		{name: "balanced", amount: -100, splits: []port.SplitLine{{Amount: -60}, {Amount: -40}}},
+		{name: "refund line", amount: -100, splits: []port.SplitLine{{Amount: -120}, {Amount: 20}}},
+		{name: "single line", amount: -100, splits: []port.SplitLine{{Amount: -100}}, wantErr: true},
+		{name: "zero line", amount: -100, splits: []port.SplitLine{{Amount: -100}, {Amount: 0}}, wantErr: true},
+		{name: "unbalanced", amount: -100, splits: []port.SplitLine{{Amount: -60}, {Amount: -30}}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := port.ValidateSplits(tt.amount, tt.splits)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package core
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"backend/core/budget/transaction/port"
+	"backend/infra/dafi"
+
+	"github.com/google/uuid"
+	"github.com/guregu/null/v6"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createTaggedTransaction(t *testing.T, svc port.Service, accountID uuid.UUID, tagIDs ...uuid.UUID) uuid.UUID {
+	t.Helper()
+
+	id := uuid.New()
+	err := svc.Create(context.Background(), port.CreateTransaction{
+		ID:             id,
+		OrganizationID: "org_1",
+		AccountID:      accountID,
+		Type:           "expense",
+		Amount:         -2500,
+		Date:           time.Date(2026, time.May, 8, 0, 0, 0, 0, time.UTC),
+		TagIDs:         tagIDs,
+	})
+	require.NoError(t, err)
+
+	return id
+}
+
+func TestService_CreateWithTags(t *testing.T) {
+	f := newFixture("EUR", "EUR")
+	vacation, work := uuid.New(), uuid.New()
+
+	id := createTaggedTransaction(t, f.svc, f.from, vacation, work)
+
+	assert.Equal(t, []uuid.UUID{vacation, work}, f.repo.txns[id].TagIDs)
+}
+
+func TestService_UpdateReplacesTags(t *testing.T) {
+	f := newFixture("EUR", "EUR")
+	vacation, work := uuid.New(), uuid.New()
+	id := createTaggedTransaction(t, f.svc, f.from, vacation)
+
+	err := f.svc.Update(context.Background(), port.UpdateTransaction{TagIDs: []uuid.UUID{work}}, dafi.FilterBy("id", dafi.Equal, id)...)
+	require.NoError(t, err)
+	assert.Equal(t, []uuid.UUID{work}, f.repo.txns[id].TagIDs)
+
+	err = f.svc.Update(context.Background(), port.UpdateTransaction{TagIDs: []uuid.UUID{}}, dafi.FilterBy("id", dafi.Equal, id)...)
+	require.NoError(t, err)
+	assert.Empty(t, f.repo.txns[id].TagIDs)
+}
+
+func TestService_UpdateWithoutTagsKeepsThem(t *testing.T) {
+	f := newFixture("EUR", "EUR")
+	vacation := uuid.New()
+	id := createTaggedTransaction(t, f.svc, f.from, vacation)
+
+	err := f.svc.Update(context.Background(), port.UpdateTransaction{Description: null.StringFrom("Hotel")}, dafi.FilterBy("id", dafi.Equal, id)...)
+	require.NoError(t, err)
+	assert.Equal(t, []uuid.UUID{vacation}, f.repo.txns[id].TagIDs)
+}
@@ -0,0 +1,376 @@
+package core
+
+import (
+	"context"
+
+	accountport "backend/core/budget/account/port"
+	"backend/core/budget/transaction/port"
+	"backend/infra/dafi"
+	basedomain "backend/port"
+	apperrors "backend/port/errors"
+
+	"github.com/google/uuid"
+	"github.com/guregu/null/v6"
+	"github.com/samber/oops"
+)
+
+const (
+	outflowLeg = "outflow"
+	inflowLeg  = "inflow"
+)
+
+// legID derives the id of one side of a transfer from the transfer id, so a retried
+// request cannot create a second pair of rows.
+func legID(transferID uuid.UUID, leg string) uuid.UUID {
+	return uuid.NewSHA1(transferID, []byte(leg))
+}
+
+func (s service) CreateTransfer(ctx context.Context, input port.CreateTransfer) error {
+	if err := input.Validate(ctx); err != nil {
+		return oops.WithContext(ctx).In(apperrors.LayerService).Code(apperrors.CodeValidation).Wrap(err)
+	}
+
+	from, to, err := s.transferAccounts(ctx, input.FromAccountID, input.ToAccountID)
+	if err != nil {
+		return oops.WithContext(ctx).In(apperrors.LayerService).Wrap(err)
+	}
+
+	if err := checkTransferAccounts(ctx, input.OrganizationID, from, to); err != nil {
+		return oops.WithContext(ctx).In(apperrors.LayerService).Wrap(err)
+	}
+
+	sameCurrency := from.CurrencyCode == to.CurrencyCode
+	sent, received, err := transferAmounts(sameCurrency, null.IntFrom(input.Amount), input.ToAmount, 0, 0)
+	if err != nil {
+		return oops.WithContext(ctx).In(apperrors.LayerService).Wrap(err)
+	}
+
+	transferID := input.ID
+	outflowID, inflowID := legID(transferID, outflowLeg), legID(transferID, inflowLeg)
+
+	legs := []port.CreateTransaction{
+		{
+			ID:             outflowID,
+			OrganizationID: input.OrganizationID,
+			AccountID:      input.FromAccountID,
+			Type:           port.TypeTransfer,
+			Amount:         -sent,
+			Description:    input.Description,
+			Date:           input.Date,
+			Status:         port.StatusPending,
+			TransferID:     &transferID,
+			CounterpartID:  &inflowID,
+		},
+		{
+			ID:             inflowID,
+			OrganizationID: input.OrganizationID,
+			AccountID:      input.ToAccountID,
+			Type:           port.TypeTransfer,
+			Amount:         received,
+			Description:    input.Description,
+			Date:           input.Date,
+			Status:         port.StatusPending,
+			TransferID:     &transferID,
+			CounterpartID:  &outflowID,
+		},
+	}
+
+	err = basedomain.Atomically(ctx, s.uow, s.tx, s.withTx, func(txSvc service) error {
+		if err := txSvc.repo.CreateBulk(ctx, legs); err != nil {
+			return err
+		}
+
+		deltas := balanceDeltas{}
+		deltas.add(input.FromAccountID, -sent)
+		deltas.add(input.ToAccountID, received)
+
+		return txSvc.applyBalances(ctx, deltas)
+	})
+	if err != nil {
+		return oops.WithContext(ctx).In(apperrors.LayerService).Wrap(err)
+	}
+
+	s.logger.WithContext(ctx).Info("transfer created", "transferId", transferID, "sameCurrency", sameCurrency)
+
+	return nil
+}
+
+func (s service) FindTransfer(ctx context.Context, id uuid.UUID) (port.Transfer, error) {
+	transfer, err := s.findTransfer(ctx, id)
+	if err != nil {
+		return port.Transfer{}, oops.WithContext(ctx).In(apperrors.LayerService).Wrap(err)
+	}
+
+	return transfer, nil
+}
+
+func (s service) UpdateTransfer(ctx context.Context, id uuid.UUID, input port.UpdateTransfer) error {
+	if err := input.Validate(ctx); err != nil {
+		return oops.WithContext(ctx).In(apperrors.LayerService).Code(apperrors.CodeValidation).Wrap(err)
+	}
+
+	err := basedomain.Atomically(ctx, s.uow, s.tx, s.withTx, func(txSvc service) error {
+		transfer, err := txSvc.findTransfer(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if input.Amount.Valid || input.ToAmount.Valid || input.Date != nil {
+			for _, leg := range []port.Transaction{transfer.Outflow, transfer.Inflow} {
+				if err := checkNotReconciled(ctx, leg); err != nil {
+					return err
+				}
+			}
+		}
+
+		outflow := port.UpdateTransaction{Description: input.Description, Date: input.Date}
+		inflow := port.UpdateTransaction{Description: input.Description, Date: input.Date}
+
+		if input.Amount.Valid || input.ToAmount.Valid {
+			from, to, err := txSvc.transferAccounts(ctx, transfer.Outflow.AccountID, transfer.Inflow.AccountID)
+			if err != nil {
+				return err
+			}
+
+			sent, received, err := transferAmounts(from.CurrencyCode == to.CurrencyCode, input.Amount, input.ToAmount, -transfer.Outflow.Amount, transfer.Inflow.Amount)
+			if err != nil {
+				return err
+			}
+
+			outflow.Amount = null.IntFrom(-sent)
+			inflow.Amount = null.IntFrom(received)
+		}
+
+		if err := txSvc.repo.Update(ctx, outflow, dafi.FilterBy("id", dafi.Equal, transfer.Outflow.ID)...); err != nil {
+			return err
+		}
+
+		if err := txSvc.repo.Update(ctx, inflow, dafi.FilterBy("id", dafi.Equal, transfer.Inflow.ID)...); err != nil {
+			return err
+		}
+
+		deltas := balanceDeltas{}
+		if outflow.Amount.Valid {
+			deltas.add(transfer.Outflow.AccountID, outflow.Amount.Int64-transfer.Outflow.Amount)
+			deltas.add(transfer.Inflow.AccountID, inflow.Amount.Int64-transfer.Inflow.Amount)
+		}
+
+		return txSvc.applyBalances(ctx, deltas)
+	})
+	if err != nil {
+		return oops.WithContext(ctx).In(apperrors.LayerService).Wrap(err)
+	}
+
+	s.logger.WithContext(ctx).Info("transfer updated", "transferId", id)
+
+	return nil
+}
+
+func (s service) DeleteTransfer(ctx context.Context, id uuid.UUID) error {
+	var keys []string
+	err := basedomain.Atomically(ctx, s.uow, s.tx, s.withTx, func(txSvc service) error {
+		transfer, err := txSvc.findTransfer(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		for _, leg := range []port.Transaction{transfer.Outflow, transfer.Inflow} {
+			if err := checkNotReconciled(ctx, leg); err != nil {
+				return err
+			}
+		}
+
+		keys, err = txSvc.attachments.Detach(ctx, []uuid.UUID{transfer.Outflow.ID, transfer.Inflow.ID})
+		if err != nil {
+			return err
+		}
+
+		if err := txSvc.repo.Delete(ctx, dafi.FilterBy("transferId", dafi.Equal, id)...); err != nil {
+			return err
+		}
+
+		deltas := balanceDeltas{}
+		deltas.add(transfer.Outflow.AccountID, -transfer.Outflow.Amount)
+		deltas.add(transfer.Inflow.AccountID, -transfer.Inflow.Amount)
+
+		return txSvc.applyBalances(ctx, deltas)
+	})
+	if err != nil {
+		return oops.WithContext(ctx).In(apperrors.LayerService).Wrap(err)
+	}
+
+	s.attachments.Purge(ctx, keys)
+
+	s.logger.WithContext(ctx).Info("transfer deleted", "transferId", id)
+
+	return nil
+}
+
+func (s service) findTransfer(ctx context.Context, id uuid.UUID) (port.Transfer, error) {
+	legs, err := s.repo.FindAll(ctx, dafi.Where("transferId", dafi.Equal, id))
+	if err != nil {
+		return port.Transfer{}, err
+	}
+
+	if len(legs) != 2 {
+		return port.Transfer{}, oops.WithContext(ctx).In(apperrors.LayerService).
+			Code(apperrors.CodeNotFound).
+			Errorf("transfer %s has %d leg(s)", id, len(legs))
+	}
+
+	transfer := port.Transfer{ID: id, OrganizationID: legs[0].OrganizationID}
+	for _, leg := range legs {
+		if leg.Amount < 0 {
+			transfer.Outflow = leg
+		} else {
+			transfer.Inflow = leg
+		}
+	}
+
+	return transfer, nil
+}
+
+// counterpartUpdate mirrors an update of one transfer leg onto the other leg and adds
+// the resulting balance change to deltas. A reconciled counterpart keeps its date and
+// amount. Amounts are only mirrored between accounts in the same currency; the two
+// amounts of a cross-currency transfer are changed together through UpdateTransfer.
+// Transfers move money between accounts and are left out of the budgets, so a leg
+// cannot be categorized, budgeted or split.
+func (s service) counterpartUpdate(ctx context.Context, leg port.Transaction, input port.UpdateTransaction, deltas balanceDeltas) (port.UpdateTransaction, error) {
+	if input.CategoryID != nil || input.SubcategoryID != nil || input.BudgetID != nil || len(input.Splits) > 0 {
+		return port.UpdateTransaction{}, oops.WithContext(ctx).In(apperrors.LayerService).
+			Code(apperrors.CodeValidation).
+			Public("A transfer cannot be categorized, budgeted or split.").
+			Errorf("transaction %s is part of transfer %s", leg.ID, *leg.TransferID)
+	}
+
+	if input.Type.Valid && input.Type.String != port.TypeTransfer {
+		return port.UpdateTransaction{}, oops.WithContext(ctx).In(apperrors.LayerService).
+			Code(apperrors.CodeConflict).
+			Public("The type of a transfer cannot be changed.").
+			Errorf("transaction %s is part of transfer %s", leg.ID, *leg.TransferID)
+	}
+
+	update := port.UpdateTransaction{Description: input.Description, Date: input.Date}
+	if !input.Amount.Valid && input.Date == nil {
+		return update, nil
+	}
+
+	if input.Amount.Valid && ((input.Amount.Int64 < 0) != (leg.Amount < 0) || input.Amount.Int64 == 0) {
+		return port.UpdateTransaction{}, oops.WithContext(ctx).In(apperrors.LayerService).
+			Code(apperrors.CodeValidation).
+			Public("The amount of a transfer leg cannot change sign.").
+			Errorf("amount %d changes the direction of transaction %s", input.Amount.Int64, leg.ID)
+	}
+
+	counterpart, err := s.repo.FindOne(ctx, dafi.Where("id", dafi.Equal, *leg.CounterpartID))
+	if err != nil {
+		return port.UpdateTransaction{}, err
+	}
+
+	if err := checkNotReconciled(ctx, counterpart); err != nil {
+		return port.UpdateTransaction{}, err
+	}
+
+	if !input.Amount.Valid {
+		return update, nil
+	}
+
+	account, counterpartAccount, err := s.transferAccounts(ctx, leg.AccountID, counterpart.AccountID)
+	if err != nil {
+		return port.UpdateTransaction{}, err
+	}
+
+	if account.CurrencyCode != counterpartAccount.CurrencyCode {
+		return port.UpdateTransaction{}, oops.WithContext(ctx).In(apperrors.LayerService).
+			Code(apperrors.CodeConflict).
+			Public("The amounts of a transfer between currencies must be changed on the transfer.").
+			Errorf("transfer %s is between accounts in different currencies", *leg.TransferID)
+	}
+
+	update.Amount = null.IntFrom(-input.Amount.Int64)
+	deltas.add(counterpart.AccountID, update.Amount.Int64-counterpart.Amount)
+
+	return update, nil
+}
+
+// transferAccounts loads the two accounts of a transfer.
+func (s service) transferAccounts(ctx context.Context, fromAccountID, toAccountID uuid.UUID) (accountport.Account, accountport.Account, error) {
+	from, err := s.accountRepo.FindOne(ctx, dafi.Where("id", dafi.Equal, fromAccountID))
+	if err != nil {
+		return accountport.Account{}, accountport.Account{}, err
+	}
+
+	to, err := s.accountRepo.FindOne(ctx, dafi.Where("id", dafi.Equal, toAccountID))
+	if err != nil {
+		return accountport.Account{}, accountport.Account{}, err
+	}
+
+	return from, to, nil
+}
+
+// checkTransferAccounts accepts a transfer only between two distinct, active accounts
+// of the organization.
+func checkTransferAccounts(ctx context.Context, organizationID string, from, to accountport.Account) error {
+	if from.ID == to.ID {
+		return oops.WithContext(ctx).In(apperrors.LayerService).
+			Code(apperrors.CodeValidation).
+			Public("A transfer needs two different accounts.").
+			Errorf("transfer from account %s to itself", from.ID)
+	}
+
+	for _, account := range []accountport.Account{from, to} {
+		if account.OrganizationID != organizationID {
+			return oops.WithContext(ctx).In(apperrors.LayerService).
+				Code(apperrors.CodeValidation).
+				Public("Both accounts of a transfer must belong to the organization.").
+				Errorf("account %s does not belong to organization %s", account.ID, organizationID)
+		}
+
+		if !account.IsActive {
+			return oops.WithContext(ctx).In(apperrors.LayerService).
+				Code(apperrors.CodeValidation).
+				Public("Both accounts of a transfer must be active.").
+				Errorf("account %s is inactive", account.ID)
+		}
+	}
+
+	return nil
+}
+
+// transferAmounts resolves the amount sent from the source account and the amount
+// received on the destination account, both positive. Amounts that are not given
+// keep their current value. Between accounts in the same currency both are always
+// equal; otherwise the received amount must be known.
+func transferAmounts(sameCurrency bool, amount, toAmount null.Int, sent, received int64) (int64, int64, error) {
+	if amount.Valid {
+		sent = amount.Int64
+	}
+
+	if !sameCurrency {
+		if toAmount.Valid {
+			received = toAmount.Int64
+		}
+
+		if received <= 0 {
+			return 0, 0, oops.In(apperrors.LayerService).
+				Code(apperrors.CodeValidation).
+				Public("The received amount is required for a transfer between currencies.").
+				Errorf("toAmount is required between accounts in different currencies")
+		}
+
+		return sent, received, nil
+	}
+
+	if toAmount.Valid {
+		if amount.Valid && toAmount.Int64 != amount.Int64 {
+			return 0, 0, oops.In(apperrors.LayerService).
+				Code(apperrors.CodeValidation).
+				Public("The received amount must equal the amount for a transfer in one currency.").
+				Errorf("toAmount %d differs from amount %d in the same currency", toAmount.Int64, amount.Int64)
+		}
+		sent = toAmount.Int64
+	}
+
+	return sent, sent, nil
+}
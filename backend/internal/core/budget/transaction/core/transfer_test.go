@@ -0,0 +1,252 @@
+package core
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"backend/core/budget/transaction/port"
+	"backend/infra/dafi"
+	apperrors "backend/port/errors"
+
+	"github.com/google/uuid"
+	"github.com/guregu/null/v6"
+	"github.com/samber/oops"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestService_CreateTransfer(t *testing.T) {
+	f := newFixture("EUR", "USD")
+	svc, repo, from, to := f.svc, f.repo, f.from, f.to
+	id := uuid.New()
+
+	err := svc.CreateTransfer(context.Background(), port.CreateTransfer{
+		ID:             id,
+		OrganizationID: "org_1",
+		FromAccountID:  from,
+		ToAccountID:    to,
+		Amount:         10000,
+		ToAmount:       null.IntFrom(10850),
+		Date:           time.Date(2026, time.April, 18, 0, 0, 0, 0, time.UTC),
+	})
+	require.NoError(t, err)
+
+	transfer, err := svc.FindTransfer(context.Background(), id)
+	require.NoError(t, err)
+	assert.Len(t, repo.txns, 2)
+	assert.Equal(t, from, transfer.Outflow.AccountID)
+	assert.Equal(t, int64(-10000), transfer.Outflow.Amount)
+	assert.Equal(t, to, transfer.Inflow.AccountID)
+	assert.Equal(t, int64(10850), transfer.Inflow.Amount)
+	assert.Equal(t, transfer.Inflow.ID, *transfer.Outflow.CounterpartID)
+	assert.Equal(t, transfer.Outflow.ID, *transfer.Inflow.CounterpartID)
+	assert.Equal(t, port.TypeTransfer, transfer.Outflow.Type)
+	assert.Equal(t, int64(-10000), f.accounts.balances[from])
+	assert.Equal(t, int64(10850), f.accounts.balances[to])
+}
+
+func TestService_CreateTransferRequiresToAmountBetweenCurrencies(t *testing.T) {
+	f := newFixture("EUR", "USD")
+	svc, repo, from, to := f.svc, f.repo, f.from, f.to
+
+	err := svc.CreateTransfer(context.Background(), port.CreateTransfer{
+		ID:             uuid.New(),
+		OrganizationID: "org_1",
+		FromAccountID:  from,
+		ToAccountID:    to,
+		Amount:         10000,
+		Date:           time.Date(2026, time.April, 18, 0, 0, 0, 0, time.UTC),
+	})
+	require.Error(t, err)
+
+	oopsErr, ok := oops.AsOops(err)
+	require.True(t, ok)
+	assert.Equal(t, apperrors.CodeValidation, oopsErr.Code())
+	assert.Empty(t, repo.txns)
+}
+
+func TestService_CreateTransferChecksAccounts(t *testing.T) {
+	date := time.Date(2026, time.April, 18, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name  string
+		setup func(f fixture) port.CreateTransfer
+	}{
+		{
+			name: "same account",
+			setup: func(f fixture) port.CreateTransfer {
+				return port.CreateTransfer{FromAccountID: f.from, ToAccountID: f.from}
+			},
+		},
+		{
+			name: "account of another organization",
+			setup: func(f fixture) port.CreateTransfer {
+				f.accounts.organizations[f.to] = "org_2"
+				return port.CreateTransfer{FromAccountID: f.from, ToAccountID: f.to}
+			},
+		},
+		{
+			name: "inactive account",
+			setup: func(f fixture) port.CreateTransfer {
+				f.accounts.inactive[f.from] = true
+				return port.CreateTransfer{FromAccountID: f.from, ToAccountID: f.to}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFixture("EUR", "EUR")
+			input := tt.setup(f)
+			input.ID, input.OrganizationID, input.Amount, input.Date = uuid.New(), "org_1", 10000, date
+
+			err := f.svc.CreateTransfer(context.Background(), input)
+			requireCode(t, err, apperrors.CodeValidation)
+			assert.Empty(t, f.repo.txns)
+			assert.Empty(t, f.accounts.balances)
+		})
+	}
+}
+
+func TestService_UpdateMirrorsCounterpart(t *testing.T) {
+	f := newFixture("EUR", "EUR")
+	svc, repo, from, to := f.svc, f.repo, f.from, f.to
+	id := uuid.New()
+	require.NoError(t, svc.CreateTransfer(context.Background(), port.CreateTransfer{
+		ID:             id,
+		OrganizationID: "org_1",
+		FromAccountID:  from,
+		ToAccountID:    to,
+		Amount:         5000,
+		Date:           time.Date(2026, time.April, 18, 0, 0, 0, 0, time.UTC),
+	}))
+
+	date := time.Date(2026, time.April, 20, 0, 0, 0, 0, time.UTC)
+	outflowID := legID(id, outflowLeg)
+	err := svc.Update(context.Background(), port.UpdateTransaction{
+		Amount:      null.IntFrom(-7500),
+		Description: null.StringFrom("Savings"),
+		Date:        &date,
+	}, dafi.FilterBy("id", dafi.Equal, outflowID)...)
+	require.NoError(t, err)
+
+	inflow := repo.txns[legID(id, inflowLeg)]
+	assert.Equal(t, int64(7500), inflow.Amount)
+	assert.Equal(t, "Savings", inflow.Description.String)
+	assert.Equal(t, date, inflow.Date)
+	assert.Equal(t, int64(-7500), repo.txns[outflowID].Amount)
+	assert.Equal(t, int64(-7500), f.accounts.balances[from])
+	assert.Equal(t, int64(7500), f.accounts.balances[to])
+}
+
+func TestService_UpdateRejectsCategorizingTransferLeg(t *testing.T) {
+	f := newFixture("EUR", "EUR")
+	id := uuid.New()
+	require.NoError(t, f.svc.CreateTransfer(context.Background(), port.CreateTransfer{
+		ID:             id,
+		OrganizationID: "org_1",
+		FromAccountID:  f.from,
+		ToAccountID:    f.to,
+		Amount:         5000,
+		Date:           time.Date(2026, time.April, 18, 0, 0, 0, 0, time.UTC),
+	}))
+
+	categoryID, budgetID := uuid.New(), uuid.New()
+	for name, input := range map[string]port.UpdateTransaction{
+		"category":    {CategoryID: &categoryID},
+		"subcategory": {SubcategoryID: &categoryID},
+		"budget":      {BudgetID: &budgetID},
+	} {
+		t.Run(name, func(t *testing.T) {
+			err := f.svc.Update(context.Background(), input, dafi.FilterBy("id", dafi.Equal, legID(id, outflowLeg))...)
+			requireCode(t, err, apperrors.CodeValidation)
+		})
+	}
+
+	outflow := f.repo.txns[legID(id, outflowLeg)]
+	assert.Nil(t, outflow.CategoryID)
+	assert.Nil(t, outflow.BudgetID)
+}
+
+func TestService_UpdateRejectsCrossCurrencyAmount(t *testing.T) {
+	f := newFixture("EUR", "USD")
+	svc, repo, from, to := f.svc, f.repo, f.from, f.to
+	id := uuid.New()
+	require.NoError(t, svc.CreateTransfer(context.Background(), port.CreateTransfer{
+		ID:             id,
+		OrganizationID: "org_1",
+		FromAccountID:  from,
+		ToAccountID:    to,
+		Amount:         10000,
+		ToAmount:       null.IntFrom(10850),
+		Date:           time.Date(2026, time.April, 18, 0, 0, 0, 0, time.UTC),
+	}))
+
+	err := svc.Update(context.Background(), port.UpdateTransaction{Amount: null.IntFrom(12000)},
+		dafi.FilterBy("id", dafi.Equal, legID(id, inflowLeg))...)
+	require.Error(t, err)
+
+	oopsErr, ok := oops.AsOops(err)
+	require.True(t, ok)
+	assert.Equal(t, apperrors.CodeConflict, oopsErr.Code())
+	assert.Equal(t, int64(10850), repo.txns[legID(id, inflowLeg)].Amount)
+}
+
+func TestService_DeleteRemovesBothLegs(t *testing.T) {
+	f := newFixture("EUR", "EUR")
+	svc, repo, from, to := f.svc, f.repo, f.from, f.to
+	id := uuid.New()
+	require.NoError(t, svc.CreateTransfer(context.Background(), port.CreateTransfer{
+		ID:             id,
+		OrganizationID: "org_1",
+		FromAccountID:  from,
+		ToAccountID:    to,
+		Amount:         5000,
+		Date:           time.Date(2026, time.April, 18, 0, 0, 0, 0, time.UTC),
+	}))
+
+	f.attachments.keys[legID(id, outflowLeg)] = []string{"org_1/outflow/receipt"}
+	f.attachments.keys[legID(id, inflowLeg)] = []string{"org_1/inflow/receipt"}
+
+	err := svc.Delete(context.Background(), dafi.FilterBy("id", dafi.Equal, legID(id, inflowLeg))...)
+	require.NoError(t, err)
+	assert.Empty(t, repo.txns)
+	assert.Zero(t, f.accounts.balances[from])
+	assert.Zero(t, f.accounts.balances[to])
+	assert.ElementsMatch(t, []string{"org_1/outflow/receipt", "org_1/inflow/receipt"}, f.attachments.purged)
+}
+
+func TestTransferAmounts(t *testing.T) {
+	tests := []struct {
+		name           string
+		sameCurrency   bool
+		amount         null.Int
+		toAmount       null.Int
+		sent, received int64
+		wantSent       int64
+		wantReceived   int64
+		wantErr        bool
+	}{
+		{name: "same currency", sameCurrency: true, amount: null.IntFrom(100), wantSent: 100, wantReceived: 100},
+		{name: "same currency matching toAmount", sameCurrency: true, amount: null.IntFrom(100), toAmount: null.IntFrom(100), wantSent: 100, wantReceived: 100},
+		{name: "same currency differing toAmount", sameCurrency: true, amount: null.IntFrom(100), toAmount: null.IntFrom(90), wantErr: true},
+		{name: "same currency toAmount only", sameCurrency: true, toAmount: null.IntFrom(90), sent: 100, received: 100, wantSent: 90, wantReceived: 90},
+		{name: "cross currency", amount: null.IntFrom(100), toAmount: null.IntFrom(108), wantSent: 100, wantReceived: 108},
+		{name: "cross currency missing toAmount", amount: null.IntFrom(100), wantErr: true},
+		{name: "cross currency keeps received", amount: null.IntFrom(120), sent: 100, received: 108, wantSent: 120, wantReceived: 108},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sent, received, err := transferAmounts(tt.sameCurrency, tt.amount, tt.toAmount, tt.sent, tt.received)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantSent, sent)
+			assert.Equal(t, tt.wantReceived, received)
+		})
+	}
+}
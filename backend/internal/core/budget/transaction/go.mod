@@ -5,6 +5,12 @@ go 1.24.0
 toolchain go1.24.12
 
 require (
+	backend/core/budget/account v0.0.0
+	backend/core/budget/categorization_rule v0.0.0
+	backend/core/budget/currency v0.0.0
+	backend/core/budget/organization_currency v0.0.0
+	backend/core/budget/payee v0.0.0
+	backend/infra/money v0.0.0
 	github.com/google/uuid v1.6.0
 	github.com/guregu/null/v6 v6.0.0
 	github.com/jackc/pgx/v5 v5.8.0
@@ -13,6 +19,18 @@ require (
 	github.com/samber/oops v1.21.0
 )
 
+replace backend/core/budget/account => ../account
+
+replace backend/core/budget/categorization_rule => ../categorization_rule
+
+replace backend/core/budget/currency => ../currency
+
+replace backend/core/budget/organization_currency => ../organization_currency
+
+replace backend/core/budget/payee => ../payee
+
+replace backend/infra/money => ../../../../pkg/money
+
 require (
 	github.com/jackc/pgpassfile v1.0.0 // indirect
 	github.com/jackc/pgservicefile v0.0.0-20240606120523-5a60cdf6a761 // indirect
@@ -1,6 +1,10 @@
 package transaction
 
 import (
+	accountport "backend/core/budget/account/port"
+	categorizationruleport "backend/core/budget/categorization_rule/port"
+	organizationcurrencyport "backend/core/budget/organization_currency/port"
+	payeeport "backend/core/budget/payee/port"
 	"backend/core/budget/transaction/adapter/handler"
 	"backend/core/budget/transaction/adapter/postgres"
 	"backend/core/budget/transaction/core"
@@ -20,8 +24,14 @@ func Module(i do.Injector) {
 
 	di.Provide(i, func(i do.Injector) (port.Service, error) {
 		repo := di.MustInvoke[port.Repository](i)
+		accountRepository := di.MustInvoke[accountport.Repository](i)
+		organizationCurrencyService := di.MustInvoke[organizationcurrencyport.Service](i)
+		categorizationRuleService := di.MustInvoke[categorizationruleport.Service](i)
+		payeeService := di.MustInvoke[payeeport.Service](i)
+		attachmentRemover := di.MustInvoke[port.AttachmentRemover](i)
+		uow := di.MustInvoke[basedomain.UnitOfWork](i)
 		logger := di.MustInvoke[basedomain.Logger](i)
-		return core.New(repo, logger), nil
+		return core.New(repo, accountRepository, organizationCurrencyService, categorizationRuleService, payeeService, attachmentRemover, uow, logger), nil
 	})
 
 	di.Provide(i, func(i do.Injector) (handler.HTTP, error) {
@@ -2,50 +2,217 @@ package port
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"backend/adapter/validation"
+	"backend/infra/money"
 	"github.com/google/uuid"
 	"github.com/guregu/null/v6"
 )
 
+// TypeTransfer is the type of both legs of a transfer.
+const TypeTransfer = "transfer"
+
+// Statuses of a transaction: pending until the bank posts it, cleared once it shows
+// on a bank statement and reconciled once a finalized reconciliation locked it.
+// Reconciled is only set through a reconciliation and left through Unlock.
+const (
+	StatusPending    = "pending"
+	StatusCleared    = "cleared"
+	StatusReconciled = "reconciled"
+)
+
+// CreateTransaction records a transaction. OriginalCurrencyCode and OriginalAmount
+// record a charge made in another currency than the account's; Amount may then be left
+// out and is converted from the original amount with ExchangeRate, or with the
+// organization rates when no rate is given; an Amount given without a rate sets the
+// rate it implies. A transaction that looks like one already recorded is refused with
+// a [DuplicateError] unless AllowDuplicate is set.
 type CreateTransaction struct {
-	ID                      uuid.UUID   `json:"id"`
-	OrganizationID          string      `json:"organizationId"`
-	AccountID               uuid.UUID   `json:"accountId"`
-	CategoryID              *uuid.UUID  `json:"categoryId"`
-	SubcategoryID           *uuid.UUID  `json:"subcategoryId"`
-	BudgetID                *uuid.UUID  `json:"budgetId"`
-	Type                    string      `json:"type"`
-	Amount                  int64       `json:"amount"`
-	Description             null.String `json:"description"`
-	ExternalReferenceNumber null.String `json:"externalReferenceNumber"`
-	Date                    time.Time   `json:"date"`
+	ID                      uuid.UUID              `json:"id"`
+	OrganizationID          string                 `json:"organizationId"`
+	AccountID               uuid.UUID              `json:"accountId"`
+	CategoryID              *uuid.UUID             `json:"categoryId"`
+	SubcategoryID           *uuid.UUID             `json:"subcategoryId"`
+	BudgetID                *uuid.UUID             `json:"budgetId"`
+	PayeeID                 *uuid.UUID             `json:"payeeId"`
+	Type                    string                 `json:"type"`
+	Amount                  int64                  `json:"amount"`
+	OriginalCurrencyCode    null.String            `json:"originalCurrencyCode"`
+	OriginalAmount          null.Int               `json:"originalAmount"`
+	ExchangeRate            money.NullExchangeRate `json:"exchangeRate"`
+	Description             null.String            `json:"description"`
+	ExternalReferenceNumber null.String            `json:"externalReferenceNumber"`
+	Date                    time.Time              `json:"date"`
+	Status                  string                 `json:"status"`
+	Splits                  []SplitLine            `json:"splits"`
+	TagIDs                  []uuid.UUID            `json:"tagIds"`
+	AllowDuplicate          bool                   `json:"allowDuplicate"`
+	TransferID              *uuid.UUID             `json:"-"`
+	CounterpartID           *uuid.UUID             `json:"-"`
+}
+
+// IsForeign reports whether the transaction was charged in another currency than its
+// account's.
+func (c CreateTransaction) IsForeign() bool {
+	return c.OriginalCurrencyCode.Valid
+}
+
+// DuplicateError reports the recorded transactions a new one seems to duplicate.
+type DuplicateError struct {
+	DuplicateIDs []uuid.UUID
+}
+
+func (e DuplicateError) Error() string {
+	return fmt.Sprintf("transaction looks like %d recorded transactions: %v", len(e.DuplicateIDs), e.DuplicateIDs)
+}
+
+// ProblemExtensions lists the suspected duplicates in the problem details of the
+// conflict.
+func (e DuplicateError) ProblemExtensions() map[string]any {
+	return map[string]any{"duplicateIds": e.DuplicateIDs}
 }
 
 func (c CreateTransaction) Validate(ctx context.Context) error {
-	return validation.ValidateStruct(ctx, &c,
+	split := len(c.Splits) > 0
+	foreign := c.IsForeign()
+
+	err := validation.ValidateStruct(ctx, &c,
 		validation.Field(&c.ID, validation.Required, validation.IsUUID),
 		validation.Field(&c.AccountID, validation.Required, validation.IsUUID),
+		validation.Field(&c.CategoryID, validation.When(split, validation.Nil)),
+		validation.Field(&c.SubcategoryID, validation.When(split, validation.Nil)),
 		validation.Field(&c.Type, validation.Required, validation.Length(1, 20)),
-		validation.Field(&c.Amount, validation.Required),
+		validation.Field(&c.Amount, validation.When(!foreign, validation.Required)),
+		validation.Field(&c.OriginalCurrencyCode, validation.Length(3, 3)),
+		validation.Field(&c.OriginalAmount, validation.When(foreign, validation.Required), validation.When(!foreign, validation.Nil)),
+		validation.Field(&c.ExchangeRate, validation.When(!foreign, validation.Nil)),
 		validation.Field(&c.Date, validation.Required),
+		validation.Field(&c.Status, validation.In(StatusPending, StatusCleared)),
 	)
+	if err != nil || !split || c.Amount == 0 {
+		return err
+	}
+
+	return ValidateSplits(c.Amount, c.Splits)
 }
 
 type UpdateTransaction struct {
-	CategoryID              *uuid.UUID  `json:"categoryId"`
-	SubcategoryID           *uuid.UUID  `json:"subcategoryId"`
-	BudgetID                *uuid.UUID  `json:"budgetId"`
-	Type                    null.String `json:"type"`
-	Amount                  null.Int    `json:"amount"`
-	Description             null.String `json:"description"`
-	ExternalReferenceNumber null.String `json:"externalReferenceNumber"`
-	Date                    *time.Time  `json:"date"`
+	CategoryID              *uuid.UUID             `json:"categoryId"`
+	SubcategoryID           *uuid.UUID             `json:"subcategoryId"`
+	BudgetID                *uuid.UUID             `json:"budgetId"`
+	PayeeID                 *uuid.UUID             `json:"payeeId"`
+	Type                    null.String            `json:"type"`
+	Amount                  null.Int               `json:"amount"`
+	OriginalAmount          null.Int               `json:"originalAmount"`
+	ExchangeRate            money.NullExchangeRate `json:"exchangeRate"`
+	Description             null.String            `json:"description"`
+	ExternalReferenceNumber null.String            `json:"externalReferenceNumber"`
+	Date                    *time.Time             `json:"date"`
+	Status                  null.String            `json:"status"`
+	// Splits replaces the split lines when given; an empty list removes them.
+	Splits []SplitLine `json:"splits"`
+	// TagIDs replaces the tags when given; an empty list removes them.
+	TagIDs []uuid.UUID `json:"tagIds"`
+}
+
+// ChangesLedger reports whether the update touches what a reconciliation locks: the
+// type, amount, date or status of a transaction.
+func (u UpdateTransaction) ChangesLedger() bool {
+	return u.Type.Valid || u.ChangesConversion() || u.Date != nil || u.Status.Valid
+}
+
+// ChangesConversion reports whether the update touches the amount of a transaction,
+// directly or through the original amount and exchange rate it was converted from. On
+// a transaction charged in another currency the amount is then converted again, so
+// that it stays the original amount at the rate: a new amount without a rate sets the
+// rate it implies, and otherwise the amount follows the given or recorded rate.
+func (u UpdateTransaction) ChangesConversion() bool {
+	return u.Amount.Valid || u.OriginalAmount.Valid || u.ExchangeRate.Valid
 }
 
 func (u UpdateTransaction) Validate(ctx context.Context) error {
+	split := len(u.Splits) > 0
+
 	return validation.ValidateStruct(ctx, &u,
+		validation.Field(&u.CategoryID, validation.When(split, validation.Nil)),
+		validation.Field(&u.SubcategoryID, validation.When(split, validation.Nil)),
 		validation.Field(&u.Type, validation.NilOrNotEmpty, validation.Length(1, 20)),
+		validation.Field(&u.Status, validation.In(StatusPending, StatusCleared)),
+	)
+}
+
+// SplitLine is one line of a split transaction, in minor units of the account currency.
+type SplitLine struct {
+	CategoryID    *uuid.UUID  `json:"categoryId"`
+	SubcategoryID *uuid.UUID  `json:"subcategoryId"`
+	Amount        int64       `json:"amount"`
+	Memo          null.String `json:"memo"`
+}
+
+// ValidateSplits checks the split lines of a transaction of the given amount: there
+// are at least two of them, none is zero and together they add up to the amount.
+func ValidateSplits(amount int64, splits []SplitLine) error {
+	if len(splits) < 2 {
+		return fmt.Errorf("splits: a split transaction needs at least 2 lines, got %d", len(splits))
+	}
+
+	var total int64
+	for i, line := range splits {
+		if line.Amount == 0 {
+			return fmt.Errorf("splits: line %d has no amount", i+1)
+		}
+		if line.Memo.Valid && len(line.Memo.String) > 255 {
+			return fmt.Errorf("splits: the memo of line %d is longer than 255 characters", i+1)
+		}
+		total += line.Amount
+	}
+
+	if total != amount {
+		return fmt.Errorf("splits: lines add up to %d instead of the transaction amount %d", total, amount)
+	}
+
+	return nil
+}
+
+// CreateTransfer moves Amount, in minor units of the source account currency, from
+// FromAccountID to ToAccountID. ToAmount is the amount received in minor units of the
+// destination account currency; it is required when the two currencies differ.
+type CreateTransfer struct {
+	ID             uuid.UUID   `json:"id"`
+	OrganizationID string      `json:"organizationId"`
+	FromAccountID  uuid.UUID   `json:"fromAccountId"`
+	ToAccountID    uuid.UUID   `json:"toAccountId"`
+	Amount         int64       `json:"amount"`
+	ToAmount       null.Int    `json:"toAmount"`
+	Description    null.String `json:"description"`
+	Date           time.Time   `json:"date"`
+}
+
+func (c CreateTransfer) Validate(ctx context.Context) error {
+	return validation.ValidateStruct(ctx, &c,
+		validation.Field(&c.ID, validation.Required),
+		validation.Field(&c.FromAccountID, validation.Required),
+		validation.Field(&c.ToAccountID, validation.Required, validation.NotIn(c.FromAccountID)),
+		validation.Field(&c.Amount, validation.Required, validation.Min(int64(1))),
+		validation.Field(&c.ToAmount, validation.Min(int64(1))),
+		validation.Field(&c.Date, validation.Required),
+	)
+}
+
+// UpdateTransfer changes both legs of a transfer. Amount and ToAmount follow the same
+// rules as in CreateTransfer.
+type UpdateTransfer struct {
+	Amount      null.Int    `json:"amount"`
+	ToAmount    null.Int    `json:"toAmount"`
+	Description null.String `json:"description"`
+	Date        *time.Time  `json:"date"`
+}
+
+func (u UpdateTransfer) Validate(ctx context.Context) error {
+	return validation.ValidateStruct(ctx, &u,
+		validation.Field(&u.Amount, validation.Min(int64(1))),
+		validation.Field(&u.ToAmount, validation.Min(int64(1))),
 	)
 }
@@ -2,7 +2,9 @@ package port
 
 import (
 	"context"
+	"time"
 
+	"backend/infra/dafi"
 	basedomain "backend/port"
 
 	"github.com/google/uuid"
@@ -14,10 +16,53 @@ type Repository interface {
 	basedomain.RepositoryTx[Repository]
 	CountByAccountID(ctx context.Context, accountID uuid.UUID) (int64, error)
 	ExistsForOrganization(ctx context.Context, organizationID string) (bool, error)
+	// ReplaceSplits swaps the split lines of a transaction for the given ones. A
+	// transaction that ends up split loses its own category.
+	ReplaceSplits(ctx context.Context, transactionID uuid.UUID, organizationID string, splits []SplitLine) error
+	// ReplaceTags swaps the tags of a transaction for the given ones. Tags of another
+	// organization are refused.
+	ReplaceTags(ctx context.Context, transactionID uuid.UUID, organizationID string, tagIDs []uuid.UUID) error
+	// MarkReconciled locks the cleared transactions of an account dated on or before
+	// through, and returns how many it locked.
+	MarkReconciled(ctx context.Context, accountID, reconciliationID uuid.UUID, through time.Time) (int64, error)
+	// Unlock turns a reconciled transaction back into a cleared one.
+	Unlock(ctx context.Context, id uuid.UUID) error
+	// Categorize sets the category, subcategory and budget of a transaction, clearing
+	// those given as nil.
+	Categorize(ctx context.Context, id uuid.UUID, categoryID uuid.UUID, subcategoryID, budgetID *uuid.UUID) error
 }
 
 type Service interface {
 	basedomain.UseCaseCommand[CreateTransaction, UpdateTransaction]
 	basedomain.UseCaseQuery[Transaction]
 	basedomain.UseCaseTx[Service]
+	CreateTransfer(ctx context.Context, input CreateTransfer) error
+	FindTransfer(ctx context.Context, id uuid.UUID) (Transfer, error)
+	UpdateTransfer(ctx context.Context, id uuid.UUID, input UpdateTransfer) error
+	DeleteTransfer(ctx context.Context, id uuid.UUID) error
+	// Unlock releases a reconciled transaction so it can be changed again. It becomes
+	// cleared and no longer counts as part of the reconciliation that locked it.
+	Unlock(ctx context.Context, id uuid.UUID) error
+	// Recategorize runs the categorization rules again over the transactions that
+	// meet criteria, which must name the organization with an organizationId equality
+	// filter. Transfer legs and split transactions are left alone, as are those no
+	// rule matches.
+	Recategorize(ctx context.Context, criteria dafi.Criteria) (Recategorization, error)
+	// FindDuplicates tells, for every input in order, the transactions CreateBulk
+	// would refuse it as a duplicate of: recorded ones, or earlier inputs that are not
+	// duplicates themselves, by their ID. It locks nothing, so it only previews what a
+	// create would do.
+	FindDuplicates(ctx context.Context, inputs basedomain.List[CreateTransaction]) ([][]uuid.UUID, error)
+}
+
+// AttachmentRemover removes the files attached to the transactions being deleted,
+// whose records would otherwise keep them from being deleted.
+type AttachmentRemover interface {
+	basedomain.UseCaseTx[AttachmentRemover]
+	// Detach drops the attachment records of the transactions and returns the keys of
+	// their content, to Purge once the deletion is committed.
+	Detach(ctx context.Context, transactionIDs []uuid.UUID) ([]string, error)
+	// Purge removes detached content. Content that cannot be removed is only logged,
+	// as nothing refers to it anymore.
+	Purge(ctx context.Context, keys []string)
 }
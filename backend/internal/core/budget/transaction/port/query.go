@@ -3,22 +3,74 @@ package port
 import (
 	"time"
 
+	"backend/infra/money"
 	"github.com/google/uuid"
 	"github.com/guregu/null/v6"
 )
 
+// Transaction is an entry on an account. OriginalCurrencyCode, OriginalAmount and
+// ExchangeRate are set on a transaction charged in another currency: Amount is
+// OriginalAmount converted at ExchangeRate, in units of the account currency per one
+// unit of the original currency.
 type Transaction struct {
-	ID                      uuid.UUID   `json:"id"`
-	OrganizationID          string      `json:"organizationId"`
-	AccountID               uuid.UUID   `json:"accountId"`
-	CategoryID              *uuid.UUID  `json:"categoryId"`
-	SubcategoryID           *uuid.UUID  `json:"subcategoryId"`
-	BudgetID                *uuid.UUID  `json:"budgetId"`
-	Type                    string      `json:"type"`
-	Amount                  int64       `json:"amount"`
-	Description             null.String `json:"description"`
-	ExternalReferenceNumber null.String `json:"externalReferenceNumber"`
-	Date                    time.Time   `json:"date"`
-	CreatedAt               time.Time   `json:"createdAt"`
-	UpdatedAt               time.Time   `json:"updatedAt"`
+	ID                      uuid.UUID              `json:"id"`
+	OrganizationID          string                 `json:"organizationId"`
+	AccountID               uuid.UUID              `json:"accountId"`
+	CategoryID              *uuid.UUID             `json:"categoryId"`
+	SubcategoryID           *uuid.UUID             `json:"subcategoryId"`
+	BudgetID                *uuid.UUID             `json:"budgetId"`
+	PayeeID                 *uuid.UUID             `json:"payeeId"`
+	Type                    string                 `json:"type"`
+	Amount                  int64                  `json:"amount"`
+	OriginalCurrencyCode    null.String            `json:"originalCurrencyCode"`
+	OriginalAmount          null.Int               `json:"originalAmount"`
+	ExchangeRate            money.NullExchangeRate `json:"exchangeRate"`
+	Description             null.String            `json:"description"`
+	ExternalReferenceNumber null.String            `json:"externalReferenceNumber"`
+	Date                    time.Time              `json:"date"`
+	TransferID              *uuid.UUID             `json:"transferId"`
+	CounterpartID           *uuid.UUID             `json:"counterpartId"`
+	Status                  string                 `json:"status"`
+	ReconciliationID        *uuid.UUID             `json:"reconciliationId"`
+	Splits                  []Split                `json:"splits"`
+	TagIDs                  []uuid.UUID            `json:"tagIds"`
+	CreatedAt               time.Time              `json:"createdAt"`
+	UpdatedAt               time.Time              `json:"updatedAt"`
+}
+
+// Split is one line of a transaction spread over several categories. The lines of a
+// transaction always add up to its amount.
+type Split struct {
+	ID            uuid.UUID   `json:"id"`
+	TransactionID uuid.UUID   `json:"transactionId"`
+	CategoryID    *uuid.UUID  `json:"categoryId"`
+	SubcategoryID *uuid.UUID  `json:"subcategoryId"`
+	Amount        int64       `json:"amount"`
+	Memo          null.String `json:"memo"`
+}
+
+// IsTransferLeg reports whether the transaction is one side of a transfer.
+func (t Transaction) IsTransferLeg() bool {
+	return t.TransferID != nil && t.CounterpartID != nil
+}
+
+// IsReconciled reports whether a finalized reconciliation locked the transaction.
+func (t Transaction) IsReconciled() bool {
+	return t.Status == StatusReconciled
+}
+
+// Transfer is a movement of money between two accounts of the organization. It is
+// stored as an outflow on the source account and an inflow on the destination account.
+type Transfer struct {
+	ID             uuid.UUID   `json:"id"`
+	OrganizationID string      `json:"organizationId"`
+	Outflow        Transaction `json:"outflow"`
+	Inflow         Transaction `json:"inflow"`
+}
+
+// Recategorization counts the transactions a run of the categorization rules matched,
+// and those of them it filed under another category, subcategory or budget.
+type Recategorization struct {
+	Matched int `json:"matched"`
+	Updated int `json:"updated"`
 }
@@ -11,3 +11,24 @@ type UnitOfWork interface {
 	Commit(ctx context.Context, tx Transaction) error
 	Rollback(ctx context.Context, tx Transaction) error
 }
+
+// Atomically runs fn on the value bind returns for a database transaction: tx when
+// the caller already runs inside one, and otherwise a transaction of uow that is
+// committed when fn succeeds and rolled back when it fails.
+func Atomically[T any](ctx context.Context, uow UnitOfWork, tx Transaction, bind func(Transaction) T, fn func(T) error) error {
+	if tx != nil {
+		return fn(bind(tx))
+	}
+
+	tx, err := uow.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = uow.Rollback(ctx, tx) }()
+
+	if err := fn(bind(tx)); err != nil {
+		return err
+	}
+
+	return uow.Commit(ctx, tx)
+}
@@ -17,6 +17,8 @@ const (
 	Like           FilterOperator = "like"
 	In             FilterOperator = "in"
 	NotIn          FilterOperator = "nin"
+	Any            FilterOperator = "any" // a list field holds at least one of the values
+	All            FilterOperator = "all" // a list field holds every one of the values
 	Contains       FilterOperator = "contains"
 	NotContains    FilterOperator = "ncontains"
 	Is             FilterOperator = "is"
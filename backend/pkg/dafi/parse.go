@@ -35,6 +35,8 @@ func NewQueryParser() *QueryParser {
 			Like:           {},
 			In:             {},
 			NotIn:          {},
+			Any:            {},
+			All:            {},
 			Contains:       {},
 			NotContains:    {},
 			Is:             {},
@@ -191,7 +193,7 @@ func (p *QueryParser) parseFilter(field string, parts []string) (Filter, error)
 	chainingKey := p.determineChainingKey(parts)
 
 	var value any = parts[1]
-	if operator == In || operator == NotIn {
+	if operator == In || operator == NotIn || operator == Any || operator == All {
 		value = strings.Split(parts[1], ",")
 	}
 
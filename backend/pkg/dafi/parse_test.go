@@ -18,6 +18,8 @@ func TestQueryParser_Parse(t *testing.T) {
 		"like":      {},
 		"in":        {},
 		"nin":       {},
+		"any":       {},
+		"all":       {},
 		"contains":  {},
 		"ncontains": {},
 		"is":        {},
@@ -77,6 +79,21 @@ func TestQueryParser_Parse(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name:   "list operators split their values",
+			fields: fields{operators: defaultOperators},
+			args: args{values: url.Values{
+				"labels": []string{"any:red,blue"},
+				"tags":   []string{"all:home,work"},
+			}},
+			want: Criteria{
+				Filters: Filters{
+					{Field: "labels", Operator: "any", Value: []string{"red", "blue"}, ChainingKey: And},
+					{Field: "tags", Operator: "all", Value: []string{"home", "work"}, ChainingKey: And},
+				},
+			},
+			wantErr: false,
+		},
 		{
 			name:   "single relation",
 			fields: fields{operators: defaultOperators},
@@ -151,6 +151,13 @@ func oopsErrorToProblem(oopsErr oops.OopsError, instance string, mappings map[st
 		problem = problem.WithDetail("An unexpected error occurred")
 	}
 
+	var extender ProblemExtender
+	if status < 500 && errors.As(oopsErr, &extender) {
+		for key, value := range extender.ProblemExtensions() {
+			problem = problem.WithExtension(key, value)
+		}
+	}
+
 	return problem, status
 }
 
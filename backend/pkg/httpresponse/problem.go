@@ -29,6 +29,12 @@ type ProblemDetail struct {
 	Extensions map[string]any `json:"extensions,omitempty"`
 }
 
+// ProblemExtender is implemented by errors that carry extension members for the
+// problem details they are reported with, such as the IDs of conflicting resources.
+type ProblemExtender interface {
+	ProblemExtensions() map[string]any
+}
+
 // NewProblemDetail creates a new ProblemDetail with the given type, title, and status.
 func NewProblemDetail(problemType, title string, status int) ProblemDetail {
 	return ProblemDetail{
@@ -0,0 +1,36 @@
+package httpresponse
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/labstack/echo/v4"
+	"github.com/samber/oops"
+)
+
+// FormFile reads the file uploaded as the multipart field name, refusing one larger
+// than maxSize bytes. Parsing the form buffers the whole request body, so routes that
+// take uploads are also wrapped in a body limit that refuses oversized requests first.
+func FormFile(c echo.Context, name string, maxSize int64) (string, []byte, error) {
+	header, err := c.FormFile(name)
+	if err != nil {
+		return "", nil, err
+	}
+
+	file, err := header.Open()
+	if err != nil {
+		return "", nil, err
+	}
+	defer file.Close()
+
+	content, err := io.ReadAll(io.LimitReader(file, maxSize+1))
+	if err != nil {
+		return "", nil, err
+	}
+	if int64(len(content)) > maxSize {
+		return "", nil, oops.Public(fmt.Sprintf("The file is larger than %d MB.", maxSize>>20)).
+			Errorf("file %s exceeds %d bytes", header.Filename, maxSize)
+	}
+
+	return header.Filename, content, nil
+}
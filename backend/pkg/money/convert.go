@@ -0,0 +1,119 @@
+package money
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// maxDecimalPlaces bounds the decimal places of a [Currency] so that 10^n fits an int64.
+const maxDecimalPlaces = 18
+
+// Currency is the part of an ISO 4217 currency that conversions depend on: how many
+// decimal places its minor unit has (0 for JPY and CLP, 2 for USD, 3 for KWD, ...).
+type Currency struct {
+	Code          string
+	DecimalPlaces int16
+}
+
+// RoundingMode decides which way a conversion result that falls between two minor
+// units is rounded.
+type RoundingMode int
+
+const (
+	// RoundHalfUp rounds halves away from zero (2.5 -> 3, -2.5 -> -3).
+	RoundHalfUp RoundingMode = iota
+	// RoundHalfEven rounds halves to the nearest even minor unit (2.5 -> 2, 3.5 -> 4),
+	// also known as banker's rounding.
+	RoundHalfEven
+)
+
+// Convert converts amount in minor units of from into minor units of to, where rate is
+// units of to per one unit of from. The arithmetic is exact; only the final result is
+// rounded, using mode.
+func Convert(amount Minor, from, to Currency, rate ExchangeRate, mode RoundingMode) (Minor, error) {
+	if !rate.IsPositive() {
+		return 0, fmt.Errorf("money: exchange rate %s is not positive", rate)
+	}
+	for _, c := range []Currency{from, to} {
+		if c.DecimalPlaces < 0 || c.DecimalPlaces > maxDecimalPlaces {
+			return 0, fmt.Errorf("money: invalid decimal places %d for %s", c.DecimalPlaces, c.Code)
+		}
+	}
+
+	num := new(big.Int).Mul(big.NewInt(int64(amount)), big.NewInt(int64(rate)))
+	num.Mul(num, pow10(to.DecimalPlaces))
+	den := new(big.Int).Mul(big.NewInt(exchangeRateMultiplier), pow10(from.DecimalPlaces))
+
+	q, err := roundQuo(num, den, mode)
+	if err != nil {
+		return 0, fmt.Errorf("money: converting %d %s to %s: %w", amount, from.Code, to.Code, err)
+	}
+
+	return Minor(q), nil
+}
+
+// ImpliedRate returns the rate at which amount in minor units of from converts to
+// converted in minor units of to, rounding half away from zero at [ExchangeRateScale].
+// Both amounts must be non-zero and of the same sign.
+func ImpliedRate(amount Minor, from Currency, converted Minor, to Currency) (ExchangeRate, error) {
+	if amount == 0 || converted == 0 || (amount < 0) != (converted < 0) {
+		return 0, fmt.Errorf("money: %d %s and %d %s imply no positive rate", amount, from.Code, converted, to.Code)
+	}
+	for _, c := range []Currency{from, to} {
+		if c.DecimalPlaces < 0 || c.DecimalPlaces > maxDecimalPlaces {
+			return 0, fmt.Errorf("money: invalid decimal places %d for %s", c.DecimalPlaces, c.Code)
+		}
+	}
+
+	num := new(big.Int).Mul(new(big.Int).Abs(big.NewInt(int64(converted))), big.NewInt(exchangeRateMultiplier))
+	num.Mul(num, pow10(from.DecimalPlaces))
+	den := new(big.Int).Mul(new(big.Int).Abs(big.NewInt(int64(amount))), pow10(to.DecimalPlaces))
+
+	q, err := roundQuo(num, den, RoundHalfUp)
+	if err != nil {
+		return 0, fmt.Errorf("money: rate of %d %s to %s: %w", amount, from.Code, to.Code, err)
+	}
+	if q == 0 {
+		return 0, fmt.Errorf("money: rate of %d %s to %d %s rounds to zero", amount, from.Code, converted, to.Code)
+	}
+
+	return ExchangeRate(q), nil
+}
+
+// CrossRate derives the rate from one currency to another from their rates against a
+// common base currency, rounding half away from zero at [ExchangeRateScale].
+func CrossRate(from, to ExchangeRate) (ExchangeRate, error) {
+	if !from.IsPositive() {
+		return 0, fmt.Errorf("money: exchange rate %s is not positive", from)
+	}
+
+	num := new(big.Int).Mul(big.NewInt(int64(to)), big.NewInt(exchangeRateMultiplier))
+	q, err := roundQuo(num, big.NewInt(int64(from)), RoundHalfUp)
+	if err != nil {
+		return 0, fmt.Errorf("money: cross rate: %w", err)
+	}
+
+	return ExchangeRate(q), nil
+}
+
+func pow10(n int16) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+}
+
+// roundQuo divides num by a positive den and rounds the quotient with mode.
+func roundQuo(num, den *big.Int, mode RoundingMode) (int64, error) {
+	q, r := new(big.Int).QuoRem(num, den, new(big.Int))
+	if r.Sign() != 0 {
+		// Compare the remainder with half of den: cmp < 0 is below half, 0 is exactly half.
+		cmp := new(big.Int).Mul(new(big.Int).Abs(r), big.NewInt(2)).Cmp(den)
+		if cmp > 0 || (cmp == 0 && (mode == RoundHalfUp || q.Bit(0) == 1)) {
+			q.Add(q, big.NewInt(int64(num.Sign())))
+		}
+	}
+
+	if !q.IsInt64() {
+		return 0, fmt.Errorf("result %s is out of range", q)
+	}
+
+	return q.Int64(), nil
+}
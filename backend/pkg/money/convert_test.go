@@ -0,0 +1,97 @@
+package money
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func mustRate(t *testing.T, s string) ExchangeRate {
+	t.Helper()
+	r, err := ParseExchangeRate(s)
+	require.NoError(t, err)
+	return r
+}
+
+func TestConvert(t *testing.T) {
+	usd := Currency{Code: "USD", DecimalPlaces: 2}
+	eur := Currency{Code: "EUR", DecimalPlaces: 2}
+	jpy := Currency{Code: "JPY", DecimalPlaces: 0}
+	kwd := Currency{Code: "KWD", DecimalPlaces: 3}
+	btc := Currency{Code: "BTC", DecimalPlaces: 8}
+
+	tests := []struct {
+		name     string
+		amount   Minor
+		from, to Currency
+		rate     string
+		mode     RoundingMode
+		want     Minor
+	}{
+		{name: "same decimal places", amount: 10000, from: eur, to: usd, rate: "1.085", want: 10850},
+		{name: "half up rounds away from zero", amount: 5, from: eur, to: usd, rate: "0.5", want: 3},
+		{name: "half up negative", amount: -5, from: eur, to: usd, rate: "0.5", want: -3},
+		{name: "half even rounds down to even", amount: 5, from: eur, to: usd, rate: "0.5", mode: RoundHalfEven, want: 2},
+		{name: "half even rounds up to even", amount: 7, from: eur, to: usd, rate: "0.5", mode: RoundHalfEven, want: 4},
+		{name: "half even negative", amount: -5, from: eur, to: usd, rate: "0.5", mode: RoundHalfEven, want: -2},
+		{name: "half even above half", amount: 5, from: eur, to: usd, rate: "0.51", mode: RoundHalfEven, want: 3},
+		{name: "to fewer decimal places", amount: 1000, from: usd, to: jpy, rate: "150", want: 1500},
+		{name: "to more decimal places", amount: 1500, from: jpy, to: usd, rate: "0.0068", want: 1020},
+		{name: "three decimal places", amount: 1000, from: usd, to: kwd, rate: "0.307", want: 3070},
+		{name: "eight decimal places", amount: 6500000, from: usd, to: btc, rate: "0.0000153846", want: 99999900},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Convert(tt.amount, tt.from, tt.to, mustRate(t, tt.rate), tt.mode)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestConvert_invalid(t *testing.T) {
+	usd := Currency{Code: "USD", DecimalPlaces: 2}
+
+	_, err := Convert(100, usd, usd, 0, RoundHalfUp)
+	require.Error(t, err)
+
+	_, err = Convert(100, usd, Currency{Code: "XXX", DecimalPlaces: 19}, ExchangeRateOne(), RoundHalfUp)
+	require.Error(t, err)
+
+	_, err = Convert(Minor(1<<62), usd, Currency{Code: "BTC", DecimalPlaces: 8}, ExchangeRateOne(), RoundHalfUp)
+	require.Error(t, err)
+}
+
+func TestCrossRate(t *testing.T) {
+	rate, err := CrossRate(mustRate(t, "0.92"), mustRate(t, "150"))
+	require.NoError(t, err)
+	assert.Equal(t, mustRate(t, "163.0434782609"), rate)
+
+	_, err = CrossRate(0, ExchangeRateOne())
+	require.Error(t, err)
+}
+
+func TestImpliedRate(t *testing.T) {
+	usd := Currency{Code: "USD", DecimalPlaces: 2}
+	eur := Currency{Code: "EUR", DecimalPlaces: 2}
+	jpy := Currency{Code: "JPY", DecimalPlaces: 0}
+
+	rate, err := ImpliedRate(5000, eur, 5435, usd)
+	require.NoError(t, err)
+	assert.Equal(t, mustRate(t, "1.087"), rate)
+
+	rate, err = ImpliedRate(-1500, jpy, -1020, usd)
+	require.NoError(t, err)
+	assert.Equal(t, mustRate(t, "0.0068"), rate)
+
+	rate, err = ImpliedRate(3, usd, 1, usd)
+	require.NoError(t, err)
+	assert.Equal(t, mustRate(t, "0.3333333333"), rate)
+
+	for _, amounts := range [][2]Minor{{0, 100}, {100, 0}, {100, -100}} {
+		_, err := ImpliedRate(amounts[0], usd, amounts[1], usd)
+		assert.Error(t, err)
+	}
+}
@@ -2,25 +2,44 @@ package money
 
 import (
 	"fmt"
-	"math"
+	"math/big"
+	"regexp"
+	"strings"
 )
 
-// defaultMajorDecimalPlaces is used when a JSON number is interpreted as major units (e.g. dollars).
-// TODO: derive per currency from ISO 4217 when CreateAccount carries currency context end-to-end.
-const defaultMajorDecimalPlaces = 2
+// plainDecimal matches a signed decimal written out in full, e.g. "-12.34". It leaves
+// out the fractions ("1/3") and exponents big.Rat also parses: "1e9999999" alone
+// would build a number millions of bits long.
+var plainDecimal = regexp.MustCompile(`^[+-]?\d+(\.\d+)?$`)
 
-// majorFloatToMinor converts a major-unit floating amount (e.g. 12.34) to minor units
-// using half-up rounding at decimalPlaces.
-func majorFloatToMinor(f float64, decimalPlaces int) (Minor, error) {
-	if decimalPlaces < 0 || decimalPlaces > 18 {
+// parseDecimal parses s, surrounding spaces aside, as a plain decimal.
+func parseDecimal(s string) (*big.Rat, bool) {
+	s = strings.TrimSpace(s)
+	if !plainDecimal.MatchString(s) {
+		return nil, false
+	}
+	return new(big.Rat).SetString(s)
+}
+
+// ParseMajor converts a decimal amount in major units (e.g. "12.34" or "-40", without
+// exponent) into minor units of a currency with decimalPlaces
+// (0 for JPY and CLP, 2 for USD, ...). The string is parsed exactly; digits beyond
+// decimalPlaces are rounded half away from zero.
+func ParseMajor(s string, decimalPlaces int16) (Minor, error) {
+	if decimalPlaces < 0 || decimalPlaces > maxDecimalPlaces {
 		return 0, fmt.Errorf("money: invalid decimalPlaces %d", decimalPlaces)
 	}
 
-	factor := math.Pow10(decimalPlaces)
-	rounded := math.Round(f * factor)
-	if rounded > float64(math.MaxInt64) || rounded < float64(math.MinInt64) {
-		return 0, fmt.Errorf("money: amount out of int64 range")
+	r, ok := parseDecimal(s)
+	if !ok {
+		return 0, fmt.Errorf("money: %q is not a decimal amount", s)
+	}
+
+	scaled := r.Mul(r, new(big.Rat).SetInt(pow10(decimalPlaces)))
+	q, err := roundQuo(scaled.Num(), scaled.Denom(), RoundHalfUp)
+	if err != nil {
+		return 0, fmt.Errorf("money: amount %s: %w", s, err)
 	}
 
-	return Minor(int64(rounded)), nil
+	return Minor(q), nil
 }
@@ -4,8 +4,8 @@ import (
 	"database/sql/driver"
 	"encoding/json"
 	"fmt"
-	"math"
-	"strconv"
+	"math/big"
+	"strings"
 )
 
 // ExchangeRateScale is the number of fractional decimal digits stored for an
@@ -25,11 +25,6 @@ func ExchangeRateOne() ExchangeRate {
 	return ExchangeRate(exchangeRateMultiplier)
 }
 
-// Float64 returns the rate as a float for JSON APIs and display.
-func (r ExchangeRate) Float64() float64 {
-	return float64(r) / float64(exchangeRateMultiplier)
-}
-
 // IsOne reports whether r is exactly 1 (base currency row).
 func (r ExchangeRate) IsOne() bool {
 	return r == ExchangeRateOne()
@@ -40,21 +35,40 @@ func (r ExchangeRate) IsPositive() bool {
 	return int64(r) > 0
 }
 
-// ParseExchangeRate converts a JSON/API float to a fixed-scale [ExchangeRate].
-func ParseExchangeRate(f float64) (ExchangeRate, error) {
-	if math.IsNaN(f) || math.IsInf(f, 0) {
-		return 0, fmt.Errorf("money: exchange rate is not a finite number")
+// ParseExchangeRate converts a decimal string (e.g. "0.92", without exponent) to a
+// fixed-scale [ExchangeRate] without going through floats.
+// Digits beyond [ExchangeRateScale] are rounded half away from zero, like PostgreSQL
+// does when storing into NUMERIC(20,10).
+func ParseExchangeRate(s string) (ExchangeRate, error) {
+	r, ok := parseDecimal(s)
+	if !ok {
+		return 0, fmt.Errorf("money: %q is not a decimal exchange rate", s)
 	}
-	scaled := math.Round(f * float64(exchangeRateMultiplier))
-	if scaled > float64(math.MaxInt64) || scaled < float64(math.MinInt64) {
+	scaled := r.Mul(r, new(big.Rat).SetInt64(exchangeRateMultiplier))
+	q, err := roundQuo(scaled.Num(), scaled.Denom(), RoundHalfUp)
+	if err != nil {
 		return 0, fmt.Errorf("money: exchange rate out of range")
 	}
-	return ExchangeRate(int64(scaled)), nil
+	return ExchangeRate(q), nil
+}
+
+// String formats r as an exact decimal without trailing fractional zeros (e.g. "0.92").
+func (r ExchangeRate) String() string {
+	x := int64(r)
+	sign := ""
+	if x < 0 {
+		sign, x = "-", -x
+	}
+	frac := strings.TrimRight(fmt.Sprintf("%010d", x%exchangeRateMultiplier), "0")
+	if frac == "" {
+		return fmt.Sprintf("%s%d", sign, x/exchangeRateMultiplier)
+	}
+	return fmt.Sprintf("%s%d.%s", sign, x/exchangeRateMultiplier, frac)
 }
 
-// MarshalJSON encodes as a JSON number.
+// MarshalJSON encodes as an exact JSON number.
 func (r ExchangeRate) MarshalJSON() ([]byte, error) {
-	return json.Marshal(r.Float64())
+	return []byte(r.String()), nil
 }
 
 // UnmarshalJSON decodes a JSON number into a fixed-scale rate.
@@ -63,11 +77,11 @@ func (r *ExchangeRate) UnmarshalJSON(data []byte) error {
 		*r = 0
 		return nil
 	}
-	var f float64
-	if err := json.Unmarshal(data, &f); err != nil {
+	var n json.Number
+	if err := json.Unmarshal(data, &n); err != nil {
 		return fmt.Errorf("money: UnmarshalJSON ExchangeRate: %w", err)
 	}
-	parsed, err := ParseExchangeRate(f)
+	parsed, err := ParseExchangeRate(n.String())
 	if err != nil {
 		return fmt.Errorf("money: UnmarshalJSON ExchangeRate: %w", err)
 	}
@@ -90,14 +104,10 @@ func (r *ExchangeRate) Scan(src interface{}) error {
 	default:
 		return fmt.Errorf("money: cannot scan %T into ExchangeRate", src)
 	}
-	f, err := strconv.ParseFloat(str, 64)
+	parsed, err := ParseExchangeRate(str)
 	if err != nil {
 		return fmt.Errorf("money: scan ExchangeRate: %w", err)
 	}
-	parsed, err := ParseExchangeRate(f)
-	if err != nil {
-		return err
-	}
 	*r = parsed
 	return nil
 }
@@ -9,21 +9,60 @@ import (
 )
 
 func TestParseExchangeRate_one(t *testing.T) {
-	r, err := ParseExchangeRate(1)
+	r, err := ParseExchangeRate("1")
 	require.NoError(t, err)
 	assert.True(t, r.IsOne())
-	assert.InDelta(t, 1.0, r.Float64(), 1e-12)
+	assert.Equal(t, "1", r.String())
 }
 
 func TestParseExchangeRate_positive(t *testing.T) {
-	r, err := ParseExchangeRate(0.92)
+	r, err := ParseExchangeRate("0.92")
 	require.NoError(t, err)
 	assert.False(t, r.IsOne())
-	assert.InDelta(t, 0.92, r.Float64(), 1e-9)
+	assert.Equal(t, ExchangeRate(9_200_000_000), r)
+	assert.Equal(t, "0.92", r.String())
+}
+
+func TestParseExchangeRate_exact(t *testing.T) {
+	tests := []struct {
+		in   string
+		want ExchangeRate
+	}{
+		{in: "0.1", want: 1_000_000_000},
+		{in: "1.2345678901", want: 12_345_678_901},
+		{in: "1.08695652173913", want: 10_869_565_217},
+		{in: "0.00000000005", want: 1},
+		{in: "0.0015", want: 15_000_000},
+		{in: "-0.92", want: -9_200_000_000},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := ParseExchangeRate(tt.in)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestParseExchangeRate_invalid(t *testing.T) {
+	_, err := ParseExchangeRate("abc")
+	require.Error(t, err)
+
+	_, err = ParseExchangeRate("1e30")
+	require.Error(t, err)
+
+	for _, in := range []string{"1/3", "1e5", "1.5e-3", "1e9999999"} {
+		_, err = ParseExchangeRate(in)
+		assert.Error(t, err, in)
+	}
+
+	var r ExchangeRate
+	require.Error(t, json.Unmarshal([]byte(`1e5`), &r))
 }
 
 func TestExchangeRate_JSON_roundTrip(t *testing.T) {
-	orig, err := ParseExchangeRate(31)
+	orig, err := ParseExchangeRate("31")
 	require.NoError(t, err)
 	b, err := json.Marshal(orig)
 	require.NoError(t, err)
@@ -33,8 +72,18 @@ func TestExchangeRate_JSON_roundTrip(t *testing.T) {
 	assert.Equal(t, orig, back)
 }
 
+func TestExchangeRate_JSON_exactDecimal(t *testing.T) {
+	var r ExchangeRate
+	require.NoError(t, json.Unmarshal([]byte("0.0068"), &r))
+	assert.Equal(t, ExchangeRate(68_000_000), r)
+
+	b, err := json.Marshal(r)
+	require.NoError(t, err)
+	assert.Equal(t, "0.0068", string(b))
+}
+
 func TestExchangeRate_Value_scanRoundTrip(t *testing.T) {
-	orig, err := ParseExchangeRate(1.2345678901)
+	orig, err := ParseExchangeRate("1.2345678901")
 	require.NoError(t, err)
 
 	v, err := orig.Value()
@@ -4,29 +4,68 @@ import (
 	"database/sql/driver"
 	"encoding/json"
 	"fmt"
+	"strings"
 )
 
-// MajorAmount is a create-request balance in major display units (e.g. 40 = 40.00 in USD).
-// JSON always unmarshals as a float and converts to Minor using defaultMajorDecimalPlaces.
-// Use this on POST bodies; list/get responses use Minor (integer minor units) instead.
+// MajorAmount is a create-request amount in major display units (e.g. 40 = 40.00 USD,
+// 40 = 40 JPY). JSON accepts a number or a decimal string without exponent and keeps
+// its exact text; Minor stays zero until [MajorAmount.Resolve] is called with the
+// decimal places of the currency the amount is in. Use this on POST bodies and [NullMajorAmount] on
+// PATCH bodies; list/get responses use Minor (integer minor units) instead.
 type MajorAmount struct {
 	Minor Minor
+	text  string
 }
 
+// MajorAmountFrom returns the amount written as s, e.g. "12.34", not yet resolved.
+func MajorAmountFrom(s string) (MajorAmount, error) {
+	s = strings.TrimSpace(s)
+	if _, ok := parseDecimal(s); !ok {
+		return MajorAmount{}, fmt.Errorf("money: %q is not a decimal amount", s)
+	}
+	return MajorAmount{text: s}, nil
+}
+
+// Resolve sets Minor from the amount in major units of a currency with decimalPlaces.
+// An omitted amount resolves to zero.
+func (m *MajorAmount) Resolve(decimalPlaces int16) error {
+	if m.text == "" {
+		m.Minor = 0
+		return nil
+	}
+	mv, err := ParseMajor(m.text, decimalPlaces)
+	if err != nil {
+		return err
+	}
+	m.Minor = mv
+	return nil
+}
+
+// UnmarshalJSON keeps the exact decimal text of a JSON number or string.
 func (m *MajorAmount) UnmarshalJSON(data []byte) error {
 	if string(data) == "null" {
-		m.Minor = 0
+		*m = MajorAmount{}
 		return nil
 	}
-	var f float64
-	if err := json.Unmarshal(data, &f); err != nil {
-		return fmt.Errorf("money: UnmarshalJSON MajorAmount: %w", err)
+
+	var text string
+	if len(data) > 0 && data[0] == '"' {
+		if err := json.Unmarshal(data, &text); err != nil {
+			return fmt.Errorf("money: UnmarshalJSON MajorAmount: %w", err)
+		}
+	} else {
+		var n json.Number
+		if err := json.Unmarshal(data, &n); err != nil {
+			return fmt.Errorf("money: UnmarshalJSON MajorAmount: %w", err)
+		}
+		text = n.String()
 	}
-	mv, err := majorFloatToMinor(f, defaultMajorDecimalPlaces)
+
+	parsed, err := MajorAmountFrom(text)
 	if err != nil {
 		return fmt.Errorf("money: UnmarshalJSON MajorAmount: %w", err)
 	}
-	m.Minor = mv
+	*m = parsed
 	return nil
 }
 
@@ -8,26 +8,98 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
-func TestMajorAmount_UnmarshalJSON_wholeMajorUnits(t *testing.T) {
+func unmarshalBalance(t *testing.T, body string, decimalPlaces int16) Minor {
+	t.Helper()
 	var s struct {
 		B MajorAmount `json:"currentBalance"`
 	}
-	require.NoError(t, json.Unmarshal([]byte(`{"currentBalance":40}`), &s))
-	assert.Equal(t, Minor(4000), s.B.Minor)
+	require.NoError(t, json.Unmarshal([]byte(body), &s))
+	require.NoError(t, s.B.Resolve(decimalPlaces))
+	return s.B.Minor
+}
+
+func TestMajorAmount_UnmarshalJSON_wholeMajorUnits(t *testing.T) {
+	assert.Equal(t, Minor(4000), unmarshalBalance(t, `{"currentBalance":40}`, 2))
 }
 
 func TestMajorAmount_UnmarshalJSON_fractionalMajorUnits(t *testing.T) {
-	var s struct {
-		B MajorAmount `json:"currentBalance"`
-	}
-	require.NoError(t, json.Unmarshal([]byte(`{"currentBalance":12.34}`), &s))
-	assert.Equal(t, Minor(1234), s.B.Minor)
+	assert.Equal(t, Minor(1234), unmarshalBalance(t, `{"currentBalance":12.34}`, 2))
 }
 
 func TestMajorAmount_UnmarshalJSON_zero(t *testing.T) {
+	assert.Equal(t, Minor(0), unmarshalBalance(t, `{"currentBalance":0}`, 2))
+}
+
+func TestMajorAmount_UnmarshalJSON_omitted(t *testing.T) {
+	assert.Equal(t, Minor(0), unmarshalBalance(t, `{}`, 2))
+}
+
+func TestMajorAmount_UnmarshalJSON_zeroDecimalCurrency(t *testing.T) {
+	assert.Equal(t, Minor(40), unmarshalBalance(t, `{"currentBalance":40}`, 0))
+}
+
+func TestMajorAmount_UnmarshalJSON_decimalString(t *testing.T) {
+	assert.Equal(t, Minor(1_234_567), unmarshalBalance(t, `{"currentBalance":"1234.567"}`, 3))
+}
+
+func TestMajorAmount_UnmarshalJSON_invalid(t *testing.T) {
+	var m MajorAmount
+	require.Error(t, json.Unmarshal([]byte(`"12,34"`), &m))
+	require.Error(t, json.Unmarshal([]byte(`true`), &m))
+	require.Error(t, json.Unmarshal([]byte(`"1/3"`), &m))
+	require.Error(t, json.Unmarshal([]byte(`"1e5"`), &m))
+	require.Error(t, json.Unmarshal([]byte(`1e5`), &m))
+}
+
+func TestParseMajor(t *testing.T) {
+	tests := []struct {
+		in            string
+		decimalPlaces int16
+		want          Minor
+	}{
+		{in: "0.1", decimalPlaces: 2, want: 10},
+		{in: "19.99", decimalPlaces: 2, want: 1999},
+		{in: "-40", decimalPlaces: 0, want: -40},
+		{in: "0.005", decimalPlaces: 2, want: 1},
+		{in: "-0.005", decimalPlaces: 2, want: -1},
+		{in: "+150", decimalPlaces: 2, want: 15000},
+		{in: "0.00000001", decimalPlaces: 8, want: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := ParseMajor(tt.in, tt.decimalPlaces)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	_, err := ParseMajor("1", 19)
+	require.Error(t, err)
+
+	for _, in := range []string{"1/3", "1e5", "1e9999999", ".5", "5.", "0x10", ""} {
+		_, err := ParseMajor(in, 2)
+		assert.Error(t, err, in)
+	}
+}
+
+func TestNullMajorAmount_UnmarshalJSON(t *testing.T) {
 	var s struct {
-		B MajorAmount `json:"currentBalance"`
+		B NullMajorAmount `json:"currentBalance"`
 	}
-	require.NoError(t, json.Unmarshal([]byte(`{"currentBalance":0}`), &s))
-	assert.Equal(t, Minor(0), s.B.Minor)
+
+	require.NoError(t, json.Unmarshal([]byte(`{"currentBalance": 40.5}`), &s))
+	require.NoError(t, s.B.Resolve(2))
+	assert.True(t, s.B.Valid)
+	assert.Equal(t, Minor(4050), s.B.Amount.Minor)
+
+	s.B = NullMajorAmount{}
+	require.NoError(t, json.Unmarshal([]byte(`{}`), &s))
+	require.NoError(t, s.B.Resolve(2))
+	assert.False(t, s.B.Valid)
+
+	require.NoError(t, json.Unmarshal([]byte(`{"currentBalance": null}`), &s))
+	assert.False(t, s.B.Valid)
+
+	require.Error(t, json.Unmarshal([]byte(`{"currentBalance": "4o"}`), &s))
 }
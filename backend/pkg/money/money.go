@@ -0,0 +1,153 @@
+package money
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"sort"
+)
+
+// ErrCurrencyMismatch is returned when two amounts in different currencies are combined.
+var ErrCurrencyMismatch = errors.New("money: currency mismatch")
+
+// Money is an amount of minor units of a currency, identified by its ISO 4217 code.
+// Unlike a bare [Minor], arithmetic on Money refuses to mix currencies.
+type Money struct {
+	Amount   Minor  `json:"amount"`
+	Currency string `json:"currency"`
+}
+
+// New returns amount minor units of currency.
+func New(amount Minor, currency string) Money {
+	return Money{Amount: amount, Currency: currency}
+}
+
+func (m Money) String() string {
+	return fmt.Sprintf("%d %s", m.Amount, m.Currency)
+}
+
+// IsZero reports whether m is zero.
+func (m Money) IsZero() bool {
+	return m.Amount == 0
+}
+
+// IsPositive reports whether m is greater than zero.
+func (m Money) IsPositive() bool {
+	return m.Amount > 0
+}
+
+// IsNegative reports whether m is less than zero.
+func (m Money) IsNegative() bool {
+	return m.Amount < 0
+}
+
+// Negate returns m with the opposite sign.
+func (m Money) Negate() (Money, error) {
+	if m.Amount == math.MinInt64 {
+		return Money{}, fmt.Errorf("money: -(%s) overflows", m)
+	}
+	return Money{Amount: -m.Amount, Currency: m.Currency}, nil
+}
+
+// Add returns m + o.
+func (m Money) Add(o Money) (Money, error) {
+	if err := m.sameCurrency(o); err != nil {
+		return Money{}, err
+	}
+	if (o.Amount > 0 && m.Amount > math.MaxInt64-o.Amount) || (o.Amount < 0 && m.Amount < math.MinInt64-o.Amount) {
+		return Money{}, fmt.Errorf("money: %s + %s overflows", m, o)
+	}
+	return Money{Amount: m.Amount + o.Amount, Currency: m.Currency}, nil
+}
+
+// Subtract returns m - o.
+func (m Money) Subtract(o Money) (Money, error) {
+	if err := m.sameCurrency(o); err != nil {
+		return Money{}, err
+	}
+	if (o.Amount > 0 && m.Amount < math.MinInt64+o.Amount) || (o.Amount < 0 && m.Amount > math.MaxInt64+o.Amount) {
+		return Money{}, fmt.Errorf("money: %s - %s overflows", m, o)
+	}
+	return Money{Amount: m.Amount - o.Amount, Currency: m.Currency}, nil
+}
+
+// Compare returns -1, 0 or +1 when m is less than, equal to or greater than o.
+func (m Money) Compare(o Money) (int, error) {
+	if err := m.sameCurrency(o); err != nil {
+		return 0, err
+	}
+	switch {
+	case m.Amount < o.Amount:
+		return -1, nil
+	case m.Amount > o.Amount:
+		return 1, nil
+	}
+	return 0, nil
+}
+
+// Allocate splits m into len(ratios) parts proportional to ratios without losing a
+// minor unit: the parts always add up to m. Units left over after truncating every
+// share go one each to the parts with the largest remainders, earlier parts first on a
+// tie, so 100 cents in thirds is 34, 33, 33. Ratios must not be negative and at least
+// one must be positive.
+func (m Money) Allocate(ratios ...int64) ([]Money, error) {
+	if len(ratios) == 0 {
+		return nil, fmt.Errorf("money: allocate needs at least one ratio")
+	}
+
+	total := new(big.Int)
+	for _, r := range ratios {
+		if r < 0 {
+			return nil, fmt.Errorf("money: allocate ratio %d is negative", r)
+		}
+		total.Add(total, big.NewInt(r))
+	}
+	if total.Sign() == 0 {
+		return nil, fmt.Errorf("money: allocate ratios add up to zero")
+	}
+
+	// Allocate the absolute amount and restore the sign at the end, so a negative
+	// amount is split exactly like its positive counterpart.
+	amount := new(big.Int).Abs(big.NewInt(int64(m.Amount)))
+
+	shares := make([]*big.Int, len(ratios))
+	remainders := make([]*big.Int, len(ratios))
+	allocated := new(big.Int)
+	for i, r := range ratios {
+		shares[i], remainders[i] = new(big.Int).QuoRem(new(big.Int).Mul(amount, big.NewInt(r)), total, new(big.Int))
+		allocated.Add(allocated, shares[i])
+	}
+
+	order := make([]int, len(ratios))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return remainders[order[a]].Cmp(remainders[order[b]]) > 0
+	})
+
+	leftover := new(big.Int).Sub(amount, allocated).Int64()
+	for _, i := range order[:leftover] {
+		shares[i].Add(shares[i], big.NewInt(1))
+	}
+
+	// No share is larger than the amount, so once signed every one fits in a Minor,
+	// even the whole of math.MinInt64 whose absolute value does not.
+	parts := make([]Money, len(ratios))
+	for i, share := range shares {
+		if m.Amount < 0 {
+			share.Neg(share)
+		}
+		parts[i] = Money{Amount: Minor(share.Int64()), Currency: m.Currency}
+	}
+
+	return parts, nil
+}
+
+func (m Money) sameCurrency(o Money) error {
+	if m.Currency != o.Currency {
+		return fmt.Errorf("%w: %s and %s", ErrCurrencyMismatch, m.Currency, o.Currency)
+	}
+	return nil
+}
@@ -0,0 +1,109 @@
+package money
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMoney_Add(t *testing.T) {
+	sum, err := New(1050, "USD").Add(New(-250, "USD"))
+	require.NoError(t, err)
+	assert.Equal(t, New(800, "USD"), sum)
+
+	_, err = New(1050, "USD").Add(New(250, "EUR"))
+	require.ErrorIs(t, err, ErrCurrencyMismatch)
+
+	_, err = New(math.MaxInt64, "USD").Add(New(1, "USD"))
+	require.Error(t, err)
+}
+
+func TestMoney_Subtract(t *testing.T) {
+	diff, err := New(1050, "USD").Subtract(New(2000, "USD"))
+	require.NoError(t, err)
+	assert.Equal(t, New(-950, "USD"), diff)
+	assert.True(t, diff.IsNegative())
+
+	_, err = New(1, "USD").Subtract(New(1, "JPY"))
+	require.ErrorIs(t, err, ErrCurrencyMismatch)
+
+	_, err = New(0, "USD").Subtract(New(math.MinInt64, "USD"))
+	require.Error(t, err)
+
+	_, err = New(math.MinInt64, "USD").Subtract(New(1, "USD"))
+	require.Error(t, err)
+
+	diff, err = New(-1, "USD").Subtract(New(math.MinInt64, "USD"))
+	require.NoError(t, err)
+	assert.Equal(t, New(math.MaxInt64, "USD"), diff)
+}
+
+func TestMoney_Negate(t *testing.T) {
+	neg, err := New(-950, "USD").Negate()
+	require.NoError(t, err)
+	assert.Equal(t, New(950, "USD"), neg)
+
+	neg, err = New(math.MaxInt64, "USD").Negate()
+	require.NoError(t, err)
+	assert.Equal(t, New(-math.MaxInt64, "USD"), neg)
+
+	_, err = New(math.MinInt64, "USD").Negate()
+	require.Error(t, err)
+}
+
+func TestMoney_Compare(t *testing.T) {
+	cmp, err := New(100, "USD").Compare(New(200, "USD"))
+	require.NoError(t, err)
+	assert.Equal(t, -1, cmp)
+
+	cmp, err = New(200, "USD").Compare(New(200, "USD"))
+	require.NoError(t, err)
+	assert.Equal(t, 0, cmp)
+
+	_, err = New(200, "USD").Compare(New(200, "CLP"))
+	require.ErrorIs(t, err, ErrCurrencyMismatch)
+}
+
+func TestMoney_Allocate(t *testing.T) {
+	tests := []struct {
+		name   string
+		amount Minor
+		ratios []int64
+		want   []Minor
+	}{
+		{name: "thirds", amount: 100, ratios: []int64{1, 1, 1}, want: []Minor{34, 33, 33}},
+		{name: "negative thirds", amount: -100, ratios: []int64{1, 1, 1}, want: []Minor{-34, -33, -33}},
+		{name: "largest remainder first", amount: 5, ratios: []int64{3, 7}, want: []Minor{2, 3}},
+		{name: "percentages", amount: 1001, ratios: []int64{70, 20, 10}, want: []Minor{701, 200, 100}},
+		{name: "zero ratio gets nothing", amount: 10, ratios: []int64{1, 0, 2}, want: []Minor{3, 0, 7}},
+		{name: "zero amount", amount: 0, ratios: []int64{1, 2}, want: []Minor{0, 0}},
+		{name: "large amount", amount: math.MaxInt64, ratios: []int64{1, 1}, want: []Minor{math.MaxInt64/2 + 1, math.MaxInt64 / 2}},
+		{name: "smallest amount whole", amount: math.MinInt64, ratios: []int64{0, 1}, want: []Minor{0, math.MinInt64}},
+		{name: "smallest amount in thirds", amount: math.MinInt64, ratios: []int64{1, 1, 1}, want: []Minor{-3074457345618258603, -3074457345618258603, -3074457345618258602}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parts, err := New(tt.amount, "USD").Allocate(tt.ratios...)
+			require.NoError(t, err)
+			require.Len(t, parts, len(tt.want))
+
+			for i, want := range tt.want {
+				assert.Equal(t, New(want, "USD"), parts[i])
+			}
+		})
+	}
+}
+
+func TestMoney_Allocate_invalid(t *testing.T) {
+	_, err := New(100, "USD").Allocate()
+	require.Error(t, err)
+
+	_, err = New(100, "USD").Allocate(0, 0)
+	require.Error(t, err)
+
+	_, err = New(100, "USD").Allocate(1, -1)
+	require.Error(t, err)
+}
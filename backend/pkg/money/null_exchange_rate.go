@@ -24,15 +24,9 @@ func (n *NullExchangeRate) UnmarshalJSON(data []byte) error {
 		n.Valid = false
 		return nil
 	}
-	var f float64
-	if err := json.Unmarshal(data, &f); err != nil {
+	if err := n.Rate.UnmarshalJSON(data); err != nil {
 		return fmt.Errorf("money: UnmarshalJSON NullExchangeRate: %w", err)
 	}
-	r, err := ParseExchangeRate(f)
-	if err != nil {
-		return fmt.Errorf("money: UnmarshalJSON NullExchangeRate: %w", err)
-	}
-	n.Rate = r
 	n.Valid = true
 	return nil
 }
@@ -52,3 +46,16 @@ func (n NullExchangeRate) Value() (driver.Value, error) {
 	}
 	return n.Rate.Value()
 }
+
+// Scan implements sql.Scanner for nullable PostgreSQL NUMERIC columns.
+func (n *NullExchangeRate) Scan(src interface{}) error {
+	if src == nil {
+		n.Rate, n.Valid = 0, false
+		return nil
+	}
+	if err := n.Rate.Scan(src); err != nil {
+		return err
+	}
+	n.Valid = true
+	return nil
+}
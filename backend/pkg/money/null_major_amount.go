@@ -0,0 +1,57 @@
+package money
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+)
+
+// NullMajorAmount is a [MajorAmount] that may be null (omitted or JSON null).
+// Used for partial PATCH bodies; like MajorAmount it must be resolved before Minor is
+// read.
+type NullMajorAmount struct {
+	Amount MajorAmount
+	Valid  bool
+}
+
+// NullMajorAmountFrom returns a non-null wrapper around m.
+func NullMajorAmountFrom(m MajorAmount) NullMajorAmount {
+	return NullMajorAmount{Amount: m, Valid: true}
+}
+
+// Resolve resolves the amount, when there is one, in a currency with decimalPlaces.
+func (n *NullMajorAmount) Resolve(decimalPlaces int16) error {
+	if !n.Valid {
+		return nil
+	}
+	return n.Amount.Resolve(decimalPlaces)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (n *NullMajorAmount) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		n.Amount, n.Valid = MajorAmount{}, false
+		return nil
+	}
+	if err := n.Amount.UnmarshalJSON(data); err != nil {
+		return fmt.Errorf("money: UnmarshalJSON NullMajorAmount: %w", err)
+	}
+	n.Valid = true
+	return nil
+}
+
+// MarshalJSON encodes null when invalid.
+func (n NullMajorAmount) MarshalJSON() ([]byte, error) {
+	if !n.Valid {
+		return []byte("null"), nil
+	}
+	return json.Marshal(n.Amount)
+}
+
+// Value implements driver.Valuer for partial SQL updates.
+func (n NullMajorAmount) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	return n.Amount.Value()
+}
@@ -0,0 +1,23 @@
+package sqlcraft
+
+// Column expressions for aggregate queries, meant to be passed to Select together
+// with GroupBy:
+//
+//	Select("category_id", As(Sum("amount"), "total")).
+//		From("budget.transactions").
+//		GroupBy("category_id")
+
+// Sum returns a SUM of expr that yields 0 instead of NULL when there is nothing to add.
+func Sum(expr string) string {
+	return "COALESCE(SUM(" + expr + "), 0)"
+}
+
+// Count returns a COUNT of the rows where expr is not NULL; use "*" to count every row.
+func Count(expr string) string {
+	return "COUNT(" + expr + ")"
+}
+
+// As aliases a column expression.
+func As(expr, alias string) string {
+	return expr + " AS " + alias
+}
@@ -0,0 +1,85 @@
+package sqlcraft
+
+import (
+	"testing"
+
+	"backend/infra/dafi"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSelectQuery_Aggregate(t *testing.T) {
+	sqlColumnByDomainField := map[string]string{
+		"budgetId":      "t.budget_id",
+		"categoryId":    "t.category_id",
+		"subcategoryId": "t.subcategory_id",
+	}
+
+	tests := []struct {
+		name    string
+		query   SelectQuery
+		want    Result
+		wantErr bool
+	}{
+		{
+			name: "sum grouped by column",
+			query: Select("category_id", As(Sum("amount"), "total")).
+				From("transactions").
+				GroupBy("category_id"),
+			want: Result{
+				SQL:  "SELECT category_id, COALESCE(SUM(amount), 0) AS total FROM transactions GROUP BY category_id",
+				Args: []any{},
+			},
+		},
+		{
+			name: "filtered aggregate with joins and domain fields",
+			query: Select("t.category_id", "t.subcategory_id", As(Sum("t.amount"), "activity"), As(Count("*"), "transactions")).
+				From("transactions t").
+				InnerJoin("accounts a", "a.id = t.account_id").
+				Where(dafi.Filter{Field: "budgetId", Value: "b-1"}).
+				SQLColumnByDomainField(sqlColumnByDomainField).
+				GroupBy("categoryId", "subcategoryId"),
+			want: Result{
+				SQL:  "SELECT t.category_id, t.subcategory_id, COALESCE(SUM(t.amount), 0) AS activity, COUNT(*) AS transactions FROM transactions t INNER JOIN accounts a ON a.id = t.account_id WHERE t.budget_id = $1 GROUP BY t.category_id, t.subcategory_id",
+				Args: []any{"b-1"},
+			},
+		},
+		{
+			name: "unknown group field",
+			query: Select("t.category_id", As(Sum("t.amount"), "activity")).
+				From("transactions t").
+				SQLColumnByDomainField(sqlColumnByDomainField).
+				GroupBy("accountId"),
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.query.ToSQL()
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestSelectQuery_GroupBy_Reusable(t *testing.T) {
+	groups := []string{"categoryId"}
+	query := Select("category_id", As(Count("*"), "n")).
+		From("transactions").
+		SQLColumnByDomainField(map[string]string{"categoryId": "category_id"}).
+		GroupBy(groups...)
+
+	first, err := query.ToSQL()
+	assert.NoError(t, err)
+
+	second, err := query.ToSQL()
+	assert.NoError(t, err)
+
+	assert.Equal(t, first, second)
+	assert.Equal(t, []string{"categoryId"}, groups)
+}
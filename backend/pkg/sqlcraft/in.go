@@ -67,3 +67,16 @@ func In(value any, initialArgCount int) Result {
 		Args: args,
 	}
 }
+
+// Array builds an ARRAY constructor out of the values In accepts, to compare them
+// with an array column.
+func Array(value any, initialArgCount int) Result {
+	result := In(value, initialArgCount)
+	if result.SQL == "" {
+		return result
+	}
+
+	result.SQL = "ARRAY[" + result.SQL[1:len(result.SQL)-1] + "]"
+
+	return result
+}
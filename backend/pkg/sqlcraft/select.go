@@ -102,6 +102,14 @@ func (s SelectQuery) SQLColumnByDomainField(sqlColumnByDomainField map[string]st
 	return s
 }
 
+// GroupBy groups the rows of the query by the given fields. When a domain field
+// mapping is set, the fields are domain names translated to SQL columns.
+func (s SelectQuery) GroupBy(fields ...string) SelectQuery {
+	s.groups = append([]string(nil), fields...)
+
+	return s
+}
+
 // InnerJoin adds an INNER JOIN to the query.
 func (s SelectQuery) InnerJoin(table, condition string) SelectQuery {
 	return s.addJoin(InnerJoinType, table, condition)
@@ -287,16 +295,18 @@ func BuildPagination(pagination dafi.Pagination) string {
 
 // BuildGroupBy builds the GROUP BY clause.
 func BuildGroupBy(groups []string, sqlColumnByDomainField map[string]string) (string, error) {
+	columns := groups
 	if len(sqlColumnByDomainField) > 0 {
+		columns = make([]string, len(groups))
 		for i, group := range groups {
 			sqlColumnName, ok := sqlColumnByDomainField[group]
 			if !ok {
 				return "", fmt.Errorf("invalid field name for grouping: %s", group)
 			}
 
-			groups[i] = sqlColumnName
+			columns[i] = sqlColumnName
 		}
 	}
 
-	return " GROUP BY " + strings.Join(groups, ", "), nil
+	return " GROUP BY " + strings.Join(columns, ", "), nil
 }
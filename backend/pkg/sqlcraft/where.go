@@ -23,6 +23,8 @@ var psqlOperatorByDafiOperator = map[dafi.FilterOperator]string{
 	dafi.IsNotNull:      "IS NOT NULL",
 	dafi.In:             "IN",
 	dafi.NotIn:          "NOT IN",
+	dafi.Any:            "&&",
+	dafi.All:            "@>",
 	dafi.Default:        "",
 }
 
@@ -82,6 +84,16 @@ func Where(initialArgCount int, filters ...dafi.Filter) (Result, error) {
 			builder.WriteString(inResult.SQL)
 			args = append(args, inResult.Args...)
 			argCount += len(inResult.Args)
+		case dafi.Any, dafi.All:
+			builder.WriteString(string(filter.Field))
+			builder.WriteString(" ")
+			builder.WriteString(psqlOperatorByDafiOperator[operator])
+			builder.WriteString(" ")
+
+			arrayResult := Array(filter.Value, argCount+1)
+			builder.WriteString(arrayResult.SQL)
+			args = append(args, arrayResult.Args...)
+			argCount += len(arrayResult.Args)
 		case dafi.Contains, dafi.NotContains:
 			builder.WriteString(string(filter.Field))
 			builder.WriteString(" ")
@@ -235,6 +235,45 @@ func TestWhere(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "any operator",
+			args: args{
+				filters: dafi.Filters{
+					dafi.Filter{
+						Field:    "tags",
+						Operator: dafi.Any,
+						Value:    []string{"red", "blue"},
+					},
+				},
+			},
+			want: Result{
+				SQL:  " WHERE tags && ARRAY[$1, $2]",
+				Args: []any{"red", "blue"},
+			},
+			wantErr: false,
+		},
+		{
+			name: "all operator after another filter",
+			args: args{
+				filters: dafi.Filters{
+					dafi.Filter{
+						Field:    "status",
+						Operator: dafi.Equal,
+						Value:    "active",
+					},
+					dafi.Filter{
+						Field:    "tags",
+						Operator: dafi.All,
+						Value:    []string{"red", "blue"},
+					},
+				},
+			},
+			want: Result{
+				SQL:  " WHERE status = $1 AND tags @> ARRAY[$2, $3]",
+				Args: []any{"active", "red", "blue"},
+			},
+			wantErr: false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {